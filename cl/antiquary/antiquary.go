@@ -62,6 +62,11 @@ type Antiquary struct {
 	// set to nil
 	currentState *state.CachingBeaconState
 	balances32   []byte
+	// full states are stored every stateCheckpointInterval slots, 0 disables them.
+	stateCheckpointInterval uint64
+	lastStateCheckpoint     uint64
+	// best light client updates of the replayed periods
+	lightClientUpdates *lightClientUpdatesCollector
 }
 
 func NewAntiquary(ctx context.Context, blobStorage blob_storage.BlobStorage, genesisState *state.CachingBeaconState, validatorsTable *state_accessors.StaticValidatorTable, cfg *clparams.BeaconChainConfig, dirs datadir.Dirs, downloader proto_downloader.DownloaderClient, mainDB kv.RwDB, sn *freezeblocks.CaplinSnapshots, reader freezeblocks.BeaconSnapshotReader, logger log.Logger, states, blocks, blobs bool, snBuildSema *semaphore.Weighted) *Antiquary {
@@ -87,9 +92,16 @@ func NewAntiquary(ctx context.Context, blobStorage blob_storage.BlobStorage, gen
 		genesisState:    genesisState,
 		blocks:          blocks,
 		blobs:           blobs,
+
+		lightClientUpdates: newLightClientUpdatesCollector(cfg, reader),
 	}
 }
 
+// SetStateCheckpointInterval sets how many slots apart full states are stored by the states antiquary.
+func (a *Antiquary) SetStateCheckpointInterval(interval uint64) {
+	a.stateCheckpointInterval = interval
+}
+
 // Check if the snapshot directory has beacon blocks files aka "contains beaconblock" and has a ".seg" extension over its first layer
 func doesSnapshotDirHaveBeaconBlocksFiles(snapshotDir string) bool {
 	// Iterate over the files in the snapshot directory
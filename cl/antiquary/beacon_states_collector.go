@@ -62,6 +62,7 @@ type beaconStatesCollector struct {
 	activeValidatorIndiciesCollector *etl.Collector
 	balancesDumpsCollector           *etl.Collector
 	effectiveBalancesDumpCollector   *etl.Collector
+	stateCheckpointsCollector        *etl.Collector
 
 	buf        *bytes.Buffer
 	compressor *zstd.Encoder
@@ -95,6 +96,7 @@ func newBeaconStatesCollector(beaconCfg *clparams.BeaconChainConfig, tmpdir stri
 		activeValidatorIndiciesCollector: etl.NewCollector(kv.ActiveValidatorIndicies, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		balancesDumpsCollector:           etl.NewCollector(kv.BalancesDump, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		effectiveBalancesDumpCollector:   etl.NewCollector(kv.EffectiveBalancesDump, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
+		stateCheckpointsCollector:        etl.NewCollector(kv.StateCheckpoints, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		logger:                           logger,
 		beaconCfg:                        beaconCfg,
 
@@ -286,6 +288,15 @@ func (i *beaconStatesCollector) collectInactivityScores(slot uint64, inactivityS
 	return antiquateFullUint64List(i.inactivityScoresCollector, slot, inactivityScores, i.buf, i.compressor)
 }
 
+// collectStateCheckpoint stores the whole state, historical queries close to it are then served without reconstructing the state.
+func (i *beaconStatesCollector) collectStateCheckpoint(st *state.CachingBeaconState) error {
+	encoded, err := st.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	return antiquateFullUint64List(i.stateCheckpointsCollector, st.Slot(), encoded, i.buf, i.compressor)
+}
+
 func (i *beaconStatesCollector) flush(ctx context.Context, tx kv.RwTx) error {
 	loadfunc := func(k, v []byte, table etl.CurrentTableReader, next etl.LoadNextFunc) error {
 		return next(k, k, v)
@@ -342,6 +353,9 @@ func (i *beaconStatesCollector) flush(ctx context.Context, tx kv.RwTx) error {
 	if err := i.effectiveBalancesDumpCollector.Load(tx, kv.EffectiveBalancesDump, loadfunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
 		return err
 	}
+	if err := i.stateCheckpointsCollector.Load(tx, kv.StateCheckpoints, loadfunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+		return err
+	}
 
 	return i.balancesDumpsCollector.Load(tx, kv.BalancesDump, loadfunc, etl.TransformArgs{Quit: ctx.Done()})
 }
@@ -365,6 +379,7 @@ func (i *beaconStatesCollector) close() {
 	i.activeValidatorIndiciesCollector.Close()
 	i.balancesDumpsCollector.Close()
 	i.effectiveBalancesDumpCollector.Close()
+	i.stateCheckpointsCollector.Close()
 }
 
 // antiquateFullUint64List goes on mdbx as it is full of common repeated patter always and thus fits with 16KB pages.
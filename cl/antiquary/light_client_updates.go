@@ -0,0 +1,123 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package antiquary
+
+import (
+	"context"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/lightclient_utils"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// lightClientUpdatesCollector keeps track of the best light client update of every sync committee period met
+// while replaying the historical states.
+type lightClientUpdatesCollector struct {
+	cfg    *clparams.BeaconChainConfig
+	reader freezeblocks.BeaconSnapshotReader
+
+	// attestedBlock is the last block applied to the state.
+	attestedBlock  *cltypes.SignedBeaconBlock
+	finalizedRoot  libcommon.Hash
+	finalizedBlock *cltypes.SignedBeaconBlock
+
+	best map[uint64]*cltypes.LightClientUpdate // period -> best update
+}
+
+func newLightClientUpdatesCollector(cfg *clparams.BeaconChainConfig, reader freezeblocks.BeaconSnapshotReader) *lightClientUpdatesCollector {
+	return &lightClientUpdatesCollector{
+		cfg:    cfg,
+		reader: reader,
+		best:   make(map[uint64]*cltypes.LightClientUpdate),
+	}
+}
+
+// collect creates the update signed by block. It must be called before block is applied, when attestedState is
+// still the post state of the previous block.
+func (l *lightClientUpdatesCollector) collect(ctx context.Context, tx kv.Tx, attestedState *state.CachingBeaconState, block *cltypes.SignedBeaconBlock) error {
+	attestedBlock := l.attestedBlock
+	l.attestedBlock = block
+	if attestedBlock == nil || attestedBlock.Version() < clparams.AltairVersion || attestedBlock.Block.Slot != attestedState.Slot() {
+		return nil
+	}
+	if block.Block.Body.SyncAggregate.Sum() < int(l.cfg.MinSyncCommitteeParticipants) {
+		return nil
+	}
+
+	finalizedRoot := attestedState.FinalizedCheckpoint().BlockRoot()
+	if finalizedRoot != l.finalizedRoot {
+		finalizedBlock, err := l.reader.ReadBlockByRoot(ctx, tx, finalizedRoot)
+		if err != nil {
+			return err
+		}
+		l.finalizedRoot, l.finalizedBlock = finalizedRoot, finalizedBlock
+	}
+
+	nextSyncCommitteeBranch, err := attestedState.NextSyncCommitteeBranch()
+	if err != nil {
+		return err
+	}
+	finalityBranch, err := attestedState.FinalityRootBranch()
+	if err != nil {
+		return err
+	}
+	update, err := lightclient_utils.CreateLightClientUpdate(l.cfg, block, l.finalizedBlock, attestedBlock, attestedState.Slot(),
+		attestedState.NextSyncCommittee(), attestedState.FinalizedCheckpoint(), hashVector(nextSyncCommitteeBranch), hashVector(finalityBranch))
+	if err != nil {
+		log.Debug("Could not create light client update", "slot", block.Block.Slot, "err", err)
+		return nil
+	}
+	period := l.cfg.SyncCommitteePeriod(attestedState.Slot())
+	if best, ok := l.best[period]; ok && !lightclient_utils.IsBetterUpdate(l.cfg, update, best) {
+		return nil
+	}
+	l.best[period] = update
+	return nil
+}
+
+// flush writes the collected updates which are better than the stored ones.
+func (l *lightClientUpdatesCollector) flush(tx kv.RwTx) error {
+	for period, update := range l.best {
+		stored, err := beacon_indicies.ReadLightClientUpdate(tx, period)
+		if err != nil {
+			return err
+		}
+		if stored != nil && !lightclient_utils.IsBetterUpdate(l.cfg, update, stored) {
+			continue
+		}
+		if err := beacon_indicies.WriteLightClientUpdate(tx, period, update); err != nil {
+			return err
+		}
+	}
+	clear(l.best)
+	return nil
+}
+
+func hashVector(in [][32]byte) solid.HashVectorSSZ {
+	out := solid.NewHashVector(len(in))
+	for i, v := range in {
+		out.Set(i, libcommon.Hash(v))
+	}
+	return out
+}
@@ -296,6 +296,10 @@ func (s *Antiquary) IncrementBeaconState(ctx context.Context, to uint64) error {
 		prevValSet = prevValSet[:0]
 		prevValSet = append(prevValSet, s.currentState.RawValidatorSet()...)
 
+		if err := s.lightClientUpdates.collect(ctx, tx, s.currentState, block); err != nil {
+			return err
+		}
+
 		fullValidation := slot%1000 == 0 || first
 		blockRewardsCollector := &eth2.BlockRewardsCollector{}
 		// We sanity check the state every 1k slots or when we start.
@@ -333,6 +337,13 @@ func (s *Antiquary) IncrementBeaconState(ctx context.Context, to uint64) error {
 				return err
 			}
 		}
+		// store a full state at the first block of every checkpoint interval.
+		if s.stateCheckpointInterval > 0 && slot/s.stateCheckpointInterval > s.lastStateCheckpoint/s.stateCheckpointInterval {
+			if err := stateAntiquaryCollector.collectStateCheckpoint(s.currentState); err != nil {
+				return err
+			}
+			s.lastStateCheckpoint = slot
+		}
 		// collect current diffs.
 		if err := stateAntiquaryCollector.collectBalancesDiffs(ctx, slot, s.balances32, s.currentState.RawBalances()); err != nil {
 			return err
@@ -380,6 +391,9 @@ func (s *Antiquary) IncrementBeaconState(ctx context.Context, to uint64) error {
 	if err := stateAntiquaryCollector.flush(ctx, rwTx); err != nil {
 		return err
 	}
+	if err := s.lightClientUpdates.flush(rwTx); err != nil {
+		return err
+	}
 
 	if err := state_accessors.SetStateProcessingProgress(rwTx, s.currentState.Slot()); err != nil {
 		return err
@@ -445,6 +459,7 @@ func (s *Antiquary) initializeStateAntiquaryIfNeeded(ctx context.Context, tx kv.
 	backoffStep := backoffStrides
 
 	historicalReader := historical_states_reader.NewHistoricalStatesReader(s.cfg, s.snReader, s.validatorsTable, s.genesisState)
+	historicalReader.SetStateCheckpointInterval(s.stateCheckpointInterval)
 
 	for {
 		attempt, err := computeSlotToBeRequested(tx, s.cfg, s.genesisState.Slot(), targetSlot, backoffStep)
@@ -488,6 +503,11 @@ func (s *Antiquary) initializeStateAntiquaryIfNeeded(ctx context.Context, tx kv.
 		break
 	}
 
+	s.lastStateCheckpoint, _, err = state_accessors.ReadLatestStateCheckpointSlot(tx)
+	if err != nil {
+		return err
+	}
+
 	s.balances32 = s.balances32[:0]
 	s.balances32 = append(s.balances32, s.currentState.RawBalances()...)
 	return s.currentState.InitBeaconState()
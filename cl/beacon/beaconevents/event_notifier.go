@@ -3,15 +3,30 @@ package beaconevents
 type EventEmitter struct {
 	stateFeed     *stateFeed     // block state feed
 	operationFeed *operationFeed // block operation feed
+	history       *eventHistory  // recent events of both feeds, for replay
 }
 
 func NewEventEmitter() *EventEmitter {
+	history := newEventHistory(DefaultHistorySize)
 	return &EventEmitter{
-		operationFeed: newOpFeed(),
-		stateFeed:     newStateFeed(),
+		operationFeed: newOpFeed(history),
+		stateFeed:     newStateFeed(history),
+		history:       history,
 	}
 }
 
+// EventsSince returns the retained events of the given topics emitted after the event with id lastId, oldest first,
+// together with the topics which lost some of those events to the bounded history.
+func (e *EventEmitter) EventsSince(topics []EventTopic, lastId uint64) ([]*EventStream, []Gap) {
+	return e.history.since(topics, lastId)
+}
+
+// EventsFromSlot returns the retained events of the given topics referring to slot or later, oldest first, together
+// with the topics which lost some of those events to the bounded history.
+func (e *EventEmitter) EventsFromSlot(topics []EventTopic, slot uint64) ([]*EventStream, []Gap) {
+	return e.history.fromSlot(topics, slot)
+}
+
 func (e *EventEmitter) State() *stateFeed {
 	return e.stateFeed
 }
@@ -0,0 +1,221 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beaconevents
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// DefaultHistorySize is the number of events kept for every topic.
+const DefaultHistorySize = 1024
+
+// Gap reports that events of a topic were evicted from the history before they could be replayed.
+type Gap struct {
+	Topic EventTopic `json:"topic"`
+	// LastDroppedId and LastDroppedSlot describe the newest evicted event.
+	LastDroppedId   uint64 `json:"last_dropped_id,string"`
+	LastDroppedSlot uint64 `json:"last_dropped_slot,string"`
+}
+
+// eventRing is a bounded FIFO of the events of a single topic.
+type eventRing struct {
+	events []*EventStream
+	head   int // index of the oldest event
+	count  int
+
+	// newest evicted event, dropped is false as long as nothing was evicted.
+	dropped                        bool
+	lastDroppedId, lastDroppedSlot uint64
+
+	overflows metrics.Counter
+}
+
+func (r *eventRing) push(e *EventStream) {
+	if r.count == len(r.events) {
+		oldest := r.events[r.head]
+		r.dropped, r.lastDroppedId, r.lastDroppedSlot = true, oldest.Id, oldest.Slot
+		r.head = (r.head + 1) % len(r.events)
+		r.count--
+		r.overflows.Inc()
+	}
+	r.events[(r.head+r.count)%len(r.events)] = e
+	r.count++
+}
+
+func (r *eventRing) forEach(fn func(e *EventStream)) {
+	for i := 0; i < r.count; i++ {
+		fn(r.events[(r.head+i)%len(r.events)])
+	}
+}
+
+// eventHistory assigns monotonic ids to events and keeps the most recent ones of every topic for replay.
+type eventHistory struct {
+	// sendMu makes id assignment and delivery atomic, so subscribers see ids in increasing order.
+	sendMu sync.Mutex
+
+	mu    sync.RWMutex
+	size  int
+	rings map[EventTopic]*eventRing
+	// ids start from the boot time, so that ids handed out before a restart are recognisable.
+	firstId, nextId uint64
+	// lastSlot is the highest slot seen, used for events which do not carry a slot.
+	lastSlot uint64
+}
+
+func newEventHistory(size int) *eventHistory {
+	firstId := uint64(time.Now().UnixNano())
+	return &eventHistory{
+		size:    size,
+		rings:   make(map[EventTopic]*eventRing),
+		firstId: firstId,
+		nextId:  firstId,
+	}
+}
+
+// send records the event and hands it over to deliver.
+func (h *eventHistory) send(e *EventStream, deliver func(*EventStream) int) int {
+	h.sendMu.Lock()
+	defer h.sendMu.Unlock()
+	h.record(e)
+	return deliver(e)
+}
+
+func (h *eventHistory) record(e *EventStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e.Id = h.nextId
+	h.nextId++
+	slot, ok := eventSlot(e.Data)
+	if !ok {
+		slot = h.lastSlot
+	} else if slot > h.lastSlot {
+		h.lastSlot = slot
+	}
+	e.Slot = slot
+
+	ring, ok := h.rings[e.Event]
+	if !ok {
+		ring = &eventRing{
+			events:    make([]*EventStream, h.size),
+			overflows: metrics.GetOrCreateCounter(fmt.Sprintf(`beacon_events_buffer_overflow{topic="%s"}`, e.Event)),
+		}
+		h.rings[e.Event] = ring
+	}
+	ring.push(e)
+}
+
+// since returns the retained events of topics with an id greater than lastId, oldest first, and the topics
+// for which some of those events are gone.
+func (h *eventHistory) since(topics []EventTopic, lastId uint64) ([]*EventStream, []Gap) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var (
+		events []*EventStream
+		gaps   []Gap
+	)
+	for _, topic := range topics {
+		ring, ok := h.rings[topic]
+		switch {
+		case lastId+1 < h.firstId:
+			// the id was handed out by a previous run, whatever happened in between is lost.
+			gap := Gap{Topic: topic, LastDroppedId: h.firstId - 1}
+			if ok && ring.dropped {
+				gap.LastDroppedId, gap.LastDroppedSlot = ring.lastDroppedId, ring.lastDroppedSlot
+			}
+			gaps = append(gaps, gap)
+		case ok && ring.dropped && ring.lastDroppedId > lastId:
+			gaps = append(gaps, Gap{Topic: topic, LastDroppedId: ring.lastDroppedId, LastDroppedSlot: ring.lastDroppedSlot})
+		}
+		if !ok {
+			continue
+		}
+		ring.forEach(func(e *EventStream) {
+			if e.Id > lastId {
+				events = append(events, e)
+			}
+		})
+	}
+	sortById(events)
+	return events, gaps
+}
+
+// fromSlot returns the retained events of topics recorded at slot or later, oldest first, and the topics for which
+// some of those events are gone.
+func (h *eventHistory) fromSlot(topics []EventTopic, slot uint64) ([]*EventStream, []Gap) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var (
+		events []*EventStream
+		gaps   []Gap
+	)
+	for _, topic := range topics {
+		ring, ok := h.rings[topic]
+		if !ok {
+			continue
+		}
+		if ring.dropped && ring.lastDroppedSlot >= slot {
+			gaps = append(gaps, Gap{Topic: topic, LastDroppedId: ring.lastDroppedId, LastDroppedSlot: ring.lastDroppedSlot})
+		}
+		ring.forEach(func(e *EventStream) {
+			if e.Slot >= slot {
+				events = append(events, e)
+			}
+		})
+	}
+	sortById(events)
+	return events, gaps
+}
+
+func sortById(events []*EventStream) {
+	sort.Slice(events, func(i, j int) bool { return events[i].Id < events[j].Id })
+}
+
+// eventSlot returns the slot an event refers to, if its payload has one.
+func eventSlot(data interface{}) (uint64, bool) {
+	switch d := data.(type) {
+	case *HeadData:
+		return d.Slot, true
+	case *BlockData:
+		return d.Slot, true
+	case *BlockGossipData:
+		return d.Slot, true
+	case *ChainReorgData:
+		return d.Slot, true
+	case *PayloadAttributesData:
+		return d.Data.ProposalSlot, true
+	case *LightClientFinalityUpdateData:
+		return d.Data.SignatureSlot, true
+	case *LightClientOptimisticUpdateData:
+		return d.Data.SignatureSlot, true
+	case *AttestationData:
+		return d.AttestantionData().Slot(), true
+	case *ContributionAndProofData:
+		if d.Message != nil && d.Message.Contribution != nil {
+			return d.Message.Contribution.Slot, true
+		}
+	case *BlobSidecarData:
+		if d.SignedBlockHeader != nil && d.SignedBlockHeader.Header != nil {
+			return d.SignedBlockHeader.Header.Slot, true
+		}
+	}
+	return 0, false
+}
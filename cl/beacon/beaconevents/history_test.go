@@ -0,0 +1,66 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beaconevents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHistoryReplay(t *testing.T) {
+	h := newEventHistory(3)
+	send := func(e *EventStream) int { return 0 }
+
+	var heads []*EventStream
+	for slot := uint64(1); slot <= 5; slot++ {
+		head := &EventStream{Event: StateHead, Data: &HeadData{Slot: slot}}
+		h.send(head, send)
+		heads = append(heads, head)
+		// finalized checkpoints carry no slot and get the latest one.
+		h.send(&EventStream{Event: StateFinalizedCheckpoint, Data: &FinalizedCheckpointData{}}, send)
+	}
+	for i := 1; i < len(heads); i++ {
+		require.Greater(t, heads[i].Id, heads[i-1].Id)
+	}
+
+	// resuming from a retained event: no gap.
+	events, gaps := h.since([]EventTopic{StateHead}, heads[2].Id)
+	require.Empty(t, gaps)
+	require.Equal(t, []*EventStream{heads[3], heads[4]}, events)
+
+	// resuming from an evicted event: gap marker and the retained events.
+	events, gaps = h.since([]EventTopic{StateHead, StateFinalizedCheckpoint}, heads[0].Id)
+	require.Len(t, gaps, 2)
+	require.Equal(t, heads[1].Id, gaps[0].LastDroppedId)
+	require.Len(t, events, 6)
+	for i := 1; i < len(events); i++ {
+		require.Greater(t, events[i].Id, events[i-1].Id)
+	}
+
+	// ids from a previous run.
+	_, gaps = h.since([]EventTopic{StateHead}, 1)
+	require.Len(t, gaps, 1)
+
+	// replay from slot.
+	events, gaps = h.fromSlot([]EventTopic{StateHead, StateFinalizedCheckpoint}, 4)
+	require.Empty(t, gaps)
+	require.Len(t, events, 4)
+	require.Equal(t, uint64(4), events[0].Slot)
+	_, gaps = h.fromSlot([]EventTopic{StateHead}, 2)
+	require.Equal(t, []Gap{{Topic: StateHead, LastDroppedId: heads[1].Id, LastDroppedSlot: 2}}, gaps)
+}
@@ -10,6 +10,9 @@ import (
 type EventStream struct {
 	Event EventTopic  `json:"event"`
 	Data  interface{} `json:"data"`
+	// Id is a monotonic identifier assigned when the event is emitted, Slot the slot the event refers to.
+	Id   uint64 `json:"-"`
+	Slot uint64 `json:"-"`
 }
 
 type EventTopic string
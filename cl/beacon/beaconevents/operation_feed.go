@@ -3,35 +3,41 @@ package beaconevents
 import ethevent "github.com/erigontech/erigon/event"
 
 type operationFeed struct {
-	feed *ethevent.Feed
+	feed    *ethevent.Feed
+	history *eventHistory
 }
 
-func newOpFeed() *operationFeed {
+func newOpFeed(history *eventHistory) *operationFeed {
 	return &operationFeed{
-		feed: &ethevent.Feed{},
+		feed:    &ethevent.Feed{},
+		history: history,
 	}
 }
 
+func (f *operationFeed) send(e *EventStream) int {
+	return f.history.send(e, func(e *EventStream) int { return f.feed.Send(e) })
+}
+
 func (f *operationFeed) Subscribe(channel chan *EventStream) ethevent.Subscription {
 	return f.feed.Subscribe(channel)
 }
 
 func (f *operationFeed) SendAttestation(value *AttestationData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpAttestation,
 		Data:  value,
 	})
 }
 
 func (f *operationFeed) SendVoluntaryExit(value *VoluntaryExitData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpVoluntaryExit,
 		Data:  value,
 	})
 }
 
 func (f *operationFeed) SendProposerSlashing(value *ProposerSlashingData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpProposerSlashing,
 		Data:  value,
 	})
@@ -39,28 +45,28 @@ func (f *operationFeed) SendProposerSlashing(value *ProposerSlashingData) int {
 }
 
 func (f *operationFeed) SendAttesterSlashing(value *AttesterSlashingData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpAttesterSlashing,
 		Data:  value,
 	})
 }
 
 func (f *operationFeed) SendBlsToExecution(value *BlsToExecutionChangesData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpBlsToExecution,
 		Data:  value,
 	})
 }
 
 func (f *operationFeed) SendContributionProof(value *ContributionAndProofData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpContributionProof,
 		Data:  value,
 	})
 }
 
 func (f *operationFeed) SendBlobSidecar(value *BlobSidecarData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: OpBlobSidecar,
 		Data:  value,
 	})
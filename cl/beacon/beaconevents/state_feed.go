@@ -5,21 +5,27 @@ import (
 )
 
 type stateFeed struct {
-	feed *ethevent.Feed
+	feed    *ethevent.Feed
+	history *eventHistory
 }
 
-func newStateFeed() *stateFeed {
+func newStateFeed(history *eventHistory) *stateFeed {
 	return &stateFeed{
-		feed: &ethevent.Feed{},
+		feed:    &ethevent.Feed{},
+		history: history,
 	}
 }
 
+func (f *stateFeed) send(e *EventStream) int {
+	return f.history.send(e, func(e *EventStream) int { return f.feed.Send(e) })
+}
+
 func (f *stateFeed) Subscribe(channel chan *EventStream) ethevent.Subscription {
 	return f.feed.Subscribe(channel)
 }
 
 func (f *stateFeed) SendHead(value *HeadData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateHead,
 		Data:  value,
 	})
@@ -27,7 +33,7 @@ func (f *stateFeed) SendHead(value *HeadData) int {
 
 // The node has received a block (from P2P or API) that is successfully imported on the fork-choice on_block handler
 func (f *stateFeed) SendBlock(value *BlockData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateBlock,
 		Data:  value,
 	})
@@ -35,42 +41,42 @@ func (f *stateFeed) SendBlock(value *BlockData) int {
 
 // The node has received a block (from P2P or API) that passes validation rules of the beacon_block topic
 func (f *stateFeed) SendBlockGossip(value *BlockGossipData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateBlockGossip,
 		Data:  value,
 	})
 }
 
 func (f *stateFeed) SendFinalizedCheckpoint(value *FinalizedCheckpointData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateFinalizedCheckpoint,
 		Data:  value,
 	})
 }
 
 func (f *stateFeed) SendLightClientFinalityUpdate(value *LightClientFinalityUpdateData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateLightClientFinalityUpdate,
 		Data:  value,
 	})
 }
 
 func (f *stateFeed) SendLightClientOptimisticUpdate(value *LightClientOptimisticUpdateData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateLightClientOptimisticUpdate,
 		Data:  value,
 	})
 }
 
 func (f *stateFeed) SendChainReorg(value *ChainReorgData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StateChainReorg,
 		Data:  value,
 	})
 }
 
 func (f *stateFeed) SendPayloadAttributes(value *PayloadAttributesData) int {
-	return f.feed.Send(&EventStream{
+	return f.send(&EventStream{
 		Event: StatePayloadAttributes,
 		Data:  value,
 	})
@@ -20,13 +20,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
 	event "github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
 )
 
+// gapEventName is the SSE event sent when events requested for replay are no longer retained.
+const gapEventName = "gap"
+
+var eventStreamGaps = metrics.GetOrCreateCounter("beacon_events_replay_gaps")
+
 var validTopics = map[event.EventTopic]struct{}{
 	// operation events
 	event.OpAttestation:       {},
@@ -56,6 +64,22 @@ func (a *ApiHandler) EventSourceGetV1Events(w http.ResponseWriter, r *http.Reque
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 
+	// Resume after the last event the client saw, or replay everything retained from a slot onward.
+	var lastEventId *uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid Last-Event-ID: "+v, http.StatusBadRequest)
+			return
+		}
+		lastEventId = &id
+	}
+	replayFromSlot, err := beaconhttp.Uint64FromQueryParams(r, "replay_from_slot")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	topics := r.URL.Query()["topics"]
 	subscribeTopics := mapset.NewSet[event.EventTopic]()
 	for _, v := range topics {
@@ -74,26 +98,51 @@ func (a *ApiHandler) EventSourceGetV1Events(w http.ResponseWriter, r *http.Reque
 	defer opSub.Unsubscribe()
 	defer stateSub.Unsubscribe()
 
+	// Replay after subscribing so that nothing falls between the history and the live stream, live events
+	// which were already replayed are skipped by id.
+	var replayedUpTo uint64
+	if lastEventId != nil || replayFromSlot != nil {
+		var (
+			replay []*event.EventStream
+			gaps   []event.Gap
+		)
+		if lastEventId != nil {
+			replay, gaps = a.emitters.EventsSince(subscribeTopics.ToSlice(), *lastEventId)
+		} else {
+			replay, gaps = a.emitters.EventsFromSlot(subscribeTopics.ToSlice(), *replayFromSlot)
+		}
+		for _, gap := range gaps {
+			eventStreamGaps.Inc()
+			buf, err := json.Marshal(gap)
+			if err != nil {
+				log.Warn("failed to encode gap", "err", err, "topic", gap.Topic)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", gapEventName, string(buf)); err != nil {
+				log.Warn("failed to write event", "err", err)
+				return
+			}
+		}
+		for _, e := range replay {
+			if err := writeEvent(w, e); err != nil {
+				log.Warn("failed to write event", "err", err)
+				return
+			}
+			replayedUpTo = e.Id
+		}
+		w.(http.Flusher).Flush()
+	}
+
 	ticker := time.NewTicker(time.Duration(a.beaconChainCfg.SecondsPerSlot) * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case e := <-eventCh:
-			if !subscribeTopics.Contains(e.Event) {
-				continue
-			}
-			if e.Data == nil {
-				log.Warn("event data is nil", "event", e)
+			if !subscribeTopics.Contains(e.Event) || e.Id <= replayedUpTo {
 				continue
 			}
-			// marshal and send
-			buf, err := json.Marshal(e.Data)
-			if err != nil {
-				log.Warn("failed to encode data", "err", err, "topic", e.Event)
-				continue
-			}
-			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Event, string(buf)); err != nil {
+			if err := writeEvent(w, e); err != nil {
 				log.Warn("failed to write event", "err", err)
 				continue
 			}
@@ -118,3 +167,18 @@ func (a *ApiHandler) EventSourceGetV1Events(w http.ResponseWriter, r *http.Reque
 		}
 	}
 }
+
+// writeEvent writes e in the SSE format, its id lets clients resume the stream through Last-Event-ID.
+func writeEvent(w http.ResponseWriter, e *event.EventStream) error {
+	if e.Data == nil {
+		log.Warn("event data is nil", "event", e)
+		return nil
+	}
+	buf, err := json.Marshal(e.Data)
+	if err != nil {
+		log.Warn("failed to encode data", "err", err, "topic", e.Event)
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Event, string(buf))
+	return err
+}
@@ -21,9 +21,11 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 )
 
 func (a *ApiHandler) GetEthV1BeaconLightClientBootstrap(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
@@ -78,6 +80,18 @@ func (a *ApiHandler) GetEthV1BeaconLightClientFinalityUpdate(w http.ResponseWrit
 	}).WithVersion(version), nil
 }
 
+// lightClientUpdate returns the best update of a period, looking at the persisted history if forkchoice does not have it.
+func (a *ApiHandler) lightClientUpdate(tx kv.Tx, period uint64) (*cltypes.LightClientUpdate, bool, error) {
+	if update, has := a.forkchoiceStore.GetLightClientUpdate(period); has {
+		return update, true, nil
+	}
+	update, err := beacon_indicies.ReadLightClientUpdate(tx, period)
+	if err != nil {
+		return nil, false, err
+	}
+	return update, update != nil, nil
+}
+
 func (a *ApiHandler) GetEthV1BeaconLightClientUpdates(w http.ResponseWriter, r *http.Request) {
 
 	startPeriod, err := beaconhttp.Uint64FromQueryParams(r, "start_period")
@@ -99,6 +113,13 @@ func (a *ApiHandler) GetEthV1BeaconLightClientUpdates(w http.ResponseWriter, r *
 		return
 	}
 
+	tx, err := a.indiciesDB.BeginRo(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
 	resp := []interface{}{}
 	endPeriod := *startPeriod + *count
 	currentSlot := a.ethClock.GetCurrentSlot()
@@ -110,7 +131,11 @@ func (a *ApiHandler) GetEthV1BeaconLightClientUpdates(w http.ResponseWriter, r *
 	// Fetch from [start_period, start_period + count]
 	for i := *startPeriod; i <= endPeriod; i++ {
 		respUpdate := map[string]interface{}{}
-		update, has := a.forkchoiceStore.GetLightClientUpdate(i)
+		update, has, err := a.lightClientUpdate(tx, i)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		if !has {
 			notFoundPrev = true
 			continue
@@ -77,6 +77,41 @@ func (a *ApiHandler) blockRootFromStateId(ctx context.Context, tx kv.Tx, stateId
 	}
 }
 
+// emptyHistoricalSlot returns the slot referenced by stateId if it is an archived slot without a canonical block.
+// Such states are not reachable through a block root and have to be read from the historical states reader directly.
+func (a *ApiHandler) emptyHistoricalSlot(tx kv.Tx, stateId *beaconhttp.SegmentID) (uint64, bool, error) {
+	slot := stateId.GetSlot()
+	if slot == nil || *slot >= a.forkchoiceStore.LowestAvaiableSlot() {
+		return 0, false, nil
+	}
+	root, err := beacon_indicies.ReadCanonicalBlockRoot(tx, *slot)
+	if err != nil {
+		return 0, false, err
+	}
+	return *slot, root == (libcommon.Hash{}), nil
+}
+
+// blockRootFromStateIdOrEmptySlot is blockRootFromStateId but yields a zero root instead of failing for
+// archived slots without a block, which callers then serve through emptyHistoricalSlot.
+func (a *ApiHandler) blockRootFromStateIdOrEmptySlot(ctx context.Context, tx kv.Tx, stateId *beaconhttp.SegmentID) (libcommon.Hash, int, error) {
+	if _, ok, err := a.emptyHistoricalSlot(tx, stateId); err != nil {
+		return libcommon.Hash{}, http.StatusInternalServerError, err
+	} else if ok {
+		return libcommon.Hash{}, http.StatusOK, nil
+	}
+	return a.blockRootFromStateId(ctx, tx, stateId)
+}
+
+// readEmptyHistoricalSlotValidators reads validators and balances of an archived slot without a block by replaying
+// the empty slots on top of the nearest stored state.
+func (a *ApiHandler) readEmptyHistoricalSlotValidators(ctx context.Context, tx kv.Tx, slot uint64) (*solid.ValidatorSet, solid.Uint64ListSSZ, error) {
+	s, err := a.stateReader.ReadHistoricalState(ctx, tx, slot)
+	if err != nil || s == nil {
+		return nil, nil, err
+	}
+	return s.Validators(), s.Balances(), nil
+}
+
 type rootResponse struct {
 	Root libcommon.Hash `json:"root"`
 }
@@ -186,6 +221,19 @@ func (a *ApiHandler) getFullState(w http.ResponseWriter, r *http.Request) (*beac
 		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
 	}
 
+	if slot, ok, err := a.emptyHistoricalSlot(tx, blockId); err != nil {
+		return nil, err
+	} else if ok {
+		state, err := a.stateReader.ReadHistoricalState(ctx, tx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state at slot %d", slot))
+		}
+		return newBeaconResponse(state).WithFinalized(true).WithVersion(state.Version()).WithOptimistic(false), nil
+	}
+
 	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
 	if err != nil {
 		return nil, beaconhttp.NewEndpointError(httpStatus, err)
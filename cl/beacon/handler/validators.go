@@ -229,7 +229,7 @@ func (a *ApiHandler) GetEthV1BeaconStatesValidators(w http.ResponseWriter, r *ht
 		return
 	}
 
-	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	blockRoot, httpStatus, err := a.blockRootFromStateIdOrEmptySlot(ctx, tx, blockId)
 	if err != nil {
 		http.Error(w, err.Error(), httpStatus)
 		return
@@ -275,7 +275,7 @@ func (a *ApiHandler) PostEthV1BeaconStatesValidators(w http.ResponseWriter, r *h
 		return
 	}
 
-	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	blockRoot, httpStatus, err := a.blockRootFromStateIdOrEmptySlot(ctx, tx, blockId)
 	if err != nil {
 		http.Error(w, err.Error(), httpStatus)
 		return
@@ -326,6 +326,22 @@ func (a *ApiHandler) writeValidatorsResponse(
 		responseValidators(w, filterIndicies, statusFilters, state.Epoch(s), s.Balances(), s.Validators(), false, isOptimistic)
 		return
 	}
+	if emptySlot, ok, err := a.emptyHistoricalSlot(tx, blockId); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if ok {
+		validatorSet, balances, err := a.readEmptyHistoricalSlotValidators(r.Context(), tx, emptySlot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if validatorSet == nil {
+			http.Error(w, fmt.Errorf("state not found for slot %v", emptySlot).Error(), http.StatusNotFound)
+			return
+		}
+		responseValidators(w, filterIndicies, statusFilters, emptySlot/a.beaconChainCfg.SlotsPerEpoch, balances, validatorSet, true, false)
+		return
+	}
 	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -437,7 +453,7 @@ func (a *ApiHandler) GetEthV1BeaconStatesValidator(w http.ResponseWriter, r *htt
 		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
 	}
 
-	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	blockRoot, httpStatus, err := a.blockRootFromStateIdOrEmptySlot(ctx, tx, blockId)
 	if err != nil {
 		return nil, beaconhttp.NewEndpointError(httpStatus, err)
 	}
@@ -464,6 +480,18 @@ func (a *ApiHandler) GetEthV1BeaconStatesValidator(w http.ResponseWriter, r *htt
 		}
 		return responseValidator(validatorIndex, state.Epoch(s), s.Balances(), s.Validators(), false, isOptimistic)
 	}
+	if emptySlot, ok, err := a.emptyHistoricalSlot(tx, blockId); err != nil {
+		return nil, err
+	} else if ok {
+		validatorSet, balances, err := a.readEmptyHistoricalSlotValidators(ctx, tx, emptySlot)
+		if err != nil {
+			return nil, err
+		}
+		if validatorSet == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, errors.New("validators not found"))
+		}
+		return responseValidator(validatorIndex, emptySlot/a.beaconChainCfg.SlotsPerEpoch, balances, validatorSet, true, false)
+	}
 	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
 	if err != nil {
 		return nil, err
@@ -561,7 +589,7 @@ func (a *ApiHandler) getValidatorBalances(ctx context.Context, w http.ResponseWr
 	}
 	defer tx.Rollback()
 
-	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	blockRoot, httpStatus, err := a.blockRootFromStateIdOrEmptySlot(ctx, tx, blockId)
 	if err != nil {
 		http.Error(w, err.Error(), httpStatus)
 		return
@@ -584,6 +612,22 @@ func (a *ApiHandler) getValidatorBalances(ctx context.Context, w http.ResponseWr
 		responseValidatorsBalances(w, filterIndicies, s.Balances(), false, isOptimistic)
 		return
 	}
+	if emptySlot, ok, err := a.emptyHistoricalSlot(tx, blockId); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if ok {
+		_, balances, err := a.readEmptyHistoricalSlotValidators(ctx, tx, emptySlot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if balances == nil {
+			http.Error(w, fmt.Errorf("state not found for slot %v", emptySlot).Error(), http.StatusNotFound)
+			return
+		}
+		responseValidatorsBalances(w, filterIndicies, balances, true, false)
+		return
+	}
 	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -44,7 +44,9 @@ type CaplinConfig struct {
 	BlobBackfilling     bool
 	BlobPruningDisabled bool
 	Archive             bool
-	NetworkId           NetworkType
+	// StatesCheckpointInterval is the number of slots between full state checkpoints written by the archival node
+	StatesCheckpointInterval uint64
+	NetworkId                NetworkType
 	// DisableCheckpointSync is optional and is used to disable checkpoint sync used by default in the node
 	DisabledCheckpointSync bool
 	// CaplinMeVRelayUrl is optional and is used to connect to the external builder service.
@@ -63,6 +65,12 @@ type CaplinConfig struct {
 	CaplinDiscoveryTCPPort uint64
 	SentinelAddr           string
 	SentinelPort           uint64
+	// SentinelDebugAddr is optional and is used to serve the sentinel debug endpoints (peer scores)
+	SentinelDebugAddr string
+	// SentinelScoreParamsPath is optional and points to a yaml file overriding the gossipsub topic score parameters
+	SentinelScoreParamsPath string
+	// GossipCaptureDir is optional and enables archiving gossip messages that were rejected by validation
+	GossipCaptureDir string
 	// Erigon Sync
 	LoopBlockLimit uint64
 	// Beacon API router configuration
@@ -107,6 +115,8 @@ const (
 const (
 	SubDivisionFolderSize = 10_000
 	SlotsPerDump          = 1536
+	// DefaultStateCheckpointInterval is the default number of slots between full historical state checkpoints.
+	DefaultStateCheckpointInterval = 8192
 )
 
 var (
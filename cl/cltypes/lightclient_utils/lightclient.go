@@ -199,3 +199,83 @@ func CreateLightClientBootstrap(state *state.CachingBeaconState, block *cltypes.
 		CurrentSyncCommitteeBranch: hashVector,
 	}, nil
 }
+
+// IsBetterUpdate implements the specs is_better_update, it returns true if newUpdate should replace oldUpdate
+// as the best light client update of its sync committee period.
+func IsBetterUpdate(cfg *clparams.BeaconChainConfig, newUpdate, oldUpdate *cltypes.LightClientUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation
+	maxActiveParticipants := len(newUpdate.SyncAggregate.SyncCommiteeBits) * 8
+	newNumActiveParticipants := newUpdate.SyncAggregate.Sum()
+	oldNumActiveParticipants := oldUpdate.SyncAggregate.Sum()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee
+	newHasRelevantSyncCommittee := IsSyncCommitteeUpdate(newUpdate) &&
+		cfg.SyncCommitteePeriod(newUpdate.AttestedHeader.Beacon.Slot) == cfg.SyncCommitteePeriod(newUpdate.SignatureSlot)
+	oldHasRelevantSyncCommittee := IsSyncCommitteeUpdate(oldUpdate) &&
+		cfg.SyncCommitteePeriod(oldUpdate.AttestedHeader.Beacon.Slot) == cfg.SyncCommitteePeriod(oldUpdate.SignatureSlot)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// Compare indication of any finality
+	newHasFinality := IsFinalityUpdate(newUpdate)
+	oldHasFinality := IsFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality
+	if newHasFinality {
+		newHasSyncCommitteeFinality := cfg.SyncCommitteePeriod(newUpdate.FinalizedHeader.Beacon.Slot) == cfg.SyncCommitteePeriod(newUpdate.AttestedHeader.Beacon.Slot)
+		oldHasSyncCommitteeFinality := cfg.SyncCommitteePeriod(oldUpdate.FinalizedHeader.Beacon.Slot) == cfg.SyncCommitteePeriod(oldUpdate.AttestedHeader.Beacon.Slot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best)
+	if newUpdate.AttestedHeader.Beacon.Slot != oldUpdate.AttestedHeader.Beacon.Slot {
+		return newUpdate.AttestedHeader.Beacon.Slot < oldUpdate.AttestedHeader.Beacon.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+// IsSyncCommitteeUpdate implements the specs is_sync_committee_update.
+//
+// def is_sync_committee_update(update: LightClientUpdate) -> bool:
+//
+//	return update.next_sync_committee_branch != NextSyncCommitteeBranch()
+func IsSyncCommitteeUpdate(update *cltypes.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+// IsFinalityUpdate implements the specs is_finality_update.
+//
+// def is_finality_update(update: LightClientUpdate) -> bool:
+//
+//	return update.finality_branch != FinalityBranch()
+func IsFinalityUpdate(update *cltypes.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+func isEmptyBranch(branch solid.HashVectorSSZ) bool {
+	empty := true
+	branch.Range(func(_ int, h libcommon.Hash, _ int) bool {
+		empty = h == (libcommon.Hash{})
+		return empty
+	})
+	return empty
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package lightclient_utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+)
+
+func newTestUpdate(participants int, finality bool, attestedSlot uint64) *cltypes.LightClientUpdate {
+	u := cltypes.NewLightClientUpdate(clparams.DenebVersion)
+	for i := 0; i < participants; i++ {
+		u.SyncAggregate.SyncCommiteeBits[i/8] |= 1 << (i % 8)
+	}
+	if finality {
+		u.FinalityBranch.Set(0, libcommon.Hash{1})
+	}
+	u.AttestedHeader.Beacon.Slot = attestedSlot
+	u.SignatureSlot = attestedSlot + 1
+	return u
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+
+	// supermajority beats everything else
+	require.True(t, IsBetterUpdate(cfg, newTestUpdate(400, false, 10), newTestUpdate(300, true, 10)))
+	require.False(t, IsBetterUpdate(cfg, newTestUpdate(300, true, 10), newTestUpdate(400, false, 10)))
+	// without supermajority, more participants win
+	require.True(t, IsBetterUpdate(cfg, newTestUpdate(301, false, 10), newTestUpdate(300, true, 10)))
+	// with supermajority, finality wins over participation
+	require.True(t, IsBetterUpdate(cfg, newTestUpdate(400, true, 10), newTestUpdate(500, false, 10)))
+	// then participation
+	require.True(t, IsBetterUpdate(cfg, newTestUpdate(500, true, 10), newTestUpdate(400, true, 10)))
+	// then older data
+	require.True(t, IsBetterUpdate(cfg, newTestUpdate(400, true, 9), newTestUpdate(400, true, 10)))
+	require.False(t, IsBetterUpdate(cfg, newTestUpdate(400, true, 10), newTestUpdate(400, true, 10)))
+}
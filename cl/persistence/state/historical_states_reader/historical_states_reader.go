@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
@@ -34,6 +35,7 @@ import (
 	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/phase1/core/state/lru"
+	"github.com/erigontech/erigon/cl/transition"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 	"github.com/klauspost/compress/zstd"
 )
@@ -47,6 +49,9 @@ type HistoricalStatesReader struct {
 	validatorTable *state_accessors.StaticValidatorTable // We can save 80% of the I/O by caching the validator table
 	blockReader    freezeblocks.BeaconSnapshotReader
 	genesisState   *state.CachingBeaconState
+	// full state checkpoints are stored every stateCheckpointInterval slots, so any slot within that many slots
+	// after one is served by replaying the blocks on top of it.
+	stateCheckpointInterval uint64
 
 	// cache for shuffled sets
 	shuffledSetsCache *lru.Cache[uint64, []uint64]
@@ -65,11 +70,21 @@ func NewHistoricalStatesReader(cfg *clparams.BeaconChainConfig, blockReader free
 		genesisState:      genesisState,
 		validatorTable:    validatorTable,
 		shuffledSetsCache: cache,
+
+		stateCheckpointInterval: clparams.DefaultStateCheckpointInterval,
 	}
 }
 
+// SetStateCheckpointInterval sets how many slots apart the full state checkpoints read by the reader are stored.
+func (r *HistoricalStatesReader) SetStateCheckpointInterval(interval uint64) {
+	r.stateCheckpointInterval = interval
+}
+
+// ReadHistoricalState returns the state at any slot up to the latest processed one. When a full state checkpoint is
+// at most one checkpoint interval before the slot, the state is obtained by applying the blocks since the checkpoint
+// to it. Otherwise a state at a block is reconstructed from the historical tables and a state at an empty slot is
+// obtained by advancing the closest previous state through the empty slots.
 func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.Tx, slot uint64) (*state.CachingBeaconState, error) {
-	ret := state.New(r.cfg)
 	latestProcessedState, err := state_accessors.GetStateProcessingProgress(tx)
 	if err != nil {
 		return nil, err
@@ -84,15 +99,113 @@ func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.
 	if slot == r.genesisState.Slot() {
 		return r.genesisState.Copy()
 	}
+	start := time.Now()
+	checkpointSlot, compressedCheckpoint, err := state_accessors.ReadNearestStateCheckpoint(tx, slot)
+	if err != nil {
+		return nil, err
+	}
+	if compressedCheckpoint != nil && slot-checkpointSlot <= r.stateCheckpointInterval {
+		defer observeStateReadLatency(stateReadFromCheckpoint, start)
+		return r.replayFromStateCheckpoint(ctx, tx, checkpointSlot, compressedCheckpoint, slot)
+	}
+
 	// Read the current block (we need the block header) + other stuff
 	block, err := r.blockReader.ReadBlockBySlot(ctx, tx, slot)
 	if err != nil {
 		return nil, err
 	}
-	if block == nil {
-		log.Warn("block not found", "slot", slot)
-		return nil, nil
+	if block != nil {
+		defer observeStateReadLatency(stateReadReconstructed, start)
+		return r.reconstructState(tx, slot, block)
+	}
+
+	// Empty slot, find the closest previous state and process the empty slots on top of it.
+	baseSlot := slot - 1
+	for ; baseSlot > r.genesisState.Slot(); baseSlot-- {
+		slotData, err := state_accessors.ReadSlotData(tx, baseSlot)
+		if err != nil {
+			return nil, err
+		}
+		if slotData != nil {
+			break
+		}
+	}
+	base, err := r.ReadHistoricalState(ctx, tx, baseSlot)
+	if err != nil || base == nil {
+		return nil, err
+	}
+	if err := base.InitBeaconState(); err != nil {
+		return nil, err
+	}
+	if err := transition.DefaultMachine.ProcessSlots(base, slot); err != nil {
+		return nil, fmt.Errorf("failed to process empty slots %d..%d: %w", baseSlot, slot, err)
+	}
+	observeStateReadLatency(stateReadEmptySlot, start)
+	return base, nil
+}
+
+// replayFromStateCheckpoint decodes the checkpoint and applies the blocks after it up to the given slot, empty slots at
+// the end are processed as well.
+func (r *HistoricalStatesReader) replayFromStateCheckpoint(ctx context.Context, tx kv.Tx, checkpointSlot uint64, compressed []byte, slot uint64) (*state.CachingBeaconState, error) {
+	ret, err := r.decodeStateCheckpoint(tx, checkpointSlot, compressed)
+	if err != nil {
+		return nil, err
+	}
+	if checkpointSlot == slot {
+		return ret, nil
+	}
+	if err := ret.InitBeaconState(); err != nil {
+		return nil, err
 	}
+	for current := checkpointSlot + 1; current <= slot; current++ {
+		block, err := r.blockReader.ReadBlockBySlot(ctx, tx, current)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			continue
+		}
+		// the blocks were already verified when they were first processed.
+		if err := transition.TransitionState(ret, block, nil, false); err != nil {
+			return nil, fmt.Errorf("failed to replay block at slot %d on top of the checkpoint at slot %d: %w", current, checkpointSlot, err)
+		}
+	}
+	if ret.Slot() < slot {
+		if err := transition.DefaultMachine.ProcessSlots(ret, slot); err != nil {
+			return nil, fmt.Errorf("failed to process empty slots %d..%d: %w", ret.Slot(), slot, err)
+		}
+	}
+	return ret, nil
+}
+
+func (r *HistoricalStatesReader) decodeStateCheckpoint(tx kv.Tx, slot uint64, compressed []byte) (*state.CachingBeaconState, error) {
+	// checkpoints are taken at blocks, so the version of the state is in the slot data.
+	slotData, err := state_accessors.ReadSlotData(tx, slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotData == nil {
+		return nil, fmt.Errorf("slot data not found for state checkpoint at slot %d", slot)
+	}
+	decompressor, err := zstd.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer decompressor.Close()
+	encoded, err := io.ReadAll(decompressor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress state checkpoint at slot %d: %w", slot, err)
+	}
+	ret := state.New(r.cfg)
+	if err := ret.DecodeSSZ(encoded, int(slotData.Version)); err != nil {
+		return nil, fmt.Errorf("failed to decode state checkpoint at slot %d: %w", slot, err)
+	}
+	return ret, nil
+}
+
+// reconstructState rebuilds the state right after the given block from the historical tables.
+func (r *HistoricalStatesReader) reconstructState(tx kv.Tx, slot uint64, block *cltypes.SignedBeaconBlock) (*state.CachingBeaconState, error) {
+	ret := state.New(r.cfg)
 	blockHeader := block.SignedBeaconBlockHeader().Header
 	blockHeader.Root = common.Hash{}
 	// Read the epoch and per-slot data.
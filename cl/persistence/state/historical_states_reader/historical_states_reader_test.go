@@ -24,12 +24,14 @@ import (
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/antiquary"
 	"github.com/erigontech/erigon/cl/antiquary/tests"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/base_encoding"
 	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
 	"github.com/erigontech/erigon/cl/persistence/state/historical_states_reader"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
@@ -78,3 +80,78 @@ func TestStateAntiquaryBellatrix(t *testing.T) {
 	blocks, preState, postState := tests.GetBellatrixRandom()
 	runTest(t, blocks, preState, postState)
 }
+
+func TestReadHistoricalStateFromCheckpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("replays a whole chain twice")
+	}
+	blocks, preState, postState := tests.GetBellatrixRandom()
+	ctx := context.Background()
+
+	// antiquate the same chain with and without checkpoints.
+	antiquate := func(checkpointInterval uint64) (kv.RwDB, *historical_states_reader.HistoricalStatesReader) {
+		db := memdb.NewTestDB(t)
+		reader := tests.LoadChain(blocks, postState, db, t)
+		genesis, err := preState.Copy()
+		require.NoError(t, err)
+		a := antiquary.NewAntiquary(ctx, nil, genesis, state_accessors.NewStaticValidatorTable(), &clparams.MainnetBeaconConfig, datadir.New(t.TempDir()), nil, db, nil, reader, log.New(), true, true, true, nil)
+		a.SetStateCheckpointInterval(checkpointInterval)
+		require.NoError(t, a.IncrementBeaconState(ctx, blocks[len(blocks)-1].Block.Slot+33))
+
+		tx, err := db.BeginRo(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		vt := state_accessors.NewStaticValidatorTable()
+		require.NoError(t, state_accessors.ReadValidatorsTable(tx, vt))
+		hr := historical_states_reader.NewHistoricalStatesReader(&clparams.MainnetBeaconConfig, reader, vt, preState)
+		hr.SetStateCheckpointInterval(checkpointInterval)
+		return db, hr
+	}
+	fullDB, fullReader := antiquate(0)
+	checkpointsDB, checkpointsReader := antiquate(32)
+
+	fullTx, err := fullDB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer fullTx.Rollback()
+	checkpointsTx, err := checkpointsDB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer checkpointsTx.Rollback()
+
+	var checkpoints []uint64
+	require.NoError(t, checkpointsTx.ForEach(kv.StateCheckpoints, nil, func(k, _ []byte) error {
+		checkpoints = append(checkpoints, base_encoding.Decode64FromBytes4(k))
+		return nil
+	}))
+	require.GreaterOrEqual(t, len(checkpoints), 2)
+
+	// every block after the first checkpoint is replayed on top of a checkpoint.
+	for _, block := range blocks {
+		if block.Block.Slot <= checkpoints[0] {
+			continue
+		}
+		replayed, err := checkpointsReader.ReadHistoricalState(ctx, checkpointsTx, block.Block.Slot)
+		require.NoError(t, err)
+		replayedRoot, err := replayed.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, block.Block.StateRoot, libcommon.Hash(replayedRoot), "slot %d", block.Block.Slot)
+	}
+
+	// the last block before the last checkpoint matches the state reconstructed from the historical tables.
+	var block *cltypes.SignedBeaconBlock
+	for _, b := range blocks {
+		if b.Block.Slot > checkpoints[len(checkpoints)-2] && b.Block.Slot < checkpoints[len(checkpoints)-1] {
+			block = b
+		}
+	}
+	require.NotNil(t, block)
+
+	expected, err := fullReader.ReadHistoricalState(ctx, fullTx, block.Block.Slot)
+	require.NoError(t, err)
+	expectedRoot, err := expected.HashSSZ()
+	require.NoError(t, err)
+	replayed, err := checkpointsReader.ReadHistoricalState(ctx, checkpointsTx, block.Block.Slot)
+	require.NoError(t, err)
+	replayedRoot, err := replayed.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, replayedRoot)
+}
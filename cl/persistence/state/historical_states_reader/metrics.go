@@ -0,0 +1,40 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package historical_states_reader
+
+import (
+	"time"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// How a historical state was obtained.
+const (
+	stateReadFromCheckpoint = "checkpoint"
+	stateReadReconstructed  = "reconstructed"
+	stateReadEmptySlot      = "empty_slot"
+)
+
+var stateReadLatency = map[string]metrics.Summary{
+	stateReadFromCheckpoint: metrics.GetOrCreateSummary(`caplin_historical_state_read{source="checkpoint"}`),
+	stateReadReconstructed:  metrics.GetOrCreateSummary(`caplin_historical_state_read{source="reconstructed"}`),
+	stateReadEmptySlot:      metrics.GetOrCreateSummary(`caplin_historical_state_read{source="empty_slot"}`),
+}
+
+func observeStateReadLatency(source string, start time.Time) {
+	stateReadLatency[source].ObserveDuration(start)
+}
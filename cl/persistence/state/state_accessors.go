@@ -313,3 +313,46 @@ func ReadActiveIndicies(tx kv.Tx, slot uint64) ([]uint64, error) {
 	buf := bytes.NewBuffer(v)
 	return base_encoding.ReadRabbits(nil, buf)
 }
+
+// ReadNearestStateCheckpoint returns the latest full state checkpoint at or before the given slot.
+// The returned state is zstd compressed and nil if there is no such checkpoint.
+func ReadNearestStateCheckpoint(tx kv.Tx, slot uint64) (checkpointSlot uint64, compressed []byte, err error) {
+	cursor, err := tx.Cursor(kv.StateCheckpoints)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cursor.Close()
+	key := base_encoding.Encode64ToBytes4(slot)
+	k, v, err := cursor.Seek(key)
+	if err != nil {
+		return 0, nil, err
+	}
+	switch {
+	case k == nil:
+		// every checkpoint is before the slot.
+		k, v, err = cursor.Last()
+	case !bytes.Equal(k, key):
+		k, v, err = cursor.Prev()
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if k == nil {
+		return 0, nil, nil
+	}
+	return base_encoding.Decode64FromBytes4(k), v, nil
+}
+
+// ReadLatestStateCheckpointSlot returns the slot of the most recent full state checkpoint, or false if there is none.
+func ReadLatestStateCheckpointSlot(tx kv.Tx) (uint64, bool, error) {
+	cursor, err := tx.Cursor(kv.StateCheckpoints)
+	if err != nil {
+		return 0, false, err
+	}
+	defer cursor.Close()
+	k, _, err := cursor.Last()
+	if err != nil || k == nil {
+		return 0, false, err
+	}
+	return base_encoding.Decode64FromBytes4(k), true, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state_accessors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon/cl/persistence/base_encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNearestStateCheckpoint(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, ok, err := ReadLatestStateCheckpointSlot(tx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for _, slot := range []uint64{100, 200, 300} {
+		require.NoError(t, tx.Put(kv.StateCheckpoints, base_encoding.Encode64ToBytes4(slot), []byte{byte(slot / 100)}))
+	}
+
+	for _, tc := range []struct {
+		slot, expected uint64
+		found          bool
+	}{
+		{slot: 50, found: false},
+		{slot: 100, expected: 100, found: true},
+		{slot: 150, expected: 100, found: true},
+		{slot: 300, expected: 300, found: true},
+		{slot: 1000, expected: 300, found: true},
+	} {
+		checkpointSlot, compressed, err := ReadNearestStateCheckpoint(tx, tc.slot)
+		require.NoError(t, err)
+		if !tc.found {
+			require.Nil(t, compressed)
+			continue
+		}
+		require.Equal(t, tc.expected, checkpointSlot)
+		require.Equal(t, []byte{byte(tc.expected / 100)}, compressed)
+	}
+
+	latest, ok, err := ReadLatestStateCheckpointSlot(tx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(300), latest)
+}
@@ -252,9 +252,9 @@ func (f *forkGraphDisk) AddChainSegment(signedBlock *cltypes.SignedBeaconBlock,
 		} else {
 			f.newestLightClientUpdate.Store(lcUpdate)
 			period := f.beaconCfg.SyncCommitteePeriod(newState.Slot())
-			_, hasPeriod := f.lightClientUpdates.Load(period)
-			if !hasPeriod {
-				log.Info("Adding light client update", "period", period)
+			best, hasPeriod := f.lightClientUpdates.Load(period)
+			if !hasPeriod || lightclient_utils.IsBetterUpdate(f.beaconCfg, lcUpdate, best.(*cltypes.LightClientUpdate)) {
+				log.Debug("Updating best light client update", "period", period, "signatureSlot", lcUpdate.SignatureSlot)
 				f.lightClientUpdates.Store(period, lcUpdate)
 			}
 			// light client events
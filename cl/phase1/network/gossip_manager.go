@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"sync"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -28,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	sentinel "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
+	"github.com/erigontech/erigon-lib/metrics"
 	"github.com/erigontech/erigon-lib/types/ssz"
 	"github.com/erigontech/erigon/cl/beacon/beaconevents"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -35,6 +38,7 @@ import (
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/erigontech/erigon/cl/gossip"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice"
+	"github.com/erigontech/erigon/cl/phase1/network/gossipcapture"
 	"github.com/erigontech/erigon/cl/phase1/network/services"
 	"github.com/erigontech/erigon/cl/utils/eth_clock"
 	"github.com/erigontech/erigon/cl/validator/committee_subscription"
@@ -63,6 +67,14 @@ type GossipManager struct {
 	voluntaryExitService         services.VoluntaryExitService
 	blsToExecutionChangeService  services.BLSToExecutionChangeService
 	proposerSlashingService      services.ProposerSlashingService
+
+	// capture archives rejected messages, nil if disabled
+	capture *gossipcapture.Writer
+
+	// light client updates published so far, see PublishLightClientUpdate
+	lightClientMu             sync.Mutex
+	publishedFinalityUpdate   *cltypes.LightClientUpdate
+	publishedOptimisticUpdate *cltypes.LightClientUpdate
 }
 
 func NewGossipReceiver(
@@ -81,6 +93,7 @@ func NewGossipReceiver(
 	voluntaryExitService services.VoluntaryExitService,
 	blsToExecutionChangeService services.BLSToExecutionChangeService,
 	proposerSlashingService services.ProposerSlashingService,
+	capture *gossipcapture.Writer,
 ) *GossipManager {
 	return &GossipManager{
 		sentinel:                     s,
@@ -98,6 +111,7 @@ func NewGossipReceiver(
 		voluntaryExitService:         voluntaryExitService,
 		blsToExecutionChangeService:  blsToExecutionChangeService,
 		proposerSlashingService:      proposerSlashingService,
+		capture:                      capture,
 	}
 }
 
@@ -106,7 +120,7 @@ func operationsContract[T ssz.EncodableSSZ](ctx context.Context, g *GossipManage
 	object := t.Clone().(T)
 	if err := object.DecodeSSZ(common.CopyBytes(data.Data), version); err != nil {
 		g.sentinel.BanPeer(ctx, data.Peer)
-		return err
+		return services.WithReason(services.ReasonDecodeFailed, err)
 	}
 	if err := fn(object /*test=*/, false); err != nil {
 		return err
@@ -134,7 +148,10 @@ func (g *GossipManager) onRecv(ctx context.Context, data *sentinel.GossipData, l
 		Data:     common.CopyBytes(data.Data),
 	}
 
-	if err := g.routeAndProcess(ctx, data); err != nil {
+	version := g.beaconConfig.GetCurrentStateVersion(g.ethClock.GetCurrentEpoch())
+	err = g.routeAndProcess(ctx, data, version)
+	g.recordValidationResult(data, version, err)
+	if err != nil {
 		return err
 	}
 	if errors.Is(err, services.ErrIgnore) {
@@ -150,10 +167,43 @@ func (g *GossipManager) onRecv(ctx context.Context, data *sentinel.GossipData, l
 	return nil
 }
 
-func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.GossipData) error {
-	currentEpoch := g.ethClock.GetCurrentEpoch()
-	version := g.beaconConfig.GetCurrentStateVersion(currentEpoch)
+// subnetSuffix matches the subnet index of blob sidecar, attestation and sync committee topics.
+var subnetSuffix = regexp.MustCompile(`_[0-9]+$`)
+
+// recordValidationResult counts the outcome of a message by topic and reason and archives it if it was rejected.
+func (g *GossipManager) recordValidationResult(data *sentinel.GossipData, version clparams.StateVersion, err error) {
+	outcome, reason := services.ClassifyValidationResult(err)
+	topic := subnetSuffix.ReplaceAllString(data.Name, "")
+	metrics.GetOrCreateCounter(fmt.Sprintf(`gossip_validation{topic="%s",outcome="%s",reason="%s"}`, topic, outcome, reason)).Inc()
+
+	if g.capture == nil || outcome != services.ValidationReject {
+		return
+	}
+	record := &gossipcapture.Record{
+		Time:     time.Now(),
+		Topic:    data.Name,
+		SubnetId: data.SubnetId,
+		Outcome:  string(outcome),
+		Reason:   string(reason),
+		Error:    err.Error(),
+		Version:  version,
+		Data:     data.Data,
+	}
+	if data.Peer != nil {
+		record.Peer = data.Peer.Pid
+	}
+	if err := g.capture.Write(record); err != nil {
+		log.Warn("[Beacon Gossip] Failed to capture rejected message", "err", err)
+	}
+}
+
+// Validate runs a message through the validators for the given state version without republishing it.
+// It is used to replay captured messages.
+func (g *GossipManager) Validate(ctx context.Context, data *sentinel.GossipData, version clparams.StateVersion) error {
+	return g.routeAndProcess(ctx, data, version)
+}
 
+func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.GossipData, version clparams.StateVersion) error {
 	// Depending on the type of the received data, we create an instance of a specific type that implements the ObjectSSZ interface,
 	// then attempts to deserialize the received data into it.
 	// If the deserialization fails, an error is logged and the loop returns to the next iteration.
@@ -162,27 +212,27 @@ func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.Goss
 	case gossip.TopicNameBeaconBlock:
 		obj := cltypes.NewSignedBeaconBlock(g.beaconConfig)
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		log.Debug("Received block via gossip", "slot", obj.Block.Slot)
 		return g.blockService.ProcessMessage(ctx, data.SubnetId, obj)
 	case gossip.TopicNameSyncCommitteeContributionAndProof:
 		obj := &cltypes.SignedContributionAndProof{}
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		return g.syncContributionService.ProcessMessage(ctx, data.SubnetId, obj)
 	case gossip.TopicNameVoluntaryExit:
 		obj := &cltypes.SignedVoluntaryExit{}
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		return g.voluntaryExitService.ProcessMessage(ctx, data.SubnetId, obj)
 
 	case gossip.TopicNameProposerSlashing:
 		obj := &cltypes.ProposerSlashing{}
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		return g.proposerSlashingService.ProcessMessage(ctx, data.SubnetId, obj)
 	case gossip.TopicNameAttesterSlashing:
@@ -190,22 +240,25 @@ func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.Goss
 	case gossip.TopicNameBlsToExecutionChange:
 		obj := &cltypes.SignedBLSToExecutionChange{}
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		return g.blsToExecutionChangeService.ProcessMessage(ctx, data.SubnetId, obj)
 	case gossip.TopicNameBeaconAggregateAndProof:
 		obj := &cltypes.SignedAggregateAndProof{}
 		if err := obj.DecodeSSZ(data.Data, int(version)); err != nil {
-			return err
+			return services.WithReason(services.ReasonDecodeFailed, err)
 		}
 		return g.aggregateAndProofService.ProcessMessage(ctx, data.SubnetId, obj)
+	case gossip.TopicNameLightClientFinalityUpdate, gossip.TopicNameLightClientOptimisticUpdate:
+		// we produce light client updates from our own chain, so the ones from the network are neither processed nor forwarded.
+		return services.WithReason(services.ReasonNotProcessed, services.ErrIgnore)
 	default:
 		switch {
 		case gossip.IsTopicBlobSidecar(data.Name):
 			// decode sidecar
 			blobSideCar := &cltypes.BlobSidecar{}
 			if err := blobSideCar.DecodeSSZ(data.Data, int(version)); err != nil {
-				return err
+				return services.WithReason(services.ReasonDecodeFailed, err)
 			}
 			defer log.Debug("Received blob sidecar via gossip", "index", *data.SubnetId, "size", datasize.ByteSize(len(blobSideCar.Blob)))
 			// The background checks above are enough for now.
@@ -213,17 +266,17 @@ func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.Goss
 		case gossip.IsTopicSyncCommittee(data.Name):
 			msg := &cltypes.SyncCommitteeMessage{}
 			if err := msg.DecodeSSZ(common.CopyBytes(data.Data), int(version)); err != nil {
-				return err
+				return services.WithReason(services.ReasonDecodeFailed, err)
 			}
 			return g.syncCommitteeMessagesService.ProcessMessage(ctx, data.SubnetId, msg)
 		case gossip.IsTopicBeaconAttestation(data.Name):
 			att := &solid.Attestation{}
 			if err := att.DecodeSSZ(data.Data, int(version)); err != nil {
-				return err
+				return services.WithReason(services.ReasonDecodeFailed, err)
 			}
 			return g.attestationService.ProcessMessage(ctx, data.SubnetId, att)
 		default:
-			return fmt.Errorf("unknown topic %s", data.Name)
+			return services.WithReason(services.ReasonUnknownTopic, fmt.Errorf("unknown topic %s", data.Name))
 		}
 	}
 }
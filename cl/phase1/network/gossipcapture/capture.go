@@ -0,0 +1,204 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package gossipcapture archives gossip messages that failed validation so that they can be
+// inspected and replayed through the validators later on.
+//
+// The archive is a directory of JSON lines files named capture-<unix nano>.jsonl. A file is rotated
+// once it grows past the configured size and the oldest files are removed once there are too many.
+package gossipcapture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+const (
+	filePrefix = "capture-"
+	fileSuffix = ".jsonl"
+
+	DefaultMaxFileSize = 64 * 1024 * 1024
+	DefaultMaxFiles    = 16
+)
+
+// Record is a single captured gossip message.
+type Record struct {
+	Time     time.Time             `json:"time"`
+	Topic    string                `json:"topic"`
+	SubnetId *uint64               `json:"subnet_id,omitempty"`
+	Peer     string                `json:"peer"`
+	Outcome  string                `json:"outcome"`
+	Reason   string                `json:"reason"`
+	Error    string                `json:"error"`
+	Version  clparams.StateVersion `json:"version"`
+	Data     hexutility.Bytes      `json:"data"` // raw SSZ payload
+}
+
+// Writer appends records to a rotating archive. It is safe for concurrent use.
+type Writer struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	size int64
+}
+
+// NewWriter creates the archive directory if needed. Zero limits fall back to the defaults.
+func NewWriter(dir string, maxFileSize int64, maxFiles int) (*Writer, error) {
+	if maxFileSize <= 0 {
+		maxFileSize = DefaultMaxFileSize
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Writer{dir: dir, maxFileSize: maxFileSize, maxFiles: maxFiles}, nil
+}
+
+// Write appends a record, rotating the current file if it is full.
+func (w *Writer) Write(r *Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil || w.size+int64(len(line)) > w.maxFileSize {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.buf.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+	// records are rare and we want them on disk even if the node crashes.
+	return w.buf.Flush()
+}
+
+// Close flushes and closes the current file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeFile()
+}
+
+func (w *Writer) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.buf.Flush()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	w.file, w.buf, w.size = nil, nil, 0
+	return err
+}
+
+func (w *Writer) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	name := filepath.Join(w.dir, fmt.Sprintf("%s%d%s", filePrefix, time.Now().UnixNano(), fileSuffix))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file, w.buf, w.size = f, bufio.NewWriter(f), 0
+
+	files, err := Files(w.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > w.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// Files returns the archive files in dir, oldest first.
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), filePrefix) || !strings.HasSuffix(e.Name(), fileSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	// file names embed a fixed width timestamp, so lexical order is chronological.
+	sort.Strings(files)
+	return files, nil
+}
+
+// ForEach calls fn for every record in the archive, oldest first. Iteration stops at the first error.
+func ForEach(dir string, fn func(*Record) error) error {
+	files, err := Files(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := forEachInFile(file, fn); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func forEachInFile(file string, fn func(*Record) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	for {
+		r := &Record{}
+		if err := dec.Decode(r); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+}
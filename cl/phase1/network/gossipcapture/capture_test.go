@@ -0,0 +1,61 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package gossipcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+func TestWriterRotation(t *testing.T) {
+	dir := t.TempDir()
+	// every record is bigger than half a file, so each one ends up in its own file.
+	w, err := NewWriter(dir, 300, 3)
+	require.NoError(t, err)
+
+	subnet := uint64(7)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, w.Write(&Record{
+			Topic:    "beacon_attestation_7",
+			SubnetId: &subnet,
+			Peer:     "peer",
+			Outcome:  "reject",
+			Reason:   "invalid_signature",
+			Version:  clparams.DenebVersion,
+			Data:     make([]byte, 60+i),
+		}))
+	}
+	require.NoError(t, w.Close())
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	var sizes []int
+	require.NoError(t, ForEach(dir, func(r *Record) error {
+		require.Equal(t, "beacon_attestation_7", r.Topic)
+		require.Equal(t, subnet, *r.SubnetId)
+		require.Equal(t, clparams.DenebVersion, r.Version)
+		sizes = append(sizes, len(r.Data))
+		return nil
+	}))
+	// the two oldest files were removed.
+	require.Equal(t, []int{62, 63, 64}, sizes)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"context"
+	"time"
+
+	sentinel "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/lightclient_utils"
+	"github.com/erigontech/erigon/cl/gossip"
+)
+
+// PublishLightClientUpdate gossips the finality and optimistic updates derived from update. Following the light client
+// networking specs, an update is only sent if it is newer than the last one sent on the topic and not before a third
+// of its signature slot has elapsed.
+func (g *GossipManager) PublishLightClientUpdate(ctx context.Context, update *cltypes.LightClientUpdate) {
+	if update == nil {
+		return
+	}
+	publishAt := g.ethClock.GetSlotTime(update.SignatureSlot).Add(time.Duration(g.beaconConfig.SecondsPerSlot) * time.Second / 3)
+	if wait := time.Until(publishAt); wait > 0 {
+		time.AfterFunc(wait, func() { g.publishLightClientUpdate(ctx, update) })
+		return
+	}
+	g.publishLightClientUpdate(ctx, update)
+}
+
+func (g *GossipManager) publishLightClientUpdate(ctx context.Context, update *cltypes.LightClientUpdate) {
+	g.lightClientMu.Lock()
+	defer g.lightClientMu.Unlock()
+
+	if lightclient_utils.IsFinalityUpdate(update) && isNewerFinalityUpdate(update, g.publishedFinalityUpdate) {
+		finalityUpdate := &cltypes.LightClientFinalityUpdate{
+			AttestedHeader:  update.AttestedHeader,
+			FinalizedHeader: update.FinalizedHeader,
+			FinalityBranch:  update.FinalityBranch,
+			SyncAggregate:   update.SyncAggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}
+		if g.publishLightClientObject(ctx, gossip.TopicNameLightClientFinalityUpdate, finalityUpdate) {
+			g.publishedFinalityUpdate = update
+		}
+	}
+	if g.publishedOptimisticUpdate == nil || update.AttestedHeader.Beacon.Slot > g.publishedOptimisticUpdate.AttestedHeader.Beacon.Slot {
+		optimisticUpdate := &cltypes.LightClientOptimisticUpdate{
+			AttestedHeader: update.AttestedHeader,
+			SyncAggregate:  update.SyncAggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}
+		if g.publishLightClientObject(ctx, gossip.TopicNameLightClientOptimisticUpdate, optimisticUpdate) {
+			g.publishedOptimisticUpdate = update
+		}
+	}
+}
+
+func (g *GossipManager) publishLightClientObject(ctx context.Context, topic string, obj ssz.EncodableSSZ) bool {
+	encoded, err := obj.EncodeSSZ(nil)
+	if err != nil {
+		log.Debug("failed to encode light client update", "topic", topic, "err", err)
+		return false
+	}
+	if _, err := g.sentinel.PublishGossip(ctx, &sentinel.GossipData{Name: topic, Data: encoded}); err != nil {
+		log.Debug("failed publish gossip", "topic", topic, "err", err)
+		return false
+	}
+	return true
+}
+
+// isNewerFinalityUpdate tells whether update finalizes a later header than prev, or the same header with a
+// sync committee supermajority that prev did not have.
+func isNewerFinalityUpdate(update, prev *cltypes.LightClientUpdate) bool {
+	if prev == nil {
+		return true
+	}
+	slot, prevSlot := update.FinalizedHeader.Beacon.Slot, prev.FinalizedHeader.Beacon.Slot
+	if slot != prevSlot {
+		return slot > prevSlot
+	}
+	return hasSupermajority(update.SyncAggregate) && !hasSupermajority(prev.SyncAggregate)
+}
+
+func hasSupermajority(aggregate *cltypes.SyncAggregate) bool {
+	return aggregate.Sum()*3 >= len(aggregate.SyncCommiteeBits)*8*2
+}
@@ -80,7 +80,7 @@ func (a *aggregateAndProofServiceImpl) ProcessMessage(
 ) error {
 	headState := a.syncedDataManager.HeadState()
 	if headState == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 	selectionProof := aggregateAndProof.Message.SelectionProof
 	aggregateData := aggregateAndProof.Message.Aggregate.AttestantionData()
@@ -90,12 +90,12 @@ func (a *aggregateAndProofServiceImpl) ProcessMessage(
 
 	if aggregateData.Slot() > headState.Slot() {
 		a.scheduleAggregateForLaterProcessing(aggregateAndProof)
-		return ErrIgnore
+		return WithReason(ReasonFutureSlot, ErrIgnore)
 	}
 	epoch := slot / a.beaconCfg.SlotsPerEpoch
 	// [IGNORE] the epoch of aggregate.data.slot is either the current or previous epoch (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance) -- i.e. compute_epoch_at_slot(aggregate.data.slot) in (get_previous_epoch(state), get_current_epoch(state))
 	if state.PreviousEpoch(headState) != epoch && state.Epoch(headState) != epoch {
-		return ErrIgnore
+		return WithReason(ReasonNotInPropagationRange, ErrIgnore)
 	}
 	finalizedCheckpoint := a.forkchoiceStore.FinalizedCheckpoint()
 	finalizedSlot := finalizedCheckpoint.Epoch() * a.beaconCfg.SlotsPerEpoch
@@ -104,38 +104,38 @@ func (a *aggregateAndProofServiceImpl) ProcessMessage(
 		aggregateData.BeaconBlockRoot(),
 		finalizedSlot,
 	) != finalizedCheckpoint.BlockRoot() {
-		return ErrIgnore
+		return WithReason(ReasonNotFinalizedDescendant, ErrIgnore)
 	}
 
 	// [IGNORE] The block being voted for (aggregate.data.beacon_block_root) has been seen (via both gossip and non-gossip sources) (a client MAY queue aggregates for processing once block is retrieved).
 	if _, ok := a.forkchoiceStore.GetHeader(aggregateData.BeaconBlockRoot()); !ok {
-		return ErrIgnore
+		return WithReason(ReasonUnknownBlock, ErrIgnore)
 	}
 
 	// [REJECT] The committee index is within the expected range -- i.e. index < get_committee_count_per_slot(state, aggregate.data.target.epoch).
 	committeeCountPerSlot := headState.CommitteeCount(target.Epoch())
 	if aggregateData.CommitteeIndex() >= committeeCountPerSlot {
-		return errors.New("invalid committee index in aggregate and proof")
+		return WithReason(ReasonInvalidCommitteeIndex, errors.New("invalid committee index in aggregate and proof"))
 	}
 	// [REJECT] The aggregate attestation's epoch matches its target -- i.e. aggregate.data.target.epoch == compute_epoch_at_slot(aggregate.data.slot)
 	if aggregateData.Target().Epoch() != epoch {
-		return errors.New("invalid target epoch in aggregate and proof")
+		return WithReason(ReasonWrongEpoch, errors.New("invalid target epoch in aggregate and proof"))
 	}
 	committee, err := headState.GetBeaconCommitee(slot, committeeIndex)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 
 	// [REJECT] The aggregator's validator index is within the committee -- i.e. aggregate_and_proof.aggregator_index in get_beacon_committee(state, aggregate.data.slot, index).
 	if !slices.Contains(committee, aggregateAndProof.Message.AggregatorIndex) {
-		return errors.New("committee index not in committee")
+		return WithReason(ReasonNotInCommittee, errors.New("committee index not in committee"))
 	}
 	// [REJECT] The aggregate attestation's target block is an ancestor of the block named in the LMD vote -- i.e. get_checkpoint_block(store, aggregate.data.beacon_block_root, aggregate.data.target.epoch) == aggregate.data.target.root
 	if a.forkchoiceStore.Ancestor(
 		aggregateData.BeaconBlockRoot(),
 		epoch*a.beaconCfg.SlotsPerEpoch,
 	) != target.BlockRoot() {
-		return errors.New("invalid target block")
+		return WithReason(ReasonInvalidTargetBlock, errors.New("invalid target block"))
 	}
 	if a.test {
 		return nil
@@ -144,7 +144,7 @@ func (a *aggregateAndProofServiceImpl) ProcessMessage(
 	// [REJECT] aggregate_and_proof.selection_proof selects the validator as an aggregator for the slot -- i.e. is_aggregator(state, aggregate.data.slot, index, aggregate_and_proof.selection_proof) returns True.
 	if !state.IsAggregator(a.beaconCfg, uint64(len(committee)), committeeIndex, selectionProof) {
 		log.Warn("receveived aggregate and proof from invalid aggregator")
-		return errors.New("invalid aggregate and proof")
+		return WithReason(ReasonNotAggregator, errors.New("invalid aggregate and proof"))
 	}
 	attestingIndicies, err := headState.GetAttestingIndicies(
 		aggregateAndProof.Message.Aggregate.AttestantionData(),
@@ -152,10 +152,10 @@ func (a *aggregateAndProofServiceImpl) ProcessMessage(
 		true,
 	)
 	if err != nil {
-		return err
+		return WithReason(ReasonInvalidAggregation, err)
 	}
 	if err := verifySignaturesOnAggregate(headState, aggregateAndProof); err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	} // Add to aggregation pool
 	a.opPool.AttestationsPool.Insert(
 		aggregateAndProof.Message.Aggregate.Signature(),
@@ -94,39 +94,39 @@ func (s *attestationService) ProcessMessage(ctx context.Context, subnet *uint64,
 	)
 	headState := s.syncedDataManager.HeadStateReader()
 	if headState == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 
 	// [REJECT] The committee index is within the expected range
 	committeeCount := computeCommitteeCountPerSlot(headState, slot, s.beaconCfg.SlotsPerEpoch)
 	if committeeIndex >= committeeCount {
-		return fmt.Errorf("committee index out of range, %d >= %d", committeeIndex, committeeCount)
+		return WithReason(ReasonInvalidCommitteeIndex, fmt.Errorf("committee index out of range, %d >= %d", committeeIndex, committeeCount))
 	}
 	// [REJECT] The attestation is for the correct subnet -- i.e. compute_subnet_for_attestation(committees_per_slot, attestation.data.slot, index) == subnet_id
 	subnetId := computeSubnetForAttestation(committeeCount, slot, committeeIndex, s.beaconCfg.SlotsPerEpoch, s.netCfg.AttestationSubnetCount)
 	if subnet == nil || subnetId != *subnet {
-		return errors.New("wrong subnet")
+		return WithReason(ReasonWrongSubnet, errors.New("wrong subnet"))
 	}
 	// [IGNORE] attestation.data.slot is within the last ATTESTATION_PROPAGATION_SLOT_RANGE slots (within a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance) --
 	// i.e. attestation.data.slot + ATTESTATION_PROPAGATION_SLOT_RANGE >= current_slot >= attestation.data.slot (a client MAY queue future attestations for processing at the appropriate slot).
 	currentSlot := s.ethClock.GetCurrentSlot()
 	if currentSlot < slot || currentSlot > slot+s.netCfg.AttestationPropagationSlotRange {
-		return fmt.Errorf("not in propagation range %w", ErrIgnore)
+		return WithReason(ReasonNotInPropagationRange, fmt.Errorf("not in propagation range %w", ErrIgnore))
 	}
 	// [REJECT] The attestation's epoch matches its target -- i.e. attestation.data.target.epoch == compute_epoch_at_slot(attestation.data.slot)
 	if targetEpoch != slot/s.beaconCfg.SlotsPerEpoch {
-		return errors.New("epoch mismatch")
+		return WithReason(ReasonWrongEpoch, errors.New("epoch mismatch"))
 	}
 	// [REJECT] The number of aggregation bits matches the committee size -- i.e. len(aggregation_bits) == len(get_beacon_committee(state, attestation.data.slot, index)).
 	beaconCommittee, err := s.forkchoiceStore.GetBeaconCommitee(slot, committeeIndex)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	bits := att.AggregationBits()
 	expectedAggregationBitsLength := len(beaconCommittee)
 	actualAggregationBitsLength := utils.GetBitlistLength(bits)
 	if actualAggregationBitsLength != expectedAggregationBitsLength {
-		return fmt.Errorf("aggregation bits count mismatch: %d != %d", actualAggregationBitsLength, expectedAggregationBitsLength)
+		return WithReason(ReasonInvalidAggregation, fmt.Errorf("aggregation bits count mismatch: %d != %d", actualAggregationBitsLength, expectedAggregationBitsLength))
 	}
 
 	//[REJECT] The attestation is unaggregated -- that is, it has exactly one participating validator (len([bit for bit in aggregation_bits if bit]) == 1, i.e. exactly 1 bit is set).
@@ -145,23 +145,23 @@ func (s *attestationService) ProcessMessage(ctx context.Context, subnet *uint64,
 	}
 
 	if setBits == 0 {
-		return ErrIgnore // Ignore if it is just an empty bitlist
+		return WithReason(ReasonInvalidAggregation, ErrIgnore) // Ignore if it is just an empty bitlist
 	}
 	if setBits != 1 {
-		return errors.New("attestation does not have exactly one participating validator")
+		return WithReason(ReasonInvalidAggregation, errors.New("attestation does not have exactly one participating validator"))
 	}
 	// [IGNORE] There has been no other valid attestation seen on an attestation subnet that has an identical attestation.data.target.epoch and participating validator index.
 	if err != nil {
 		return err
 	}
 	if onBitIndex >= len(beaconCommittee) {
-		return errors.New("on bit index out of committee range")
+		return WithReason(ReasonInvalidAggregation, errors.New("on bit index out of committee range"))
 	}
 	// mark the validator as seen
 	vIndex := beaconCommittee[onBitIndex]
 	epochLastTime, ok := s.validatorAttestationSeen.Get(vIndex)
 	if ok && epochLastTime == targetEpoch {
-		return fmt.Errorf("validator already seen in target epoch %w", ErrIgnore)
+		return WithReason(ReasonAlreadySeen, fmt.Errorf("validator already seen in target epoch %w", ErrIgnore))
 	}
 	s.validatorAttestationSeen.Add(vIndex, targetEpoch)
 
@@ -169,49 +169,49 @@ func (s *attestationService) ProcessMessage(ctx context.Context, subnet *uint64,
 	signature := att.Signature()
 	pubKey, err := headState.ValidatorPublicKey(int(beaconCommittee[onBitIndex]))
 	if err != nil {
-		return fmt.Errorf("unable to get public key: %v", err)
+		return WithReason(ReasonInternal, fmt.Errorf("unable to get public key: %v", err))
 	}
 	domain, err := headState.GetDomain(s.beaconCfg.DomainBeaconAttester, targetEpoch)
 	if err != nil {
-		return fmt.Errorf("unable to get the domain: %v", err)
+		return WithReason(ReasonInternal, fmt.Errorf("unable to get the domain: %v", err))
 	}
 	signingRoot, err := computeSigningRoot(att.AttestantionData(), domain)
 	if err != nil {
-		return fmt.Errorf("unable to get signing root: %v", err)
+		return WithReason(ReasonInternal, fmt.Errorf("unable to get signing root: %v", err))
 	}
 	if valid, err := blsVerify(signature[:], signingRoot[:], pubKey[:]); err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	} else if !valid {
 		log.Warn("lodestar: invalid signature", "signature", common.Bytes2Hex(signature[:]), "signningRoot", common.Bytes2Hex(signingRoot[:]), "pubKey", common.Bytes2Hex(pubKey[:]))
-		return errors.New("invalid signature")
+		return WithReason(ReasonInvalidSignature, errors.New("invalid signature"))
 	}
 
 	// [IGNORE] The block being voted for (attestation.data.beacon_block_root) has been seen (via both gossip and non-gossip sources)
 	// (a client MAY queue attestations for processing once block is retrieved).
 	if _, ok := s.forkchoiceStore.GetHeader(root); !ok {
 		s.scheduleAttestationForLaterProcessing(att)
-		return ErrIgnore
+		return WithReason(ReasonUnknownBlock, ErrIgnore)
 	}
 
 	// [REJECT] The attestation's target block is an ancestor of the block named in the LMD vote -- i.e.
 	// get_checkpoint_block(store, attestation.data.beacon_block_root, attestation.data.target.epoch) == attestation.data.target.root
 	startSlotAtEpoch := targetEpoch * s.beaconCfg.SlotsPerEpoch
 	if s.forkchoiceStore.Ancestor(root, startSlotAtEpoch) != att.AttestantionData().Target().BlockRoot() {
-		return errors.New("invalid target block")
+		return WithReason(ReasonInvalidTargetBlock, errors.New("invalid target block"))
 	}
 	// [IGNORE] The current finalized_checkpoint is an ancestor of the block defined by attestation.data.beacon_block_root --
 	// i.e. get_checkpoint_block(store, attestation.data.beacon_block_root, store.finalized_checkpoint.epoch) == store.finalized_checkpoint.root
 	startSlotAtEpoch = s.forkchoiceStore.FinalizedCheckpoint().Epoch() * s.beaconCfg.SlotsPerEpoch
 	if s.forkchoiceStore.Ancestor(root, startSlotAtEpoch) != s.forkchoiceStore.FinalizedCheckpoint().BlockRoot() {
-		return fmt.Errorf("invalid finalized checkpoint %w", ErrIgnore)
+		return WithReason(ReasonNotFinalizedDescendant, fmt.Errorf("invalid finalized checkpoint %w", ErrIgnore))
 	}
 
 	err = s.committeeSubscribe.CheckAggregateAttestation(att)
 	if errors.Is(err, aggregation.ErrIsSuperset) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadyAggregated, ErrIgnore)
 	}
 	if err != nil {
-		return err
+		return WithReason(ReasonInvalidOperation, err)
 	}
 	s.emitters.Operation().SendAttestation(att)
 	return nil
@@ -86,45 +86,45 @@ func (b *blobSidecarService) ProcessMessage(ctx context.Context, subnetId *uint6
 	headState := b.syncedDataManager.HeadState()
 	if headState == nil {
 		b.scheduleBlobSidecarForLaterExecution(msg)
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 
 	// [REJECT] The sidecar's index is consistent with MAX_BLOBS_PER_BLOCK -- i.e. blob_sidecar.index < MAX_BLOBS_PER_BLOCK.
 	if msg.Index >= b.beaconCfg.MaxBlobsPerBlock {
-		return errors.New("blob index out of range")
+		return WithReason(ReasonInvalidIndex, errors.New("blob index out of range"))
 	}
 	sidecarSubnetIndex := msg.Index % b.beaconCfg.MaxBlobsPerBlock
 	if sidecarSubnetIndex != *subnetId {
-		return ErrBlobIndexOutOfRange
+		return WithReason(ReasonWrongSubnet, ErrBlobIndexOutOfRange)
 	}
 	currentSlot := b.ethClock.GetCurrentSlot()
 	sidecarSlot := msg.SignedBlockHeader.Header.Slot
 	// [IGNORE] The block is not from a future slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance) -- i.e. validate that
 	// signed_beacon_block.message.slot <= current_slot (a client MAY queue future blocks for processing at the appropriate slot).
 	if currentSlot < sidecarSlot && !b.ethClock.IsSlotCurrentSlotWithMaximumClockDisparity(sidecarSlot) {
-		return ErrIgnore
+		return WithReason(ReasonFutureSlot, ErrIgnore)
 	}
 
 	if b.forkchoiceStore.FinalizedSlot() >= sidecarSlot {
-		return ErrIgnore
+		return WithReason(ReasonFinalizedSlot, ErrIgnore)
 	}
 
 	blockRoot, err := msg.SignedBlockHeader.Header.HashSSZ()
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	// Do not bother with blocks processed by fork choice already.
 	if _, has := b.forkchoiceStore.GetHeader(blockRoot); has {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 
 	parentHeader, has := b.forkchoiceStore.GetHeader(msg.SignedBlockHeader.Header.ParentRoot)
 	if !has {
 		b.scheduleBlobSidecarForLaterExecution(msg)
-		return ErrIgnore
+		return WithReason(ReasonUnknownParent, ErrIgnore)
 	}
 	if msg.SignedBlockHeader.Header.Slot <= parentHeader.Slot {
-		return ErrInvalidSidecarSlot
+		return WithReason(ReasonParentNotOlder, ErrInvalidSidecarSlot)
 	}
 
 	if err := b.verifyAndStoreBlobSidecar(headState, msg); err != nil {
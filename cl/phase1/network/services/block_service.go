@@ -93,7 +93,7 @@ func (b *blockService) ProcessMessage(ctx context.Context, _ *uint64, msg *cltyp
 	headState := b.syncedData.HeadState()
 	if headState == nil {
 		b.scheduleBlockForLaterProcessing(msg)
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 
 	blockEpoch := msg.Block.Slot / b.beaconCfg.SlotsPerEpoch
@@ -103,12 +103,12 @@ func (b *blockService) ProcessMessage(ctx context.Context, _ *uint64, msg *cltyp
 	// [IGNORE] The block is not from a future slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance) -- i.e. validate that
 	//signed_beacon_block.message.slot <= current_slot (a client MAY queue future blocks for processing at the appropriate slot).
 	if currentSlot < msg.Block.Slot && !b.ethClock.IsSlotCurrentSlotWithMaximumClockDisparity(msg.Block.Slot) {
-		return ErrIgnore
+		return WithReason(ReasonFutureSlot, ErrIgnore)
 	}
 	// [IGNORE] The block is from a slot greater than the latest finalized slot -- i.e. validate that signed_beacon_block.message.slot > compute_start_slot_at_epoch(store.finalized_checkpoint.epoch)
 	// (a client MAY choose to validate and store such blocks for additional purposes -- e.g. slashing detection, archive nodes, etc).
 	if blockEpoch <= headState.FinalizedCheckpoint().Epoch() {
-		return ErrIgnore
+		return WithReason(ReasonFinalizedSlot, ErrIgnore)
 	}
 
 	// [IGNORE] The block is the first block with valid signature received for the proposer for the slot, signed_beacon_block.message.slot.
@@ -117,22 +117,22 @@ func (b *blockService) ProcessMessage(ctx context.Context, _ *uint64, msg *cltyp
 		slot:          msg.Block.Slot,
 	}
 	if b.seenBlocksCache.Contains(seenCacheKey) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 
 	// [IGNORE] The block's parent (defined by block.parent_root) has been seen (via both gossip and non-gossip sources) (a client MAY queue blocks for processing once the parent block is retrieved).
 	parentHeader, ok := b.forkchoiceStore.GetHeader(msg.Block.ParentRoot)
 	if !ok {
 		b.scheduleBlockForLaterProcessing(msg)
-		return ErrIgnore
+		return WithReason(ReasonUnknownParent, ErrIgnore)
 	}
 	if parentHeader.Slot >= msg.Block.Slot {
-		return ErrBlockYoungerThanParent
+		return WithReason(ReasonParentNotOlder, ErrBlockYoungerThanParent)
 	}
 
 	// [REJECT] The length of KZG commitments is less than or equal to the limitation defined in Consensus Layer -- i.e. validate that len(body.signed_beacon_block.message.blob_kzg_commitments) <= MAX_BLOBS_PER_BLOCK
 	if msg.Block.Body.BlobKzgCommitments.Len() > int(b.beaconCfg.MaxBlobsPerBlock) {
-		return ErrInvalidCommitmentsCount
+		return WithReason(ReasonInvalidCommitments, ErrInvalidCommitmentsCount)
 	}
 	b.publishBlockGossipEvent(msg)
 
@@ -140,9 +140,9 @@ func (b *blockService) ProcessMessage(ctx context.Context, _ *uint64, msg *cltyp
 	if err := b.processAndStoreBlock(ctx, msg); err != nil {
 		if err == forkchoice.ErrEIP4844DataNotAvailable {
 			b.scheduleBlockForLaterProcessing(msg)
-			return ErrIgnore
+			return WithReason(ReasonDataNotAvailable, ErrIgnore)
 		}
-		return err
+		return WithReason(ReasonInvalidBlock, err)
 	}
 	return nil
 }
@@ -58,33 +58,33 @@ func (s *blsToExecutionChangeService) ProcessMessage(ctx context.Context, subnet
 	// [IGNORE] The signed_bls_to_execution_change is the first valid signed bls to execution change received
 	// for the validator with index signed_bls_to_execution_change.message.validator_index.
 	if s.operationsPool.BLSToExecutionChangesPool.Has(msg.Signature) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 	change := msg.Message
 	stateReader := s.syncedDataManager.HeadStateReader()
 	if stateReader == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 	stateMutator := s.syncedDataManager.HeadStateMutator()
 	if stateMutator == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 
 	// [IGNORE] current_epoch >= CAPELLA_FORK_EPOCH, where current_epoch is defined by the current wall-clock time.
 	if !(stateReader.Version() >= clparams.CapellaVersion) {
-		return ErrIgnore
+		return WithReason(ReasonWrongEpoch, ErrIgnore)
 	}
 	// ref: https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/beacon-chain.md#new-process_bls_to_execution_change
 	// assert address_change.validator_index < len(state.validators)
 	validator, err := stateReader.ValidatorForValidatorIndex(int(change.ValidatorIndex))
 	if err != nil {
-		return fmt.Errorf("unable to retrieve validator: %v", err)
+		return WithReason(ReasonInvalidValidator, fmt.Errorf("unable to retrieve validator: %v", err))
 	}
 	wc := validator.WithdrawalCredentials()
 
 	// assert validator.withdrawal_credentials[:1] == BLS_WITHDRAWAL_PREFIX
 	if wc[0] != byte(s.beaconCfg.BLSWithdrawalPrefixByte) {
-		return errors.New("invalid withdrawal credentials prefix")
+		return WithReason(ReasonInvalidOperation, errors.New("invalid withdrawal credentials prefix"))
 	}
 
 	// assert validator.withdrawal_credentials[1:] == hash(address_change.from_bls_pubkey)[1:]
@@ -92,25 +92,25 @@ func (s *blsToExecutionChangeService) ProcessMessage(ctx context.Context, subnet
 	// Check the validator's withdrawal credentials against the provided message.
 	hashedFrom := utils.Sha256(change.From[:])
 	if !bytes.Equal(hashedFrom[1:], wc[1:]) {
-		return errors.New("invalid withdrawal credentials hash")
+		return WithReason(ReasonInvalidOperation, errors.New("invalid withdrawal credentials hash"))
 	}
 
 	// assert bls.Verify(address_change.from_bls_pubkey, signing_root, signed_address_change.signature)
 	genesisValidatorRoot := stateReader.GenesisValidatorsRoot()
 	domain, err := fork.ComputeDomain(s.beaconCfg.DomainBLSToExecutionChange[:], utils.Uint32ToBytes4(uint32(s.beaconCfg.GenesisForkVersion)), genesisValidatorRoot)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	signedRoot, err := computeSigningRoot(change, domain)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	valid, err := blsVerify(msg.Signature[:], signedRoot[:], change.From[:])
 	if err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	}
 	if !valid {
-		return errors.New("invalid signature")
+		return WithReason(ReasonInvalidSignature, errors.New("invalid signature"))
 	}
 
 	// validator.withdrawal_credentials = (
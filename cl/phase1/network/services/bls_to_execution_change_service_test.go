@@ -210,7 +210,7 @@ func (t *blsToExecutionChangeTestSuite) TestProcessMessage() {
 			t.Require().Error(err)
 			fmt.Printf("Error: %v\n", err)
 			if tt.specificErr != nil {
-				t.Require().Equal(tt.specificErr, err)
+				t.Require().ErrorIs(err, tt.specificErr)
 			}
 		} else {
 			t.Require().NoError(err)
@@ -67,60 +67,60 @@ func (s *proposerSlashingService) ProcessMessage(ctx context.Context, subnet *ui
 	// [IGNORE] The proposer slashing is the first valid proposer slashing received for the proposer with index proposer_slashing.signed_header_1.message.proposer_index
 	pIndex := msg.Header1.Header.ProposerIndex
 	if _, ok := s.cache.Get(pIndex); ok {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 
 	if s.operationsPool.ProposerSlashingsPool.Has(pool.ComputeKeyForProposerSlashing(msg)) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 	h1 := msg.Header1.Header
 	h2 := msg.Header2.Header
 
 	// Verify header slots match
 	if h1.Slot != h2.Slot {
-		return fmt.Errorf("non-matching slots on proposer slashing: %d != %d", h1.Slot, h2.Slot)
+		return WithReason(ReasonInvalidOperation, fmt.Errorf("non-matching slots on proposer slashing: %d != %d", h1.Slot, h2.Slot))
 	}
 
 	// Verify header proposer indices match
 	if h1.ProposerIndex != h2.ProposerIndex {
-		return fmt.Errorf("non-matching proposer indices proposer slashing: %d != %d", h1.ProposerIndex, h2.ProposerIndex)
+		return WithReason(ReasonInvalidIndex, fmt.Errorf("non-matching proposer indices proposer slashing: %d != %d", h1.ProposerIndex, h2.ProposerIndex))
 	}
 
 	// Verify the headers are different
 	if *h1 == *h2 {
-		return errors.New("proposee slashing headers are the same")
+		return WithReason(ReasonInvalidOperation, errors.New("proposee slashing headers are the same"))
 	}
 
 	// Verify the proposer is slashable
 	state := s.syncedDataManager.HeadStateReader()
 	if state == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 	proposer, err := state.ValidatorForValidatorIndex(int(h1.ProposerIndex))
 	if err != nil {
-		return fmt.Errorf("unable to retrieve state: %v", err)
+		return WithReason(ReasonInvalidValidator, fmt.Errorf("unable to retrieve state: %v", err))
 	}
 	if !proposer.IsSlashable(s.ethClock.GetCurrentEpoch()) {
-		return fmt.Errorf("proposer is not slashable: %v", proposer)
+		return WithReason(ReasonInvalidValidator, fmt.Errorf("proposer is not slashable: %v", proposer))
 	}
 
 	// Verify signatures for both headers
 	for _, signedHeader := range []*cltypes.SignedBeaconBlockHeader{msg.Header1, msg.Header2} {
 		domain, err := state.GetDomain(s.beaconCfg.DomainBeaconProposer, st.GetEpochAtSlot(s.beaconCfg, signedHeader.Header.Slot))
 		if err != nil {
-			return fmt.Errorf("unable to get domain: %v", err)
+			return WithReason(ReasonInternal, fmt.Errorf("unable to get domain: %v", err))
 		}
 		pk := proposer.PublicKey()
 		signingRoot, err := computeSigningRoot(signedHeader, domain)
 		if err != nil {
-			return fmt.Errorf("unable to compute signing root: %v", err)
+			return WithReason(ReasonInternal, fmt.Errorf("unable to compute signing root: %v", err))
 		}
 		valid, err := blsVerify(signedHeader.Signature[:], signingRoot[:], pk[:])
 		if err != nil {
-			return fmt.Errorf("unable to verify signature: %v", err)
+			return WithReason(ReasonInvalidSignature, fmt.Errorf("unable to verify signature: %v", err))
 		}
 		if !valid {
-			return fmt.Errorf("invalid signature: signature %v, root %v, pubkey %v", signedHeader.Signature[:], signingRoot[:], pk)
+			return WithReason(ReasonInvalidSignature, fmt.Errorf("invalid signature: signature %v, root %v, pubkey %v", signedHeader.Signature[:], signingRoot[:], pk))
 		}
 	}
 
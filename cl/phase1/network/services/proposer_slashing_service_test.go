@@ -227,7 +227,7 @@ func (t *proposerSlashingTestSuite) TestProcessMessage() {
 		if tt.wantErr {
 			t.Assert().Error(err)
 			if tt.err != nil {
-				t.Assert().Equal(tt.err, err)
+				t.Assert().ErrorIs(err, tt.err)
 			}
 		} else {
 			t.Assert().NoError(err)
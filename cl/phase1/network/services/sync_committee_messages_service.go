@@ -76,18 +76,18 @@ func (s *syncCommitteeMessagesService) ProcessMessage(ctx context.Context, subne
 	defer s.mu.Unlock()
 	headState := s.syncedDataManager.HeadState()
 	if headState == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 	// [IGNORE] The message's slot is for the current slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance), i.e. sync_committee_message.slot == current_slot.
 	if !s.ethClock.IsSlotCurrentSlotWithMaximumClockDisparity(msg.Slot) {
-		return ErrIgnore
+		return WithReason(ReasonNotInPropagationRange, ErrIgnore)
 	}
 
 	// [REJECT] The subnet_id is valid for the given validator, i.e. subnet_id in compute_subnets_for_sync_committee(state, sync_committee_message.validator_index).
 	// Note this validation implies the validator is part of the broader current sync committee along with the correct subcommittee.
 	subnets, err := subnets.ComputeSubnetsForSyncCommittee(headState, msg.ValidatorIndex)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	seenSyncCommitteeMessageIdentifier := seenSyncCommitteeMessage{
 		subnet:         *subnet,
@@ -96,20 +96,20 @@ func (s *syncCommitteeMessagesService) ProcessMessage(ctx context.Context, subne
 	}
 
 	if !slices.Contains(subnets, *subnet) {
-		return fmt.Errorf("validator is not into any subnet %d", *subnet)
+		return WithReason(ReasonWrongSubnet, fmt.Errorf("validator is not into any subnet %d", *subnet))
 	}
 	// [IGNORE] There has been no other valid sync committee message for the declared slot for the validator referenced by sync_committee_message.validator_index.
 	if _, ok := s.seenSyncCommitteeMessages[seenSyncCommitteeMessageIdentifier]; ok {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 	// [REJECT] The signature is valid for the message beacon_block_root for the validator referenced by validator_index
 	if err := verifySyncCommitteeMessageSignature(headState, msg); !s.test && err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	}
 	s.seenSyncCommitteeMessages[seenSyncCommitteeMessageIdentifier] = struct{}{}
 	s.cleanupOldSyncCommitteeMessages() // cleanup old messages
 	// Aggregate the message
-	return s.syncContributionPool.AddSyncCommitteeMessage(headState, *subnet, msg)
+	return WithReason(ReasonInvalidOperation, s.syncContributionPool.AddSyncCommitteeMessage(headState, *subnet, msg))
 }
 
 // cleanupOldSyncCommitteeMessages removes old sync committee messages from the cache
@@ -89,61 +89,61 @@ func (s *syncContributionService) ProcessMessage(ctx context.Context, subnet *ui
 
 	headState := s.syncedDataManager.HeadState()
 	if headState == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 
 	// [REJECT] The subcommittee index is in the allowed range, i.e. contribution.subcommittee_index < SYNC_COMMITTEE_SUBNET_COUNT.
 	if contributionAndProof.Contribution.SubcommitteeIndex >= clparams.MainnetBeaconConfig.SyncCommitteeSubnetCount {
-		return errors.New("subcommittee index is out of range")
+		return WithReason(ReasonInvalidCommitteeIndex, errors.New("subcommittee index is out of range"))
 	}
 
 	aggregatorPubKey, err := headState.ValidatorPublicKey(int(contributionAndProof.AggregatorIndex))
 	if err != nil {
-		return err
+		return WithReason(ReasonInvalidValidator, err)
 	}
 	subcommiteePubsKeys, err := s.getSyncSubcommitteePubkeys(headState, contributionAndProof.Contribution.SubcommitteeIndex)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 
 	// [IGNORE] The contribution's slot is for the current slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance), i.e. contribution.slot == current_slot.
 	if !s.ethClock.IsSlotCurrentSlotWithMaximumClockDisparity(contributionAndProof.Contribution.Slot) {
-		return ErrIgnore
+		return WithReason(ReasonNotInPropagationRange, ErrIgnore)
 	}
 
 	// [REJECT] The contribution has participants -- that is, any(contribution.aggregation_bits).
 	if bytes.Equal(aggregationBits, make([]byte, len(aggregationBits))) { // check if the aggregation bits are all zeros
-		return errors.New("contribution has no participants")
+		return WithReason(ReasonInvalidAggregation, errors.New("contribution has no participants"))
 	}
 
 	modulo := max(1, s.beaconCfg.SyncCommitteeSize/s.beaconCfg.SyncCommitteeSubnetCount/s.beaconCfg.TargetAggregatorsPerSyncSubcommittee)
 	hashSignature := utils.Sha256(selectionProof[:])
 	if !s.test && binary.LittleEndian.Uint64(hashSignature[:8])%modulo != 0 {
-		return errors.New("selects the validator as an aggregator")
+		return WithReason(ReasonNotAggregator, errors.New("selects the validator as an aggregator"))
 	}
 
 	// [REJECT] The aggregator's validator index is in the declared subcommittee of the current sync committee -- i.e. state.validators[contribution_and_proof.aggregator_index].pubkey in get_sync_subcommittee_pubkeys(state, contribution.subcommittee_index).
 	if !slices.Contains(subcommiteePubsKeys, aggregatorPubKey) {
-		return errors.New("aggregator's validator index is not in subcommittee")
+		return WithReason(ReasonNotInCommittee, errors.New("aggregator's validator index is not in subcommittee"))
 	}
 
 	// [IGNORE] The sync committee contribution is the first valid contribution received for the aggregator with index contribution_and_proof.aggregator_index for the slot contribution.slot and subcommittee index contribution.subcommittee_index (this requires maintaining a cache of size SYNC_COMMITTEE_SIZE for this topic that can be flushed after each slot).
 	if s.wasContributionSeen(contributionAndProof) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 
 	// [REJECT] The contribution_and_proof.selection_proof is a valid signature of the SyncAggregatorSelectionData derived from the contribution by the validator with index contribution_and_proof.aggregator_index.
 	if err := verifySyncContributionSelectionProof(headState, contributionAndProof); !s.test && err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	}
 	// [REJECT] The aggregator signature, signed_contribution_and_proof.signature, is valid.
 	if err := verifyAggregatorSignatureForSyncContribution(headState, signedContribution); !s.test && err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	}
 	// [REJECT] The aggregate signature is valid for the message beacon_block_root and aggregate pubkey derived
 	// from the participation info in aggregation_bits for the subcommittee specified by the contribution.subcommittee_index.
 	if err := verifySyncContributionProofAggregatedSignature(headState, contributionAndProof.Contribution, subcommiteePubsKeys); !s.test && err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	}
 	// mark the valid contribution as seen
 	s.markContributionAsSeen(contributionAndProof)
@@ -153,9 +153,9 @@ func (s *syncContributionService) ProcessMessage(ctx context.Context, subnet *ui
 	// add the contribution to the pool
 	err = s.syncContributionPool.AddSyncContribution(headState, contributionAndProof.Contribution)
 	if errors.Is(err, sync_contribution_pool.ErrIsSuperset) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadyAggregated, ErrIgnore)
 	}
-	return err
+	return WithReason(ReasonInvalidOperation, err)
 }
 
 // def get_sync_subcommittee_pubkeys(state: BeaconState, subcommittee_index: uint64) -> Sequence[BLSPubkey]:
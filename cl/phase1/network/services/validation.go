@@ -0,0 +1,118 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"errors"
+)
+
+// ValidationOutcome is the gossipsub verdict on a message.
+type ValidationOutcome string
+
+const (
+	ValidationAccept ValidationOutcome = "accept"
+	ValidationIgnore ValidationOutcome = "ignore"
+	ValidationReject ValidationOutcome = "reject"
+)
+
+// ValidationReason explains why a gossip message was accepted, ignored or rejected.
+type ValidationReason string
+
+const (
+	ReasonValid       ValidationReason = "valid"
+	ReasonUnspecified ValidationReason = "unspecified"
+	ReasonInternal    ValidationReason = "internal_error"
+
+	ReasonDecodeFailed ValidationReason = "decode_failed"
+	ReasonUnknownTopic ValidationReason = "unknown_topic"
+	ReasonNotSynced    ValidationReason = "not_synced"
+	ReasonAlreadySeen  ValidationReason = "already_seen"
+	ReasonNotProcessed ValidationReason = "not_processed"
+
+	ReasonFutureSlot            ValidationReason = "future_slot"
+	ReasonFinalizedSlot         ValidationReason = "finalized_slot"
+	ReasonNotInPropagationRange ValidationReason = "not_in_propagation_range"
+	ReasonWrongEpoch            ValidationReason = "wrong_epoch"
+
+	ReasonUnknownParent          ValidationReason = "unknown_parent"
+	ReasonUnknownBlock           ValidationReason = "unknown_block"
+	ReasonParentNotOlder         ValidationReason = "parent_not_older"
+	ReasonNotFinalizedDescendant ValidationReason = "not_finalized_descendant"
+	ReasonInvalidTargetBlock     ValidationReason = "invalid_target_block"
+	ReasonDataNotAvailable       ValidationReason = "data_not_available"
+	ReasonInvalidBlock           ValidationReason = "invalid_block"
+
+	ReasonWrongSubnet           ValidationReason = "wrong_subnet"
+	ReasonInvalidCommitteeIndex ValidationReason = "invalid_committee_index"
+	ReasonInvalidAggregation    ValidationReason = "invalid_aggregation_bits"
+	ReasonNotAggregator         ValidationReason = "not_aggregator"
+	ReasonNotInCommittee        ValidationReason = "not_in_committee"
+	ReasonAlreadyAggregated     ValidationReason = "already_aggregated"
+
+	ReasonInvalidProposer       ValidationReason = "invalid_proposer"
+	ReasonInvalidIndex          ValidationReason = "invalid_index"
+	ReasonInvalidCommitments    ValidationReason = "invalid_commitments"
+	ReasonInvalidInclusionProof ValidationReason = "invalid_inclusion_proof"
+	ReasonInvalidKZGProof       ValidationReason = "invalid_kzg_proof"
+	ReasonInvalidValidator      ValidationReason = "invalid_validator"
+	ReasonInvalidOperation      ValidationReason = "invalid_operation"
+	ReasonInvalidSignature      ValidationReason = "invalid_signature"
+)
+
+// validationError attaches a reason to the error returned by a service. The outcome itself is still
+// decided by whether the wrapped error is ErrIgnore, so errors.Is(err, ErrIgnore) keeps working.
+type validationError struct {
+	reason ValidationReason
+	err    error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *validationError) Unwrap() error {
+	return e.err
+}
+
+// WithReason attaches a reason code to a validation error.
+func WithReason(reason ValidationReason, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &validationError{reason: reason, err: err}
+}
+
+// ClassifyValidationResult turns the error returned by ProcessMessage into a gossipsub outcome and a reason.
+// Errors without a reason are reported as "unspecified". Internal errors say nothing about the message,
+// so they are ignored rather than rejected.
+func ClassifyValidationResult(err error) (ValidationOutcome, ValidationReason) {
+	if err == nil {
+		return ValidationAccept, ReasonValid
+	}
+	outcome := ValidationReject
+	if errors.Is(err, ErrIgnore) {
+		outcome = ValidationIgnore
+	}
+	var vErr *validationError
+	if errors.As(err, &vErr) {
+		if vErr.reason == ReasonInternal {
+			outcome = ValidationIgnore
+		}
+		return outcome, vErr.reason
+	}
+	return outcome, ReasonUnspecified
+}
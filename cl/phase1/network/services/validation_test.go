@@ -0,0 +1,69 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyValidationResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		outcome ValidationOutcome
+		reason  ValidationReason
+	}{
+		{"valid", nil, ValidationAccept, ReasonValid},
+		{"bare ignore", ErrIgnore, ValidationIgnore, ReasonUnspecified},
+		{"bare reject", errors.New("boom"), ValidationReject, ReasonUnspecified},
+		{"not synced", WithReason(ReasonNotSynced, ErrIgnore), ValidationIgnore, ReasonNotSynced},
+		{"already seen", WithReason(ReasonAlreadySeen, ErrIgnore), ValidationIgnore, ReasonAlreadySeen},
+		{"future slot", WithReason(ReasonFutureSlot, ErrIgnore), ValidationIgnore, ReasonFutureSlot},
+		{"finalized slot", WithReason(ReasonFinalizedSlot, ErrIgnore), ValidationIgnore, ReasonFinalizedSlot},
+		{"unknown parent", WithReason(ReasonUnknownParent, ErrIgnore), ValidationIgnore, ReasonUnknownParent},
+		{"not processed", WithReason(ReasonNotProcessed, ErrIgnore), ValidationIgnore, ReasonNotProcessed},
+		{"data not available", WithReason(ReasonDataNotAvailable, ErrIgnore), ValidationIgnore, ReasonDataNotAvailable},
+		{"ignore wrapped by fmt", WithReason(ReasonNotInPropagationRange, fmt.Errorf("not in propagation range %w", ErrIgnore)), ValidationIgnore, ReasonNotInPropagationRange},
+		{"parent not older", WithReason(ReasonParentNotOlder, ErrBlockYoungerThanParent), ValidationReject, ReasonParentNotOlder},
+		{"invalid commitments", WithReason(ReasonInvalidCommitments, ErrInvalidCommitmentsCount), ValidationReject, ReasonInvalidCommitments},
+		{"inclusion proof", WithReason(ReasonInvalidInclusionProof, ErrCommitmentsInclusionProofFailed), ValidationReject, ReasonInvalidInclusionProof},
+		{"blob index", WithReason(ReasonWrongSubnet, ErrBlobIndexOutOfRange), ValidationReject, ReasonWrongSubnet},
+		{"invalid signature", WithReason(ReasonInvalidSignature, errors.New("invalid signature")), ValidationReject, ReasonInvalidSignature},
+		{"internal", WithReason(ReasonInternal, errors.New("db closed")), ValidationIgnore, ReasonInternal},
+		{"internal wrapped by caller", fmt.Errorf("process: %w", WithReason(ReasonInternal, errors.New("db closed"))), ValidationIgnore, ReasonInternal},
+		{"reason wrapped by caller", fmt.Errorf("process: %w", WithReason(ReasonWrongEpoch, errors.New("epoch mismatch"))), ValidationReject, ReasonWrongEpoch},
+		{"nil error with reason", WithReason(ReasonInvalidOperation, nil), ValidationAccept, ReasonValid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, reason := ClassifyValidationResult(tt.err)
+			require.Equal(t, tt.outcome, outcome)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
+
+func TestWithReasonKeepsError(t *testing.T) {
+	err := WithReason(ReasonParentNotOlder, ErrBlockYoungerThanParent)
+	require.ErrorIs(t, err, ErrBlockYoungerThanParent)
+	require.Equal(t, ErrBlockYoungerThanParent.Error(), err.Error())
+	require.False(t, errors.Is(err, ErrIgnore))
+}
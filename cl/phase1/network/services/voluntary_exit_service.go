@@ -61,43 +61,43 @@ func (s *voluntaryExitService) ProcessMessage(ctx context.Context, subnet *uint6
 
 	// [IGNORE] The voluntary exit is the first valid voluntary exit received for the validator with index signed_voluntary_exit.message.validator_index.
 	if s.operationsPool.VoluntaryExitsPool.Has(voluntaryExit.ValidatorIndex) {
-		return ErrIgnore
+		return WithReason(ReasonAlreadySeen, ErrIgnore)
 	}
 
 	// ref: https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/beacon-chain.md#voluntary-exits
 	// def process_voluntary_exit(state: BeaconState, signed_voluntary_exit: SignedVoluntaryExit) -> None:
 	state := s.syncedDataManager.HeadStateReader()
 	if state == nil {
-		return ErrIgnore
+		return WithReason(ReasonNotSynced, ErrIgnore)
 	}
 	val, err := state.ValidatorForValidatorIndex(int(voluntaryExit.ValidatorIndex))
 	if err != nil {
-		return ErrIgnore
+		return WithReason(ReasonInvalidValidator, ErrIgnore)
 	}
 	curEpoch := s.ethClock.GetCurrentEpoch()
 
 	// Verify the validator is active
 	// assert is_active_validator(validator, get_current_epoch(state))
 	if !val.Active(curEpoch) {
-		return errors.New("validator is not active")
+		return WithReason(ReasonInvalidValidator, errors.New("validator is not active"))
 	}
 
 	// Verify exit has not been initiated
 	// assert validator.exit_epoch == FAR_FUTURE_EPOCH
 	if !(val.ExitEpoch() == s.beaconCfg.FarFutureEpoch) {
-		return fmt.Errorf("verify exit has not been initiated. exitEpoch: %d, farFutureEpoch: %d", val.ExitEpoch(), s.beaconCfg.FarFutureEpoch)
+		return WithReason(ReasonInvalidOperation, fmt.Errorf("verify exit has not been initiated. exitEpoch: %d, farFutureEpoch: %d", val.ExitEpoch(), s.beaconCfg.FarFutureEpoch))
 	}
 
 	// Exits must specify an epoch when they become valid; they are not valid before then
 	// assert get_current_epoch(state) >= voluntary_exit.epoch
 	if !(curEpoch >= voluntaryExit.Epoch) {
-		return errors.New("exits must specify an epoch when they become valid; they are not valid before then")
+		return WithReason(ReasonFutureSlot, errors.New("exits must specify an epoch when they become valid; they are not valid before then"))
 	}
 
 	// Verify the validator has been active long enough
 	// assert get_current_epoch(state) >= validator.activation_epoch + SHARD_COMMITTEE_PERIOD
 	if !(curEpoch >= val.ActivationEpoch()+s.beaconCfg.ShardCommitteePeriod) {
-		return errors.New("verify the validator has been active long enough")
+		return WithReason(ReasonInvalidValidator, errors.New("verify the validator has been active long enough"))
 	}
 
 	// Verify signature
@@ -113,16 +113,16 @@ func (s *voluntaryExitService) ProcessMessage(ctx context.Context, subnet *uint6
 		domain, err = fork.ComputeDomain(domainType[:], utils.Uint32ToBytes4(uint32(s.beaconCfg.CapellaForkVersion)), state.GenesisValidatorsRoot())
 	}
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	signingRoot, err := computeSigningRoot(voluntaryExit, domain)
 	if err != nil {
-		return err
+		return WithReason(ReasonInternal, err)
 	}
 	if valid, err := blsVerify(msg.Signature[:], signingRoot[:], pk[:]); err != nil {
-		return err
+		return WithReason(ReasonInvalidSignature, err)
 	} else if !valid {
-		return errors.New("ProcessVoluntaryExit: BLS verification failed")
+		return WithReason(ReasonInvalidSignature, errors.New("ProcessVoluntaryExit: BLS verification failed"))
 	}
 
 	s.operationsPool.VoluntaryExitsPool.Insert(voluntaryExit.ValidatorIndex, msg)
@@ -226,7 +226,7 @@ func (t *voluntaryExitTestSuite) TestProcessMessage() {
 		if tt.wantErr {
 			t.Require().Error(err)
 			if tt.err != nil {
-				t.Require().Equal(tt.err, err)
+				t.Require().ErrorIs(err, tt.err)
 			}
 			log.Printf("error msg: %v", err.Error())
 		} else {
@@ -13,6 +13,7 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/beacon/beaconevents"
 	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes/lightclient_utils"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
@@ -183,6 +184,36 @@ func runIndexingRoutines(ctx context.Context, tx kv.RwTx, cfg *Cfg, headState *s
 	if err := state_accessors.IncrementHistoricalRootsTable(tx, headState, preverifiedHistoricalRoots); err != nil {
 		return fmt.Errorf("failed to increment historical roots table: %w", err)
 	}
+	if err := persistLightClientUpdates(tx, cfg, headState.Slot()); err != nil {
+		return fmt.Errorf("failed to persist light client updates: %w", err)
+	}
+	return nil
+}
+
+// persistLightClientUpdates stores the best light client update of the head period so that it survives restarts.
+// The previous period is stored too, as its best update can still change right after the period boundary.
+func persistLightClientUpdates(tx kv.RwTx, cfg *Cfg, headSlot uint64) error {
+	period := cfg.beaconCfg.SyncCommitteePeriod(headSlot)
+	periods := []uint64{period}
+	if period > 0 {
+		periods = append(periods, period-1)
+	}
+	for _, p := range periods {
+		update, has := cfg.forkChoice.GetLightClientUpdate(p)
+		if !has {
+			continue
+		}
+		stored, err := beacon_indicies.ReadLightClientUpdate(tx, p)
+		if err != nil {
+			return err
+		}
+		if stored != nil && !lightclient_utils.IsBetterUpdate(cfg.beaconCfg, update, stored) {
+			continue
+		}
+		if err := beacon_indicies.WriteLightClientUpdate(tx, p, update); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -327,6 +358,9 @@ func postForkchoiceOperations(ctx context.Context, tx kv.RwTx, logger log.Logger
 	if err := saveHeadStateOnDiskIfNeeded(cfg, headState); err != nil {
 		return fmt.Errorf("failed to save head state on disk: %w", err)
 	}
+	// Gossip the newest light client data
+	cfg.gossipManager.PublishLightClientUpdate(ctx, cfg.forkChoice.NewestLightClientUpdate())
+
 	// Lastly, emit the head event
 	emitHeadEvent(cfg, headSlot, headRoot, headState)
 	emitNextPaylodAttributesEvent(cfg, headSlot, headRoot, headState)
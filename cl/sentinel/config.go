@@ -50,6 +50,8 @@ type SentinelConfig struct {
 
 	EnableBlocks   bool
 	ActiveIndicies uint64
+	// ScoreParamsPath is an optional yaml file overriding the default topic score parameters.
+	ScoreParamsPath string
 }
 
 func convertToCryptoPrivkey(privkey *ecdsa.PrivateKey) (crypto.PrivKey, error) {
@@ -0,0 +1,78 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// newDebugRouter exposes the gossipsub scoring state:
+//
+//	GET  /peer_scores             score breakdown of all peers, lowest score first
+//	GET  /peer_scores/{peerId}    score breakdown of a single peer
+//	GET  /score_params            score parameters currently set on every joined topic
+//	POST /score_params/reload     re-read the score params file and apply it
+func (s *Sentinel) newDebugRouter() http.Handler {
+	mux := chi.NewRouter()
+	mux.Get("/peer_scores", func(w http.ResponseWriter, r *http.Request) {
+		writeDebugJSON(w, s.scoreTracker.Reports())
+	})
+	mux.Get("/peer_scores/{peerId}", func(w http.ResponseWriter, r *http.Request) {
+		pid, err := peer.Decode(chi.URLParam(r, "peerId"))
+		if err != nil {
+			http.Error(w, "Invalid Peer Id: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		report := s.scoreTracker.Report(pid)
+		if report == nil {
+			http.Error(w, "No score for peer", http.StatusNotFound)
+			return
+		}
+		writeDebugJSON(w, report)
+	})
+	mux.Get("/score_params", func(w http.ResponseWriter, r *http.Request) {
+		params := map[string]*pubsub.TopicScoreParams{}
+		s.appliedTopicParams.Range(func(key, value any) bool {
+			params[key.(string)] = value.(*pubsub.TopicScoreParams)
+			return true
+		})
+		writeDebugJSON(w, struct {
+			Thresholds *pubsub.PeerScoreThresholds         `json:"thresholds"`
+			Topics     map[string]*pubsub.TopicScoreParams `json:"topics"`
+		}{s.scoreTracker.thresholds, params})
+	})
+	mux.Post("/score_params/reload", func(w http.ResponseWriter, r *http.Request) {
+		if err := s.ReloadScoreParams(); err != nil {
+			http.Error(w, "Reloading score params: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func writeDebugJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
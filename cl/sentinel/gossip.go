@@ -208,6 +208,7 @@ func (s *Sentinel) forkWatcher() {
 				s.subManager.subscriptions.Range(func(key, value interface{}) bool {
 					sub := value.(*GossipSubscription)
 					s.subManager.unsubscribe(key.(string))
+					s.appliedTopicParams.Delete(key)
 					_, err := s.SubscribeGossip(sub.gossip_topic, sub.expiration.Load().(time.Time))
 					if err != nil {
 						log.Warn("[Gossip] Failed to resubscribe to topic", "err", err)
@@ -240,9 +241,12 @@ func (s *Sentinel) SubscribeGossip(topic GossipTopic, expiration time.Time, opts
 	if err != nil {
 		return nil, fmt.Errorf("failed to join topic %s, err=%w", path, err)
 	}
-	topicScoreParams := s.topicScoreParams(topic.Name)
-	if topicScoreParams != nil {
-		sub.topic.SetScoreParams(topicScoreParams)
+	if topicScoreParams := s.topicScoreParams(topic.Name); topicScoreParams != nil {
+		if err := sub.topic.SetScoreParams(topicScoreParams); err != nil {
+			log.Warn("[Gossip] Failed to set topic score params", "topic", path, "err", err)
+		} else {
+			s.appliedTopicParams.Store(path, topicScoreParams)
+		}
 	}
 	s.subManager.AddSubscription(path, sub)
 
@@ -254,26 +258,92 @@ func (s *Sentinel) Unsubscribe(topic GossipTopic, opts ...pubsub.TopicOpt) (err
 	if err != nil {
 		log.Error("[Gossip] Failed to calculate fork choice", "err", err)
 	}
-	s.subManager.unsubscribe(fmt.Sprintf("/eth2/%x/%s/%s", digest, topic.Name, topic.CodecStr))
+	path := fmt.Sprintf("/eth2/%x/%s/%s", digest, topic.Name, topic.CodecStr)
+	s.subManager.unsubscribe(path)
+	s.appliedTopicParams.Delete(path)
 
 	return nil
 }
 
+// topicScoreParams returns the score parameters of a topic, with the overrides from the score params file applied.
 func (s *Sentinel) topicScoreParams(topic string) *pubsub.TopicScoreParams {
-	switch {
-	case strings.Contains(topic, gossip.TopicNameBeaconBlock) || gossip.IsTopicBlobSidecar(topic):
+	class := topicScoreClass(topic)
+	return s.scoreOverrides.Load().apply(class, s.defaultTopicScoreParams(class))
+}
+
+func (s *Sentinel) defaultTopicScoreParams(class string) *pubsub.TopicScoreParams {
+	switch class {
+	case ScoreClassBeaconBlock, ScoreClassBlobSidecar:
 		return s.defaultBlockTopicParams()
-	case strings.Contains(topic, gossip.TopicNameVoluntaryExit):
+	case ScoreClassVoluntaryExit:
 		return s.defaultVoluntaryExitTopicParams()
-	case gossip.IsTopicBeaconAttestation(topic):
+	case ScoreClassBeaconAttestation:
 		return s.defaultAggregateSubnetTopicParams()
-	case gossip.IsTopicSyncCommittee(topic):
+	case ScoreClassSyncCommittee:
 		return s.defaultSyncSubnetTopicParams(s.cfg.ActiveIndicies)
 	default:
 		return nil
 	}
 }
 
+// appliedTopicScoreParams returns the score parameters currently set on a joined topic.
+func (s *Sentinel) appliedTopicScoreParams(topic string) *pubsub.TopicScoreParams {
+	params, ok := s.appliedTopicParams.Load(topic)
+	if !ok {
+		return nil
+	}
+	return params.(*pubsub.TopicScoreParams)
+}
+
+func (s *Sentinel) validateScoreParamsOverrides(overrides *ScoreParamsOverrides) error {
+	for _, class := range []string{ScoreClassBeaconBlock, ScoreClassBlobSidecar, ScoreClassVoluntaryExit, ScoreClassBeaconAttestation, ScoreClassSyncCommittee} {
+		params := overrides.apply(class, s.defaultTopicScoreParams(class))
+		if params == nil {
+			continue
+		}
+		if err := validateTopicScoreParams(params); err != nil {
+			return fmt.Errorf("%s: %w", class, err)
+		}
+	}
+	return nil
+}
+
+// ReloadScoreParams re-reads the score params file and applies it to all the topics we joined.
+func (s *Sentinel) ReloadScoreParams() error {
+	if s.cfg.ScoreParamsPath == "" {
+		return errors.New("no score params file configured")
+	}
+	overrides, err := LoadScoreParamsOverrides(s.cfg.ScoreParamsPath)
+	if err != nil {
+		return err
+	}
+	// validate everything first, so that a bad file is not partially applied.
+	if err := s.validateScoreParamsOverrides(overrides); err != nil {
+		return err
+	}
+	s.scoreOverrides.Store(overrides)
+
+	s.subManager.subscriptions.Range(func(key, value any) bool {
+		sub := value.(*GossipSubscription)
+		sub.lock.Lock()
+		defer sub.lock.Unlock()
+		if sub.topic == nil {
+			return true
+		}
+		params := s.topicScoreParams(sub.gossip_topic.Name)
+		if params == nil {
+			return true
+		}
+		if err = sub.topic.SetScoreParams(params); err != nil {
+			err = fmt.Errorf("failed to set score params on %s: %w", key, err)
+			return false
+		}
+		s.appliedTopicParams.Store(key, params)
+		return true
+	})
+	return err
+}
+
 // Based on the prysm parameters.
 // https://gist.github.com/blacktemplar/5c1862cb3f0e32a1a7fb0b25e79e6e2c
 func (s *Sentinel) defaultBlockTopicParams() *pubsub.TopicScoreParams {
@@ -19,6 +19,7 @@ package handlers
 import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/sentinel/communication/ssz_snappy"
 	"github.com/erigontech/erigon/cl/utils"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -114,6 +115,12 @@ func (c *ConsensusHandlers) lightClientUpdatesByRangeHandler(s network.Stream) e
 		return err
 	}
 
+	tx, err := c.indiciesDB.BeginRo(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	lightClientUpdates := make([]*cltypes.LightClientUpdate, 0, maxLightClientsPerRequest)
 
 	endPeriod := req.StartPeriod + req.Count
@@ -126,6 +133,13 @@ func (c *ConsensusHandlers) lightClientUpdatesByRangeHandler(s network.Stream) e
 	// Fetch from [start_period, start_period + count]
 	for i := req.StartPeriod; i < endPeriod; i++ {
 		update, has := c.forkChoiceReader.GetLightClientUpdate(i)
+		if !has {
+			// fall back to the persisted history for periods that are not in memory anymore.
+			if update, err = beacon_indicies.ReadLightClientUpdate(tx, i); err != nil {
+				return err
+			}
+			has = update != nil
+		}
 		if !has {
 			notFoundPrev = true
 			continue
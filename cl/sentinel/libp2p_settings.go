@@ -31,15 +31,18 @@ func (s *Sentinel) scoreDecay(totalDurationDecay time.Duration) float64 {
 	return math.Pow(decayToZero, 1/float64(numOfTimes))
 }
 
-func (s *Sentinel) pubsubOptions() []pubsub.Option {
-	thresholds := &pubsub.PeerScoreThresholds{
+func (s *Sentinel) peerScoreThresholds() *pubsub.PeerScoreThresholds {
+	return &pubsub.PeerScoreThresholds{
 		GossipThreshold:             -4000,
 		PublishThreshold:            -8000,
 		GraylistThreshold:           -16000,
 		AcceptPXThreshold:           100,
 		OpportunisticGraftThreshold: 5,
 	}
-	scoreParams := &pubsub.PeerScoreParams{
+}
+
+func (s *Sentinel) peerScoreParams() *pubsub.PeerScoreParams {
+	return &pubsub.PeerScoreParams{
 		Topics:        make(map[string]*pubsub.TopicScoreParams),
 		TopicScoreCap: 32.72,
 		AppSpecificScore: func(p peer.ID) float64 {
@@ -56,6 +59,12 @@ func (s *Sentinel) pubsubOptions() []pubsub.Option {
 		DecayToZero:                 decayToZero,
 		RetainScore:                 100 * s.oneEpochDuration(), // Retain for 100 epochs
 	}
+}
+
+func (s *Sentinel) pubsubOptions() []pubsub.Option {
+	thresholds := s.peerScoreThresholds()
+	scoreParams := s.peerScoreParams()
+	s.scoreTracker = newPeerScoreTracker(s.peerScoreParams(), thresholds, s.appliedTopicScoreParams)
 	pubsubQueueSize := 600
 	psOpts := []pubsub.Option{
 		pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign),
@@ -65,6 +74,8 @@ func (s *Sentinel) pubsubOptions() []pubsub.Option {
 		pubsub.WithMaxMessageSize(int(s.cfg.NetworkConfig.GossipMaxSizeBellatrix)),
 		pubsub.WithValidateQueueSize(pubsubQueueSize),
 		pubsub.WithPeerScore(scoreParams, thresholds),
+		pubsub.WithPeerScoreInspect(pubsub.ExtendedPeerScoreInspectFn(s.scoreTracker.inspect), peerScoreInspectInterval),
+		pubsub.WithRawTracer(s.scoreTracker),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
 	}
 	return psOpts
@@ -0,0 +1,299 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	// peerScoreInspectInterval is how often gossipsub hands us a snapshot of all peer scores.
+	peerScoreInspectInterval = 10 * time.Second
+	// maxRecentPenalties is how many penalties we remember per peer.
+	maxRecentPenalties = 16
+)
+
+// Penalty kinds recorded by the peer score tracker.
+const (
+	PenaltyInvalidMessage = "invalid_message"
+	PenaltyBehaviour      = "behaviour"
+	PenaltyPruned         = "pruned"
+)
+
+var (
+	peerScoreGraylisted     = metrics.GetOrCreateGauge("sentinel_peer_score_graylisted")
+	peerScoreBelowGossip    = metrics.GetOrCreateGauge("sentinel_peer_score_below_gossip_threshold")
+	peerScoreMinimum        = metrics.GetOrCreateGauge("sentinel_peer_score_min")
+	peerScoreMedian         = metrics.GetOrCreateGauge("sentinel_peer_score_median")
+	peerScoreMaximum        = metrics.GetOrCreateGauge("sentinel_peer_score_max")
+	peerScoreInvalidPenalty = metrics.GetOrCreateCounter(`sentinel_peer_score_penalties{kind="invalid_message"}`)
+	peerScoreBehaviourPen   = metrics.GetOrCreateCounter(`sentinel_peer_score_penalties{kind="behaviour"}`)
+	peerScorePrunes         = metrics.GetOrCreateCounter(`sentinel_peer_score_penalties{kind="pruned"}`)
+)
+
+// PeerPenalty is a single penalty that was observed for a peer.
+type PeerPenalty struct {
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"`
+	Topic string    `json:"topic,omitempty"`
+	Delta float64   `json:"delta"`
+}
+
+// TopicScoreReport breaks down the score a peer gets on a single topic.
+// The P-fields are the weighted contributions before the topic weight is applied.
+type TopicScoreReport struct {
+	InMesh                   bool    `json:"in_mesh"`
+	TimeInMesh               float64 `json:"time_in_mesh_seconds"`
+	FirstMessageDeliveries   float64 `json:"first_message_deliveries"`
+	MeshMessageDeliveries    float64 `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries float64 `json:"invalid_message_deliveries"`
+	TopicWeight              float64 `json:"topic_weight"`
+	P1                       float64 `json:"p1_time_in_mesh"`
+	P2                       float64 `json:"p2_first_message_deliveries"`
+	P3                       float64 `json:"p3_mesh_message_deliveries"`
+	P4                       float64 `json:"p4_invalid_messages"`
+	Score                    float64 `json:"score"`
+}
+
+// PeerScoreReport is the full breakdown of a peer's gossipsub score.
+// P3b (mesh failure penalty) is not exposed by gossipsub, so it is only accounted for in Score.
+type PeerScoreReport struct {
+	PeerID          string                       `json:"peer_id"`
+	Score           float64                      `json:"score"`
+	Graylisted      bool                         `json:"graylisted"`
+	Topics          map[string]*TopicScoreReport `json:"topics"`
+	P5              float64                      `json:"p5_app_specific"`
+	P6              float64                      `json:"p6_ip_colocation"`
+	P7              float64                      `json:"p7_behaviour_penalty"`
+	Mesh            []string                     `json:"mesh"`
+	RecentPenalties []PeerPenalty                `json:"recent_penalties"`
+}
+
+// peerScoreTracker collects gossipsub score snapshots, mesh membership and penalties so that
+// they can be inspected through the debug endpoint and the metrics.
+type peerScoreTracker struct {
+	params      *pubsub.PeerScoreParams
+	thresholds  *pubsub.PeerScoreThresholds
+	topicParams func(topic string) *pubsub.TopicScoreParams
+
+	mu        sync.RWMutex
+	snapshots map[peer.ID]*pubsub.PeerScoreSnapshot
+	mesh      map[peer.ID]map[string]struct{}
+	penalties map[peer.ID][]PeerPenalty
+}
+
+func newPeerScoreTracker(params *pubsub.PeerScoreParams, thresholds *pubsub.PeerScoreThresholds, topicParams func(topic string) *pubsub.TopicScoreParams) *peerScoreTracker {
+	return &peerScoreTracker{
+		params:      params,
+		thresholds:  thresholds,
+		topicParams: topicParams,
+		snapshots:   make(map[peer.ID]*pubsub.PeerScoreSnapshot),
+		mesh:        make(map[peer.ID]map[string]struct{}),
+		penalties:   make(map[peer.ID][]PeerPenalty),
+	}
+}
+
+// inspect is the gossipsub ExtendedPeerScoreInspectFn.
+func (t *peerScoreTracker) inspect(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	now := time.Now()
+	t.mu.Lock()
+	for pid, snap := range snapshots {
+		prev, ok := t.snapshots[pid]
+		if !ok {
+			continue
+		}
+		if delta := snap.BehaviourPenalty - prev.BehaviourPenalty; delta > 0 {
+			t.addPenaltyLocked(pid, PeerPenalty{Time: now, Kind: PenaltyBehaviour, Delta: delta})
+			peerScoreBehaviourPen.Inc()
+		}
+		for topic, ts := range snap.Topics {
+			prevTs, ok := prev.Topics[topic]
+			if !ok {
+				continue
+			}
+			if delta := ts.InvalidMessageDeliveries - prevTs.InvalidMessageDeliveries; delta > 0 {
+				t.addPenaltyLocked(pid, PeerPenalty{Time: now, Kind: PenaltyInvalidMessage, Topic: topic, Delta: delta})
+				peerScoreInvalidPenalty.Inc()
+			}
+		}
+	}
+	t.snapshots = snapshots
+	for pid := range t.penalties {
+		if _, ok := snapshots[pid]; !ok {
+			delete(t.penalties, pid)
+		}
+	}
+	t.mu.Unlock()
+	t.updateMetrics(snapshots)
+}
+
+func (t *peerScoreTracker) updateMetrics(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	scores := make([]float64, 0, len(snapshots))
+	var graylisted, belowGossip int
+	for _, snap := range snapshots {
+		scores = append(scores, snap.Score)
+		if snap.Score < t.thresholds.GraylistThreshold {
+			graylisted++
+		}
+		if snap.Score < t.thresholds.GossipThreshold {
+			belowGossip++
+		}
+	}
+	peerScoreGraylisted.SetInt(graylisted)
+	peerScoreBelowGossip.SetInt(belowGossip)
+	if len(scores) == 0 {
+		return
+	}
+	sort.Float64s(scores)
+	peerScoreMinimum.Set(scores[0])
+	peerScoreMedian.Set(scores[len(scores)/2])
+	peerScoreMaximum.Set(scores[len(scores)-1])
+}
+
+func (t *peerScoreTracker) addPenaltyLocked(pid peer.ID, penalty PeerPenalty) {
+	penalties := append(t.penalties[pid], penalty)
+	if len(penalties) > maxRecentPenalties {
+		penalties = penalties[len(penalties)-maxRecentPenalties:]
+	}
+	t.penalties[pid] = penalties
+}
+
+// Reports returns the score breakdown of every peer we have a snapshot for, lowest score first.
+func (t *peerScoreTracker) Reports() []*PeerScoreReport {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	reports := make([]*PeerScoreReport, 0, len(t.snapshots))
+	for pid := range t.snapshots {
+		reports = append(reports, t.reportLocked(pid))
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Score < reports[j].Score
+	})
+	return reports
+}
+
+// Report returns the score breakdown of a single peer, or nil if we have no snapshot for it.
+func (t *peerScoreTracker) Report(pid peer.ID) *PeerScoreReport {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if _, ok := t.snapshots[pid]; !ok {
+		return nil
+	}
+	return t.reportLocked(pid)
+}
+
+func (t *peerScoreTracker) reportLocked(pid peer.ID) *PeerScoreReport {
+	snap := t.snapshots[pid]
+	report := &PeerScoreReport{
+		PeerID:          pid.String(),
+		Score:           snap.Score,
+		Graylisted:      snap.Score < t.thresholds.GraylistThreshold,
+		Topics:          make(map[string]*TopicScoreReport, len(snap.Topics)),
+		P5:              snap.AppSpecificScore * t.params.AppSpecificWeight,
+		P6:              snap.IPColocationFactor * t.params.IPColocationFactorWeight,
+		Mesh:            []string{},
+		RecentPenalties: append([]PeerPenalty{}, t.penalties[pid]...),
+	}
+	if excess := snap.BehaviourPenalty - t.params.BehaviourPenaltyThreshold; excess > 0 {
+		report.P7 = excess * excess * t.params.BehaviourPenaltyWeight
+	}
+	for topic := range t.mesh[pid] {
+		report.Mesh = append(report.Mesh, topic)
+	}
+	sort.Strings(report.Mesh)
+
+	for topic, ts := range snap.Topics {
+		_, inMesh := t.mesh[pid][topic]
+		topicReport := &TopicScoreReport{
+			InMesh:                   inMesh,
+			TimeInMesh:               ts.TimeInMesh.Seconds(),
+			FirstMessageDeliveries:   ts.FirstMessageDeliveries,
+			MeshMessageDeliveries:    ts.MeshMessageDeliveries,
+			InvalidMessageDeliveries: ts.InvalidMessageDeliveries,
+		}
+		report.Topics[topic] = topicReport
+		params := t.topicParams(topic)
+		if params == nil {
+			continue
+		}
+		topicReport.TopicWeight = params.TopicWeight
+		if params.TimeInMeshQuantum > 0 {
+			p1 := float64(ts.TimeInMesh / params.TimeInMeshQuantum)
+			if p1 > params.TimeInMeshCap {
+				p1 = params.TimeInMeshCap
+			}
+			topicReport.P1 = p1 * params.TimeInMeshWeight
+		}
+		topicReport.P2 = ts.FirstMessageDeliveries * params.FirstMessageDeliveriesWeight
+		meshDeliveriesActive := inMesh && ts.TimeInMesh >= params.MeshMessageDeliveriesActivation
+		if meshDeliveriesActive && ts.MeshMessageDeliveries < params.MeshMessageDeliveriesThreshold {
+			deficit := params.MeshMessageDeliveriesThreshold - ts.MeshMessageDeliveries
+			topicReport.P3 = deficit * deficit * params.MeshMessageDeliveriesWeight
+		}
+		topicReport.P4 = ts.InvalidMessageDeliveries * ts.InvalidMessageDeliveries * params.InvalidMessageDeliveriesWeight
+		topicReport.Score = (topicReport.P1 + topicReport.P2 + topicReport.P3 + topicReport.P4) * params.TopicWeight
+	}
+	return report
+}
+
+// Graft implements pubsub.RawTracer.
+func (t *peerScoreTracker) Graft(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.mesh[p]; !ok {
+		t.mesh[p] = make(map[string]struct{})
+	}
+	t.mesh[p][topic] = struct{}{}
+}
+
+// Prune implements pubsub.RawTracer.
+func (t *peerScoreTracker) Prune(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.mesh[p], topic)
+	t.addPenaltyLocked(p, PeerPenalty{Time: time.Now(), Kind: PenaltyPruned, Topic: topic})
+	peerScorePrunes.Inc()
+}
+
+// RemovePeer implements pubsub.RawTracer.
+func (t *peerScoreTracker) RemovePeer(p peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.mesh, p)
+}
+
+// The rest of pubsub.RawTracer is of no interest to us.
+func (t *peerScoreTracker) AddPeer(p peer.ID, proto protocol.ID)        {}
+func (t *peerScoreTracker) Join(topic string)                           {}
+func (t *peerScoreTracker) Leave(topic string)                          {}
+func (t *peerScoreTracker) ValidateMessage(msg *pubsub.Message)         {}
+func (t *peerScoreTracker) DeliverMessage(msg *pubsub.Message)          {}
+func (t *peerScoreTracker) RejectMessage(msg *pubsub.Message, r string) {}
+func (t *peerScoreTracker) DuplicateMessage(msg *pubsub.Message)        {}
+func (t *peerScoreTracker) ThrottlePeer(p peer.ID)                      {}
+func (t *peerScoreTracker) RecvRPC(rpc *pubsub.RPC)                     {}
+func (t *peerScoreTracker) SendRPC(rpc *pubsub.RPC, p peer.ID)          {}
+func (t *peerScoreTracker) DropRPC(rpc *pubsub.RPC, p peer.ID)          {}
+func (t *peerScoreTracker) UndeliverableMessage(msg *pubsub.Message)    {}
@@ -0,0 +1,201 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"gopkg.in/yaml.v2"
+
+	"github.com/erigontech/erigon/cl/gossip"
+)
+
+// Topic classes that share the same score parameters. They are also the keys of the
+// score parameters override file.
+const (
+	ScoreClassBeaconBlock       = "beacon_block"
+	ScoreClassBlobSidecar       = "blob_sidecar"
+	ScoreClassVoluntaryExit     = "voluntary_exit"
+	ScoreClassBeaconAttestation = "beacon_attestation"
+	ScoreClassSyncCommittee     = "sync_committee"
+)
+
+// topicScoreClass maps a topic to the class of score parameters it uses, or "" if the topic is not scored.
+func topicScoreClass(topic string) string {
+	switch {
+	case gossip.IsTopicBlobSidecar(topic):
+		return ScoreClassBlobSidecar
+	case strings.Contains(topic, gossip.TopicNameBeaconBlock):
+		return ScoreClassBeaconBlock
+	case strings.Contains(topic, gossip.TopicNameVoluntaryExit):
+		return ScoreClassVoluntaryExit
+	case gossip.IsTopicBeaconAttestation(topic):
+		return ScoreClassBeaconAttestation
+	case gossip.IsTopicSyncCommittee(topic):
+		return ScoreClassSyncCommittee
+	default:
+		return ""
+	}
+}
+
+// TopicScoreParamsOverride overrides single fields of the default topic score parameters.
+// Unset fields keep their default value. Durations are written as Go durations, e.g. "12s".
+type TopicScoreParamsOverride struct {
+	TopicWeight *float64 `yaml:"topic_weight"`
+
+	TimeInMeshWeight  *float64       `yaml:"time_in_mesh_weight"`
+	TimeInMeshQuantum *time.Duration `yaml:"time_in_mesh_quantum"`
+	TimeInMeshCap     *float64       `yaml:"time_in_mesh_cap"`
+
+	FirstMessageDeliveriesWeight *float64 `yaml:"first_message_deliveries_weight"`
+	FirstMessageDeliveriesDecay  *float64 `yaml:"first_message_deliveries_decay"`
+	FirstMessageDeliveriesCap    *float64 `yaml:"first_message_deliveries_cap"`
+
+	MeshMessageDeliveriesWeight     *float64       `yaml:"mesh_message_deliveries_weight"`
+	MeshMessageDeliveriesDecay      *float64       `yaml:"mesh_message_deliveries_decay"`
+	MeshMessageDeliveriesCap        *float64       `yaml:"mesh_message_deliveries_cap"`
+	MeshMessageDeliveriesThreshold  *float64       `yaml:"mesh_message_deliveries_threshold"`
+	MeshMessageDeliveriesWindow     *time.Duration `yaml:"mesh_message_deliveries_window"`
+	MeshMessageDeliveriesActivation *time.Duration `yaml:"mesh_message_deliveries_activation"`
+
+	MeshFailurePenaltyWeight *float64 `yaml:"mesh_failure_penalty_weight"`
+	MeshFailurePenaltyDecay  *float64 `yaml:"mesh_failure_penalty_decay"`
+
+	InvalidMessageDeliveriesWeight *float64 `yaml:"invalid_message_deliveries_weight"`
+	InvalidMessageDeliveriesDecay  *float64 `yaml:"invalid_message_deliveries_decay"`
+}
+
+// ScoreParamsOverrides is the content of the score parameters override file, keyed by topic class.
+type ScoreParamsOverrides struct {
+	Topics map[string]*TopicScoreParamsOverride `yaml:"topics"`
+}
+
+// LoadScoreParamsOverrides reads the score parameters override file.
+func LoadScoreParamsOverrides(path string) (*ScoreParamsOverrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	overrides := &ScoreParamsOverrides{}
+	if err := yaml.UnmarshalStrict(data, overrides); err != nil {
+		return nil, fmt.Errorf("invalid score params file %s: %w", path, err)
+	}
+	for class := range overrides.Topics {
+		switch class {
+		case ScoreClassBeaconBlock, ScoreClassBlobSidecar, ScoreClassVoluntaryExit, ScoreClassBeaconAttestation, ScoreClassSyncCommittee:
+		default:
+			return nil, fmt.Errorf("invalid score params file %s: unknown topic class %q", path, class)
+		}
+	}
+	return overrides, nil
+}
+
+// apply returns a copy of params with the overrides of the given topic class applied.
+func (o *ScoreParamsOverrides) apply(class string, params *pubsub.TopicScoreParams) *pubsub.TopicScoreParams {
+	if o == nil || params == nil {
+		return params
+	}
+	override, ok := o.Topics[class]
+	if !ok || override == nil {
+		return params
+	}
+	p := *params
+	setFloat := func(dst *float64, src *float64) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setDuration := func(dst *time.Duration, src *time.Duration) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setFloat(&p.TopicWeight, override.TopicWeight)
+	setFloat(&p.TimeInMeshWeight, override.TimeInMeshWeight)
+	setDuration(&p.TimeInMeshQuantum, override.TimeInMeshQuantum)
+	setFloat(&p.TimeInMeshCap, override.TimeInMeshCap)
+	setFloat(&p.FirstMessageDeliveriesWeight, override.FirstMessageDeliveriesWeight)
+	setFloat(&p.FirstMessageDeliveriesDecay, override.FirstMessageDeliveriesDecay)
+	setFloat(&p.FirstMessageDeliveriesCap, override.FirstMessageDeliveriesCap)
+	setFloat(&p.MeshMessageDeliveriesWeight, override.MeshMessageDeliveriesWeight)
+	setFloat(&p.MeshMessageDeliveriesDecay, override.MeshMessageDeliveriesDecay)
+	setFloat(&p.MeshMessageDeliveriesCap, override.MeshMessageDeliveriesCap)
+	setFloat(&p.MeshMessageDeliveriesThreshold, override.MeshMessageDeliveriesThreshold)
+	setDuration(&p.MeshMessageDeliveriesWindow, override.MeshMessageDeliveriesWindow)
+	setDuration(&p.MeshMessageDeliveriesActivation, override.MeshMessageDeliveriesActivation)
+	setFloat(&p.MeshFailurePenaltyWeight, override.MeshFailurePenaltyWeight)
+	setFloat(&p.MeshFailurePenaltyDecay, override.MeshFailurePenaltyDecay)
+	setFloat(&p.InvalidMessageDeliveriesWeight, override.InvalidMessageDeliveriesWeight)
+	setFloat(&p.InvalidMessageDeliveriesDecay, override.InvalidMessageDeliveriesDecay)
+	return &p
+}
+
+// validateTopicScoreParams applies the same rules as gossipsub does when the parameters are set on a topic,
+// so that a bad override file is reported before anything is applied.
+func validateTopicScoreParams(p *pubsub.TopicScoreParams) error {
+	isDecay := func(v float64) bool { return v > 0 && v < 1 && !math.IsNaN(v) }
+	isFinite := func(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) }
+
+	switch {
+	case p.TopicWeight < 0 || !isFinite(p.TopicWeight):
+		return errors.New("invalid topic weight; must be >= 0 and a valid number")
+	// P1
+	case p.TimeInMeshQuantum == 0:
+		return errors.New("invalid TimeInMeshQuantum; must be non zero")
+	case p.TimeInMeshWeight < 0 || !isFinite(p.TimeInMeshWeight):
+		return errors.New("invalid TimeInMeshWeight; must be positive (or 0 to disable) and a valid number")
+	case p.TimeInMeshWeight != 0 && (p.TimeInMeshCap <= 0 || !isFinite(p.TimeInMeshCap)):
+		return errors.New("invalid TimeInMeshCap; must be positive and a valid number")
+	// P2
+	case p.FirstMessageDeliveriesWeight < 0 || !isFinite(p.FirstMessageDeliveriesWeight):
+		return errors.New("invalid FirstMessageDeliveriesWeight; must be positive (or 0 to disable) and a valid number")
+	case p.FirstMessageDeliveriesWeight != 0 && !isDecay(p.FirstMessageDeliveriesDecay):
+		return errors.New("invalid FirstMessageDeliveriesDecay; must be between 0 and 1")
+	case p.FirstMessageDeliveriesWeight != 0 && (p.FirstMessageDeliveriesCap <= 0 || !isFinite(p.FirstMessageDeliveriesCap)):
+		return errors.New("invalid FirstMessageDeliveriesCap; must be positive and a valid number")
+	// P3
+	case p.MeshMessageDeliveriesWeight > 0 || !isFinite(p.MeshMessageDeliveriesWeight):
+		return errors.New("invalid MeshMessageDeliveriesWeight; must be negative (or 0 to disable) and a valid number")
+	case p.MeshMessageDeliveriesWeight != 0 && !isDecay(p.MeshMessageDeliveriesDecay):
+		return errors.New("invalid MeshMessageDeliveriesDecay; must be between 0 and 1")
+	case p.MeshMessageDeliveriesWeight != 0 && (p.MeshMessageDeliveriesCap <= 0 || !isFinite(p.MeshMessageDeliveriesCap)):
+		return errors.New("invalid MeshMessageDeliveriesCap; must be positive and a valid number")
+	case p.MeshMessageDeliveriesWeight != 0 && (p.MeshMessageDeliveriesThreshold <= 0 || !isFinite(p.MeshMessageDeliveriesThreshold)):
+		return errors.New("invalid MeshMessageDeliveriesThreshold; must be positive and a valid number")
+	case p.MeshMessageDeliveriesWindow < 0:
+		return errors.New("invalid MeshMessageDeliveriesWindow; must be non-negative")
+	case p.MeshMessageDeliveriesWeight != 0 && p.MeshMessageDeliveriesActivation < time.Second:
+		return errors.New("invalid MeshMessageDeliveriesActivation; must be at least 1s")
+	// P3b
+	case p.MeshFailurePenaltyWeight > 0 || !isFinite(p.MeshFailurePenaltyWeight):
+		return errors.New("invalid MeshFailurePenaltyWeight; must be negative (or 0 to disable) and a valid number")
+	case p.MeshFailurePenaltyWeight != 0 && !isDecay(p.MeshFailurePenaltyDecay):
+		return errors.New("invalid MeshFailurePenaltyDecay; must be between 0 and 1")
+	// P4
+	case p.InvalidMessageDeliveriesWeight > 0 || !isFinite(p.InvalidMessageDeliveriesWeight):
+		return errors.New("invalid InvalidMessageDeliveriesWeight; must be negative (or 0 to disable) and a valid number")
+	case !isDecay(p.InvalidMessageDeliveriesDecay):
+		return errors.New("invalid InvalidMessageDeliveriesDecay; must be between 0 and 1")
+	}
+	return nil
+}
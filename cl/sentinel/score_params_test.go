@@ -0,0 +1,142 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+func scoringTestSentinel() *Sentinel {
+	networkConfig, beaconConfig := clparams.GetConfigsByNetwork(clparams.MainnetNetwork)
+	return &Sentinel{cfg: &SentinelConfig{
+		NetworkConfig:  networkConfig,
+		BeaconConfig:   beaconConfig,
+		ActiveIndicies: 1_000_000,
+	}}
+}
+
+// The expected values follow the recommendations from
+// https://gist.github.com/blacktemplar/5c1862cb3f0e32a1a7fb0b25e79e6e2c
+func TestDefaultPeerScoreParams(t *testing.T) {
+	s := scoringTestSentinel()
+	slot := 12 * time.Second
+	epoch := 32 * slot
+
+	thresholds := s.peerScoreThresholds()
+	require.Less(t, thresholds.PublishThreshold, thresholds.GossipThreshold)
+	require.Less(t, thresholds.GraylistThreshold, thresholds.PublishThreshold)
+	require.Equal(t, -4000.0, thresholds.GossipThreshold)
+	require.Equal(t, -8000.0, thresholds.PublishThreshold)
+	require.Equal(t, -16000.0, thresholds.GraylistThreshold)
+	require.Equal(t, 100.0, thresholds.AcceptPXThreshold)
+	require.Equal(t, 5.0, thresholds.OpportunisticGraftThreshold)
+
+	params := s.peerScoreParams()
+	require.Equal(t, slot, params.DecayInterval)
+	require.Equal(t, 0.01, params.DecayToZero)
+	require.Equal(t, 100*epoch, params.RetainScore)
+	require.Equal(t, 10, params.IPColocationFactorThreshold)
+	require.Equal(t, 6.0, params.BehaviourPenaltyThreshold)
+	require.Less(t, params.IPColocationFactorWeight, 0.0)
+	require.Less(t, params.BehaviourPenaltyWeight, 0.0)
+	// a behaviour penalty decays to zero in 10 epochs.
+	require.InDelta(t, 0.01, math.Pow(params.BehaviourPenaltyDecay, float64(10*epoch/slot)), 1e-9)
+	// the topic score cap must not be reachable by only staying in the mesh.
+	require.Less(t, params.TopicScoreCap, maxScore())
+}
+
+func TestDefaultTopicScoreParams(t *testing.T) {
+	s := scoringTestSentinel()
+	slot := 12 * time.Second
+
+	for _, class := range []string{ScoreClassBeaconBlock, ScoreClassBlobSidecar, ScoreClassVoluntaryExit, ScoreClassBeaconAttestation, ScoreClassSyncCommittee} {
+		params := s.defaultTopicScoreParams(class)
+		require.NotNil(t, params, class)
+		require.NoError(t, validateTopicScoreParams(params), class)
+		require.Equal(t, slot, params.TimeInMeshQuantum, class)
+		require.Equal(t, 300.0, params.TimeInMeshCap, class)
+		require.InDelta(t, float64(maxInMeshScore), params.TimeInMeshWeight*params.TimeInMeshCap, 1e-9, class)
+		require.Less(t, params.InvalidMessageDeliveriesWeight, 0.0, class)
+	}
+
+	block := s.defaultTopicScoreParams(ScoreClassBeaconBlock)
+	require.Equal(t, beaconBlockWeight, block.TopicWeight)
+	require.Equal(t, 2*time.Second, block.MeshMessageDeliveriesWindow)
+
+	// every attestation and sync subnet can earn at most maxFirstDeliveryScore from first deliveries.
+	for _, class := range []string{ScoreClassBeaconAttestation, ScoreClassSyncCommittee} {
+		params := s.defaultTopicScoreParams(class)
+		require.InDelta(t, float64(maxFirstDeliveryScore), params.FirstMessageDeliveriesWeight*params.FirstMessageDeliveriesCap, 1e-9, class)
+		// a single invalid message on a subnet cancels the maximal positive score of the peer.
+		require.InDelta(t, -maxScore(), params.InvalidMessageDeliveriesWeight*params.TopicWeight, 1e-9, class)
+	}
+	require.InDelta(t, attestationTotalWeight, s.defaultTopicScoreParams(ScoreClassBeaconAttestation).TopicWeight*float64(s.cfg.NetworkConfig.AttestationSubnetCount), 1e-9)
+	require.InDelta(t, syncCommitteesTotalWeight, s.defaultTopicScoreParams(ScoreClassSyncCommittee).TopicWeight*float64(s.cfg.BeaconConfig.SyncCommitteeSubnetCount), 1e-9)
+
+	require.Empty(t, topicScoreClass("/eth2/00000000/proposer_slashing/ssz_snappy"))
+	require.Equal(t, ScoreClassBlobSidecar, topicScoreClass("/eth2/00000000/blob_sidecar_3/ssz_snappy"))
+	require.Equal(t, ScoreClassBeaconAttestation, topicScoreClass("/eth2/00000000/beacon_attestation_12/ssz_snappy"))
+}
+
+func TestScoreParamsOverrides(t *testing.T) {
+	s := scoringTestSentinel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "score.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+topics:
+  beacon_block:
+    topic_weight: 0.5
+    mesh_message_deliveries_window: 3s
+`), 0o644))
+	overrides, err := LoadScoreParamsOverrides(path)
+	require.NoError(t, err)
+	s.scoreOverrides.Store(overrides)
+
+	params := s.topicScoreParams("/eth2/00000000/beacon_block/ssz_snappy")
+	require.Equal(t, 0.5, params.TopicWeight)
+	require.Equal(t, 3*time.Second, params.MeshMessageDeliveriesWindow)
+	defaults := s.defaultBlockTopicParams()
+	require.Equal(t, defaults.FirstMessageDeliveriesCap, params.FirstMessageDeliveriesCap)
+	// other classes are left untouched.
+	require.Equal(t, voluntaryExitWeight, s.topicScoreParams("/eth2/00000000/voluntary_exit/ssz_snappy").TopicWeight)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+topics:
+  beacon_block:
+    invalid_message_deliveries_weight: 10
+`), 0o644))
+	overrides, err = LoadScoreParamsOverrides(path)
+	require.NoError(t, err)
+	require.Error(t, validateTopicScoreParams(overrides.apply(ScoreClassBeaconBlock, defaults)))
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+topics:
+  beacon_blocks:
+    topic_weight: 0.5
+`), 0o644))
+	_, err = LoadScoreParamsOverrides(path)
+	require.Error(t, err)
+}
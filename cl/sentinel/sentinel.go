@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -86,7 +87,8 @@ type Sentinel struct {
 	cfg      *SentinelConfig
 	peers    *peers.Pool
 
-	httpApi http.Handler
+	httpApi  http.Handler
+	debugApi http.Handler
 
 	handshaker *handshake.HandShaker
 
@@ -105,6 +107,10 @@ type Sentinel struct {
 	ethClock         eth_clock.EthereumClock
 
 	metadataLock sync.Mutex
+
+	scoreTracker       *peerScoreTracker
+	scoreOverrides     atomic.Pointer[ScoreParamsOverrides]
+	appliedTopicParams sync.Map // map from topic string to *pubsub.TopicScoreParams
 }
 
 func (s *Sentinel) createLocalNode(
@@ -215,6 +221,17 @@ func New(
 		ethClock:         ethClock,
 	}
 
+	if cfg.ScoreParamsPath != "" {
+		overrides, err := LoadScoreParamsOverrides(cfg.ScoreParamsPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validateScoreParamsOverrides(overrides); err != nil {
+			return nil, err
+		}
+		s.scoreOverrides.Store(overrides)
+	}
+
 	// Setup discovery
 	enodes := make([]*enode.Node, len(cfg.NetworkConfig.BootNodes))
 	for i, bootnode := range cfg.NetworkConfig.BootNodes {
@@ -276,6 +293,7 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("[Sentinel] failed to subscribe to gossip err=%w", err)
 	}
+	s.debugApi = s.newDebugRouter()
 
 	return s, nil
 }
@@ -284,6 +302,11 @@ func (s *Sentinel) ReqRespHandler() http.Handler {
 	return s.httpApi
 }
 
+// DebugHandler serves the sentinel debug endpoints (peer scores and score parameters).
+func (s *Sentinel) DebugHandler() http.Handler {
+	return s.debugApi
+}
+
 func (s *Sentinel) RecvGossip() <-chan *GossipMessage {
 	return s.subManager.Recv()
 }
@@ -115,7 +115,9 @@ func (s *SentinelServer) PublishGossip(_ context.Context, msg *sentinelrpc.Gossi
 		gossip.TopicNameProposerSlashing,
 		gossip.TopicNameSyncCommitteeContributionAndProof,
 		gossip.TopicNameAttesterSlashing,
-		gossip.TopicNameBlsToExecutionChange:
+		gossip.TopicNameBlsToExecutionChange,
+		gossip.TopicNameLightClientFinalityUpdate,
+		gossip.TopicNameLightClientOptimisticUpdate:
 		subscription = manager.GetMatchingSubscription(msg.Name)
 	default:
 		// check subnets
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 	"time"
 
@@ -46,6 +47,8 @@ type ServerConfig struct {
 	Addr          string
 	Creds         credentials.TransportCredentials
 	InitialStatus *cltypes.Status
+	// DebugAddr is an optional address to serve the sentinel debug endpoints on.
+	DebugAddr string
 }
 
 func generateSubnetsTopics(template string, maxIds int) []sentinel.GossipTopic {
@@ -98,8 +101,8 @@ func createSentinel(
 		sentinel.ProposerSlashingSsz,
 		sentinel.AttesterSlashingSsz,
 		sentinel.BlsToExecutionChangeSsz,
-		////sentinel.LightClientFinalityUpdateSsz,
-		////sentinel.LightClientOptimisticUpdateSsz,
+		sentinel.LightClientFinalityUpdateSsz,
+		sentinel.LightClientOptimisticUpdateSsz,
 		sentinel.SyncCommitteeContributionAndProofSsz,
 		sentinel.BeaconAggregateAndProofSsz,
 	}
@@ -164,6 +167,9 @@ func StartSentinelService(
 	if srvCfg.InitialStatus != nil {
 		sent.SetStatus(srvCfg.InitialStatus)
 	}
+	if srvCfg.DebugAddr != "" {
+		go StartDebugServe(sent, srvCfg.DebugAddr, logger)
+	}
 	server := NewSentinelServer(ctx, sent, logger)
 	go StartServe(server, srvCfg, srvCfg.Creds)
 
@@ -188,3 +194,11 @@ func StartServe(
 		log.Warn("[Sentinel] could not serve service", "reason", err)
 	}
 }
+
+// StartDebugServe serves the sentinel debug endpoints (peer scores, score parameters) over plain http.
+func StartDebugServe(sent *sentinel.Sentinel, addr string, logger log.Logger) {
+	logger.Info("[Sentinel] Serving debug endpoints", "addr", addr)
+	if err := http.ListenAndServe(addr, sent.DebugHandler()); err != nil {
+		logger.Warn("[Sentinel] could not serve debug endpoints", "reason", err)
+	}
+}
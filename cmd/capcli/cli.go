@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -43,10 +44,15 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/metrics"
 
+	"github.com/erigontech/erigon/cl/aggregation"
 	"github.com/erigontech/erigon/cl/antiquary"
+	"github.com/erigontech/erigon/cl/beacon/beacon_router_configuration"
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/clparams/initial_state"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/monitor"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/persistence/format/snapshot_format"
 	"github.com/erigontech/erigon/cl/persistence/format/snapshot_format/getters"
@@ -54,10 +60,18 @@ import (
 	"github.com/erigontech/erigon/cl/persistence/state/historical_states_reader"
 	"github.com/erigontech/erigon/cl/phase1/core/checkpoint_sync"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
 	"github.com/erigontech/erigon/cl/phase1/network"
+	"github.com/erigontech/erigon/cl/phase1/network/gossipcapture"
+	"github.com/erigontech/erigon/cl/phase1/network/services"
 	"github.com/erigontech/erigon/cl/phase1/stages"
+	"github.com/erigontech/erigon/cl/pool"
 	"github.com/erigontech/erigon/cl/rpc"
+	"github.com/erigontech/erigon/cl/utils"
 	"github.com/erigontech/erigon/cl/utils/eth_clock"
+	"github.com/erigontech/erigon/cl/validator/committee_subscription"
+	"github.com/erigontech/erigon/cl/validator/sync_contribution_pool"
 	"github.com/erigontech/erigon/cmd/caplin/caplin1"
 	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/eth/ethconfig"
@@ -78,6 +92,7 @@ var CLI struct {
 	BlobArchiveStoreCheck   BlobArchiveStoreCheck   `cmd:"" help:"blob archive store check"`
 	DumpBlobsSnapshots      DumpBlobsSnapshots      `cmd:"" help:"dump blobs snapshots"`
 	CheckBlobsSnapshots     CheckBlobsSnapshots     `cmd:"" help:"check blobs snapshots"`
+	ReplayGossip            ReplayGossip            `cmd:"" help:"replay captured gossip messages through the validators"`
 }
 
 type chainCfg struct {
@@ -1045,3 +1060,116 @@ func (c *CheckBlobsSnapshots) Run(ctx *Context) error {
 	}
 	return nil
 }
+
+// replaySentinel stands in for the sentinel while replaying captured gossip, nothing is sent to the network.
+type replaySentinel struct {
+	sentinel.SentinelClient
+}
+
+func (replaySentinel) BanPeer(context.Context, *sentinel.Peer, ...grpc.CallOption) (*sentinel.EmptyMessage, error) {
+	return &sentinel.EmptyMessage{}, nil
+}
+
+func (replaySentinel) PublishGossip(context.Context, *sentinel.GossipData, ...grpc.CallOption) (*sentinel.EmptyMessage, error) {
+	return &sentinel.EmptyMessage{}, nil
+}
+
+func (replaySentinel) SetSubscribeExpiry(context.Context, *sentinel.RequestSubscribeExpiry, ...grpc.CallOption) (*sentinel.EmptyMessage, error) {
+	return &sentinel.EmptyMessage{}, nil
+}
+
+type ReplayGossip struct {
+	chainCfg
+	CaptureDir string `help:"directory of the gossip capture archive" type:"existingdir" required:""`
+	State      string `help:"ssz encoded beacon state to validate the messages against" type:"existingfile" required:""`
+	Topic      string `help:"only replay messages of this topic" default:""`
+}
+
+// Run replays the captured messages through the gossip validators and reports, for every message, the
+// recorded outcome next to the one obtained now. Slot range checks use the wall clock, so old captures
+// are expected to be ignored by them.
+func (r *ReplayGossip) Run(ctx *Context) error {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StderrHandler))
+	networkConfig, beaconConfig, _, err := clparams.GetConfigsByNetworkName(r.Chain)
+	if err != nil {
+		return err
+	}
+	rawState, err := os.ReadFile(r.State)
+	if err != nil {
+		return err
+	}
+	slot, err := utils.ExtractSlotFromSerializedBeaconState(rawState)
+	if err != nil {
+		return err
+	}
+	st := state.New(beaconConfig)
+	if err := st.DecodeSSZ(rawState, int(beaconConfig.GetCurrentStateVersion(slot/beaconConfig.SlotsPerEpoch))); err != nil {
+		return fmt.Errorf("could not decode state: %w", err)
+	}
+	ethClock := eth_clock.NewEthereumClock(st.GenesisTime(), st.GenesisValidatorsRoot(), beaconConfig)
+
+	tmpDir, err := os.MkdirTemp("", "capcli-replay-gossip")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	indexDB, blobStorage, err := caplin1.OpenCaplinDatabase(ctx, beaconConfig, ethClock, path.Join(tmpDir, "indexing"), path.Join(tmpDir, "blobs"), nil, true, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+
+	opPool := pool.NewOperationsPool(beaconConfig)
+	emitters := beaconevents.NewEventEmitter()
+	syncedDataManager := synced_data.NewSyncedDataManager(true, beaconConfig)
+	forkChoice, err := forkchoice.NewForkChoiceStore(
+		ethClock, st, nil, opPool, fork_graph.NewForkGraphDisk(st, afero.NewBasePathFs(afero.NewOsFs(), path.Join(tmpDir, "forkchoice")), beacon_router_configuration.RouterConfiguration{}, emitters),
+		emitters, syncedDataManager, blobStorage, monitor.NewValidatorMonitor(false, ethClock, beaconConfig, syncedDataManager))
+	if err != nil {
+		return err
+	}
+	if err := syncedDataManager.OnHeadState(st); err != nil {
+		return err
+	}
+
+	s := replaySentinel{}
+	syncContributionPool := sync_contribution_pool.NewSyncContributionPool(beaconConfig)
+	aggregationPool := aggregation.NewAggregationPool(ctx, beaconConfig, networkConfig, ethClock)
+	committeeSub := committee_subscription.NewCommitteeSubscribeManagement(ctx, indexDB, beaconConfig, networkConfig, ethClock, s, st, aggregationPool, syncedDataManager)
+	gossipManager := network.NewGossipReceiver(s, forkChoice, beaconConfig, ethClock, emitters, committeeSub,
+		services.NewBlockService(ctx, indexDB, forkChoice, syncedDataManager, ethClock, beaconConfig, emitters),
+		services.NewBlobSidecarService(ctx, beaconConfig, forkChoice, syncedDataManager, ethClock, emitters, false),
+		services.NewSyncCommitteeMessagesService(beaconConfig, ethClock, syncedDataManager, syncContributionPool, false),
+		services.NewSyncContributionService(syncedDataManager, beaconConfig, syncContributionPool, ethClock, emitters, false),
+		services.NewAggregateAndProofService(ctx, syncedDataManager, forkChoice, beaconConfig, opPool, false),
+		services.NewAttestationService(ctx, forkChoice, committeeSub, ethClock, syncedDataManager, beaconConfig, networkConfig, emitters),
+		services.NewVoluntaryExitService(opPool, emitters, syncedDataManager, beaconConfig, ethClock),
+		services.NewBLSToExecutionChangeService(opPool, emitters, syncedDataManager, beaconConfig),
+		services.NewProposerSlashingService(opPool, syncedDataManager, beaconConfig, ethClock, emitters),
+		nil)
+
+	var total, changed int
+	if err := gossipcapture.ForEach(r.CaptureDir, func(record *gossipcapture.Record) error {
+		if r.Topic != "" && record.Topic != r.Topic {
+			return nil
+		}
+		data := &sentinel.GossipData{
+			Name:     record.Topic,
+			Peer:     &sentinel.Peer{Pid: record.Peer},
+			SubnetId: record.SubnetId,
+			Data:     record.Data,
+		}
+		err := gossipManager.Validate(ctx, data, record.Version)
+		outcome, reason := services.ClassifyValidationResult(err)
+		total++
+		if string(outcome) != record.Outcome || string(reason) != record.Reason {
+			changed++
+		}
+		log.Info("Replayed gossip message", "time", record.Time, "topic", record.Topic, "peer", record.Peer,
+			"recorded", record.Outcome+"/"+record.Reason, "replayed", string(outcome)+"/"+string(reason), "err", err)
+		return nil
+	}); err != nil {
+		return err
+	}
+	log.Info("Replay done", "messages", total, "changed", changed)
+	return nil
+}
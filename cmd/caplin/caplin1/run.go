@@ -68,6 +68,7 @@ import (
 	"github.com/erigontech/erigon/cl/phase1/forkchoice"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
 	"github.com/erigontech/erigon/cl/phase1/network"
+	"github.com/erigontech/erigon/cl/phase1/network/gossipcapture"
 	"github.com/erigontech/erigon/cl/phase1/network/services"
 	"github.com/erigontech/erigon/cl/phase1/stages"
 	"github.com/erigontech/erigon/cl/pool"
@@ -283,18 +284,20 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	activeIndicies := state.GetActiveValidatorsIndices(state.Slot() / beaconConfig.SlotsPerEpoch)
 
 	sentinel, err := service.StartSentinelService(&sentinel.SentinelConfig{
-		IpAddr:         config.CaplinDiscoveryAddr,
-		Port:           int(config.CaplinDiscoveryPort),
-		TCPPort:        uint(config.CaplinDiscoveryTCPPort),
-		NetworkConfig:  networkConfig,
-		BeaconConfig:   beaconConfig,
-		TmpDir:         dirs.Tmp,
-		EnableBlocks:   true,
-		ActiveIndicies: uint64(len(activeIndicies)),
+		IpAddr:          config.CaplinDiscoveryAddr,
+		Port:            int(config.CaplinDiscoveryPort),
+		TCPPort:         uint(config.CaplinDiscoveryTCPPort),
+		NetworkConfig:   networkConfig,
+		BeaconConfig:    beaconConfig,
+		TmpDir:          dirs.Tmp,
+		EnableBlocks:    true,
+		ActiveIndicies:  uint64(len(activeIndicies)),
+		ScoreParamsPath: config.SentinelScoreParamsPath,
 	}, rcsn, blobStorage, indexDB, &service.ServerConfig{
-		Network: "tcp",
-		Addr:    fmt.Sprintf("%s:%d", config.SentinelAddr, config.SentinelPort),
-		Creds:   creds,
+		Network:   "tcp",
+		Addr:      fmt.Sprintf("%s:%d", config.SentinelAddr, config.SentinelPort),
+		Creds:     creds,
+		DebugAddr: config.SentinelDebugAddr,
 		InitialStatus: &cltypes.Status{
 			ForkDigest:     forkDigest,
 			FinalizedRoot:  state.FinalizedCheckpoint().BlockRoot(),
@@ -318,10 +321,18 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	voluntaryExitService := services.NewVoluntaryExitService(pool, emitters, syncedDataManager, beaconConfig, ethClock)
 	blsToExecutionChangeService := services.NewBLSToExecutionChangeService(pool, emitters, syncedDataManager, beaconConfig)
 	proposerSlashingService := services.NewProposerSlashingService(pool, syncedDataManager, beaconConfig, ethClock, emitters)
+	var gossipCapture *gossipcapture.Writer
+	if config.GossipCaptureDir != "" {
+		gossipCapture, err = gossipcapture.NewWriter(config.GossipCaptureDir, gossipcapture.DefaultMaxFileSize, gossipcapture.DefaultMaxFiles)
+		if err != nil {
+			return err
+		}
+		defer gossipCapture.Close()
+	}
 	// Create the gossip manager
 	gossipManager := network.NewGossipReceiver(sentinel, forkChoice, beaconConfig, ethClock, emitters, committeeSub,
 		blockService, blobService, syncCommitteeMessagesService, syncContributionService, aggregateAndProofService,
-		attestationService, voluntaryExitService, blsToExecutionChangeService, proposerSlashingService)
+		attestationService, voluntaryExitService, blsToExecutionChangeService, proposerSlashingService, gossipCapture)
 	{ // start ticking forkChoice
 		go func() {
 			tickInterval := time.NewTicker(2 * time.Millisecond)
@@ -379,6 +390,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 
 	antiq := antiquary.NewAntiquary(ctx, blobStorage, genesisState, vTables, beaconConfig, dirs, snDownloader, indexDB, csn, rcsn, logger, states, backfilling, blobBackfilling, snBuildSema)
+	antiq.SetStateCheckpointInterval(config.StatesCheckpointInterval)
 	// Create the antiquary
 	go func() {
 		if err := antiq.Loop(); err != nil {
@@ -391,6 +403,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 
 	statesReader := historical_states_reader.NewHistoricalStatesReader(beaconConfig, rcsn, vTables, genesisState)
+	statesReader.SetStateCheckpointInterval(config.StatesCheckpointInterval)
 	validatorParameters := validator_params.NewValidatorParams()
 	if config.BeaconAPIRouter.Active {
 		apiHandler := handler.NewApiHandler(
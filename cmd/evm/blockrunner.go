@@ -0,0 +1,128 @@
+// Copyright 2023 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/tests"
+)
+
+var RunFlag = cli.StringFlag{
+	Name:  "run",
+	Value: ".*",
+	Usage: "Run only those tests matching the regular expression.",
+}
+
+var blockTestCommand = cli.Command{
+	Action:    blockTestCmd,
+	Name:      "blocktest",
+	Usage:     "executes the given blockchain tests",
+	ArgsUsage: "<path>",
+	Flags:     []cli.Flag{&RunFlag},
+}
+
+func blockTestCmd(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if len(path) == 0 {
+		return errors.New("path argument required")
+	}
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlError, log.StderrHandler))
+
+	re, err := regexp.Compile(ctx.String(RunFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid regex -%s: %v", RunFlag.Name, err)
+	}
+	files, err := collectFiles(path)
+	if err != nil {
+		return err
+	}
+	results := make([]StatetestResult, 0)
+	for _, fname := range files {
+		fileResults, err := runBlockTest(fname, re)
+		if err != nil {
+			return err
+		}
+		results = append(results, fileResults...)
+	}
+	out, _ := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	return nil
+}
+
+// collectFiles returns the given file, or all json files under the given directory
+func collectFiles(path string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && (p == path || strings.HasSuffix(p, ".json")) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// runBlockTest loads the blockchain tests given by fname, and executes the ones matching re.
+func runBlockTest(fname string, re *regexp.Regexp) ([]StatetestResult, error) {
+	src, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	var blockTests map[string]*tests.BlockTest
+	if err = json.Unmarshal(src, &blockTests); err != nil {
+		return nil, fmt.Errorf("%s: %w", fname, err)
+	}
+
+	// Pull out keys to sort and ensure tests are run in order.
+	names := make([]string, 0, len(blockTests))
+	for name := range blockTests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []StatetestResult
+	for _, name := range names {
+		if !re.MatchString(name) {
+			continue
+		}
+		test := blockTests[name]
+		result := StatetestResult{Name: name, Fork: test.Network(), Pass: true}
+		if err := test.Run(nil, true); err != nil {
+			result.Pass, result.Error = false, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2021 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+
+	"github.com/erigontech/erigon/common/math"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rlp"
+)
+
+//go:generate gencodec -type header -field-override headerMarshaling -out gen_header.go
+type header struct {
+	ParentHash            libcommon.Hash     `json:"parentHash"`
+	OmmerHash             *libcommon.Hash    `json:"sha3Uncles"`
+	Coinbase              *libcommon.Address `json:"miner"`
+	Root                  libcommon.Hash     `json:"stateRoot"        gencodec:"required"`
+	TxHash                *libcommon.Hash    `json:"transactionsRoot"`
+	ReceiptHash           *libcommon.Hash    `json:"receiptsRoot"`
+	Bloom                 types.Bloom        `json:"logsBloom"`
+	Difficulty            *big.Int           `json:"difficulty"`
+	Number                *big.Int           `json:"number"           gencodec:"required"`
+	GasLimit              uint64             `json:"gasLimit"         gencodec:"required"`
+	GasUsed               uint64             `json:"gasUsed"`
+	Time                  uint64             `json:"timestamp"        gencodec:"required"`
+	Extra                 []byte             `json:"extraData"`
+	MixDigest             libcommon.Hash     `json:"mixHash"`
+	Nonce                 *types.BlockNonce  `json:"nonce"`
+	BaseFee               *big.Int           `json:"baseFeePerGas" rlp:"optional"`
+	WithdrawalsHash       *libcommon.Hash    `json:"withdrawalsRoot" rlp:"optional"`
+	BlobGasUsed           *uint64            `json:"blobGasUsed" rlp:"optional"`
+	ExcessBlobGas         *uint64            `json:"excessBlobGas" rlp:"optional"`
+	ParentBeaconBlockRoot *libcommon.Hash    `json:"parentBeaconBlockRoot" rlp:"optional"`
+	RequestsRoot          *libcommon.Hash    `json:"requestsRoot" rlp:"optional"`
+}
+
+type headerMarshaling struct {
+	Difficulty    *math.HexOrDecimal256
+	Number        *math.HexOrDecimal256
+	GasLimit      math.HexOrDecimal64
+	GasUsed       math.HexOrDecimal64
+	Time          math.HexOrDecimal64
+	Extra         hexutility.Bytes
+	BaseFee       *math.HexOrDecimal256
+	BlobGasUsed   *math.HexOrDecimal64
+	ExcessBlobGas *math.HexOrDecimal64
+}
+
+type bbInput struct {
+	Header      *header             `json:"header,omitempty"`
+	OmmersRlp   []string            `json:"ommers,omitempty"`
+	TxRlp       string              `json:"txs,omitempty"`
+	Withdrawals []*types.Withdrawal `json:"withdrawals,omitempty"`
+
+	Txs    []types.Transaction `json:"-"`
+	Ommers []*types.Header     `json:"-"`
+}
+
+// ToBlock converts i into a *types.Block
+func (i *bbInput) ToBlock() *types.Block {
+	header := &types.Header{
+		ParentHash:            i.Header.ParentHash,
+		UncleHash:             types.EmptyUncleHash,
+		Coinbase:              libcommon.Address{},
+		Root:                  i.Header.Root,
+		TxHash:                types.EmptyRootHash,
+		ReceiptHash:           types.EmptyRootHash,
+		Bloom:                 i.Header.Bloom,
+		Difficulty:            libcommon.Big0,
+		Number:                i.Header.Number,
+		GasLimit:              i.Header.GasLimit,
+		GasUsed:               i.Header.GasUsed,
+		Time:                  i.Header.Time,
+		Extra:                 i.Header.Extra,
+		MixDigest:             i.Header.MixDigest,
+		BaseFee:               i.Header.BaseFee,
+		WithdrawalsHash:       i.Header.WithdrawalsHash,
+		BlobGasUsed:           i.Header.BlobGasUsed,
+		ExcessBlobGas:         i.Header.ExcessBlobGas,
+		ParentBeaconBlockRoot: i.Header.ParentBeaconBlockRoot,
+		RequestsRoot:          i.Header.RequestsRoot,
+	}
+
+	// Fill optional values.
+	if i.Header.OmmerHash != nil {
+		header.UncleHash = *i.Header.OmmerHash
+	} else if len(i.Ommers) != 0 {
+		// Calculate the ommer hash if none is provided and there are ommers to hash
+		header.UncleHash = types.CalcUncleHash(i.Ommers)
+	}
+	if i.Header.Coinbase != nil {
+		header.Coinbase = *i.Header.Coinbase
+	}
+	if i.Header.TxHash != nil {
+		header.TxHash = *i.Header.TxHash
+	}
+	if i.Header.ReceiptHash != nil {
+		header.ReceiptHash = *i.Header.ReceiptHash
+	}
+	if i.Header.Nonce != nil {
+		header.Nonce = *i.Header.Nonce
+	}
+	if i.Header.Difficulty != nil {
+		header.Difficulty = i.Header.Difficulty
+	}
+	return types.NewBlockFromNetwork(header, &types.Body{Transactions: i.Txs, Uncles: i.Ommers, Withdrawals: i.Withdrawals})
+}
+
+// BuildBlock implements the b11r tool: it assembles a block from the header, ommers,
+// withdrawals and transactions as given, without recomputing any of the header fields.
+func BuildBlock(ctx *cli.Context) error {
+	baseDir := ""
+	if ctx.IsSet(OutputBasedir.Name) {
+		if base := ctx.String(OutputBasedir.Name); len(base) > 0 {
+			if err := os.MkdirAll(base, 0755); err != nil {
+				return NewError(ErrorIO, fmt.Errorf("failed creating output basedir: %v", err))
+			}
+			baseDir = base
+		}
+	}
+	inputData, err := readBlockInput(ctx)
+	if err != nil {
+		return err
+	}
+	return dispatchBlock(ctx, baseDir, inputData.ToBlock())
+}
+
+func readBlockInput(ctx *cli.Context) (*bbInput, error) {
+	var (
+		headerStr      = ctx.String(InputHeaderFlag.Name)
+		ommersStr      = ctx.String(InputOmmersFlag.Name)
+		withdrawalsStr = ctx.String(InputWithdrawalsFlag.Name)
+		txsStr         = ctx.String(InputTxsRlpFlag.Name)
+		inputData      = &bbInput{}
+	)
+	if headerStr == stdinSelector || ommersStr == stdinSelector || withdrawalsStr == stdinSelector || txsStr == stdinSelector {
+		decoder := json.NewDecoder(os.Stdin)
+		if err := decoder.Decode(inputData); err != nil {
+			return nil, NewError(ErrorJson, fmt.Errorf("failed unmarshaling input: %v", err))
+		}
+	}
+	if headerStr != stdinSelector {
+		var env header
+		if err := readFile(headerStr, "header", &env); err != nil {
+			return nil, err
+		}
+		inputData.Header = &env
+	}
+	if inputData.Header == nil {
+		return nil, NewError(ErrorJson, errors.New("missing header"))
+	}
+	if ommersStr != stdinSelector && ommersStr != "" {
+		var ommers []string
+		if err := readFile(ommersStr, "ommers", &ommers); err != nil {
+			return nil, err
+		}
+		inputData.OmmersRlp = ommers
+	}
+	if withdrawalsStr != stdinSelector && withdrawalsStr != "" {
+		var withdrawals []*types.Withdrawal
+		if err := readFile(withdrawalsStr, "withdrawals", &withdrawals); err != nil {
+			return nil, err
+		}
+		inputData.Withdrawals = withdrawals
+	}
+	if txsStr != stdinSelector {
+		var txs string
+		if err := readFile(txsStr, "txs", &txs); err != nil {
+			return nil, err
+		}
+		inputData.TxRlp = txs
+	}
+	// Deserialize rlp txs and ommers
+	if inputData.TxRlp != "" {
+		it, err := rlp.NewListIterator(libcommon.FromHex(inputData.TxRlp))
+		if err != nil {
+			return nil, NewError(ErrorRlp, fmt.Errorf("unable to decode transaction from rlp data: %v", err))
+		}
+		for it.Next() {
+			tx, err := types.DecodeTransaction(it.Value())
+			if err != nil {
+				return nil, NewError(ErrorRlp, fmt.Errorf("unable to decode transaction from rlp data: %v", err))
+			}
+			inputData.Txs = append(inputData.Txs, tx)
+		}
+		if err := it.Err(); err != nil {
+			return nil, NewError(ErrorRlp, fmt.Errorf("unable to decode transaction from rlp data: %v", err))
+		}
+	}
+	for _, str := range inputData.OmmersRlp {
+		var ommer types.Block
+		if err := rlp.DecodeBytes(libcommon.FromHex(str), &ommer); err != nil {
+			return nil, NewError(ErrorRlp, fmt.Errorf("unable to decode ommer from rlp data: %v", err))
+		}
+		inputData.Ommers = append(inputData.Ommers, ommer.Header())
+	}
+	return inputData, nil
+}
+
+// readFile reads the json-data in the provided path and marshals into dest.
+func readFile(path, desc string, dest interface{}) error {
+	inFile, err := os.Open(path)
+	if err != nil {
+		return NewError(ErrorIO, fmt.Errorf("failed reading %s file: %v", desc, err))
+	}
+	defer inFile.Close()
+	decoder := json.NewDecoder(inFile)
+	if err := decoder.Decode(dest); err != nil {
+		return NewError(ErrorJson, fmt.Errorf("failed unmarshaling %s file: %v", desc, err))
+	}
+	return nil
+}
+
+// dispatchBlock writes the output data to either stderr or stdout, or to the specified
+// files
+func dispatchBlock(ctx *cli.Context, baseDir string, block *types.Block) error {
+	raw, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return NewError(ErrorRlp, fmt.Errorf("failed encoding block: %v", err))
+	}
+	type blockInfo struct {
+		Rlp  hexutility.Bytes `json:"rlp"`
+		Hash libcommon.Hash   `json:"hash"`
+	}
+	enc := blockInfo{
+		Rlp:  raw,
+		Hash: block.Hash(),
+	}
+	b, err := json.MarshalIndent(enc, "", "  ")
+	if err != nil {
+		return NewError(ErrorJson, fmt.Errorf("failed marshalling output: %v", err))
+	}
+	switch dest := ctx.String(OutputBlockFlag.Name); dest {
+	case "stdout":
+		os.Stdout.Write(b)
+		os.Stdout.WriteString("\n")
+	case "stderr":
+		os.Stderr.Write(b)
+		os.Stderr.WriteString("\n")
+	default:
+		if err := saveFile(baseDir, dest, enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/urfave/cli/v2"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rlp"
+	"github.com/erigontech/erigon/tests"
+)
+
+type result struct {
+	Error        error
+	Address      libcommon.Address
+	Hash         libcommon.Hash
+	IntrinsicGas uint64
+}
+
+// MarshalJSON marshals as JSON with a hash.
+func (r *result) MarshalJSON() ([]byte, error) {
+	type xx struct {
+		Err          string             `json:"error,omitempty"`
+		Address      *libcommon.Address `json:"address,omitempty"`
+		Hash         *libcommon.Hash    `json:"hash,omitempty"`
+		IntrinsicGas hexutil.Uint64     `json:"intrinsicGas,omitempty"`
+	}
+	var out xx
+	if r.Error != nil {
+		out.Err = r.Error.Error()
+	}
+	if r.Address != (libcommon.Address{}) {
+		out.Address = &r.Address
+	}
+	if r.Hash != (libcommon.Hash{}) {
+		out.Hash = &r.Hash
+	}
+	out.IntrinsicGas = hexutil.Uint64(r.IntrinsicGas)
+	return json.Marshal(out)
+}
+
+// Transaction implements the t9n tool: it validates the RLP encoded transactions against
+// the rules of the given fork and reports sender, hash and intrinsic gas of each of them.
+func Transaction(ctx *cli.Context) error {
+	var (
+		txStr     = ctx.String(InputTxsRlpFlag.Name)
+		inputData = &input{}
+	)
+	// Construct the chainconfig
+	chainConfig, _, err := tests.GetChainConfig(ctx.String(ForknameFlag.Name))
+	if err != nil {
+		return NewError(ErrorVMConfig, fmt.Errorf("failed constructing chain configuration: %v", err))
+	}
+	// Set the chain id
+	chainConfig.ChainID = big.NewInt(ctx.Int64(ChainIDFlag.Name))
+
+	var body hexutility.Bytes
+	if txStr == stdinSelector {
+		decoder := json.NewDecoder(os.Stdin)
+		if err := decoder.Decode(inputData); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling stdin: %v", err))
+		}
+		// Decode the body of already signed transactions
+		body = libcommon.FromHex(inputData.TxRlp)
+	} else {
+		// Read input from file
+		inFile, err := os.Open(txStr)
+		if err != nil {
+			return NewError(ErrorIO, fmt.Errorf("failed reading txs file: %v", err))
+		}
+		defer inFile.Close()
+		decoder := json.NewDecoder(inFile)
+		if !strings.HasSuffix(txStr, ".rlp") {
+			return NewError(ErrorIO, errors.New("only rlp supported"))
+		}
+		if err := decoder.Decode(&body); err != nil {
+			return NewError(ErrorJson, fmt.Errorf("failed unmarshaling txs-file: %v", err))
+		}
+	}
+	signer := types.MakeSigner(chainConfig, 0, 0)
+	rules := chainConfig.Rules(0, 0)
+
+	// We now have the transactions in 'body', which is supposed to be an
+	// rlp list of transactions
+	it, err := rlp.NewListIterator([]byte(body))
+	if err != nil {
+		return NewError(ErrorRlp, err)
+	}
+	var results []result
+	for it.Next() {
+		if err := it.Err(); err != nil {
+			return NewError(ErrorIO, err)
+		}
+		tx, err := types.DecodeTransaction(it.Value())
+		if err != nil {
+			results = append(results, result{Error: err})
+			continue
+		}
+		r := result{Hash: tx.Hash()}
+		if r.Address, err = tx.Sender(*signer); err != nil {
+			r.Error = err
+			results = append(results, r)
+			continue
+		}
+		// Check intrinsic gas
+		var authorizationsLen uint64
+		if setCodeTx, ok := tx.(*types.SetCodeTransaction); ok {
+			authorizationsLen = uint64(len(setCodeTx.GetAuthorizations()))
+		}
+		gas, err := core.IntrinsicGas(tx.GetData(), tx.GetAccessList(), tx.GetTo() == nil, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai, authorizationsLen)
+		if err != nil {
+			r.Error = err
+			results = append(results, r)
+			continue
+		}
+		r.IntrinsicGas = gas
+		if tx.GetGas() < gas {
+			r.Error = fmt.Errorf("%w: have %d, want %d", core.ErrIntrinsicGas, tx.GetGas(), gas)
+			results = append(results, r)
+			continue
+		}
+		// Validate <256bit fields
+		gasLimit := uint256.NewInt(tx.GetGas())
+		switch {
+		case tx.GetNonce()+1 < tx.GetNonce():
+			r.Error = errors.New("nonce exceeds 2^64-1")
+		case tx.GetFeeCap().Cmp(tx.GetTip()) < 0:
+			r.Error = errors.New("maxFeePerGas < maxPriorityFeePerGas")
+		case overflows(gasLimit, tx.GetPrice()):
+			r.Error = errors.New("gas * gasPrice exceeds 256 bits")
+		case overflows(gasLimit, tx.GetFeeCap()):
+			r.Error = errors.New("gas * maxFeePerGas exceeds 256 bits")
+		}
+		// Check whether the init code size has been exceeded.
+		if rules.IsShanghai && tx.GetTo() == nil && len(tx.GetData()) > params.MaxInitCodeSize {
+			r.Error = errors.New("max initcode size exceeded")
+		}
+		results = append(results, r)
+	}
+	out, err := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	return err
+}
+
+// overflows tells whether gas * price does not fit into 256 bits
+func overflows(gas, price *uint256.Int) bool {
+	_, overflow := new(uint256.Int).MulOverflow(gas, price)
+	return overflow
+}
@@ -24,8 +24,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/c2h5oh/datasize"
 	mdbx2 "github.com/erigontech/mdbx-go/mdbx"
@@ -34,6 +36,7 @@ import (
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/kv/temporal"
@@ -52,6 +55,14 @@ var stateTestCommand = cli.Command{
 	Name:      "statetest",
 	Usage:     "executes the given state tests",
 	ArgsUsage: "<file>",
+	Description: `Runs the state tests of the file and prints the results as JSON array to stdout.
+
+If no file is given, the file names are read from stdin, one per line, and the results
+of every file are printed as soon as it is done. This keeps a single process alive for
+fuzzers feeding the tests one by one.
+
+With --json every executed opcode is traced to stderr in the EIP-3155 format, followed
+by the {"stateRoot": "0x.."} line of the subtest, as expected by goevmlab.`,
 }
 
 // StatetestResult contains the execution status after running a state test, any
@@ -83,14 +94,23 @@ func stateTestCmd(ctx *cli.Context) error {
 	cfg := vm.Config{
 		Debug: ctx.Bool(DebugFlag.Name) || ctx.Bool(MachineFlag.Name),
 	}
+	// Traces are buffered and flushed together with the state root of each subtest
+	traceOut := bufio.NewWriter(os.Stderr)
+	defer traceOut.Flush()
 	if machineFriendlyOutput {
-		cfg.Tracer = logger.NewJSONLogger(config, os.Stderr)
+		cfg.Tracer = logger.NewJSONLogger(config, traceOut)
 	} else if ctx.Bool(DebugFlag.Name) {
 		cfg.Tracer = logger.NewStructLogger(config)
 	}
 
+	runner, err := newStateTestRunner(cfg, machineFriendlyOutput, traceOut)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
 	if len(ctx.Args().First()) != 0 {
-		return runStateTest(ctx.Args().First(), cfg, ctx.Bool(MachineFlag.Name))
+		return runner.runStateTest(ctx.Args().First())
 	}
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
@@ -98,78 +118,106 @@ func stateTestCmd(ctx *cli.Context) error {
 		if len(fname) == 0 {
 			return nil
 		}
-		if err := runStateTest(fname, cfg, ctx.Bool(MachineFlag.Name)); err != nil {
-			return err
+		// A broken file must not stop the batch, the feeder waits for the results of each file
+		if err := runner.runStateTest(fname); err != nil {
+			out, _ := json.MarshalIndent([]StatetestResult{{Name: fname, Error: err.Error()}}, "", "  ")
+			fmt.Println(string(out))
 		}
 	}
-	return nil
+	return scanner.Err()
 }
 
-// runStateTest loads the state-test given by fname, and executes the test.
-func runStateTest(fname string, cfg vm.Config, jsonOut bool) error {
-	// Load the test content from the input file
-	src, err := os.ReadFile(fname)
-	if err != nil {
-		return err
-	}
-	var stateTests map[string]tests.StateTest
-	if err = json.Unmarshal(src, &stateTests); err != nil {
-		return err
-	}
-
-	// Iterate over all the stateTests, run them and aggregate the results
-	results, err := aggregateResultsFromStateTests(stateTests, cfg, jsonOut)
-	if err != nil {
-		return err
-	}
+// stateTestRunner executes state tests on a database kept open for the whole run.
+type stateTestRunner struct {
+	cfg      vm.Config
+	jsonOut  bool
+	traceOut *bufio.Writer
 
-	out, _ := json.MarshalIndent(results, "", "  ")
-	fmt.Println(string(out))
-	return nil
+	dirs  datadir.Dirs
+	db    kv.RwDB
+	agg   *libstate.Aggregator
+	rawDB kv.RwDB
 }
 
-func aggregateResultsFromStateTests(
-	stateTests map[string]tests.StateTest, cfg vm.Config,
-	jsonOut bool) ([]StatetestResult, error) {
+func newStateTestRunner(cfg vm.Config, jsonOut bool, traceOut *bufio.Writer) (*stateTestRunner, error) {
 	dirs := datadir.New(filepath.Join(os.TempDir(), "erigon-statetest"))
 	//this DB is shared. means:
 	// - faster sequential tests: don't need create/delete db
 	// - less parallelism: multiple processes can open same DB but only 1 can create rw-transaction (other will wait when 1-st finish)
-	_db := mdbx.NewMDBX(log.New()).
+	rawDB := mdbx.NewMDBX(log.New()).
 		Path(dirs.Chaindata).
 		Flags(func(u uint) uint {
 			return u | mdbx2.UtterlyNoSync | mdbx2.NoMetaSync | mdbx2.NoMemInit | mdbx2.WriteMap
 		}).
 		GrowthStep(1 * datasize.MB).
 		MustOpen()
-	defer _db.Close()
 
 	cr := rawdb.NewCanonicalReader(rawdbv3.TxNums)
-	agg, err := libstate.NewAggregator(context.Background(), dirs, config3.HistoryV3AggregationStep, _db, cr, log.New())
+	agg, err := libstate.NewAggregator(context.Background(), dirs, config3.HistoryV3AggregationStep, rawDB, cr, log.New())
 	if err != nil {
+		rawDB.Close()
 		return nil, err
 	}
-	defer agg.Close()
-
-	db, err := temporal.New(_db, agg)
+	db, err := temporal.New(rawDB, agg)
 	if err != nil {
+		agg.Close()
+		rawDB.Close()
 		return nil, err
 	}
-	defer db.Close()
+	return &stateTestRunner{cfg: cfg, jsonOut: jsonOut, traceOut: traceOut, dirs: dirs, db: db, agg: agg, rawDB: rawDB}, nil
+}
+
+func (r *stateTestRunner) Close() {
+	r.db.Close()
+	r.agg.Close()
+	r.rawDB.Close()
+}
+
+// runStateTest loads the state-test given by fname, and executes the test.
+func (r *stateTestRunner) runStateTest(fname string) error {
+	// Load the test content from the input file
+	src, err := os.ReadFile(fname)
+	if err != nil {
+		return err
+	}
+	var stateTests map[string]tests.StateTest
+	if err = json.Unmarshal(src, &stateTests); err != nil {
+		return err
+	}
+
+	// Iterate over all the stateTests, run them and aggregate the results
+	results, err := r.aggregateResultsFromStateTests(stateTests)
+	if err != nil {
+		return err
+	}
+
+	out, _ := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	return nil
+}
 
-	tx, txErr := db.BeginRw(context.Background())
+func (r *stateTestRunner) aggregateResultsFromStateTests(stateTests map[string]tests.StateTest) ([]StatetestResult, error) {
+	tx, txErr := r.db.BeginRw(context.Background())
 	if txErr != nil {
 		return nil, txErr
 	}
 	defer tx.Rollback()
 	results := make([]StatetestResult, 0, len(stateTests))
 
-	for key, test := range stateTests {
+	// Run the tests in a stable order, so the traces of different clients can be compared
+	keys := make([]string, 0, len(stateTests))
+	for key := range stateTests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		test := stateTests[key]
 		for _, st := range test.Subtests() {
 			// Run the test and aggregate the result
 			result := &StatetestResult{Name: key, Fork: st.Fork, Pass: true}
 
-			statedb, root, err := test.Run(tx, st, cfg, dirs)
+			statedb, root, err := test.Run(tx, st, r.cfg, r.dirs)
 			if err != nil {
 				// Test failed, mark as so and dump any state to aid debugging
 				result.Pass, result.Error = false, err.Error()
@@ -178,15 +226,22 @@ func aggregateResultsFromStateTests(
 			// print state root for evmlab tracing
 			if statedb != nil {
 				result.Root = &root
-				if jsonOut {
-					_, printErr := fmt.Fprintf(os.Stderr, "{\"stateRoot\": \"%#x\"}\n", root.Bytes())
-					if printErr != nil {
-						log.Warn("Failed to write to stderr", "err", printErr)
-					}
+				if r.jsonOut {
+					writeStateRoot(r.traceOut, root)
 				}
 			}
+			if err := r.traceOut.Flush(); err != nil {
+				log.Warn("Failed to write to stderr", "err", err)
+			}
 			results = append(results, *result)
 		}
 	}
 	return results, nil
 }
+
+// writeStateRoot outputs the post state root in the format of goevmlab
+func writeStateRoot(w io.Writer, root libcommon.Hash) {
+	if _, err := fmt.Fprintf(w, "{\"stateRoot\": \"%#x\"}\n", root.Bytes()); err != nil {
+		log.Warn("Failed to write to stderr", "err", err)
+	}
+}
@@ -265,3 +265,205 @@ func cmpJson(a, b []byte) (bool, error) {
 
 	return reflect.DeepEqual(j2, j), nil
 }
+
+type t9nInput struct {
+	inTxs  string
+	stFork string
+}
+
+func (args *t9nInput) get(base string) []string {
+	var out []string
+	if opt := args.inTxs; opt != "" {
+		out = append(out, "--input.txs")
+		out = append(out, fmt.Sprintf("%v/%v", base, opt))
+	}
+	if opt := args.stFork; opt != "" {
+		out = append(out, "--state.fork", opt)
+	}
+	return out
+}
+
+func TestT9n(t *testing.T) {
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	for i, tc := range []struct {
+		base        string
+		input       t9nInput
+		expExitCode int
+		expOut      string
+	}{
+		{ // London txs on London
+			base: "./testdata/15",
+			input: t9nInput{
+				inTxs:  "signed_txs.rlp",
+				stFork: "London",
+			},
+			expOut: "exp.json",
+		},
+		{ // London txs on Berlin
+			base: "./testdata/15",
+			input: t9nInput{
+				inTxs:  "signed_txs.rlp",
+				stFork: "Berlin",
+			},
+			expOut: "exp2.json",
+		},
+		{ // Test exit (3) on bad config
+			base: "./testdata/15",
+			input: t9nInput{
+				inTxs:  "signed_txs.rlp",
+				stFork: "Frontier+1346",
+			},
+			expExitCode: 3,
+		},
+	} {
+		args := []string{"t9n"}
+		args = append(args, tc.input.get(tc.base)...)
+
+		tt.Run("evm-test", args...)
+		tt.Logf("args:\n go run . %v\n", strings.Join(args, " "))
+		// Compare the expected output, if provided
+		if tc.expOut != "" {
+			want, err := os.ReadFile(fmt.Sprintf("%v/%v", tc.base, tc.expOut))
+			if err != nil {
+				t.Fatalf("test %d: could not read expected output: %v", i, err)
+			}
+			have := tt.Output()
+			ok, err := cmpJson(have, want)
+			switch {
+			case err != nil:
+				t.Log(string(have))
+				t.Fatalf("test %d, json parsing failed: %v", i, err)
+			case !ok:
+				t.Fatalf("test %d: output wrong, have \n%v\nwant\n%v\n", i, string(have), string(want))
+			}
+		}
+		tt.WaitExit()
+		if have, want := tt.ExitStatus(), tc.expExitCode; have != want {
+			t.Fatalf("test %d: wrong exit code, have %d, want %d", i, have, want)
+		}
+	}
+}
+
+type b11rInput struct {
+	inHeader      string
+	inOmmersRlp   string
+	inWithdrawals string
+	inTxsRlp      string
+}
+
+func (args *b11rInput) get(base string) []string {
+	var out []string
+	if opt := args.inHeader; opt != "" {
+		out = append(out, "--input.header")
+		out = append(out, fmt.Sprintf("%v/%v", base, opt))
+	}
+	if opt := args.inOmmersRlp; opt != "" {
+		out = append(out, "--input.ommers")
+		out = append(out, fmt.Sprintf("%v/%v", base, opt))
+	}
+	if opt := args.inWithdrawals; opt != "" {
+		out = append(out, "--input.withdrawals")
+		out = append(out, fmt.Sprintf("%v/%v", base, opt))
+	}
+	if opt := args.inTxsRlp; opt != "" {
+		out = append(out, "--input.txs")
+		out = append(out, fmt.Sprintf("%v/%v", base, opt))
+	}
+	out = append(out, "--output.block")
+	out = append(out, "stdout")
+	return out
+}
+
+func TestB11r(t *testing.T) {
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	for i, tc := range []struct {
+		base        string
+		input       b11rInput
+		expExitCode int
+		expOut      string
+	}{
+		{ // London block with transactions
+			base: "./testdata/20",
+			input: b11rInput{
+				inHeader: "header.json",
+				inTxsRlp: "txs.rlp",
+			},
+			expOut: "exp.json",
+		},
+		{ // Test exit (11) on missing header
+			base: "./testdata/20",
+			input: b11rInput{
+				inHeader: "missing.json",
+				inTxsRlp: "txs.rlp",
+			},
+			expExitCode: 11,
+		},
+	} {
+		args := []string{"b11r"}
+		args = append(args, tc.input.get(tc.base)...)
+
+		tt.Run("evm-test", args...)
+		tt.Logf("args:\n go run . %v\n", strings.Join(args, " "))
+		// Compare the expected output, if provided
+		if tc.expOut != "" {
+			want, err := os.ReadFile(fmt.Sprintf("%v/%v", tc.base, tc.expOut))
+			if err != nil {
+				t.Fatalf("test %d: could not read expected output: %v", i, err)
+			}
+			have := tt.Output()
+			ok, err := cmpJson(have, want)
+			switch {
+			case err != nil:
+				t.Log(string(have))
+				t.Fatalf("test %d, json parsing failed: %v", i, err)
+			case !ok:
+				t.Fatalf("test %d: output wrong, have \n%v\nwant\n%v\n", i, string(have), string(want))
+			}
+		}
+		tt.WaitExit()
+		if have, want := tt.ExitStatus(), tc.expExitCode; have != want {
+			t.Fatalf("test %d: wrong exit code, have %d, want %d", i, have, want)
+		}
+	}
+}
+
+func TestStatetestTrace(t *testing.T) {
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	base := "./testdata/27"
+	tt.Run("evm-test", "--json", "statetest", fmt.Sprintf("%v/statetest.json", base))
+	want, err := os.ReadFile(fmt.Sprintf("%v/exp.json", base))
+	if err != nil {
+		t.Fatalf("could not read expected output: %v", err)
+	}
+	have := tt.Output()
+	tt.WaitExit()
+	if ok, err := cmpJson(have, want); err != nil {
+		t.Fatalf("json parsing failed: %v", err)
+	} else if !ok {
+		t.Fatalf("output wrong, have \n%v\nwant\n%v\n", string(have), string(want))
+	}
+	if have, want := tt.ExitStatus(), 0; have != want {
+		t.Fatalf("wrong exit code, have %d, want %d", have, want)
+	}
+
+	// the trace on stderr is compared line by line
+	wantTrace, err := os.ReadFile(fmt.Sprintf("%v/exp_trace.jsonl", base))
+	if err != nil {
+		t.Fatalf("could not read expected trace: %v", err)
+	}
+	haveLines := strings.Split(strings.TrimSpace(tt.StderrText()), "\n")
+	wantLines := strings.Split(strings.TrimSpace(string(wantTrace)), "\n")
+	if len(haveLines) != len(wantLines) {
+		t.Fatalf("trace length wrong, have %d lines, want %d", len(haveLines), len(wantLines))
+	}
+	for i := range wantLines {
+		if ok, err := cmpJson([]byte(haveLines[i]), []byte(wantLines[i])); err != nil {
+			t.Fatalf("trace line %d, json parsing failed: %v", i, err)
+		} else if !ok {
+			t.Fatalf("trace line %d wrong, have \n%v\nwant\n%v\n", i, haveLines[i], wantLines[i])
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/polygon/bridge"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallreplay"
+	"github.com/erigontech/erigon/turbo/logging"
+)
+
+var (
+	httpAddrFlag = cli.StringFlag{
+		Name:  "http.addr",
+		Usage: "Address to serve the Heimdall API on",
+		Value: "localhost:1317",
+	}
+	recordingFlag = cli.StringFlag{
+		Name:  "recording",
+		Usage: "Directory of a recording made with the record command",
+	}
+	dataDirFlag = cli.StringFlag{
+		Name:  "datadir",
+		Usage: "Data directory of a Bor node to serve the already downloaded Heimdall entities from",
+	}
+	heimdallURLFlag = cli.StringFlag{
+		Name:     "heimdall.url",
+		Usage:    "URL of the Heimdall to record",
+		Required: true,
+	}
+	outputFlag = cli.StringFlag{
+		Name:     "output",
+		Usage:    "Directory to write the recording to",
+		Required: true,
+	}
+	fromSpanFlag = cli.Uint64Flag{
+		Name:  "span.from",
+		Usage: "First span id to record",
+	}
+	toSpanFlag = cli.Uint64Flag{
+		Name:  "span.to",
+		Usage: "Last span id to record, 0 for the latest span",
+	}
+	fromCheckpointFlag = cli.Uint64Flag{
+		Name:  "checkpoint.from",
+		Usage: "First checkpoint id to record",
+	}
+	toCheckpointFlag = cli.Uint64Flag{
+		Name:  "checkpoint.to",
+		Usage: "Last checkpoint id to record, 0 for the latest checkpoint",
+	}
+	fromEventFlag = cli.Uint64Flag{
+		Name:  "event.from",
+		Usage: "First state sync event id to record",
+	}
+	toEventFlag = cli.Uint64Flag{
+		Name:  "event.to",
+		Usage: "Last state sync event id to record, 0 for the latest event",
+	}
+)
+
+var recordCommand = cli.Command{
+	Name:   "record",
+	Usage:  "Record spans, checkpoints, milestones and state sync events from a live Heimdall",
+	Action: record,
+	Flags: []cli.Flag{
+		&heimdallURLFlag,
+		&outputFlag,
+		&fromSpanFlag,
+		&toSpanFlag,
+		&fromCheckpointFlag,
+		&toCheckpointFlag,
+		&fromEventFlag,
+		&toEventFlag,
+	},
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "heimdall-replay"
+	app.Version = params.VersionWithCommit(params.GitCommit)
+	app.Usage = "Serve the Heimdall API from recorded data or a Bor node's Heimdall store"
+	app.UsageText = "heimdall-replay (--recording <dir> | --datadir <dir>) [--http.addr <addr>]"
+	app.Flags = append([]cli.Flag{&httpAddrFlag, &recordingFlag, &dataDirFlag}, logging.Flags...)
+	app.Commands = []*cli.Command{&recordCommand}
+	app.Action = serve
+
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func serve(cliCtx *cli.Context) error {
+	logger := logging.SetupLoggerCtx("heimdall-replay", cliCtx, log.LvlInfo, log.LvlInfo, false)
+	ctx, cancel := common.RootContext()
+	defer cancel()
+
+	var client heimdall.HeimdallClient
+	switch {
+	case cliCtx.IsSet(recordingFlag.Name) && cliCtx.IsSet(dataDirFlag.Name):
+		return fmt.Errorf("only one of --%s and --%s can be given", recordingFlag.Name, dataDirFlag.Name)
+	case cliCtx.IsSet(recordingFlag.Name):
+		recording, err := heimdallreplay.LoadRecording(cliCtx.String(recordingFlag.Name))
+		if err != nil {
+			return err
+		}
+		logger.Info("[heimdall-replay] loaded recording",
+			"spans", len(recording.Spans),
+			"checkpoints", len(recording.Checkpoints),
+			"milestones", len(recording.Milestones),
+			"events", len(recording.Events),
+		)
+		client = recording
+	case cliCtx.IsSet(dataDirFlag.Name):
+		dirs := datadir.New(cliCtx.String(dataDirFlag.Name))
+		store := heimdall.NewMdbxServiceStore(logger, dirs.DataDir, dirs.Tmp)
+		if err := store.Prepare(ctx); err != nil {
+			return err
+		}
+		defer store.Close()
+		events := bridge.NewMdbxStore(logger, dirs.DataDir)
+		if err := events.Prepare(ctx); err != nil {
+			return err
+		}
+		defer events.Close()
+		client = heimdallreplay.NewStoreClient(store, events)
+	default:
+		return fmt.Errorf("one of --%s or --%s is required", recordingFlag.Name, dataDirFlag.Name)
+	}
+
+	listener, err := net.Listen("tcp", cliCtx.String(httpAddrFlag.Name))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: heimdallreplay.NewHandler(client, logger)}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	logger.Info("[heimdall-replay] serving Heimdall API", "addr", listener.Addr())
+	if err = server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func record(cliCtx *cli.Context) error {
+	logger := logging.SetupLoggerCtx("heimdall-replay", cliCtx, log.LvlInfo, log.LvlInfo, false)
+	ctx, cancel := common.RootContext()
+	defer cancel()
+
+	client := heimdall.NewHeimdallClient(cliCtx.String(heimdallURLFlag.Name), logger)
+	defer client.Close()
+
+	recording, err := heimdallreplay.Record(ctx, client, heimdallreplay.RecordConfig{
+		FromSpanId:       cliCtx.Uint64(fromSpanFlag.Name),
+		ToSpanId:         cliCtx.Uint64(toSpanFlag.Name),
+		FromCheckpointId: cliCtx.Uint64(fromCheckpointFlag.Name),
+		ToCheckpointId:   cliCtx.Uint64(toCheckpointFlag.Name),
+		FromEventId:      cliCtx.Uint64(fromEventFlag.Name),
+		ToEventId:        cliCtx.Uint64(toEventFlag.Name),
+	}, logger)
+	if err != nil {
+		return err
+	}
+	return recording.Save(cliCtx.String(outputFlag.Name))
+}
@@ -41,6 +41,23 @@ type HandshakeError struct {
 	Time       time.Time
 }
 
+// CensusNode is what a census knows about a live node.
+type CensusNode struct {
+	ClientID  *string
+	NetworkID *uint
+	IP        net.IP
+	ForkHash  *string
+	ForkNext  *uint64
+}
+
+// CensusEntry is a node count at a point of the census time series.
+type CensusEntry struct {
+	Time  time.Time
+	Kind  string
+	Key   string
+	Count uint
+}
+
 type DB interface {
 	io.Closer
 
@@ -68,6 +85,7 @@ type DB interface {
 	TakeHandshakeCandidates(ctx context.Context, limit uint) ([]NodeID, error)
 
 	UpdateForkCompatibility(ctx context.Context, id NodeID, isCompatFork bool) error
+	UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error
 
 	UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error
 	FindNeighborBucketKeys(ctx context.Context, id NodeID) ([]string, error)
@@ -90,4 +108,8 @@ type DB interface {
 	CountClientsWithNetworkID(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	CountClientsWithHandshakeTransientError(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	EnumerateClientIDs(ctx context.Context, maxPingTries uint, networkID uint, enumFunc func(clientID *string)) error
+	EnumerateCensusNodes(ctx context.Context, maxPingTries uint, enumFunc func(node CensusNode)) error
+
+	InsertCensus(ctx context.Context, entries []CensusEntry) error
+	FindCensus(ctx context.Context, kind string, since time.Time) ([]CensusEntry, error)
 }
@@ -214,6 +214,13 @@ func (db DBRetrier) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return err
 }
 
+func (db DBRetrier) UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error {
+	_, err := db.retry(ctx, "UpdateForkID", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.UpdateForkID(ctx, id, forkHash, forkNext)
+	})
+	return err
+}
+
 func (db DBRetrier) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	_, err := db.retry(ctx, "UpdateNeighborBucketKeys", func(ctx context.Context) (interface{}, error) {
 		return nil, db.db.UpdateNeighborBucketKeys(ctx, id, keys)
@@ -283,6 +290,13 @@ func (db DBRetrier) TakeCandidates(ctx context.Context, limit uint) ([]NodeID, e
 	return result, err
 }
 
+func (db DBRetrier) InsertCensus(ctx context.Context, entries []CensusEntry) error {
+	_, err := db.retry(ctx, "InsertCensus", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.InsertCensus(ctx, entries)
+	})
+	return err
+}
+
 func (db DBRetrier) IsConflictError(err error) bool {
 	return db.db.IsConflictError(err)
 }
@@ -73,6 +73,20 @@ CREATE TABLE IF NOT EXISTS handshake_errors (
     updated INTEGER NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS node_forks (
+    id TEXT PRIMARY KEY,
+    fork_hash TEXT NOT NULL,
+    fork_next INTEGER NOT NULL,
+    updated INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS census (
+    time INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    key TEXT NOT NULL,
+    count INTEGER NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS sentry_candidates_intake (
     id INTEGER PRIMARY KEY,
     last_event_time INTEGER NOT NULL
@@ -86,6 +100,7 @@ CREATE INDEX IF NOT EXISTS idx_nodes_compat_fork ON nodes (compat_fork);
 CREATE INDEX IF NOT EXISTS idx_nodes_network_id ON nodes (network_id);
 CREATE INDEX IF NOT EXISTS idx_nodes_handshake_retry_time ON nodes (handshake_retry_time);
 CREATE INDEX IF NOT EXISTS idx_handshake_errors_id ON handshake_errors (id);
+CREATE INDEX IF NOT EXISTS idx_census_kind_time ON census (kind, time);
 `
 
 	sqlUpsertNodeAddr = `
@@ -214,6 +229,19 @@ UPDATE nodes SET handshake_retry_time = ? WHERE id IN (123)
 UPDATE nodes SET compat_fork = ?, compat_fork_updated = ? WHERE id = ?
 `
 
+	sqlUpdateForkID = `
+INSERT INTO node_forks(
+	id,
+	fork_hash,
+	fork_next,
+	updated
+) VALUES (?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	fork_hash = excluded.fork_hash,
+	fork_next = excluded.fork_next,
+	updated = excluded.updated
+`
+
 	sqlUpdateNeighborBucketKeys = `
 UPDATE nodes SET neighbor_keys = ? WHERE id = ?
 `
@@ -301,6 +329,30 @@ SELECT client_id FROM nodes
 WHERE (ping_try < ?)
     AND ((network_id = ?) OR (network_id IS NULL))
     AND ((compat_fork == TRUE) OR (compat_fork IS NULL))
+`
+
+	sqlEnumerateCensusNodes = `
+SELECT nodes.client_id, nodes.network_id, nodes.ip, node_forks.fork_hash, node_forks.fork_next
+FROM nodes
+LEFT JOIN node_forks ON node_forks.id = nodes.id
+WHERE (nodes.ping_try < ?)
+    AND ((nodes.compat_fork == TRUE) OR (nodes.compat_fork IS NULL))
+`
+
+	sqlInsertCensus = `
+INSERT INTO census(
+	time,
+	kind,
+	key,
+	count
+) VALUES (?, ?, ?, ?)
+`
+
+	sqlFindCensus = `
+SELECT time, kind, key, count FROM census
+WHERE ((kind = ?) OR (? = ''))
+	AND (time >= ?)
+ORDER BY time, kind, count DESC
 `
 )
 
@@ -702,6 +754,16 @@ func (db *DBSQLite) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return nil
 }
 
+func (db *DBSQLite) UpdateForkID(ctx context.Context, id NodeID, forkHash string, forkNext uint64) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpdateForkID, id, forkHash, forkNext, updated)
+	if err != nil {
+		return fmt.Errorf("UpdateForkID failed: %w", err)
+	}
+	return nil
+}
+
 func (db *DBSQLite) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	keysStr := strings.Join(keys, ",")
 
@@ -937,6 +999,103 @@ func (db *DBSQLite) EnumerateClientIDs(
 	return nil
 }
 
+func (db *DBSQLite) EnumerateCensusNodes(
+	ctx context.Context,
+	maxPingTries uint,
+	enumFunc func(node CensusNode),
+) error {
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateCensusNodes, maxPingTries)
+	if err != nil {
+		return fmt.Errorf("EnumerateCensusNodes failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var clientID sql.NullString
+		var networkID sql.NullInt64
+		var ip sql.NullString
+		var forkHash sql.NullString
+		var forkNext sql.NullInt64
+		err := cursor.Scan(&clientID, &networkID, &ip, &forkHash, &forkNext)
+		if err != nil {
+			return fmt.Errorf("EnumerateCensusNodes failed to read data: %w", err)
+		}
+
+		var node CensusNode
+		if clientID.Valid {
+			node.ClientID = &clientID.String
+		}
+		if networkID.Valid {
+			value := uint(networkID.Int64)
+			node.NetworkID = &value
+		}
+		if ip.Valid {
+			node.IP = net.ParseIP(ip.String)
+		}
+		if forkHash.Valid && forkNext.Valid {
+			next := uint64(forkNext.Int64)
+			node.ForkHash = &forkHash.String
+			node.ForkNext = &next
+		}
+		enumFunc(node)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateCensusNodes failed to iterate: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) InsertCensus(ctx context.Context, entries []CensusEntry) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("InsertCensus failed to start transaction: %w", err)
+	}
+
+	for _, entry := range entries {
+		_, err = tx.ExecContext(ctx, sqlInsertCensus, entry.Time.Unix(), entry.Kind, entry.Key, entry.Count)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("InsertCensus failed: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("InsertCensus failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) FindCensus(ctx context.Context, kind string, since time.Time) ([]CensusEntry, error) {
+	cursor, err := db.db.QueryContext(ctx, sqlFindCensus, kind, kind, since.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("FindCensus failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	var entries []CensusEntry
+	for cursor.Next() {
+		var timestamp int64
+		var entry CensusEntry
+		err := cursor.Scan(&timestamp, &entry.Kind, &entry.Key, &entry.Count)
+		if err != nil {
+			return nil, fmt.Errorf("FindCensus failed to read data: %w", err)
+		}
+		entry.Time = time.Unix(timestamp, 0)
+		entries = append(entries, entry)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("FindCensus failed to iterate: %w", err)
+	}
+	return entries, nil
+}
+
 func stringsToAny(strValues []NodeID) []interface{} {
 	values := make([]interface{}, 0, len(strValues))
 	for _, value := range strValues {
@@ -21,6 +21,7 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -55,3 +56,46 @@ func TestDBSQLiteInsertAndFind(t *testing.T) {
 	assert.Equal(t, addr.PortDisc, candidate.PortDisc)
 	assert.Equal(t, addr.PortRLPx, candidate.PortRLPx)
 }
+
+func TestDBSQLiteCensus(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	var addr NodeAddr
+	addr.IP = net.ParseIP("10.0.1.16")
+	require.Nil(t, db.UpsertNodeAddr(ctx, id, addr))
+	require.Nil(t, db.UpdateClientID(ctx, id, "erigon/v2.60.0-3f8a2f9a/linux-amd64/go1.22.4"))
+	require.Nil(t, db.UpdateNetworkID(ctx, id, 1))
+	require.Nil(t, db.UpdateForkID(ctx, id, "0x9f3d2254", 0))
+
+	var nodes []CensusNode
+	err = db.EnumerateCensusNodes(ctx, 3, func(node CensusNode) {
+		nodes = append(nodes, node)
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "erigon/v2.60.0-3f8a2f9a/linux-amd64/go1.22.4", *nodes[0].ClientID)
+	assert.Equal(t, uint(1), *nodes[0].NetworkID)
+	assert.Equal(t, "0x9f3d2254", *nodes[0].ForkHash)
+	assert.Equal(t, uint64(0), *nodes[0].ForkNext)
+	assert.True(t, addr.IP.Equal(nodes[0].IP))
+
+	now := time.Unix(1_700_000_000, 0)
+	entries := []CensusEntry{
+		{now.Add(-time.Hour), "client", "erigon", 3},
+		{now, "client", "erigon", 5},
+		{now, "fork_readiness", "ready", 4},
+	}
+	require.Nil(t, db.InsertCensus(ctx, entries))
+
+	found, err := db.FindCensus(ctx, "client", now.Add(-time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, entries[:2], found)
+
+	found, err = db.FindCensus(ctx, "", now)
+	require.Nil(t, err)
+	assert.Equal(t, entries[1:], found)
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -51,6 +53,21 @@ func mainWithFlags(ctx context.Context, flags observer.CommandFlags, logger log.
 	networkID := uint(params.NetworkIDByChainName(flags.Chain))
 	go observer.StatusLoggerLoop(ctx, db, networkID, flags.StatusLogPeriod, log.Root())
 
+	if flags.CensusPeriod > 0 {
+		schedule, err := reports.NewForkSchedule(flags.Chain, flags.CensusFork)
+		if err != nil {
+			return err
+		}
+		censusConfig := reports.CensusConfig{
+			Schedule:     schedule,
+			Period:       flags.CensusPeriod,
+			Limit:        flags.CensusLimit,
+			MaxPingTries: flags.MaxPingTries,
+			ExportDir:    flags.CensusExportDir,
+		}
+		go reports.CensusLoop(ctx, db, censusConfig, log.Root())
+	}
+
 	crawlerConfig := observer.CrawlerConfig{
 		Chain:            flags.Chain,
 		Bootnodes:        server.Bootnodes(),
@@ -96,6 +113,43 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 		return nil
 	}
 
+	if flags.Census {
+		schedule, err := reports.NewForkSchedule(flags.Chain, flags.CensusFork)
+		if err != nil {
+			return err
+		}
+		report, err := reports.CreateCensusReport(ctx, db, schedule, flags.ClientsLimit, flags.MaxPingTries)
+		if err != nil {
+			return err
+		}
+		switch flags.Format {
+		case "text":
+			fmt.Println(report)
+			return nil
+		case "json":
+			return report.WriteJSON(os.Stdout)
+		case "csv":
+			return report.WriteCSV(os.Stdout)
+		default:
+			return fmt.Errorf("unknown census format %s", flags.Format)
+		}
+	}
+
+	if flags.CensusHistory {
+		entries, err := db.FindCensus(ctx, flags.CensusKind, time.Now().Add(-flags.CensusSince))
+		if err != nil {
+			return err
+		}
+		switch flags.Format {
+		case "text", "csv":
+			return reports.WriteCensusEntriesCSV(os.Stdout, entries)
+		case "json":
+			return reports.WriteCensusEntriesJSON(os.Stdout, entries)
+		default:
+			return fmt.Errorf("unknown census format %s", flags.Format)
+		}
+	}
+
 	if flags.SentryCandidates {
 		report, err := reports.CreateSentryCandidatesReport(ctx, db, flags.ErigonLogPath)
 		if err != nil {
@@ -16,7 +16,10 @@
 
 package observer
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 func clientNameBlacklist() []string {
 	return []string{
@@ -147,3 +150,42 @@ func NameFromClientID(clientID string) string {
 	parts := strings.SplitN(clientID, "/", 2)
 	return parts[0]
 }
+
+// VersionFromClientID returns the release of a client ID like "Geth/v1.13.5-stable-916d6a44/linux-amd64/go1.21.4"
+// without the commit hash and build metadata ("v1.13.5-stable"), or an empty string if it has no version.
+func VersionFromClientID(clientID string) string {
+	parts := strings.Split(clientID, "/")
+	// some clients put a custom node name first: "Geth/my-node/v1.13.5-stable/linux-amd64/go1.21.4"
+	var version string
+	for _, part := range parts[1:] {
+		if (len(part) > 1) && (part[0] == 'v') && unicode.IsDigit(rune(part[1])) {
+			version = part
+			break
+		}
+	}
+	if version == "" {
+		return ""
+	}
+
+	version, _, _ = strings.Cut(version, "+")
+	labels := strings.Split(version, "-")
+	release := labels[:1]
+	for _, label := range labels[1:] {
+		if !isCommitHashOrDate(label) {
+			release = append(release, label)
+		}
+	}
+	return strings.Join(release, "-")
+}
+
+func isCommitHashOrDate(label string) bool {
+	if len(label) < 7 {
+		return false
+	}
+	for _, c := range label {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
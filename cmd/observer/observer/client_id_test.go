@@ -0,0 +1,38 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionFromClientID(t *testing.T) {
+	tests := map[string]string{
+		"Geth/v1.13.5-stable-916d6a44/linux-amd64/go1.21.4":         "v1.13.5-stable",
+		"erigon/v2.60.0-3f8a2f9a/linux-amd64/go1.22.4":              "v2.60.0",
+		"Nethermind/v1.25.4+20b10b35/linux-x64/dotnet8.0.2":         "v1.25.4",
+		"reth/v0.2.0-beta.6-ac29b4b/x86_64-unknown-linux-gnu":       "v0.2.0-beta.6",
+		"Geth/my-node/v1.14.0-stable-87246f3c/linux-amd64/go1.22.2": "v1.14.0-stable",
+		"besu/linux-x86_64/openjdk-java-17":                         "",
+		"pchain":                                                    "",
+	}
+	for clientID, version := range tests {
+		assert.Equal(t, version, VersionFromClientID(clientID), clientID)
+	}
+}
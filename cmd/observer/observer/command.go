@@ -56,6 +56,11 @@ type CommandFlags struct {
 	HandshakeRetryDelay     time.Duration
 	HandshakeMaxTries       uint
 
+	CensusPeriod    time.Duration
+	CensusFork      uint64
+	CensusLimit     uint
+	CensusExportDir string
+
 	ErigonLogPath string
 }
 
@@ -100,6 +105,11 @@ func NewCommand() *Command {
 	instance.withHandshakeRetryDelay()
 	instance.withHandshakeMaxTries()
 
+	instance.withCensusPeriod()
+	instance.withCensusFork()
+	instance.withCensusLimit()
+	instance.withCensusExportDir()
+
 	instance.withErigonLogPath()
 
 	return &instance
@@ -227,6 +237,40 @@ func (command *Command) withHandshakeMaxTries() {
 	command.command.Flags().UintVar(&command.flags.HandshakeMaxTries, flag.Name, flag.Value, flag.Usage)
 }
 
+func (command *Command) withCensusPeriod() {
+	flag := cli.DurationFlag{
+		Name:  "census-period",
+		Usage: "How often to record a network census into the census time series (0 to disable)",
+		Value: time.Hour,
+	}
+	command.command.Flags().DurationVar(&command.flags.CensusPeriod, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withCensusFork() {
+	flag := cli.Uint64Flag{
+		Name:  "census-fork",
+		Usage: "Block number or timestamp of the upcoming fork to count ready nodes for (0 for the last fork of the chain)",
+	}
+	command.command.Flags().Uint64Var(&command.flags.CensusFork, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withCensusLimit() {
+	flag := cli.UintFlag{
+		Name:  "census-limit",
+		Usage: "A number of top entries per census breakdown to record",
+		Value: 20,
+	}
+	command.command.Flags().UintVar(&command.flags.CensusLimit, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withCensusExportDir() {
+	flag := cli.StringFlag{
+		Name:  "census-export-dir",
+		Usage: "Directory to export every recorded census to as JSON and CSV",
+	}
+	command.command.Flags().StringVar(&command.flags.CensusExportDir, flag.Name, flag.Value, flag.Usage)
+}
+
 func (command *Command) withErigonLogPath() {
 	flag := cli.StringFlag{
 		Name:  "erigon-log",
@@ -512,6 +512,13 @@ func (crawler *Crawler) saveInterrogationResult(
 		}
 	}
 
+	if forkID := interrogationForkID(result); forkID != nil {
+		dbErr := crawler.db.UpdateForkID(ctx, id, EncodeForkHash(forkID.Hash), forkID.Next)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if (result != nil) && (result.HandshakeResult != nil) && (result.HandshakeResult.HandshakeErr != nil) {
 		dbErr := crawler.db.InsertHandshakeError(ctx, id, result.HandshakeResult.HandshakeErr.StringCode())
 		if dbErr != nil {
@@ -536,6 +543,18 @@ func (crawler *Crawler) saveInterrogationResult(
 	return crawler.db.UpdateCrawlRetryTime(ctx, id, nextRetryTime)
 }
 
+// interrogationForkID prefers the fork ID of the Status message over the one from the ENR,
+// because the former reflects the head of the node.
+func interrogationForkID(result *InterrogationResult) *forkid.ID {
+	if result == nil {
+		return nil
+	}
+	if (result.HandshakeResult != nil) && (result.HandshakeResult.ForkID != nil) {
+		return result.HandshakeResult.ForkID
+	}
+	return result.ForkID
+}
+
 func (crawler *Crawler) nextRetryTime(isPingError bool, prevPingTries uint) time.Time {
 	return time.Now().Add(crawler.nextRetryDelay(isPingError, prevPingTries))
 }
@@ -252,6 +252,13 @@ func (diplomacy *Diplomacy) saveDiplomatResult(
 		}
 	}
 
+	if result.ForkID != nil {
+		dbErr := diplomacy.db.UpdateForkID(ctx, id, EncodeForkHash(result.ForkID.Hash), result.ForkID.Next)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if result.HandshakeErr != nil {
 		dbErr := diplomacy.db.InsertHandshakeError(ctx, id, result.HandshakeErr.StringCode())
 		if dbErr != nil {
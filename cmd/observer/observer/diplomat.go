@@ -24,6 +24,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cmd/observer/database"
+	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/enode"
 )
@@ -44,6 +45,7 @@ type DiplomatResult struct {
 	ClientID        *string
 	NetworkID       *uint64
 	EthVersion      *uint32
+	ForkID          *forkid.ID
 	HandshakeErr    *HandshakeError
 	HasTransientErr bool
 }
@@ -99,6 +101,10 @@ func (diplomat *Diplomat) Run(ctx context.Context) DiplomatResult {
 		result.EthVersion = &status.ProtocolVersion
 		diplomat.log.Debug("Got eth version", "ethVersion", *result.EthVersion)
 	}
+	if (status != nil) && (status.ForkID != nil) {
+		result.ForkID = status.ForkID
+		diplomat.log.Debug("Got fork ID", "forkID", EncodeForkHash(status.ForkID.Hash), "next", status.ForkID.Next)
+	}
 
 	return result
 }
@@ -73,6 +73,11 @@ type StatusMessage struct {
 	Rest            []rlp.RawValue `rlp:"tail"`
 }
 
+// EncodeForkHash formats a fork ID hash as it is stored in the database.
+func EncodeForkHash(hash [4]byte) string {
+	return fmt.Sprintf("%#x", hash[:])
+}
+
 type HandshakeErrorID string
 
 const (
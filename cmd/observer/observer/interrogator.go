@@ -60,6 +60,7 @@ type Interrogator struct {
 type InterrogationResult struct {
 	Node               *enode.Node
 	IsCompatFork       *bool
+	ForkID             *forkid.ID // from the ENR
 	HandshakeResult    *DiplomatResult
 	HandshakeRetryTime *time.Time
 	KeygenKeys         []*ecdsa.PublicKey
@@ -187,6 +188,7 @@ func (interrogator *Interrogator) Run(ctx context.Context) (*InterrogationResult
 	result := InterrogationResult{
 		interrogator.node,
 		isCompatFork,
+		forkID,
 		handshakeResult,
 		handshakeRetryTime,
 		keys,
@@ -0,0 +1,103 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cmd/observer/database"
+)
+
+type CensusConfig struct {
+	Schedule     *ForkSchedule
+	Period       time.Duration
+	Limit        uint
+	MaxPingTries uint
+	// ExportDir receives a JSON and a CSV file per census if set.
+	ExportDir string
+}
+
+// CensusLoop takes a census every period, appends it to the census time series
+// and exports it to files.
+func CensusLoop(ctx context.Context, db database.DB, config CensusConfig, logger log.Logger) {
+	retrier := database.NewDBRetrier(db, logger)
+
+	for ctx.Err() == nil {
+		if err := libcommon.Sleep(ctx, config.Period); err != nil {
+			break
+		}
+
+		report, err := CreateCensusReport(ctx, db, config.Schedule, config.Limit, config.MaxPingTries)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error("Failed to take census", "err", err)
+			}
+			continue
+		}
+
+		if err := retrier.InsertCensus(ctx, report.Entries()); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error("Failed to save census", "err", err)
+			}
+			continue
+		}
+
+		if config.ExportDir != "" {
+			if err := exportCensus(report, config.ExportDir); err != nil {
+				logger.Error("Failed to export census", "err", err)
+			}
+		}
+
+		logger.Info("Census",
+			"total", report.Total,
+			"ready", report.ForkReadiness[0].Count,
+			"notReady", report.ForkReadiness[1].Count,
+			"unknown", report.ForkReadiness[2].Count)
+	}
+}
+
+func exportCensus(report *CensusReport, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := "census-" + report.Time.UTC().Format("20060102T150405Z")
+	if err := writeCensusFile(filepath.Join(dir, name+".json"), report.WriteJSON); err != nil {
+		return err
+	}
+	return writeCensusFile(filepath.Join(dir, name+".csv"), report.WriteCSV)
+}
+
+func writeCensusFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,360 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon/cmd/observer/database"
+	"github.com/erigontech/erigon/cmd/observer/observer"
+	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/params"
+)
+
+// Census kinds, used as the "kind" column of the census time series.
+const (
+	CensusKindClient        = "client"
+	CensusKindClientVersion = "client_version"
+	CensusKindForkID        = "fork_id"
+	CensusKindForkReadiness = "fork_readiness"
+	CensusKindNetworkID     = "network_id"
+	CensusKindIPPrefix      = "ip_prefix"
+)
+
+// Keys of the fork readiness counts.
+const (
+	ForkReady    = "ready"
+	ForkNotReady = "not_ready"
+	ForkUnknown  = "unknown"
+)
+
+const censusUnknownKey = "unknown"
+
+// IP prefix lengths to group nodes by, coarse enough to not identify a node.
+const (
+	censusIPv4PrefixBits = 16
+	censusIPv6PrefixBits = 32
+)
+
+// ForkSchedule tells whether a fork ID advertised by a node is ready for an upcoming fork.
+type ForkSchedule struct {
+	NetworkID uint
+	Fork      uint64 // block number or timestamp of the upcoming fork
+	IsTime    bool
+
+	readyHashes map[string]bool
+}
+
+// NewForkSchedule prepares the readiness check for the given fork of the chain.
+// If fork is 0, the last fork configured for the chain is used.
+func NewForkSchedule(chain string, fork uint64) (*ForkSchedule, error) {
+	chainConfig := params.ChainConfigByChainName(chain)
+	genesisHash := params.GenesisHashByChainName(chain)
+	if (chainConfig == nil) || (genesisHash == nil) {
+		return nil, fmt.Errorf("unknown chain %s", chain)
+	}
+
+	// the same genesis time as the crawler fork filter
+	heightForks, timeForks := forkid.GatherForks(chainConfig, 0)
+	if fork == 0 {
+		if len(timeForks) > 0 {
+			fork = timeForks[len(timeForks)-1]
+		} else if len(heightForks) > 0 {
+			fork = heightForks[len(heightForks)-1]
+		} else {
+			return nil, fmt.Errorf("chain %s has no forks", chain)
+		}
+	}
+
+	schedule := ForkSchedule{
+		NetworkID:   uint(params.NetworkIDByChainName(chain)),
+		Fork:        fork,
+		readyHashes: make(map[string]bool),
+	}
+
+	// a node is ready if it has passed the fork, or any later one
+	switch {
+	case slices.Contains(timeForks, fork):
+		schedule.IsTime = true
+		for _, timeFork := range timeForks {
+			if timeFork >= fork {
+				id := forkid.NewIDFromForks(heightForks, timeForks, *genesisHash, math.MaxUint64, timeFork)
+				schedule.readyHashes[observer.EncodeForkHash(id.Hash)] = true
+			}
+		}
+	case slices.Contains(heightForks, fork):
+		for _, heightFork := range heightForks {
+			if heightFork >= fork {
+				id := forkid.NewIDFromForks(heightForks, timeForks, *genesisHash, heightFork, 0)
+				schedule.readyHashes[observer.EncodeForkHash(id.Hash)] = true
+			}
+		}
+		for _, timeFork := range timeForks {
+			id := forkid.NewIDFromForks(heightForks, timeForks, *genesisHash, math.MaxUint64, timeFork)
+			schedule.readyHashes[observer.EncodeForkHash(id.Hash)] = true
+		}
+	default:
+		return nil, fmt.Errorf("%d is not a fork block or time of chain %s", fork, chain)
+	}
+
+	return &schedule, nil
+}
+
+// IsReady reports whether a node advertising the fork ID knows about the fork.
+func (schedule *ForkSchedule) IsReady(forkHash string, forkNext uint64) bool {
+	return schedule.readyHashes[forkHash] || (forkNext == schedule.Fork)
+}
+
+type CensusReportEntry struct {
+	Key   string `json:"key"`
+	Count uint   `json:"count"`
+}
+
+// CensusReport is a breakdown of the live nodes. The network ID breakdown includes all nodes,
+// the other breakdowns only the nodes of the schedule network.
+type CensusReport struct {
+	Time           time.Time           `json:"time"`
+	Total          uint                `json:"total"`
+	Fork           uint64              `json:"fork"`
+	ForkIsTime     bool                `json:"forkIsTime"`
+	ForkReadiness  []CensusReportEntry `json:"forkReadiness"`
+	Clients        []CensusReportEntry `json:"clients"`
+	ClientVersions []CensusReportEntry `json:"clientVersions"`
+	ForkIDs        []CensusReportEntry `json:"forkIds"`
+	NetworkIDs     []CensusReportEntry `json:"networkIds"`
+	IPPrefixes     []CensusReportEntry `json:"ipPrefixes"`
+}
+
+func CreateCensusReport(
+	ctx context.Context,
+	db database.DB,
+	schedule *ForkSchedule,
+	limit uint,
+	maxPingTries uint,
+) (*CensusReport, error) {
+	var total uint
+	clients := make(map[string]uint)
+	clientVersions := make(map[string]uint)
+	forkIDs := make(map[string]uint)
+	networkIDs := make(map[string]uint)
+	ipPrefixes := make(map[string]uint)
+	readiness := map[string]uint{ForkReady: 0, ForkNotReady: 0, ForkUnknown: 0}
+
+	enumFunc := func(node database.CensusNode) {
+		if (node.ClientID != nil) && observer.IsClientIDBlacklisted(*node.ClientID) {
+			return
+		}
+
+		networkIDKey := censusUnknownKey
+		if node.NetworkID != nil {
+			networkIDKey = strconv.FormatUint(uint64(*node.NetworkID), 10)
+		}
+		networkIDs[networkIDKey]++
+		if (node.NetworkID != nil) && (*node.NetworkID != schedule.NetworkID) {
+			return
+		}
+		total++
+
+		if node.ClientID != nil {
+			name := observer.NameFromClientID(*node.ClientID)
+			clients[name]++
+			if version := observer.VersionFromClientID(*node.ClientID); version != "" {
+				clientVersions[name+"/"+version]++
+			} else {
+				clientVersions[name+"/"+censusUnknownKey]++
+			}
+		} else {
+			clients[censusUnknownKey]++
+		}
+
+		if (node.ForkHash != nil) && (node.ForkNext != nil) {
+			forkIDs[fmt.Sprintf("%s/%d", *node.ForkHash, *node.ForkNext)]++
+			if schedule.IsReady(*node.ForkHash, *node.ForkNext) {
+				readiness[ForkReady]++
+			} else {
+				readiness[ForkNotReady]++
+			}
+		} else {
+			forkIDs[censusUnknownKey]++
+			readiness[ForkUnknown]++
+		}
+
+		ipPrefixes[censusIPPrefix(node.IP)]++
+	}
+	if err := db.EnumerateCensusNodes(ctx, maxPingTries, enumFunc); err != nil {
+		return nil, err
+	}
+
+	report := CensusReport{
+		Time:       time.Now(),
+		Total:      total,
+		Fork:       schedule.Fork,
+		ForkIsTime: schedule.IsTime,
+		ForkReadiness: []CensusReportEntry{
+			{ForkReady, readiness[ForkReady]},
+			{ForkNotReady, readiness[ForkNotReady]},
+			{ForkUnknown, readiness[ForkUnknown]},
+		},
+		Clients:        censusTopEntries(clients, limit),
+		ClientVersions: censusTopEntries(clientVersions, limit),
+		ForkIDs:        censusTopEntries(forkIDs, limit),
+		NetworkIDs:     censusTopEntries(networkIDs, limit),
+		IPPrefixes:     censusTopEntries(ipPrefixes, limit),
+	}
+	return &report, nil
+}
+
+func censusIPPrefix(ip net.IP) string {
+	if ip == nil {
+		return censusUnknownKey
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		prefix := net.IPNet{IP: ip4.Mask(net.CIDRMask(censusIPv4PrefixBits, 32)), Mask: net.CIDRMask(censusIPv4PrefixBits, 32)}
+		return prefix.String()
+	}
+	prefix := net.IPNet{IP: ip.Mask(net.CIDRMask(censusIPv6PrefixBits, 128)), Mask: net.CIDRMask(censusIPv6PrefixBits, 128)}
+	return prefix.String()
+}
+
+// censusTopEntries returns the limit largest groups sorted by count, and the rest summed up as "...".
+func censusTopEntries(groups map[string]uint, limit uint) []CensusReportEntry {
+	entries := make([]CensusReportEntry, 0, len(groups))
+	for key, count := range groups {
+		entries = append(entries, CensusReportEntry{key, count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Key < entries[j].Key
+	})
+
+	if (limit == 0) || (uint(len(entries)) <= limit) {
+		return entries
+	}
+	others := CensusReportEntry{Key: "..."}
+	for _, entry := range entries[limit:] {
+		others.Count += entry.Count
+	}
+	return append(entries[:limit], others)
+}
+
+// Entries flattens the report into rows of the census time series.
+func (report *CensusReport) Entries() []database.CensusEntry {
+	var entries []database.CensusEntry
+	add := func(kind string, group []CensusReportEntry) {
+		for _, entry := range group {
+			entries = append(entries, database.CensusEntry{
+				Time:  report.Time,
+				Kind:  kind,
+				Key:   entry.Key,
+				Count: entry.Count,
+			})
+		}
+	}
+	add(CensusKindForkReadiness, report.ForkReadiness)
+	add(CensusKindClient, report.Clients)
+	add(CensusKindClientVersion, report.ClientVersions)
+	add(CensusKindForkID, report.ForkIDs)
+	add(CensusKindNetworkID, report.NetworkIDs)
+	add(CensusKindIPPrefix, report.IPPrefixes)
+	return entries
+}
+
+func (report *CensusReport) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("total: %d", report.Total))
+	builder.WriteRune('\n')
+	forkUnit := "block"
+	if report.ForkIsTime {
+		forkUnit = "time"
+	}
+	writeGroup := func(title string, group []CensusReportEntry) {
+		builder.WriteString(title)
+		builder.WriteRune('\n')
+		for _, entry := range group {
+			builder.WriteString(fmt.Sprintf("%6d %s", entry.Count, entry.Key))
+			builder.WriteRune('\n')
+		}
+	}
+	writeGroup(fmt.Sprintf("fork readiness (%s %d):", forkUnit, report.Fork), report.ForkReadiness)
+	writeGroup("clients:", report.Clients)
+	writeGroup("client versions:", report.ClientVersions)
+	writeGroup("fork IDs:", report.ForkIDs)
+	writeGroup("network IDs:", report.NetworkIDs)
+	writeGroup("IP prefixes:", report.IPPrefixes)
+	return builder.String()
+}
+
+func (report *CensusReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func (report *CensusReport) WriteCSV(w io.Writer) error {
+	return WriteCensusEntriesCSV(w, report.Entries())
+}
+
+// WriteCensusEntriesCSV writes census rows as CSV with a header, one row per time, kind and key.
+func WriteCensusEntriesCSV(w io.Writer, entries []database.CensusEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"time", "kind", "key", "count"}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.Time.UTC().Format(time.RFC3339),
+			entry.Kind,
+			entry.Key,
+			strconv.FormatUint(uint64(entry.Count), 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCensusEntriesJSON writes census rows as a JSON array.
+func WriteCensusEntriesJSON(w io.Writer, entries []database.CensusEntry) error {
+	type censusEntryJSON struct {
+		Time  time.Time `json:"time"`
+		Kind  string    `json:"kind"`
+		Key   string    `json:"key"`
+		Count uint      `json:"count"`
+	}
+	rows := make([]censusEntryJSON, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, censusEntryJSON{entry.Time.UTC(), entry.Kind, entry.Key, entry.Count})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/observer/database"
+	"github.com/erigontech/erigon/params"
+)
+
+const (
+	mainnetLondonBlock       = 12965000
+	mainnetArrowGlacierBlock = 13773000
+	mainnetGrayGlacierBlock  = 15050000
+	mainnetShanghaiTime      = 1681338455
+	mainnetCancunTime        = 1710338135
+	mainnetBerlinHash        = "0x0eb440f6"
+	mainnetLondonHash        = "0xb715077d"
+	mainnetArrowGlacierHash  = "0x20c327fc"
+	mainnetGrayGlacierHash   = "0xf0afd0e3"
+	mainnetShanghaiHash      = "0xdce96c2d"
+	mainnetCancunHash        = "0x9f3d2254"
+	unknownForkHash          = "0x12345678"
+)
+
+func TestForkScheduleIsReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		fork     uint64
+		forkHash string
+		forkNext uint64
+		ready    bool
+	}{
+		{"time fork: passed", mainnetShanghaiTime, mainnetShanghaiHash, mainnetCancunTime, true},
+		{"time fork: passed a later fork", mainnetShanghaiTime, mainnetCancunHash, 0, true},
+		{"time fork: announces it", mainnetShanghaiTime, mainnetGrayGlacierHash, mainnetShanghaiTime, true},
+		{"time fork: unaware", mainnetShanghaiTime, mainnetGrayGlacierHash, 0, false},
+		{"time fork: announces another fork", mainnetCancunTime, mainnetShanghaiHash, mainnetShanghaiTime + 1, false},
+		{"time fork: unknown hash", mainnetShanghaiTime, unknownForkHash, 0, false},
+		{"height fork: passed", mainnetLondonBlock, mainnetLondonHash, mainnetArrowGlacierBlock, true},
+		{"height fork: passed a later height fork", mainnetLondonBlock, mainnetArrowGlacierHash, mainnetGrayGlacierBlock, true},
+		{"height fork: passed a time fork", mainnetLondonBlock, mainnetShanghaiHash, mainnetCancunTime, true},
+		{"height fork: announces it", mainnetLondonBlock, mainnetBerlinHash, mainnetLondonBlock, true},
+		{"height fork: unaware", mainnetLondonBlock, mainnetBerlinHash, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewForkSchedule("mainnet", tt.fork)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ready, schedule.IsReady(tt.forkHash, tt.forkNext))
+		})
+	}
+}
+
+func TestNewForkSchedule(t *testing.T) {
+	schedule, err := NewForkSchedule("mainnet", mainnetShanghaiTime)
+	require.NoError(t, err)
+	assert.True(t, schedule.IsTime)
+	assert.Equal(t, uint(params.NetworkIDByChainName("mainnet")), schedule.NetworkID)
+
+	schedule, err = NewForkSchedule("mainnet", mainnetLondonBlock)
+	require.NoError(t, err)
+	assert.False(t, schedule.IsTime)
+
+	// the last fork by default
+	schedule, err = NewForkSchedule("mainnet", 0)
+	require.NoError(t, err)
+	assert.True(t, schedule.IsTime)
+	assert.GreaterOrEqual(t, schedule.Fork, uint64(mainnetCancunTime))
+
+	_, err = NewForkSchedule("mainnet", mainnetLondonBlock+1)
+	require.Error(t, err)
+	_, err = NewForkSchedule("nochain", 0)
+	require.Error(t, err)
+}
+
+func TestCreateCensusReport(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	type node struct {
+		ip        string
+		clientID  string
+		networkID uint
+		forkHash  string
+		forkNext  uint64
+	}
+	nodes := []node{
+		{"10.0.1.16", "erigon/v2.60.0-3f8a2f9a/linux-amd64/go1.22.4", 1, mainnetCancunHash, 0},
+		{"10.0.2.17", "Geth/v1.14.0-stable/linux-amd64/go1.22.2", 1, mainnetShanghaiHash, mainnetCancunTime},
+		{"10.1.0.1", "Geth/v1.11.0-stable/linux-amd64/go1.20", 1, mainnetGrayGlacierHash, 0},
+		{"2001:db8::1", "", 1, "", 0},
+		{"10.0.3.3", "Geth/v1.14.0-stable/linux-amd64/go1.22.2", 5, mainnetCancunHash, 0},
+	}
+	for i, n := range nodes {
+		id := database.NodeID(fmt.Sprintf("%0128x", i+1))
+		var addr database.NodeAddr
+		addr.IP = net.ParseIP(n.ip)
+		require.NoError(t, db.UpsertNodeAddr(ctx, id, addr))
+		if n.clientID != "" {
+			require.NoError(t, db.UpdateClientID(ctx, id, n.clientID))
+		}
+		require.NoError(t, db.UpdateNetworkID(ctx, id, n.networkID))
+		if n.forkHash != "" {
+			require.NoError(t, db.UpdateForkID(ctx, id, n.forkHash, n.forkNext))
+		}
+	}
+
+	schedule, err := NewForkSchedule("mainnet", mainnetCancunTime)
+	require.NoError(t, err)
+	report, err := CreateCensusReport(ctx, db, schedule, 10, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(4), report.Total)
+	assert.Equal(t, []CensusReportEntry{{ForkReady, 2}, {ForkNotReady, 1}, {ForkUnknown, 1}}, report.ForkReadiness)
+	assert.Equal(t, []CensusReportEntry{{"1", 4}, {"5", 1}}, report.NetworkIDs)
+	assert.Equal(t, []CensusReportEntry{{"10.0.0.0/16", 2}, {"10.1.0.0/16", 1}, {"2001:db8::/32", 1}}, report.IPPrefixes)
+	assert.Len(t, report.Entries(), len(report.ForkReadiness)+len(report.Clients)+len(report.ClientVersions)+
+		len(report.ForkIDs)+len(report.NetworkIDs)+len(report.IPPrefixes))
+}
+
+func TestCensusTopEntries(t *testing.T) {
+	groups := map[string]uint{"a": 1, "b": 5, "c": 3, "d": 3}
+	assert.Equal(t, []CensusReportEntry{{"b", 5}, {"c", 3}, {"d", 3}, {"a", 1}}, censusTopEntries(groups, 0))
+	assert.Equal(t, []CensusReportEntry{{"b", 5}, {"c", 3}, {"...", 4}}, censusTopEntries(groups, 2))
+}
@@ -18,6 +18,7 @@ package reports
 
 import (
 	"context"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/urfave/cli/v2"
@@ -32,6 +33,13 @@ type CommandFlags struct {
 	MaxPingTries uint
 	Estimate     bool
 
+	Census        bool
+	CensusHistory bool
+	CensusFork    uint64
+	CensusKind    string
+	CensusSince   time.Duration
+	Format        string
+
 	SentryCandidates bool
 	ErigonLogPath    string
 }
@@ -55,6 +63,12 @@ func NewCommand() *Command {
 	instance.withClientsLimit()
 	instance.withMaxPingTries()
 	instance.withEstimate()
+	instance.withCensus()
+	instance.withCensusHistory()
+	instance.withCensusFork()
+	instance.withCensusKind()
+	instance.withCensusSince()
+	instance.withFormat()
 	instance.withSentryCandidates()
 	instance.withErigonLogPath()
 
@@ -98,6 +112,56 @@ func (command *Command) withEstimate() {
 	command.command.Flags().BoolVar(&command.flags.Estimate, flag.Name, false, flag.Usage)
 }
 
+func (command *Command) withCensus() {
+	flag := cli.BoolFlag{
+		Name:  "census",
+		Usage: "Count nodes by client, client version, fork ID, fork readiness, network ID and IP prefix",
+	}
+	command.command.Flags().BoolVar(&command.flags.Census, flag.Name, false, flag.Usage)
+}
+
+func (command *Command) withCensusHistory() {
+	flag := cli.BoolFlag{
+		Name:  "census-history",
+		Usage: "Show the census time series recorded by the crawler",
+	}
+	command.command.Flags().BoolVar(&command.flags.CensusHistory, flag.Name, false, flag.Usage)
+}
+
+func (command *Command) withCensusFork() {
+	flag := cli.Uint64Flag{
+		Name:  "census-fork",
+		Usage: "Block number or timestamp of the upcoming fork to count ready nodes for (0 for the last fork of the chain)",
+	}
+	command.command.Flags().Uint64Var(&command.flags.CensusFork, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withCensusKind() {
+	flag := cli.StringFlag{
+		Name:  "census-kind",
+		Usage: "Census history breakdown to show: fork_readiness, client, client_version, fork_id, network_id or ip_prefix (all if empty)",
+	}
+	command.command.Flags().StringVar(&command.flags.CensusKind, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withCensusSince() {
+	flag := cli.DurationFlag{
+		Name:  "census-since",
+		Usage: "How far back to show the census history",
+		Value: 30 * 24 * time.Hour,
+	}
+	command.command.Flags().DurationVar(&command.flags.CensusSince, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withFormat() {
+	flag := cli.StringFlag{
+		Name:  "format",
+		Usage: "Census output format: text, json or csv",
+		Value: "text",
+	}
+	command.command.Flags().StringVar(&command.flags.Format, flag.Name, flag.Value, flag.Usage)
+}
+
 func (command *Command) withSentryCandidates() {
 	flag := cli.BoolFlag{
 		Name:  "sentry-candidates",
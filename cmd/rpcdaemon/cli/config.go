@@ -93,7 +93,8 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	stateCacheStr string
+	stateCacheStr        string
+	responseCacheSizeStr string
 )
 
 func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
@@ -107,6 +108,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().Uint64Var(&cfg.MaxTraces, "trace.maxtraces", 200, "Sets a limit on traces that can be returned in trace_filter")
 
 	rootCmd.PersistentFlags().StringVar(&cfg.RpcAllowListFilePath, utils.RpcAccessListFlag.Name, "", "Specify granular (method-by-method) API allowlist")
+	rootCmd.PersistentFlags().StringVar(&cfg.RpcRateLimitFilePath, utils.RpcRateLimitFlag.Name, "", utils.RpcRateLimitFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.RpcAPIKeysFilePath, utils.RpcAPIKeysFlag.Name, "", utils.RpcAPIKeysFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.RpcRequestLogFilePath, utils.RpcRequestLogFlag.Name, "", utils.RpcRequestLogFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&responseCacheSizeStr, utils.RpcResponseCacheSizeFlag.Name, utils.RpcResponseCacheSizeFlag.Value, utils.RpcResponseCacheSizeFlag.Usage)
 	rootCmd.PersistentFlags().UintVar(&cfg.RpcBatchConcurrency, utils.RpcBatchConcurrencyFlag.Name, 2, utils.RpcBatchConcurrencyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.RpcStreamingDisable, utils.RpcStreamingDisableFlag.Name, false, utils.RpcStreamingDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.DebugSingleRequest, utils.HTTPDebugSingleFlag.Name, false, utils.HTTPDebugSingleFlag.Usage)
@@ -169,6 +174,12 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	if err := rootCmd.MarkPersistentFlagFilename("rpc.accessList", "json"); err != nil {
 		panic(err)
 	}
+	if err := rootCmd.MarkPersistentFlagFilename(utils.RpcRateLimitFlag.Name, "json"); err != nil {
+		panic(err)
+	}
+	if err := rootCmd.MarkPersistentFlagFilename(utils.RpcAPIKeysFlag.Name, "json"); err != nil {
+		panic(err)
+	}
 	if err := rootCmd.MarkPersistentFlagDirname("datadir"); err != nil {
 		panic(err)
 	}
@@ -185,6 +196,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 			return fmt.Errorf("state.cache value of %v is not valid", stateCacheStr)
 		}
 
+		if err = cfg.ResponseCacheSize.UnmarshalText([]byte(responseCacheSizeStr)); err != nil {
+			return fmt.Errorf("%s value of %v is not valid", utils.RpcResponseCacheSizeFlag.Name, responseCacheSizeStr)
+		}
+
 		cfg.WithDatadir = cfg.DataDir != ""
 		if cfg.WithDatadir {
 			if cfg.DataDir == "" {
@@ -206,6 +221,8 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 
 	cfg.StateCache.MetricsLabel = "rpc"
 
+	rootCmd.AddCommand(apiKeyCommand(cfg))
+
 	return rootCmd, cfg
 }
 
@@ -547,9 +564,45 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 	return db, eth, txPool, mining, stateCache, blockReader, engine, ff, err
 }
 
-func StartRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, logger log.Logger) error {
+// NewResponseCache creates the cache of the responses pinned to finalized blocks, or returns nil
+// if it's disabled.
+func NewResponseCache(ctx context.Context, cfg *httpcfg.HttpCfg, db kv.RoDB, blockReader services.FullBlockReader, ff *rpchelper.Filters, logger log.Logger) *rpc.ResponseCache {
+	if cfg.ResponseCacheSize == 0 {
+		return nil
+	}
+	policy := rpchelper.NewResponseCachePolicy(db, blockReader, logger)
+	cache := rpc.NewResponseCache(int(cfg.ResponseCacheSize.Bytes()), policy)
+	go policy.WatchFinality(ctx, ff, cache)
+	return cache
+}
+
+// NewRequestLog opens the log of the slow and failed requests, or returns nil if it's disabled.
+func NewRequestLog(cfg *httpcfg.HttpCfg, db kv.RoDB, logger log.Logger) (*rpc.RequestLog, error) {
+	if cfg.RpcRequestLogFilePath == "" {
+		return nil, nil
+	}
+	requestLog, err := rpc.OpenRequestLog(cfg.RpcRequestLogFilePath, cfg.RPCSlowLogThreshold, latestBlockHeight{db}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.RpcRequestLogFlag.Name, err)
+	}
+	logger.Info("Logging the failed RPC requests", "file", cfg.RpcRequestLogFilePath, "slowerThan", cfg.RPCSlowLogThreshold)
+	return requestLog, nil
+}
+
+// latestBlockHeight reads the block height recorded with the logged requests.
+type latestBlockHeight struct{ db kv.RoDB }
+
+func (h latestBlockHeight) BlockHeight(ctx context.Context) (block uint64, err error) {
+	err = h.db.View(ctx, func(tx kv.Tx) error {
+		block, err = rpchelper.GetLatestBlockNumber(tx)
+		return err
+	})
+	return block, err
+}
+
+func StartRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, responseCache *rpc.ResponseCache, requestLog *rpc.RequestLog, logger log.Logger) error {
 	if cfg.Enabled {
-		return startRegularRpcServer(ctx, cfg, rpcAPI, logger)
+		return startRegularRpcServer(ctx, cfg, rpcAPI, responseCache, requestLog, logger)
 	}
 
 	return nil
@@ -567,7 +620,7 @@ func StartRpcServerWithJwtAuthentication(ctx context.Context, cfg *httpcfg.HttpC
 	return nil
 }
 
-func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, logger log.Logger) error {
+func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, responseCache *rpc.ResponseCache, requestLog *rpc.RequestLog, logger log.Logger) error {
 	// register apis and create handler stack
 	srv := rpc.NewServer(cfg.RpcBatchConcurrency, cfg.TraceRequests, cfg.DebugSingleRequest, cfg.RpcStreamingDisable, logger, cfg.RPCSlowLogThreshold)
 
@@ -577,6 +630,20 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 	}
 	srv.SetAllowList(allowListForRPC)
 
+	rateLimiter, err := parseRateLimitForRPC(cfg.RpcRateLimitFilePath)
+	if err != nil {
+		return err
+	}
+	srv.SetRateLimiter(rateLimiter)
+
+	apiKeys, err := parseAPIKeysForRPC(cfg.RpcAPIKeysFilePath)
+	if err != nil {
+		return err
+	}
+	srv.SetAPIKeys(apiKeys)
+	srv.SetResponseCache(responseCache)
+	srv.SetRequestLog(requestLog)
+
 	srv.SetBatchLimit(cfg.BatchLimit)
 
 	defer srv.Stop()
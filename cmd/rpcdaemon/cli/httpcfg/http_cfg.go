@@ -19,6 +19,8 @@ package httpcfg
 import (
 	"time"
 
+	"github.com/c2h5oh/datasize"
+
 	"github.com/erigontech/erigon/turbo/rpchelper"
 
 	"github.com/erigontech/erigon-lib/common/datadir"
@@ -67,6 +69,10 @@ type HttpCfg struct {
 	WebsocketCompression              bool
 	WebsocketSubscribeLogsChannelSize int
 	RpcAllowListFilePath              string
+	RpcRateLimitFilePath              string
+	RpcAPIKeysFilePath                string
+	RpcRequestLogFilePath             string            // JSONL log of the slow and failed requests, empty disables it
+	ResponseCacheSize                 datasize.ByteSize // 0 disables the cache of immutable responses
 	RpcBatchConcurrency               uint
 	RpcStreamingDisable               bool
 	RpcFiltersConfig                  rpchelper.FiltersConfig
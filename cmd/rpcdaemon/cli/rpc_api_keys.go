@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func parseAPIKeysForRPC(path string) (*rpc.APIKeys, error) {
+	path = strings.TrimSpace(path)
+	if path == "" { // no file is provided
+		return nil, nil
+	}
+
+	fileContents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg rpc.APIKeysConfig
+	if err := json.Unmarshal(fileContents, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid api keys file %s: %w", path, err)
+	}
+	apiKeys, err := rpc.NewAPIKeys(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api keys file %s: %w", path, err)
+	}
+	return apiKeys, nil
+}
+
+// apiKeyCommand prints a token of one of the keys of the --rpc.apikeys file, to be passed
+// by the clients as "Authorization: Bearer <token>" or "?apikey=<token>".
+func apiKeyCommand(cfg *httpcfg.HttpCfg) *cobra.Command {
+	var name string
+	var expiry time.Duration
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Print a token of an API key of the --rpc.apikeys file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiKeys, err := parseAPIKeysForRPC(cfg.RpcAPIKeysFilePath)
+			if err != nil {
+				return err
+			}
+			if apiKeys == nil {
+				return errors.New("--rpc.apikeys is required")
+			}
+			key := apiKeys.Get(name)
+			if key == nil {
+				return fmt.Errorf("no api key %q in %s", name, cfg.RpcAPIKeysFilePath)
+			}
+			var expiresAt time.Time
+			if expiry > 0 {
+				expiresAt = time.Now().Add(expiry)
+			}
+			token, err := rpc.NewAPIKeyToken(key, expiresAt)
+			if err != nil {
+				return err
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the API key")
+	cmd.Flags().DurationVar(&expiry, "expiry", 0, "Validity of the token, 0 for no expiry")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+	return cmd
+}
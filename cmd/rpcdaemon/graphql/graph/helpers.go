@@ -1,10 +1,12 @@
 package graph
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	hexutil2 "github.com/erigontech/erigon-lib/common/hexutil"
 
@@ -12,10 +14,19 @@ import (
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/common/length"
+	types2 "github.com/erigontech/erigon-lib/types"
 
+	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql/graph/model"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/adapter/ethapi"
+	"github.com/erigontech/erigon/turbo/jsonrpc"
 )
 
+var pendingBlockNrOrHash = rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+
 func convertDataToStringP(abstractMap map[string]interface{}, field string) *string {
 	var result string
 
@@ -111,6 +122,13 @@ func convertDataToUint64P(abstractMap map[string]interface{}, field string) *uin
 		}
 	case *hexutil2.Big:
 		result = v.ToInt().Uint64()
+	case string:
+		resultUint, err := hexutil2.DecodeUint64(v)
+		if err != nil {
+			result = 0
+		} else {
+			result = resultUint
+		}
 	case int:
 		result = abstractMap[field].(uint64)
 	case uint64:
@@ -135,3 +153,198 @@ func convertStrHexToDec(hexString *string) *string {
 
 	return &result
 }
+
+// blockNrOrHash selects the state after the given block.
+func blockNrOrHash(block *model.Block) rpc.BlockNumberOrHash {
+	if hash, err := hexutil2.Decode(block.Hash); err == nil && len(hash) == length.Hash {
+		return rpc.BlockNumberOrHashWithHash(libcommon.BytesToHash(hash), false)
+	}
+	return rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.Number))
+}
+
+func convertLogs(logs types.Logs, state rpc.BlockNumberOrHash) []*model.Log {
+	result := make([]*model.Log, 0, len(logs))
+	for _, rlog := range logs {
+		index := int(rlog.TxIndex)
+		tlog := &model.Log{
+			Index: int(rlog.Index),
+			Data:  "0x" + hex.EncodeToString(rlog.Data),
+			Account: &model.Account{
+				Address:       strings.ToLower(rlog.Address.String()),
+				BlockNrOrHash: state,
+			},
+			Topics: make([]string, 0, len(rlog.Topics)),
+			Transaction: &model.Transaction{
+				Hash:  rlog.TxHash.String(),
+				Index: &index,
+			},
+		}
+		for _, rtopic := range rlog.Topics {
+			tlog.Topics = append(tlog.Topics, rtopic.String())
+		}
+		result = append(result, tlog)
+	}
+	return result
+}
+
+func convertFilterCriteria(addresses []string, topics [][]string) (filters.FilterCriteria, error) {
+	var crit filters.FilterCriteria
+	for _, address := range addresses {
+		if !libcommon.IsHexAddress(address) {
+			return crit, fmt.Errorf("invalid address: %s", address)
+		}
+		crit.Addresses = append(crit.Addresses, libcommon.HexToAddress(address))
+	}
+	for _, position := range topics {
+		var hashes []libcommon.Hash
+		for _, topic := range position {
+			hashes = append(hashes, libcommon.HexToHash(topic))
+		}
+		crit.Topics = append(crit.Topics, hashes)
+	}
+	return crit, nil
+}
+
+func convertCallData(data model.CallData) (ethapi.CallArgs, error) {
+	var args ethapi.CallArgs
+	var err error
+
+	if data.From != nil {
+		from := libcommon.HexToAddress(*data.From)
+		args.From = &from
+	}
+	if data.To != nil {
+		to := libcommon.HexToAddress(*data.To)
+		args.To = &to
+	}
+	if data.Gas != nil {
+		args.Gas = (*hexutil2.Uint64)(data.Gas)
+	}
+	for _, field := range []struct {
+		value *string
+		dst   **hexutil2.Big
+	}{
+		{data.GasPrice, &args.GasPrice},
+		{data.MaxFeePerGas, &args.MaxFeePerGas},
+		{data.MaxPriorityFeePerGas, &args.MaxPriorityFeePerGas},
+		{data.Value, &args.Value},
+	} {
+		if field.value == nil {
+			continue
+		}
+		value, err := hexutil2.DecodeBig(*field.value)
+		if err != nil {
+			return args, err
+		}
+		*field.dst = (*hexutil2.Big)(value)
+	}
+	if data.Data != nil {
+		input, err := hexutil2.Decode(*data.Data)
+		if err != nil {
+			return args, err
+		}
+		args.Data = (*hexutility.Bytes)(&input)
+	}
+	return args, err
+}
+
+// convertTransaction converts a transaction and its receipt, which is nil
+// for pending transactions.
+func convertTransaction(txn *jsonrpc.RPCTransaction, receipt map[string]interface{}, state rpc.BlockNumberOrHash) *model.Transaction {
+	trans := &model.Transaction{
+		Hash:      txn.Hash.String(),
+		Nonce:     txn.Nonce.String(),
+		From:      &model.Account{Address: strings.ToLower(txn.From.String()), BlockNrOrHash: state},
+		Value:     txn.Value.String(),
+		Gas:       uint64(txn.Gas),
+		InputData: txn.Input.String(),
+		R:         txn.R.String(),
+		S:         txn.S.String(),
+		V:         txn.V.String(),
+	}
+	if txn.TransactionIndex != nil {
+		index := int(*txn.TransactionIndex)
+		trans.Index = &index
+	}
+	if txn.To != nil {
+		trans.To = &model.Account{Address: strings.ToLower(txn.To.String()), BlockNrOrHash: state}
+	}
+	if txn.GasPrice != nil {
+		trans.GasPrice = txn.GasPrice.String()
+	}
+	if txn.FeeCap != nil {
+		maxFeePerGas := txn.FeeCap.String()
+		trans.MaxFeePerGas = &maxFeePerGas
+	}
+	if txn.Tip != nil {
+		maxPriorityFeePerGas := txn.Tip.String()
+		trans.MaxPriorityFeePerGas = &maxPriorityFeePerGas
+	}
+	txType := int(txn.Type)
+	trans.Type = &txType
+	if txn.Accesses != nil {
+		trans.AccessList = convertAccessList(*txn.Accesses)
+	}
+
+	if receipt == nil {
+		return trans
+	}
+	trans.Status = convertDataToUint64P(receipt, "status")
+	trans.GasUsed = convertDataToUint64P(receipt, "gasUsed")
+	trans.CumulativeGasUsed = convertDataToUint64P(receipt, "cumulativeGasUsed")
+	trans.EffectiveGasPrice = convertDataToStringP(receipt, "effectiveGasPrice")
+	if trans.GasPrice == "" {
+		trans.GasPrice = *trans.EffectiveGasPrice
+	}
+	if contract, ok := receipt["contractAddress"].(libcommon.Address); ok {
+		trans.CreatedContract = &model.Account{Address: strings.ToLower(contract.String()), BlockNrOrHash: state}
+	}
+	trans.Logs = make([]*model.Log, 0)
+	if logs, ok := receipt["logs"].(types.Logs); ok {
+		trans.Logs = convertLogs(logs, state)
+		for _, tlog := range trans.Logs {
+			tlog.Transaction = trans
+		}
+	}
+	return trans
+}
+
+func convertAccessList(accessList types2.AccessList) []*model.AccessTuple {
+	result := make([]*model.AccessTuple, 0, len(accessList))
+	for _, tuple := range accessList {
+		storageKeys := make([]string, 0, len(tuple.StorageKeys))
+		for _, key := range tuple.StorageKeys {
+			storageKeys = append(storageKeys, key.String())
+		}
+		result = append(result, &model.AccessTuple{
+			Address:     strings.ToLower(tuple.Address.String()),
+			StorageKeys: storageKeys,
+		})
+	}
+	return result
+}
+
+func (r *Resolver) call(ctx context.Context, data model.CallData, state rpc.BlockNumberOrHash) (*model.CallResult, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.GraphQLAPI.Call(ctx, args, state)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	return &model.CallResult{
+		Data:    res.Data.String(),
+		GasUsed: uint64(res.GasUsed),
+		Status:  uint64(res.Status),
+	}, nil
+}
+
+func (r *Resolver) estimateGas(ctx context.Context, data model.CallData, state rpc.BlockNumberOrHash) (uint64, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := r.GraphQLAPI.EstimateGas(ctx, args, state)
+	return uint64(gas), err
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"github.com/erigontech/erigon/rpc"
+)
+
+// Account is bound to the schema instead of being generated, so that its
+// field resolvers know the state they are reading from.
+type Account struct {
+	Address string `json:"address"`
+	// BlockNrOrHash is the block whose state the balance, transaction count,
+	// code and storage are read from.
+	BlockNrOrHash rpc.BlockNumberOrHash `json:"-"`
+}
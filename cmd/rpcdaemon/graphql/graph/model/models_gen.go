@@ -7,14 +7,6 @@ type AccessTuple struct {
 	StorageKeys []string `json:"storageKeys"`
 }
 
-type Account struct {
-	Address          string `json:"address"`
-	Balance          string `json:"balance"`
-	TransactionCount uint64 `json:"transactionCount"`
-	Code             string `json:"code"`
-	Storage          string `json:"storage"`
-}
-
 type Block struct {
 	Number            uint64         `json:"number"`
 	Hash              string         `json:"hash"`
@@ -37,14 +29,8 @@ type Block struct {
 	TotalDifficulty   string         `json:"totalDifficulty"`
 	OmmerCount        *int           `json:"ommerCount,omitempty"`
 	Ommers            []*Block       `json:"ommers,omitempty"`
-	OmmerAt           *Block         `json:"ommerAt,omitempty"`
 	OmmerHash         string         `json:"ommerHash"`
 	Transactions      []*Transaction `json:"transactions,omitempty"`
-	TransactionAt     *Transaction   `json:"transactionAt,omitempty"`
-	Logs              []*Log         `json:"logs"`
-	Account           *Account       `json:"account"`
-	Call              *CallResult    `json:"call,omitempty"`
-	EstimateGas       uint64         `json:"estimateGas"`
 	RawHeader         string         `json:"rawHeader"`
 	Raw               string         `json:"raw"`
 	Withdrawals       []*Withdrawal  `json:"withdrawals,omitempty"`
@@ -93,9 +79,6 @@ type Mutation struct {
 type Pending struct {
 	TransactionCount int            `json:"transactionCount"`
 	Transactions     []*Transaction `json:"transactions,omitempty"`
-	Account          *Account       `json:"account"`
-	Call             *CallResult    `json:"call,omitempty"`
-	EstimateGas      uint64         `json:"estimateGas"`
 }
 
 type Query struct {
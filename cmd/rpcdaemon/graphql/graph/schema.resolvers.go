@@ -6,8 +6,7 @@ package graph
 
 import (
 	"context"
-	"encoding/hex"
-	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -18,9 +17,118 @@ import (
 	"github.com/erigontech/erigon/rpc"
 )
 
+// Balance is the resolver for the balance field.
+func (r *accountResolver) Balance(ctx context.Context, obj *model.Account) (string, error) {
+	balance, err := r.GraphQLAPI.GetBalance(ctx, common.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	return balance.String(), nil
+}
+
+// TransactionCount is the resolver for the transactionCount field.
+func (r *accountResolver) TransactionCount(ctx context.Context, obj *model.Account) (uint64, error) {
+	count, err := r.GraphQLAPI.GetTransactionCount(ctx, common.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*count), nil
+}
+
+// Code is the resolver for the code field.
+func (r *accountResolver) Code(ctx context.Context, obj *model.Account) (string, error) {
+	code, err := r.GraphQLAPI.GetCode(ctx, common.HexToAddress(obj.Address), obj.BlockNrOrHash)
+	if err != nil {
+		return "", err
+	}
+	return code.String(), nil
+}
+
+// Storage is the resolver for the storage field.
+func (r *accountResolver) Storage(ctx context.Context, obj *model.Account, slot string) (string, error) {
+	return r.GraphQLAPI.GetStorageAt(ctx, common.HexToAddress(obj.Address), slot, obj.BlockNrOrHash)
+}
+
+// OmmerAt is the resolver for the ommerAt field.
+func (r *blockResolver) OmmerAt(ctx context.Context, obj *model.Block, index int) (*model.Block, error) {
+	if index < 0 || index >= len(obj.Ommers) {
+		return nil, nil
+	}
+	return obj.Ommers[index], nil
+}
+
+// TransactionAt is the resolver for the transactionAt field.
+func (r *blockResolver) TransactionAt(ctx context.Context, obj *model.Block, index int) (*model.Transaction, error) {
+	if index < 0 || index >= len(obj.Transactions) {
+		return nil, nil
+	}
+	return obj.Transactions[index], nil
+}
+
+// Logs is the resolver for the logs field.
+func (r *blockResolver) Logs(ctx context.Context, obj *model.Block, filter model.BlockFilterCriteria) ([]*model.Log, error) {
+	blockHash := common.HexToHash(obj.Hash)
+	crit, err := convertFilterCriteria(filter.Addresses, filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	crit.BlockHash = &blockHash
+
+	logs, err := r.GraphQLAPI.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := convertLogs(logs, blockNrOrHash(obj))
+	for _, log := range result {
+		if i := *log.Transaction.Index; i < len(obj.Transactions) {
+			log.Transaction = obj.Transactions[i]
+		}
+	}
+	return result, nil
+}
+
+// Account is the resolver for the account field.
+func (r *blockResolver) Account(ctx context.Context, obj *model.Block, address string) (*model.Account, error) {
+	return &model.Account{Address: strings.ToLower(address), BlockNrOrHash: blockNrOrHash(obj)}, nil
+}
+
+// Call is the resolver for the call field.
+func (r *blockResolver) Call(ctx context.Context, obj *model.Block, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, blockNrOrHash(obj))
+}
+
+// EstimateGas is the resolver for the estimateGas field.
+func (r *blockResolver) EstimateGas(ctx context.Context, obj *model.Block, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, blockNrOrHash(obj))
+}
+
 // SendRawTransaction is the resolver for the sendRawTransaction field.
 func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
-	panic("not implemented: SendRawTransaction - sendRawTransaction")
+	encodedTx, err := hexutil.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	hash, err := r.GraphQLAPI.SendRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// Account is the resolver for the account field.
+func (r *pendingResolver) Account(ctx context.Context, obj *model.Pending, address string) (*model.Account, error) {
+	return &model.Account{Address: strings.ToLower(address), BlockNrOrHash: pendingBlockNrOrHash}, nil
+}
+
+// Call is the resolver for the call field.
+func (r *pendingResolver) Call(ctx context.Context, obj *model.Pending, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, pendingBlockNrOrHash)
+}
+
+// EstimateGas is the resolver for the estimateGas field.
+func (r *pendingResolver) EstimateGas(ctx context.Context, obj *model.Pending, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, pendingBlockNrOrHash)
 }
 
 // Block is the resolver for the block field.
@@ -43,12 +151,6 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 				return nil, err
 			}
 		}
-	} else {
-		if hash != nil {
-			blockHash, _ := hexutil.DecodeBig(*hash)
-			fmt.Println("TODO/GraphQL/Implement me, get Block by hash=", blockHash)
-			hash = nil
-		}
 	}
 
 	if number == nil && hash == nil {
@@ -63,121 +165,121 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 		blockNumber = rpc.LatestBlockNumber
 	}
 
-	res, err := r.GraphQLAPI.GetBlockDetails(ctx, blockNumber)
+	var res map[string]interface{}
+	var err error
+	if number == nil && hash != nil {
+		res, err = r.GraphQLAPI.GetBlockDetailsByHash(ctx, common.HexToHash(*hash))
+	} else {
+		res, err = r.GraphQLAPI.GetBlockDetails(ctx, blockNumber)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	block := &model.Block{}
 	absBlk := res["block"]
+	if absBlk == nil {
+		return nil, ctx.Err()
+	}
+	blk := absBlk.(map[string]interface{})
+
+	block.Difficulty = *convertDataToStringP(blk, "difficulty")
+	block.ExtraData = *convertDataToStringP(blk, "extraData")
+	block.GasLimit = uint64(*convertDataToUint64P(blk, "gasLimit"))
+	block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
+	block.Number = *convertDataToUint64P(blk, "number")
+	block.Hash = *convertDataToStringP(blk, "hash")
+	block.Miner = &model.Account{BlockNrOrHash: blockNrOrHash(block)}
+	address := convertDataToStringP(blk, "miner")
+	if address != nil {
+		block.Miner.Address = strings.ToLower(*address)
+	}
+	mixHash := convertDataToStringP(blk, "mixHash")
+	if mixHash != nil {
+		block.MixHash = *mixHash
+	}
+	blockNonce := convertDataToStringP(blk, "nonce")
+	if blockNonce != nil {
+		block.Nonce = *blockNonce
+	}
+	block.Parent = &model.Block{}
+	block.Parent.Hash = *convertDataToStringP(blk, "parentHash")
+	block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
+	block.StateRoot = *convertDataToStringP(blk, "stateRoot")
+	block.Timestamp = *convertDataToStringP(blk, "timestamp")
+	block.TransactionCount = convertDataToIntP(blk, "transactionCount")
+	block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
+	block.TotalDifficulty = *convertDataToStringP(blk, "totalDifficulty")
+	block.BaseFeePerGas = convertDataToStringP(blk, "baseFeePerGas")
+	block.Transactions = []*model.Transaction{}
+
+	block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
+	block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
+
+	// Ommers
+	block.Ommers = []*model.Block{}
+	for _, ommerHash := range blk["uncles"].([]common.Hash) {
+		block.Ommers = append(block.Ommers, &model.Block{Hash: ommerHash.String()})
+	}
 
-	if absBlk != nil {
-		blk := absBlk.(map[string]interface{})
-
-		block.Difficulty = *convertDataToStringP(blk, "difficulty")
-		block.ExtraData = *convertDataToStringP(blk, "extraData")
-		block.GasLimit = uint64(*convertDataToUint64P(blk, "gasLimit"))
-		block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
-		block.Hash = *convertDataToStringP(blk, "hash")
-		block.Miner = &model.Account{}
-		address := convertDataToStringP(blk, "miner")
-		if address != nil {
-			block.Miner.Address = strings.ToLower(*address)
+	ommerCount := len(block.Ommers)
+	block.OmmerCount = &ommerCount
+
+	// Transactions
+	absRcp := res["receipts"]
+	rcp := absRcp.([]map[string]interface{})
+	for _, transReceipt := range rcp {
+		trans := &model.Transaction{}
+		trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
+		trans.InputData = *convertDataToStringP(transReceipt, "data")
+		trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
+		trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
+		trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
+		trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
+		trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
+		transNonce := convertDataToStringP(transReceipt, "nonce")
+		if transNonce != nil {
+			trans.Nonce = *transNonce
 		}
-		mixHash := convertDataToStringP(blk, "mixHash")
-		if mixHash != nil {
-			block.MixHash = *mixHash
-		}
-		blockNonce := convertDataToStringP(blk, "nonce")
-		if blockNonce != nil {
-			block.Nonce = *blockNonce
-		}
-		block.Number = *convertDataToUint64P(blk, "number")
-		block.Parent = &model.Block{}
-		block.Parent.Hash = *convertDataToStringP(blk, "parentHash")
-		block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
-		block.StateRoot = *convertDataToStringP(blk, "stateRoot")
-		block.Timestamp = *convertDataToStringP(blk, "timestamp")
-		block.TransactionCount = convertDataToIntP(blk, "transactionCount")
-		block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
-		block.TotalDifficulty = *convertDataToStringP(blk, "totalDifficulty")
-		block.BaseFeePerGas = convertDataToStringP(blk, "baseFeePerGas")
-		block.Transactions = []*model.Transaction{}
-
-		block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
-		block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
-
-		// Ommers
-		block.Ommers = []*model.Block{}
-		for _, ommerHash := range blk["uncles"].([]common.Hash) {
-			block.Ommers = append(block.Ommers, &model.Block{Hash: ommerHash.String()})
-		}
-
-		ommerCount := len(block.Ommers)
-		block.OmmerCount = &ommerCount
-
-		// Transactions
-		absRcp := res["receipts"]
-		rcp := absRcp.([]map[string]interface{})
-		for _, transReceipt := range rcp {
-			trans := &model.Transaction{}
-			trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
-			trans.InputData = *convertDataToStringP(transReceipt, "data")
-			trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
-			trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
-			trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
-			transNonce := convertDataToStringP(transReceipt, "nonce")
-			if transNonce != nil {
-				trans.Nonce = *transNonce
-			}
-			trans.Status = convertDataToUint64P(transReceipt, "status")
-			trans.Type = convertDataToIntP(transReceipt, "type")
-			trans.Value = *convertDataToStringP(transReceipt, "value")
-
-			trans.Logs = make([]*model.Log, 0)
-			for _, rlog := range transReceipt["logs"].(types.Logs) {
-				tlog := model.Log{
-					Index: int(rlog.Index),
-					Data:  "0x" + hex.EncodeToString(rlog.Data),
-				}
-				tlog.Account = &model.Account{}
-				tlog.Account.Address = strings.ToLower(rlog.Address.String())
-
-				for _, rtopic := range rlog.Topics {
-					tlog.Topics = append(tlog.Topics, rtopic.String())
-				}
-
-				trans.Logs = append(trans.Logs, &tlog)
-			}
+		trans.Status = convertDataToUint64P(transReceipt, "status")
+		trans.Type = convertDataToIntP(transReceipt, "type")
+		trans.Value = *convertDataToStringP(transReceipt, "value")
 
-			trans.From = &model.Account{}
-			trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+		trans.Block = block
 
-			trans.To = &model.Account{}
-			address := convertDataToStringP(transReceipt, "to")
-			// To address could be nil in case of contract creation
-			if address != nil {
-				trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
+		trans.Logs = make([]*model.Log, 0)
+		if logs, ok := transReceipt["logs"].(types.Logs); ok {
+			trans.Logs = convertLogs(logs, blockNrOrHash(block))
+			for _, tlog := range trans.Logs {
+				tlog.Transaction = trans
 			}
-
-			block.Transactions = append(block.Transactions, trans)
 		}
 
-		// Withdrawals
-		block.Withdrawals = []*model.Withdrawal{}
-		absWthd := res["withdrawals"]
-		wthd := absWthd.([]map[string]interface{})
-		for _, withdrawal := range wthd {
-			wthd := &model.Withdrawal{}
-			wthd.Index = *convertDataToIntP(withdrawal, "index")
-			wthd.Validator = *convertDataToIntP(withdrawal, "validator")
-			wthd.Address = *convertDataToStringP(withdrawal, "address")
-			wthd.Amount = *convertDataToStringP(withdrawal, "amount")
-
-			block.Withdrawals = append(block.Withdrawals, wthd)
+		trans.From = &model.Account{BlockNrOrHash: blockNrOrHash(block)}
+		trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+
+		trans.To = &model.Account{BlockNrOrHash: blockNrOrHash(block)}
+		address := convertDataToStringP(transReceipt, "to")
+		// To address could be nil in case of contract creation
+		if address != nil {
+			trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
 		}
+
+		block.Transactions = append(block.Transactions, trans)
+	}
+
+	// Withdrawals
+	block.Withdrawals = []*model.Withdrawal{}
+	absWthd := res["withdrawals"]
+	wthd := absWthd.([]map[string]interface{})
+	for _, withdrawal := range wthd {
+		wthd := &model.Withdrawal{}
+		wthd.Index = *convertDataToIntP(withdrawal, "index")
+		wthd.Validator = *convertDataToIntP(withdrawal, "validator")
+		wthd.Address = *convertDataToStringP(withdrawal, "address")
+		wthd.Amount = *convertDataToStringP(withdrawal, "amount")
+
+		block.Withdrawals = append(block.Withdrawals, wthd)
 	}
 
 	return block, ctx.Err()
@@ -208,32 +310,125 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 // Pending is the resolver for the pending field.
 func (r *queryResolver) Pending(ctx context.Context) (*model.Pending, error) {
-	panic("not implemented: Pending - pending")
+	txns, err := r.GraphQLAPI.GetPendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &model.Pending{Transactions: make([]*model.Transaction, 0, len(txns))}
+	for _, txn := range txns {
+		pending.Transactions = append(pending.Transactions, convertTransaction(txn, nil, pendingBlockNrOrHash))
+	}
+	pending.TransactionCount = len(pending.Transactions)
+
+	return pending, ctx.Err()
 }
 
 // Transaction is the resolver for the transaction field.
 func (r *queryResolver) Transaction(ctx context.Context, hash string) (*model.Transaction, error) {
-	panic("not implemented: Transaction - transaction")
+	txnHash := common.HexToHash(hash)
+	txn, err := r.GraphQLAPI.GetTransactionByHash(ctx, txnHash)
+	if err != nil || txn == nil {
+		return nil, err
+	}
+
+	// Transactions still in the pool have neither a block nor a receipt
+	if txn.BlockHash == nil {
+		return convertTransaction(txn, nil, pendingBlockNrOrHash), nil
+	}
+
+	receipt, err := r.GraphQLAPI.GetTransactionReceipt(ctx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash := txn.BlockHash.String()
+	block, err := r.Block(ctx, nil, &blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	trans := convertTransaction(txn, receipt, rpc.BlockNumberOrHashWithHash(*txn.BlockHash, false))
+	trans.Block = block
+	return trans, ctx.Err()
 }
 
 // Logs is the resolver for the logs field.
 func (r *queryResolver) Logs(ctx context.Context, filter model.FilterCriteria) ([]*model.Log, error) {
-	panic("not implemented: Logs - logs")
+	crit, err := convertFilterCriteria(filter.Addresses, filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	if filter.FromBlock != nil {
+		crit.FromBlock = new(big.Int).SetUint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*filter.ToBlock)
+	}
+
+	logs, err := r.GraphQLAPI.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Log, 0, len(logs))
+	txns := make(map[common.Hash]*model.Transaction)
+	for _, log := range logs {
+		converted := convertLogs(types.Logs{log}, rpc.BlockNumberOrHashWithHash(log.BlockHash, false))[0]
+
+		// Logs only know the hash and index of their transaction
+		txn, ok := txns[log.TxHash]
+		if !ok {
+			txn, err = r.Transaction(ctx, log.TxHash.String())
+			if err != nil {
+				return nil, err
+			}
+			txns[log.TxHash] = txn
+		}
+		if txn != nil {
+			converted.Transaction = txn
+		}
+		result = append(result, converted)
+	}
+	return result, ctx.Err()
 }
 
 // GasPrice is the resolver for the gasPrice field.
 func (r *queryResolver) GasPrice(ctx context.Context) (string, error) {
-	panic("not implemented: GasPrice - gasPrice")
+	price, err := r.GraphQLAPI.GasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+	return price.String(), nil
 }
 
 // MaxPriorityFeePerGas is the resolver for the maxPriorityFeePerGas field.
 func (r *queryResolver) MaxPriorityFeePerGas(ctx context.Context) (string, error) {
-	panic("not implemented: MaxPriorityFeePerGas - maxPriorityFeePerGas")
+	tip, err := r.GraphQLAPI.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tip.String(), nil
 }
 
 // Syncing is the resolver for the syncing field.
 func (r *queryResolver) Syncing(ctx context.Context) (*model.SyncState, error) {
-	panic("not implemented: Syncing - syncing")
+	res, err := r.GraphQLAPI.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, ok := res.(map[string]interface{})
+	if !ok {
+		// Not syncing
+		return nil, nil
+	}
+
+	return &model.SyncState{
+		StartingBlock: *convertDataToUint64P(progress, "startingBlock"),
+		CurrentBlock:  *convertDataToUint64P(progress, "currentBlock"),
+		HighestBlock:  *convertDataToUint64P(progress, "highestBlock"),
+	}, nil
 }
 
 // ChainID is the resolver for the chainID field.
@@ -243,11 +438,23 @@ func (r *queryResolver) ChainID(ctx context.Context) (string, error) {
 	return "0x" + strconv.FormatUint(chainID.Uint64(), 16), err
 }
 
+// Account returns AccountResolver implementation.
+func (r *Resolver) Account() AccountResolver { return &accountResolver{r} }
+
+// Block returns BlockResolver implementation.
+func (r *Resolver) Block() BlockResolver { return &blockResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Pending returns PendingResolver implementation.
+func (r *Resolver) Pending() PendingResolver { return &pendingResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+type accountResolver struct{ *Resolver }
+type blockResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type pendingResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -17,13 +17,91 @@
 package graphql
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/rlp"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/turbo/jsonrpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
 )
 
+// TestSchemaCoversGethSchema checks that every type, field and argument of the
+// geth schema, which EIP-1767 clients are written against, is served by ours.
+func TestSchemaCoversGethSchema(t *testing.T) {
+	// field types which deliberately differ from geth
+	deviations := map[string]string{
+		"Transaction.nonce":  "BigInt!",
+		"Block.nonce":        "BigInt!",
+		"Block.timestamp":    "BigInt!",
+		"Query.block.number": "BlockNum",
+	}
+
+	load := func(name string) *ast.Schema {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		schema, err := gqlparser.LoadSchema(&ast.Source{Name: name, Input: string(data)})
+		require.NoError(t, err)
+		return schema
+	}
+	ours := load("graph/schema.graphqls")
+	geth := load("geth-schema.graphqls.ref")
+
+	sameType := func(name string, want, have *ast.Type) {
+		if deviation, ok := deviations[name]; ok {
+			require.Equal(t, deviation, have.String(), name)
+			return
+		}
+		require.Equal(t, want.String(), have.String(), name)
+	}
+
+	for typeName, gethType := range geth.Types {
+		if gethType.BuiltIn {
+			continue
+		}
+		ourType := ours.Types[typeName]
+		require.NotNil(t, ourType, "type %s is missing", typeName)
+		require.Equal(t, gethType.Kind, ourType.Kind, typeName)
+
+		for _, gethField := range gethType.Fields {
+			name := typeName + "." + gethField.Name
+			ourField := ourType.Fields.ForName(gethField.Name)
+			require.NotNil(t, ourField, "field %s is missing", name)
+			sameType(name, gethField.Type, ourField.Type)
+
+			for _, gethArg := range gethField.Arguments {
+				argName := name + "." + gethArg.Name
+				ourArg := ourField.Arguments.ForName(gethArg.Name)
+				require.NotNil(t, ourArg, "argument %s is missing", argName)
+				sameType(argName, gethArg.Type, ourArg.Type)
+			}
+		}
+	}
+}
+
 func TestGraphQLQueryBlock(t *testing.T) {
 	t.Skip("Not a unit test")
 
@@ -118,22 +196,29 @@ func TestGraphQLQueryBlock(t *testing.T) {
 			code: 200,
 			comp: "regexp",
 		},
-		// should return `estimateGas` as decimal
-		/*
-			{
-				body: `{"query": "{block{ estimateGas(data:{}) }}"}`,
-				want: `{"data":{"block":{"estimateGas":53000}}}`,
-				code: 200,
-			},
-		*/
-		// should return `status` as decimal
-		/*
-			{
-				body: `{"query": "{block {number call (data : {from : \"0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b\", to: \"0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\", data :\"0x12a7b914\"}){data status}}}"}`,
-				want: `{"data":{"block":{"number":10,"call":{"data":"0x","status":1}}}}`,
-				code: 200,
-			},
-		*/
+		{ // should return `estimateGas` as decimal
+			body: `{"query": "{block{ estimateGas(data:{}) }}"}`,
+			want: `{"data":{"block":{"estimateGas":53000}}}`,
+			code: 200,
+		},
+		{ // should return `status` as decimal
+			body: `{"query": "{block {number call (data : {from : \"0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b\", to: \"0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\", data :\"0x12a7b914\"}){data status}}}"}`,
+			want: `{"data":{"block":{"number":\d+,"call":{"data":"0x","status":1}}}}`,
+			code: 200,
+			comp: "regexp",
+		},
+		{ // Should return the balance of an account at a block
+			body: `{"query": "{block(number:0){account(address:\"0x0000000000000000000000000000000000000000\"){balance}}}"}`,
+			want: `{"data":{"block":{"account":{"balance":"0x[0-9a-f]+"}}}}`,
+			code: 200,
+			comp: "regexp",
+		},
+		{ // Should return the gas price
+			body: `{"query": "{gasPrice}"}`,
+			want: `{"data":{"gasPrice":"0x[0-9a-f]+"}}`,
+			code: 200,
+			comp: "regexp",
+		},
 	} {
 		resp, err := http.Post("http://localhost:8545/graphql", "application/json", strings.NewReader(tt.body))
 		if err != nil {
@@ -159,3 +244,124 @@ func TestGraphQLQueryBlock(t *testing.T) {
 		}
 	}
 }
+
+// TestGraphQLResolvers runs queries against the resolvers backed by the eth API
+// of a mock chain.
+func TestGraphQLResolvers(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signer := types.LatestSigner(m.ChainConfig)
+
+	// a pending block with a single transfer
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pendingTxn, err := types.SignTx(types.NewTransaction(7, libcommon.Address{0x12, 0x34}, uint256.NewInt(5), 21000, uint256.NewInt(1), nil), *signer, key)
+	require.NoError(t, err)
+	rlpBlock, err := rlp.EncodeToBytes(types.NewBlock(&types.Header{Number: big.NewInt(12)}, []types.Transaction{pendingTxn}, nil, nil, nil, nil))
+	require.NoError(t, err)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, nil, nil, func() {}, m.Log)
+	ff.HandlePendingBlock(&txpoolproto.OnPendingBlockReply{RplBlock: rlpBlock})
+
+	base := jsonrpc.NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+	eth := jsonrpc.NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Namespace: "graphql", Service: jsonrpc.NewGraphQLAPI(base, m.DB, eth)}}))
+	defer server.Close()
+
+	// block 3 deploys a token which mints 10 tokens in block 4, block 10 emits a log
+	token := chain.Receipts[2][0].ContractAddress
+	mint := chain.Blocks[3].Transactions()[0]
+	logTxn := chain.Blocks[9].Transactions()[0]
+	logReceipt := chain.Receipts[9][0]
+	require.Len(t, logReceipt.Logs, 1)
+	log0 := logReceipt.Logs[0]
+
+	mintFrom, err := mint.Sender(*signer)
+	require.NoError(t, err)
+	logFrom, err := logTxn.Sender(*signer)
+	require.NoError(t, err)
+	genesisAlloc, err := eth.GetBalance(ctx, mintFrom, rpc.BlockNumberOrHashWithNumber(0))
+	require.NoError(t, err)
+	code, err := eth.GetCode(ctx, token, rpc.BlockNumberOrHashWithNumber(4))
+	require.NoError(t, err)
+	// balanceOf(address2), the mint receiver
+	mintTo := libcommon.BytesToAddress(mint.GetData()[4:36])
+	balanceOf := "0x70a08231" + strings.Repeat("00", 12) + hex.EncodeToString(mintTo[:])
+
+	lower := func(v fmt.Stringer) string { return strings.ToLower(v.String()) }
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "transaction",
+			body: fmt.Sprintf(`{"query": "{transaction(hash:\"%s\"){hash nonce index from{address} to{address} value gas status gasUsed block{number} logs{index topics data account{address}}}}"}`, logTxn.Hash()),
+			want: fmt.Sprintf(`{"data":{"transaction":{"hash":"%s","nonce":"%#x","index":0,"from":{"address":"%s"},"to":{"address":"%s"},"value":"0x0","gas":%d,"status":1,"gasUsed":%d,"block":{"number":10},"logs":[{"index":0,"topics":["%s"],"data":"%#x","account":{"address":"%s"}}]}}}`,
+				logTxn.Hash(), logTxn.GetNonce(), lower(logFrom), lower(logTxn.GetTo()), logTxn.GetGas(), logReceipt.GasUsed, log0.Topics[0], log0.Data, lower(log0.Address)),
+		},
+		{
+			name: "transaction without logs",
+			body: fmt.Sprintf(`{"query": "{transaction(hash:\"%s\"){from{address} block{number} status logs{index}}}"}`, mint.Hash()),
+			want: fmt.Sprintf(`{"data":{"transaction":{"from":{"address":"%s"},"block":{"number":4},"status":1,"logs":[]}}}`, lower(mintFrom)),
+		},
+		{
+			name: "logs",
+			body: fmt.Sprintf(`{"query": "{logs(filter:{fromBlock:0, toBlock:11, addresses:[\"%s\"]}){index topics transaction{hash block{number}}}}"}`, log0.Address),
+			want: fmt.Sprintf(`{"data":{"logs":[{"index":0,"topics":["%s"],"transaction":{"hash":"%s","block":{"number":10}}}]}}`, log0.Topics[0], logTxn.Hash()),
+		},
+		{
+			name: "logs of a block",
+			body: fmt.Sprintf(`{"query": "{block(number:\"10\"){logs(filter:{topics:[[\"%s\"]]}){account{address}}}}"}`, log0.Topics[0]),
+			want: fmt.Sprintf(`{"data":{"block":{"logs":[{"account":{"address":"%s"}}]}}}`, lower(log0.Address)),
+		},
+		{
+			name: "logs of another address",
+			body: fmt.Sprintf(`{"query": "{logs(filter:{fromBlock:0, toBlock:11, addresses:[\"%s\"]}){index}}"}`, token),
+			want: `{"data":{"logs":[]}}`,
+		},
+		{
+			name: "account at genesis",
+			body: fmt.Sprintf(`{"query": "{block(number:\"0\"){account(address:\"%s\"){address balance transactionCount}}}"}`, mintFrom),
+			want: fmt.Sprintf(`{"data":{"block":{"account":{"address":"%s","balance":"%s","transactionCount":0}}}}`, lower(mintFrom), genesisAlloc),
+		},
+		{
+			name: "contract account",
+			body: fmt.Sprintf(`{"query": "{block(number:\"4\"){account(address:\"%s\"){balance transactionCount code storage(slot:\"0x0000000000000000000000000000000000000000000000000000000000000000\")}}}"}`, token),
+			want: fmt.Sprintf(`{"data":{"block":{"account":{"balance":"0x0","transactionCount":1,"code":"%s","storage":"0x000000000000000000000000000000000000000000000000000000000000000a"}}}}`, code),
+		},
+		{
+			name: "call",
+			body: fmt.Sprintf(`{"query": "{block(number:\"4\"){call(data:{to:\"%s\", data:\"%s\"}){data status}}}"}`, token, balanceOf),
+			want: `{"data":{"block":{"call":{"data":"0x000000000000000000000000000000000000000000000000000000000000000a","status":1}}}}`,
+		},
+		{
+			name: "reverted call",
+			body: fmt.Sprintf(`{"query": "{block{call(data:{to:\"%s\", data:\"0x12345678\"}){data status}}}"}`, token),
+			want: `{"data":{"block":{"call":{"data":"0x","status":0}}}}`,
+		},
+		{
+			name: "estimate gas",
+			body: fmt.Sprintf(`{"query": "{block{estimateGas(data:{from:\"%s\", to:\"0x0000000000000000000000000000000000001234\", value:\"0x1\"})}}"}`, mintFrom),
+			want: `{"data":{"block":{"estimateGas":21000}}}`,
+		},
+		{
+			name: "pending",
+			body: `{"query": "{pending{transactionCount transactions{hash nonce from{address} to{address} value block{number}}}}"}`,
+			want: fmt.Sprintf(`{"data":{"pending":{"transactionCount":1,"transactions":[{"hash":"%s","nonce":"0x7","from":{"address":"%s"},"to":{"address":"0x1234000000000000000000000000000000000000"},"value":"0x5","block":null}]}}}`,
+				pendingTxn.Hash(), lower(crypto.PubkeyToAddress(key.PublicKey))),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.Client().Post(server.URL, "application/json", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			have, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, tt.want, string(have))
+		})
+	}
+}
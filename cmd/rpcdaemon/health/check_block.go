@@ -24,17 +24,17 @@ import (
 	"github.com/erigontech/erigon/rpc"
 )
 
-func checkBlockNumber(blockNumber rpc.BlockNumber, api EthAPI) error {
+func checkBlockNumber(blockNumber rpc.BlockNumber, api EthAPI) (interface{}, error) {
 	if api == nil {
-		return errors.New("no connection to the Erigon server or `eth` namespace isn't enabled")
+		return nil, errors.New("no connection to the Erigon server or `eth` namespace isn't enabled")
 	}
 	data, err := api.GetBlockByNumber(context.TODO(), blockNumber, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(data) == 0 { // block not found
-		return fmt.Errorf("no known block with number %v (%x hex)", blockNumber.Uint64(), blockNumber.Uint64())
+		return nil, fmt.Errorf("no known block with number %v (%x hex)", blockNumber.Uint64(), blockNumber.Uint64())
 	}
 
-	return nil
+	return blockNumber.Uint64(), nil
 }
@@ -0,0 +1,50 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errDBTooSlow = errors.New("database read too slow")
+)
+
+// checkDBLatency measures the time of a read transaction through eth_blockNumber
+// and checks it against maxLatency. The measured value is the latency in
+// milliseconds.
+func checkDBLatency(ctx context.Context, maxLatency time.Duration, ethAPI EthAPI) (interface{}, error) {
+	if ethAPI == nil {
+		return nil, errors.New("no connection to the Erigon server or `eth` namespace isn't enabled")
+	}
+
+	start := time.Now()
+	if _, err := ethAPI.BlockNumber(ctx); err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+
+	ms := float64(latency) / float64(time.Millisecond)
+	if latency > maxLatency {
+		return ms, fmt.Errorf("%w: %s (maximum %s)", errDBTooSlow, latency, maxLatency)
+	}
+
+	return ms, nil
+}
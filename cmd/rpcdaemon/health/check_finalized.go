@@ -0,0 +1,76 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+
+	"github.com/erigontech/erigon/rpc"
+)
+
+var (
+	errFinalizedTooFarBehind = errors.New("finalized block too far behind")
+)
+
+// checkFinalizedLag checks that the finalized block is no more than maxLag
+// blocks behind the latest one. The measured value is the lag.
+func checkFinalizedLag(ctx context.Context, maxLag uint64, ethAPI EthAPI) (interface{}, error) {
+	if ethAPI == nil {
+		return nil, errors.New("no connection to the Erigon server or `eth` namespace isn't enabled")
+	}
+	latest, err := ethAPI.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	finalizedBlock, err := ethAPI.GetBlockByNumber(ctx, rpc.FinalizedBlockNumber, false)
+	if err != nil {
+		return nil, err
+	}
+	finalized, ok := blockNumberOf(finalizedBlock)
+	if !ok {
+		return nil, errors.New("no finalized block")
+	}
+
+	var lag uint64
+	if uint64(latest) > finalized {
+		lag = uint64(latest) - finalized
+	}
+	if lag > maxLag {
+		return lag, fmt.Errorf("%w: %d blocks (maximum %d)", errFinalizedTooFarBehind, lag, maxLag)
+	}
+
+	return lag, nil
+}
+
+func blockNumberOf(block map[string]interface{}) (uint64, bool) {
+	switch number := block["number"].(type) {
+	case *hexutil.Big:
+		if number == nil {
+			return 0, false
+		}
+		return number.ToInt().Uint64(), true
+	case hexutil.Uint64:
+		return uint64(number), true
+	case uint64:
+		return number, true
+	}
+	return 0, false
+}
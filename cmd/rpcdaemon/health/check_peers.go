@@ -26,19 +26,19 @@ var (
 	errNotEnoughPeers = errors.New("not enough peers")
 )
 
-func checkMinPeers(minPeerCount uint, api NetAPI) error {
+func checkMinPeers(minPeerCount uint, api NetAPI) (interface{}, error) {
 	if api == nil {
-		return errors.New("no connection to the Erigon server or `net` namespace isn't enabled")
+		return nil, errors.New("no connection to the Erigon server or `net` namespace isn't enabled")
 	}
 
 	peerCount, err := api.PeerCount(context.TODO())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if uint64(peerCount) < uint64(minPeerCount) {
-		return fmt.Errorf("%w: %d (minimum %d)", errNotEnoughPeers, peerCount, minPeerCount)
+		return uint64(peerCount), fmt.Errorf("%w: %d (minimum %d)", errNotEnoughPeers, peerCount, minPeerCount)
 	}
 
-	return nil
+	return uint64(peerCount), nil
 }
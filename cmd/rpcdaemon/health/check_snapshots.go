@@ -0,0 +1,83 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+)
+
+var (
+	errSnapshotsDownloading = errors.New("snapshots are still downloading")
+)
+
+// syncStatus is the part of the eth_syncing response the snapshots check needs.
+type syncStatus struct {
+	HighestBlock hexutil.Uint64 `json:"highestBlock"`
+	Stages       []struct {
+		StageName   string         `json:"stage_name"`
+		BlockNumber hexutil.Uint64 `json:"block_number"`
+	} `json:"stages"`
+}
+
+// checkSnapshots checks that the initial download of the snapshots is over,
+// based on eth_syncing: a synced node has all its snapshots, while a syncing one
+// has them once the snapshots stage has made progress. The measured value is the
+// progress of the snapshots stage, when known.
+func checkSnapshots(ctx context.Context, ethAPI EthAPI) (interface{}, error) {
+	if ethAPI == nil {
+		return nil, errors.New("no connection to the Erigon server or `eth` namespace isn't enabled")
+	}
+	i, err := ethAPI.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if i == nil || i == false {
+		return nil, nil
+	}
+
+	// the stages are an unexported type, so go through the json representation
+	data, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	var status syncStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, err
+	}
+	// the highest block is unknown while the snapshots are downloading
+	if uint64(status.HighestBlock) == math.MaxUint64 {
+		return nil, errSnapshotsDownloading
+	}
+	for _, stage := range status.Stages {
+		if stage.StageName != string(stages.Snapshots) {
+			continue
+		}
+		if stage.BlockNumber == 0 {
+			return uint64(0), errSnapshotsDownloading
+		}
+		return uint64(stage.BlockNumber), nil
+	}
+
+	return nil, errors.New("no snapshots stage in the sync status")
+}
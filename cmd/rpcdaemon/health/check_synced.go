@@ -27,15 +27,15 @@ var (
 	errNotSynced = errors.New("not synced")
 )
 
-func checkSynced(ethAPI EthAPI, r *http.Request) error {
+func checkSynced(ethAPI EthAPI, r *http.Request) (interface{}, error) {
 	i, err := ethAPI.Syncing(r.Context())
 	if err != nil {
 		log.Root().Warn("unable to process synced request", "err", err.Error())
-		return err
+		return nil, err
 	}
 	if i == nil || i == false {
-		return nil
+		return false, nil
 	}
 
-	return errNotSynced
+	return i, errNotSynced
 }
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon/rpc"
@@ -33,10 +34,10 @@ func checkTime(
 	r *http.Request,
 	seconds int,
 	ethAPI EthAPI,
-) error {
+) (interface{}, error) {
 	i, err := ethAPI.GetBlockByNumber(r.Context(), rpc.LatestBlockNumber, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	timestamp := 0
 	if ts, ok := i["timestamp"]; ok {
@@ -46,9 +47,11 @@ func checkTime(
 			timestamp = int(cs)
 		}
 	}
+	// the measured value is how many seconds the latest block is behind
+	behind := int(time.Now().Unix()) - timestamp
 	if timestamp < seconds {
-		return fmt.Errorf("%w: got ts: %d, need: %d", errTimestampTooOld, timestamp, seconds)
+		return behind, fmt.Errorf("%w: got ts: %d, need: %d", errTimestampTooOld, timestamp, seconds)
 	}
 
-	return nil
+	return behind, nil
 }
@@ -0,0 +1,37 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"context"
+	"errors"
+)
+
+// checkTxPool checks that the transaction pool answers. The measured value is
+// the number of transactions in each of its sub-pools.
+func checkTxPool(ctx context.Context, api TxPoolAPI) (interface{}, error) {
+	if api == nil {
+		return nil, errors.New("no connection to the Erigon server or `txpool` namespace isn't enabled")
+	}
+
+	status, err := api.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
@@ -32,17 +32,29 @@ import (
 )
 
 type requestBody struct {
-	MinPeerCount *uint            `json:"min_peer_count"`
-	BlockNumber  *rpc.BlockNumber `json:"known_block"`
+	MinPeerCount        *uint            `json:"min_peer_count"`
+	BlockNumber         *rpc.BlockNumber `json:"known_block"`
+	MaxFinalizedLag     *uint64          `json:"max_finalized_lag"`
+	TxPool              bool             `json:"txpool"`
+	SnapshotsDownloaded bool             `json:"snapshots_downloaded"`
+	MaxDBLatencyMs      *uint64          `json:"max_db_latency_ms"`
 }
 
 const (
-	urlPath          = "/health"
-	healthHeader     = "X-ERIGON-HEALTHCHECK"
-	synced           = "synced"
-	minPeerCount     = "min_peer_count"
-	checkBlock       = "check_block"
-	maxSecondsBehind = "max_seconds_behind"
+	urlPath             = "/health"
+	healthHeader        = "X-ERIGON-HEALTHCHECK"
+	synced              = "synced"
+	minPeerCount        = "min_peer_count"
+	checkBlock          = "check_block"
+	maxSecondsBehind    = "max_seconds_behind"
+	maxFinalizedLag     = "max_finalized_lag"
+	txPoolReachable     = "txpool"
+	snapshotsDownloaded = "snapshots_downloaded"
+	maxDBLatency        = "max_db_latency_ms"
+	healthcheckQuery    = "healthcheck_query"
+
+	formatParam = "format"
+	formatJSON  = "json"
 )
 
 var (
@@ -50,6 +62,36 @@ var (
 	errBadHeaderValue = errors.New("bad header value")
 )
 
+// checkResult is the outcome of a single check: the error if it failed and the
+// value it measured, if any.
+type checkResult struct {
+	err   error
+	value interface{}
+}
+
+func result(value interface{}, err error) checkResult {
+	return checkResult{err: err, value: value}
+}
+
+func disabledChecks(names ...string) map[string]checkResult {
+	results := make(map[string]checkResult, len(names))
+	for _, name := range names {
+		results[name] = checkResult{err: errCheckDisabled}
+	}
+	return results
+}
+
+// checkReport is a check in the report returned with ?format=json.
+type checkReport struct {
+	Status string      `json:"status"`
+	Value  interface{} `json:"value,omitempty"`
+}
+
+type healthReport struct {
+	Healthy bool                   `json:"healthy"`
+	Checks  map[string]checkReport `json:"checks"`
+}
+
 func ProcessHealthcheckIfNeeded(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -59,87 +101,128 @@ func ProcessHealthcheckIfNeeded(
 		return false
 	}
 
-	netAPI, ethAPI := parseAPI(rpcAPI)
+	netAPI, ethAPI, txPoolAPI := parseAPI(rpcAPI)
+	jsonReport := strings.EqualFold(r.URL.Query().Get(formatParam), formatJSON)
 
 	headers := r.Header.Values(healthHeader)
 	if len(headers) != 0 {
-		processFromHeaders(headers, ethAPI, netAPI, w, r)
+		processFromHeaders(headers, ethAPI, netAPI, txPoolAPI, jsonReport, w, r)
 	} else {
-		processFromBody(w, r, netAPI, ethAPI)
+		processFromBody(w, r, netAPI, ethAPI, txPoolAPI, jsonReport)
 	}
 
 	return true
 }
 
-func processFromHeaders(headers []string, ethAPI EthAPI, netAPI NetAPI, w http.ResponseWriter, r *http.Request) {
-	var (
-		errCheckSynced  = errCheckDisabled
-		errCheckPeer    = errCheckDisabled
-		errCheckBlock   = errCheckDisabled
-		errCheckSeconds = errCheckDisabled
-	)
+func processFromHeaders(headers []string, ethAPI EthAPI, netAPI NetAPI, txPoolAPI TxPoolAPI, jsonReport bool, w http.ResponseWriter, r *http.Request) {
+	checks := []string{synced, minPeerCount, checkBlock, maxSecondsBehind}
+	if jsonReport {
+		// the other checks are only listed in the plain response when requested, which keeps it
+		// as it was before they were added
+		checks = append(checks, maxFinalizedLag, txPoolReachable, snapshotsDownloaded, maxDBLatency)
+	}
+	results := disabledChecks(checks...)
 
 	for _, header := range headers {
 		lHeader := strings.ToLower(header)
 		if lHeader == synced {
-			errCheckSynced = checkSynced(ethAPI, r)
+			results[synced] = result(checkSynced(ethAPI, r))
 		}
 		if strings.HasPrefix(lHeader, minPeerCount) {
 			peers, err := strconv.Atoi(strings.TrimPrefix(lHeader, minPeerCount))
 			if err != nil {
-				errCheckPeer = err
+				results[minPeerCount] = checkResult{err: err}
 				break
 			}
-			errCheckPeer = checkMinPeers(uint(peers), netAPI)
+			results[minPeerCount] = result(checkMinPeers(uint(peers), netAPI))
 		}
 		if strings.HasPrefix(lHeader, checkBlock) {
 			block, err := strconv.Atoi(strings.TrimPrefix(lHeader, checkBlock))
 			if err != nil {
-				errCheckBlock = err
+				results[checkBlock] = checkResult{err: err}
 				break
 			}
-			errCheckBlock = checkBlockNumber(rpc.BlockNumber(block), ethAPI)
+			results[checkBlock] = result(checkBlockNumber(rpc.BlockNumber(block), ethAPI))
 		}
 		if strings.HasPrefix(lHeader, maxSecondsBehind) {
 			seconds, err := strconv.Atoi(strings.TrimPrefix(lHeader, maxSecondsBehind))
 			if err != nil {
-				errCheckSeconds = err
+				results[maxSecondsBehind] = checkResult{err: err}
 				break
 			}
 			if seconds < 0 {
-				errCheckSeconds = errBadHeaderValue
+				results[maxSecondsBehind] = checkResult{err: errBadHeaderValue}
 				break
 			}
 			now := time.Now().Unix()
-			errCheckSeconds = checkTime(r, int(now)-seconds, ethAPI)
+			results[maxSecondsBehind] = result(checkTime(r, int(now)-seconds, ethAPI))
+		}
+		if strings.HasPrefix(lHeader, maxFinalizedLag) {
+			blocks, err := strconv.ParseUint(strings.TrimPrefix(lHeader, maxFinalizedLag), 10, 64)
+			if err != nil {
+				results[maxFinalizedLag] = checkResult{err: err}
+				break
+			}
+			results[maxFinalizedLag] = result(checkFinalizedLag(r.Context(), blocks, ethAPI))
+		}
+		if lHeader == txPoolReachable {
+			results[txPoolReachable] = result(checkTxPool(r.Context(), txPoolAPI))
+		}
+		if lHeader == snapshotsDownloaded {
+			results[snapshotsDownloaded] = result(checkSnapshots(r.Context(), ethAPI))
+		}
+		if strings.HasPrefix(lHeader, maxDBLatency) {
+			ms, err := strconv.ParseUint(strings.TrimPrefix(lHeader, maxDBLatency), 10, 64)
+			if err != nil {
+				results[maxDBLatency] = checkResult{err: err}
+				break
+			}
+			results[maxDBLatency] = result(checkDBLatency(r.Context(), time.Duration(ms)*time.Millisecond, ethAPI))
 		}
 	}
 
-	reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds, w)
+	reportHealth(results, jsonReport, w)
 }
 
-func processFromBody(w http.ResponseWriter, r *http.Request, netAPI NetAPI, ethAPI EthAPI) {
+func processFromBody(w http.ResponseWriter, r *http.Request, netAPI NetAPI, ethAPI EthAPI, txPoolAPI TxPoolAPI, jsonReport bool) {
 	body, errParse := parseHealthCheckBody(r.Body)
 	defer r.Body.Close()
 
-	var errMinPeerCount = errCheckDisabled
-	var errCheckBlock = errCheckDisabled
+	checks := []string{minPeerCount, checkBlock}
+	if jsonReport {
+		// as with the headers, the other checks are only listed in the plain response when requested
+		checks = append(checks, maxFinalizedLag, txPoolReachable, snapshotsDownloaded, maxDBLatency)
+	}
+	results := disabledChecks(checks...)
+	results[healthcheckQuery] = checkResult{err: errParse}
 
 	if errParse != nil {
 		log.Root().Warn("unable to process healthcheck request", "err", errParse)
 	} else {
 		// 1. net_peerCount
 		if body.MinPeerCount != nil {
-			errMinPeerCount = checkMinPeers(*body.MinPeerCount, netAPI)
+			results[minPeerCount] = result(checkMinPeers(*body.MinPeerCount, netAPI))
 		}
 		// 2. custom query (shouldn't fail)
 		if body.BlockNumber != nil {
-			errCheckBlock = checkBlockNumber(*body.BlockNumber, ethAPI)
+			results[checkBlock] = result(checkBlockNumber(*body.BlockNumber, ethAPI))
+		}
+		if body.MaxFinalizedLag != nil {
+			results[maxFinalizedLag] = result(checkFinalizedLag(r.Context(), *body.MaxFinalizedLag, ethAPI))
+		}
+		if body.TxPool {
+			results[txPoolReachable] = result(checkTxPool(r.Context(), txPoolAPI))
+		}
+		if body.SnapshotsDownloaded {
+			results[snapshotsDownloaded] = result(checkSnapshots(r.Context(), ethAPI))
+		}
+		if body.MaxDBLatencyMs != nil {
+			results[maxDBLatency] = result(checkDBLatency(r.Context(), time.Duration(*body.MaxDBLatencyMs)*time.Millisecond, ethAPI))
 		}
 		// TODO add time from the last sync cycle
 	}
 
-	err := reportHealthFromBody(errParse, errMinPeerCount, errCheckBlock, w)
+	err := reportHealth(results, jsonReport, w)
 	if err != nil {
 		log.Root().Warn("unable to process healthcheck request", "err", err)
 	}
@@ -161,59 +244,39 @@ func parseHealthCheckBody(reader io.Reader) (requestBody, error) {
 	return body, nil
 }
 
-func reportHealthFromBody(errParse, errMinPeerCount, errCheckBlock error, w http.ResponseWriter) error {
+// reportHealth writes the status of every check, either as a flat map of
+// statuses or, with jsonReport, along with the measured values.
+func reportHealth(results map[string]checkResult, jsonReport bool, w http.ResponseWriter) error {
 	statusCode := http.StatusOK
-	errors := make(map[string]string)
-
-	if shouldChangeStatusCode(errParse) {
-		statusCode = http.StatusInternalServerError
-	}
-	errors["healthcheck_query"] = errorStringOrOK(errParse)
-
-	if shouldChangeStatusCode(errMinPeerCount) {
-		statusCode = http.StatusInternalServerError
-	}
-	errors["min_peer_count"] = errorStringOrOK(errMinPeerCount)
-
-	if shouldChangeStatusCode(errCheckBlock) {
-		statusCode = http.StatusInternalServerError
-	}
-	errors["check_block"] = errorStringOrOK(errCheckBlock)
-
-	return writeResponse(w, errors, statusCode)
-}
-
-func reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds error, w http.ResponseWriter) error {
-	statusCode := http.StatusOK
-	errs := make(map[string]string)
-
-	if shouldChangeStatusCode(errCheckSynced) {
-		statusCode = http.StatusInternalServerError
+	for _, res := range results {
+		if shouldChangeStatusCode(res.err) {
+			statusCode = http.StatusInternalServerError
+		}
 	}
-	errs[synced] = errorStringOrOK(errCheckSynced)
 
-	if shouldChangeStatusCode(errCheckPeer) {
-		statusCode = http.StatusInternalServerError
+	if !jsonReport {
+		errs := make(map[string]string, len(results))
+		for name, res := range results {
+			errs[name] = errorStringOrOK(res.err)
+		}
+		return writeResponse(w, errs, statusCode)
 	}
-	errs[minPeerCount] = errorStringOrOK(errCheckPeer)
 
-	if shouldChangeStatusCode(errCheckBlock) {
-		statusCode = http.StatusInternalServerError
+	report := healthReport{
+		Healthy: statusCode == http.StatusOK,
+		Checks:  make(map[string]checkReport, len(results)),
 	}
-	errs[checkBlock] = errorStringOrOK(errCheckBlock)
-
-	if shouldChangeStatusCode(errCheckSeconds) {
-		statusCode = http.StatusInternalServerError
+	for name, res := range results {
+		report.Checks[name] = checkReport{Status: errorStringOrOK(res.err), Value: res.value}
 	}
-	errs[maxSecondsBehind] = errorStringOrOK(errCheckSeconds)
-
-	return writeResponse(w, errs, statusCode)
+	return writeResponse(w, report, statusCode)
 }
 
-func writeResponse(w http.ResponseWriter, errs map[string]string, statusCode int) error {
+func writeResponse(w http.ResponseWriter, body interface{}, statusCode int) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
-	bodyJson, err := json.Marshal(errs)
+	bodyJson, err := json.Marshal(body)
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"math"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -42,10 +44,18 @@ func (n *netApiStub) PeerCount(_ context.Context) (hexutil.Uint, error) {
 }
 
 type ethApiStub struct {
-	blockResult   map[string]interface{}
-	blockError    error
-	syncingResult interface{}
-	syncingError  error
+	blockNumber      hexutil.Uint64
+	blockNumberDelay time.Duration
+	blockNumberError error
+	blockResult      map[string]interface{}
+	blockError       error
+	syncingResult    interface{}
+	syncingError     error
+}
+
+func (e *ethApiStub) BlockNumber(_ context.Context) (hexutil.Uint64, error) {
+	time.Sleep(e.blockNumberDelay)
+	return e.blockNumber, e.blockNumberError
 }
 
 func (e *ethApiStub) GetBlockByNumber(_ context.Context, _ rpc.BlockNumber, _ bool) (map[string]interface{}, error) {
@@ -56,6 +66,15 @@ func (e *ethApiStub) Syncing(_ context.Context) (interface{}, error) {
 	return e.syncingResult, e.syncingError
 }
 
+type txPoolApiStub struct {
+	response map[string]hexutil.Uint
+	error    error
+}
+
+func (t *txPoolApiStub) Status(_ context.Context) (map[string]hexutil.Uint, error) {
+	return t.response, t.error
+}
+
 func TestProcessHealthcheckIfNeeded_HeadersTests(t *testing.T) {
 	cases := []struct {
 		headers             []string
@@ -577,3 +596,453 @@ func TestProcessHealthcheckIfNeeded_RequestBody(t *testing.T) {
 		}
 	}
 }
+
+func TestProcessHealthcheckIfNeeded_ExtendedChecks(t *testing.T) {
+	type stageProgress struct {
+		StageName   string         `json:"stage_name"`
+		BlockNumber hexutil.Uint64 `json:"block_number"`
+	}
+	cases := []struct {
+		headers            []string
+		ethAPI             *ethApiStub
+		txPoolAPI          *txPoolApiStub
+		expectedStatusCode int
+		expectedBody       map[string]string
+		unexpectedKeys     []string
+	}{
+		// 0 - finalized lag within the limit, the checks not requested are not listed
+		{
+			headers:            []string{"max_finalized_lag64"},
+			ethAPI:             &ethApiStub{blockNumber: 100, blockResult: map[string]interface{}{"number": (*hexutil.Big)(big.NewInt(64))}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				maxFinalizedLag:  "HEALTHY",
+				synced:           "DISABLED",
+				minPeerCount:     "DISABLED",
+				checkBlock:       "DISABLED",
+				maxSecondsBehind: "DISABLED",
+			},
+			unexpectedKeys: []string{txPoolReachable, snapshotsDownloaded, maxDBLatency},
+		},
+		// 1 - finalized lag over the limit
+		{
+			headers:            []string{"max_finalized_lag64"},
+			ethAPI:             &ethApiStub{blockNumber: 200, blockResult: map[string]interface{}{"number": (*hexutil.Big)(big.NewInt(64))}},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxFinalizedLag: "ERROR: finalized block too far behind: 136 blocks",
+			},
+		},
+		// 2 - no finalized block
+		{
+			headers:            []string{"max_finalized_lag64"},
+			ethAPI:             &ethApiStub{blockNumber: 200},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxFinalizedLag: "ERROR: no finalized block",
+			},
+		},
+		// 3 - bad finalized lag value
+		{
+			headers:            []string{"max_finalized_lag-1"},
+			ethAPI:             &ethApiStub{},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxFinalizedLag: "ERROR: strconv.ParseUint",
+			},
+		},
+		// 4 - txpool reachable
+		{
+			headers:            []string{"txpool"},
+			ethAPI:             &ethApiStub{},
+			txPoolAPI:          &txPoolApiStub{response: map[string]hexutil.Uint{"pending": 1}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				txPoolReachable: "HEALTHY",
+			},
+		},
+		// 5 - txpool unreachable
+		{
+			headers:            []string{"txpool"},
+			ethAPI:             &ethApiStub{},
+			txPoolAPI:          &txPoolApiStub{error: errors.New("connection refused")},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				txPoolReachable: "ERROR: connection refused",
+			},
+		},
+		// 6 - txpool namespace disabled
+		{
+			headers:            []string{"txpool"},
+			ethAPI:             &ethApiStub{},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				txPoolReachable: "ERROR: no connection to the Erigon server or `txpool` namespace isn't enabled",
+			},
+		},
+		// 7 - snapshots downloaded, node synced
+		{
+			headers:            []string{"snapshots_downloaded"},
+			ethAPI:             &ethApiStub{syncingResult: false},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				snapshotsDownloaded: "HEALTHY",
+			},
+		},
+		// 8 - snapshots downloaded, node syncing
+		{
+			headers: []string{"snapshots_downloaded"},
+			ethAPI: &ethApiStub{syncingResult: map[string]interface{}{
+				"highestBlock": hexutil.Uint64(1000),
+				"stages":       []stageProgress{{"OtterSync", 900}, {"Headers", 950}},
+			}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				snapshotsDownloaded: "HEALTHY",
+			},
+		},
+		// 9 - snapshots downloading, highest block unknown
+		{
+			headers: []string{"snapshots_downloaded"},
+			ethAPI: &ethApiStub{syncingResult: map[string]interface{}{
+				"highestBlock": hexutil.Uint64(math.MaxUint64),
+			}},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				snapshotsDownloaded: "ERROR: snapshots are still downloading",
+			},
+		},
+		// 10 - snapshots downloading, no progress of the stage
+		{
+			headers: []string{"snapshots_downloaded"},
+			ethAPI: &ethApiStub{syncingResult: map[string]interface{}{
+				"highestBlock": hexutil.Uint64(1000),
+				"stages":       []stageProgress{{"OtterSync", 0}, {"Headers", 0}},
+			}},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				snapshotsDownloaded: "ERROR: snapshots are still downloading",
+			},
+		},
+		// 11 - db latency within the limit
+		{
+			headers:            []string{"max_db_latency_ms1000"},
+			ethAPI:             &ethApiStub{},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				maxDBLatency: "HEALTHY",
+			},
+		},
+		// 12 - db latency over the limit
+		{
+			headers:            []string{"max_db_latency_ms1"},
+			ethAPI:             &ethApiStub{blockNumberDelay: 20 * time.Millisecond},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxDBLatency: "ERROR: database read too slow",
+			},
+		},
+		// 13 - error reading the db
+		{
+			headers:            []string{"max_db_latency_ms1000"},
+			ethAPI:             &ethApiStub{blockNumberError: errors.New("db closed")},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxDBLatency: "ERROR: db closed",
+			},
+		},
+		// 14 - all of them along with the original checks
+		{
+			headers: []string{"synced", "min_peer_count1", "max_finalized_lag64", "txpool", "snapshots_downloaded", "max_db_latency_ms1000"},
+			ethAPI: &ethApiStub{
+				blockNumber:   100,
+				blockResult:   map[string]interface{}{"number": hexutil.Uint64(90)},
+				syncingResult: false,
+			},
+			txPoolAPI:          &txPoolApiStub{},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				synced:              "HEALTHY",
+				minPeerCount:        "HEALTHY",
+				checkBlock:          "DISABLED",
+				maxSecondsBehind:    "DISABLED",
+				maxFinalizedLag:     "HEALTHY",
+				txPoolReachable:     "HEALTHY",
+				snapshotsDownloaded: "HEALTHY",
+				maxDBLatency:        "HEALTHY",
+			},
+		},
+	}
+
+	for idx, c := range cases {
+		w := httptest.NewRecorder()
+		r, err := http.NewRequest(http.MethodGet, "http://localhost:9090/health", nil)
+		if err != nil {
+			t.Errorf("%v: creating request: %v", idx, err)
+		}
+
+		for _, header := range c.headers {
+			r.Header.Add("X-ERIGON-HEALTHCHECK", header)
+		}
+
+		apis := []rpc.API{
+			{Service: &netApiStub{response: hexutil.Uint(1)}},
+			{Service: c.ethAPI},
+		}
+		if c.txPoolAPI != nil {
+			apis = append(apis, rpc.API{Service: c.txPoolAPI})
+		}
+
+		ProcessHealthcheckIfNeeded(w, r, apis)
+
+		result := w.Result()
+		if result.StatusCode != c.expectedStatusCode {
+			t.Errorf("%v: expected status code: %v, but got: %v", idx, c.expectedStatusCode, result.StatusCode)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(result.Body).Decode(&body); err != nil {
+			t.Errorf("%v: unmarshalling the response body: %s", idx, err)
+		}
+		result.Body.Close()
+
+		for k, v := range c.expectedBody {
+			val, found := body[k]
+			if !found {
+				t.Errorf("%v: expected the key: %s to be in the response body but it wasn't there", idx, k)
+			}
+			if !strings.Contains(val, v) {
+				t.Errorf("%v: expected the response body key: %s to contain: %s, but it contained: %s", idx, k, v, val)
+			}
+		}
+		for _, k := range c.unexpectedKeys {
+			if val, found := body[k]; found {
+				t.Errorf("%v: expected the key: %s not to be in the response body but it was: %s", idx, k, val)
+			}
+		}
+	}
+}
+
+func TestProcessHealthcheckIfNeeded_RequestBodyExtendedChecks(t *testing.T) {
+	cases := []struct {
+		body               string
+		ethAPI             *ethApiStub
+		txPoolAPI          *txPoolApiStub
+		expectedStatusCode int
+		expectedBody       map[string]string
+		unexpectedKeys     []string
+	}{
+		// 0 - finalized lag within the limit, the checks not requested are not listed
+		{
+			body:               `{"max_finalized_lag": 64}`,
+			ethAPI:             &ethApiStub{blockNumber: 100, blockResult: map[string]interface{}{"number": (*hexutil.Big)(big.NewInt(64))}},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				healthcheckQuery: "HEALTHY",
+				maxFinalizedLag:  "HEALTHY",
+				minPeerCount:     "DISABLED",
+				checkBlock:       "DISABLED",
+			},
+			unexpectedKeys: []string{txPoolReachable, snapshotsDownloaded, maxDBLatency},
+		},
+		// 1 - finalized lag over the limit
+		{
+			body:               `{"max_finalized_lag": 64}`,
+			ethAPI:             &ethApiStub{blockNumber: 200, blockResult: map[string]interface{}{"number": (*hexutil.Big)(big.NewInt(64))}},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxFinalizedLag: "ERROR: finalized block too far behind: 136 blocks",
+			},
+		},
+		// 2 - txpool unreachable
+		{
+			body:               `{"txpool": true}`,
+			ethAPI:             &ethApiStub{},
+			txPoolAPI:          &txPoolApiStub{error: errors.New("connection refused")},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				txPoolReachable: "ERROR: connection refused",
+			},
+		},
+		// 3 - txpool not requested
+		{
+			body:               `{"txpool": false}`,
+			ethAPI:             &ethApiStub{},
+			expectedStatusCode: http.StatusOK,
+			unexpectedKeys:     []string{txPoolReachable},
+		},
+		// 4 - snapshots downloading
+		{
+			body: `{"snapshots_downloaded": true}`,
+			ethAPI: &ethApiStub{syncingResult: map[string]interface{}{
+				"highestBlock": hexutil.Uint64(math.MaxUint64),
+			}},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				snapshotsDownloaded: "ERROR: snapshots are still downloading",
+			},
+		},
+		// 5 - db latency over the limit
+		{
+			body:               `{"max_db_latency_ms": 1}`,
+			ethAPI:             &ethApiStub{blockNumberDelay: 20 * time.Millisecond},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				maxDBLatency: "ERROR: database read too slow",
+			},
+		},
+		// 6 - all of them along with the original checks
+		{
+			body: `{"min_peer_count": 1, "known_block": 123, "max_finalized_lag": 64, "txpool": true, "snapshots_downloaded": true, "max_db_latency_ms": 1000}`,
+			ethAPI: &ethApiStub{
+				blockNumber:   100,
+				blockResult:   map[string]interface{}{"number": hexutil.Uint64(90)},
+				syncingResult: false,
+			},
+			txPoolAPI:          &txPoolApiStub{},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: map[string]string{
+				healthcheckQuery:    "HEALTHY",
+				minPeerCount:        "HEALTHY",
+				checkBlock:          "HEALTHY",
+				maxFinalizedLag:     "HEALTHY",
+				txPoolReachable:     "HEALTHY",
+				snapshotsDownloaded: "HEALTHY",
+				maxDBLatency:        "HEALTHY",
+			},
+		},
+	}
+
+	for idx, c := range cases {
+		w := httptest.NewRecorder()
+		r, err := http.NewRequest(http.MethodPost, "http://localhost:9090/health", strings.NewReader(c.body))
+		if err != nil {
+			t.Errorf("%v: creating request: %v", idx, err)
+		}
+
+		apis := []rpc.API{
+			{Service: &netApiStub{response: hexutil.Uint(1)}},
+			{Service: c.ethAPI},
+		}
+		if c.txPoolAPI != nil {
+			apis = append(apis, rpc.API{Service: c.txPoolAPI})
+		}
+
+		ProcessHealthcheckIfNeeded(w, r, apis)
+
+		result := w.Result()
+		if result.StatusCode != c.expectedStatusCode {
+			t.Errorf("%v: expected status code: %v, but got: %v", idx, c.expectedStatusCode, result.StatusCode)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(result.Body).Decode(&body); err != nil {
+			t.Errorf("%v: unmarshalling the response body: %s", idx, err)
+		}
+		result.Body.Close()
+
+		for k, v := range c.expectedBody {
+			val, found := body[k]
+			if !found {
+				t.Errorf("%v: expected the key: %s to be in the response body but it wasn't there", idx, k)
+			}
+			if !strings.Contains(val, v) {
+				t.Errorf("%v: expected the response body key: %s to contain: %s, but it contained: %s", idx, k, v, val)
+			}
+		}
+		for _, k := range c.unexpectedKeys {
+			if val, found := body[k]; found {
+				t.Errorf("%v: expected the key: %s not to be in the response body but it was: %s", idx, k, val)
+			}
+		}
+	}
+}
+
+func TestProcessHealthcheckIfNeeded_JSONReport(t *testing.T) {
+	apis := []rpc.API{
+		{Service: &netApiStub{response: hexutil.Uint(3)}},
+		{Service: &ethApiStub{blockNumber: 100, blockResult: map[string]interface{}{"number": hexutil.Uint64(36)}}},
+		{Service: &txPoolApiStub{response: map[string]hexutil.Uint{"pending": 2, "baseFee": 0, "queued": 1}}},
+	}
+
+	type report struct {
+		Healthy bool `json:"healthy"`
+		Checks  map[string]struct {
+			Status string          `json:"status"`
+			Value  json.RawMessage `json:"value"`
+		} `json:"checks"`
+	}
+
+	t.Run("headers", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "http://localhost:9090/health?format=json", nil)
+		r.Header.Add("X-ERIGON-HEALTHCHECK", "min_peer_count5")
+		r.Header.Add("X-ERIGON-HEALTHCHECK", "max_finalized_lag64")
+		r.Header.Add("X-ERIGON-HEALTHCHECK", "txpool")
+
+		ProcessHealthcheckIfNeeded(w, r, apis)
+
+		result := w.Result()
+		defer result.Body.Close()
+		if result.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("expected status code: %v, but got: %v", http.StatusInternalServerError, result.StatusCode)
+		}
+		if ct := result.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected a json content type, got: %s", ct)
+		}
+		var body report
+		if err := json.NewDecoder(result.Body).Decode(&body); err != nil {
+			t.Fatalf("unmarshalling the response body: %s", err)
+		}
+		if body.Healthy {
+			t.Errorf("expected an unhealthy report")
+		}
+
+		expected := map[string]struct{ status, value string }{
+			minPeerCount:        {"ERROR: not enough peers", "3"},
+			maxFinalizedLag:     {"HEALTHY", "64"},
+			txPoolReachable:     {"HEALTHY", `{"baseFee":"0x0","pending":"0x2","queued":"0x1"}`},
+			synced:              {"DISABLED", ""},
+			snapshotsDownloaded: {"DISABLED", ""},
+		}
+		for name, e := range expected {
+			check, found := body.Checks[name]
+			if !found {
+				t.Errorf("expected the check: %s to be in the report but it wasn't there", name)
+				continue
+			}
+			if !strings.Contains(check.Status, e.status) {
+				t.Errorf("expected the status of: %s to contain: %s, but it was: %s", name, e.status, check.Status)
+			}
+			if string(check.Value) != e.value {
+				t.Errorf("expected the value of: %s to be: %s, but it was: %s", name, e.value, check.Value)
+			}
+		}
+	})
+
+	t.Run("body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "http://localhost:9090/health?format=json", strings.NewReader(`{"min_peer_count": 1}`))
+
+		ProcessHealthcheckIfNeeded(w, r, apis)
+
+		result := w.Result()
+		defer result.Body.Close()
+		if result.StatusCode != http.StatusOK {
+			t.Fatalf("expected status code: %v, but got: %v", http.StatusOK, result.StatusCode)
+		}
+		var body report
+		if err := json.NewDecoder(result.Body).Decode(&body); err != nil {
+			t.Fatalf("unmarshalling the response body: %s", err)
+		}
+		if !body.Healthy {
+			t.Errorf("expected a healthy report")
+		}
+		if check := body.Checks[minPeerCount]; check.Status != "HEALTHY" || string(check.Value) != "3" {
+			t.Errorf("unexpected min_peer_count check: %s %s", check.Status, check.Value)
+		}
+		if check := body.Checks[healthcheckQuery]; check.Status != "HEALTHY" {
+			t.Errorf("unexpected healthcheck_query check: %s", check.Status)
+		}
+	})
+}
@@ -29,6 +29,11 @@ type NetAPI interface {
 }
 
 type EthAPI interface {
+	BlockNumber(ctx context.Context) (hexutil.Uint64, error)
 	GetBlockByNumber(_ context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error)
 	Syncing(ctx context.Context) (interface{}, error)
 }
+
+type TxPoolAPI interface {
+	Status(ctx context.Context) (map[string]hexutil.Uint, error)
+}
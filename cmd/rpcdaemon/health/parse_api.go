@@ -20,7 +20,7 @@ import (
 	"github.com/erigontech/erigon/rpc"
 )
 
-func parseAPI(api []rpc.API) (netAPI NetAPI, ethAPI EthAPI) {
+func parseAPI(api []rpc.API) (netAPI NetAPI, ethAPI EthAPI, txPoolAPI TxPoolAPI) {
 	for _, rpc := range api {
 		if rpc.Service == nil {
 			continue
@@ -33,6 +33,10 @@ func parseAPI(api []rpc.API) (netAPI NetAPI, ethAPI EthAPI) {
 		if ethCandidate, ok := rpc.Service.(EthAPI); ok {
 			ethAPI = ethCandidate
 		}
+
+		if txPoolCandidate, ok := rpc.Service.(TxPoolAPI); ok {
+			txPoolAPI = txPoolCandidate
+		}
 	}
-	return netAPI, ethAPI
+	return netAPI, ethAPI, txPoolAPI
 }
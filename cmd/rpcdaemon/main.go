@@ -52,7 +52,14 @@ func main() {
 
 		apiList := jsonrpc.APIList(db, backend, txPool, mining, ff, stateCache, blockReader, cfg, engine, logger, nil)
 		rpc.PreAllocateRPCMetricLabels(apiList)
-		if err := cli.StartRpcServer(ctx, cfg, apiList, logger); err != nil {
+		responseCache := cli.NewResponseCache(ctx, cfg, db, blockReader, ff, logger)
+		requestLog, err := cli.NewRequestLog(cfg, db, logger)
+		if err != nil {
+			logger.Error(err.Error())
+			return nil
+		}
+		defer requestLog.Close()
+		if err := cli.StartRpcServer(ctx, cfg, apiList, responseCache, requestLog, logger); err != nil {
 			logger.Error(err.Error())
 			return nil
 		}
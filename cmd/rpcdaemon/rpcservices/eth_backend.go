@@ -335,6 +335,10 @@ func (back *RemoteBackend) LastFrozenEventBlockNum() uint64 {
 	panic("not implemented")
 }
 
+func (back *RemoteBackend) EventByIdFromSnapshot(eventId uint64) ([]byte, uint64, bool, error) {
+	return back.blockReader.EventByIdFromSnapshot(eventId)
+}
+
 func (back *RemoteBackend) Span(ctx context.Context, tx kv.Getter, spanId uint64) ([]byte, error) {
 	return back.blockReader.Span(ctx, tx, spanId)
 }
@@ -410,6 +414,22 @@ func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerR
 	return result, nil
 }
 
+func (back *RemoteBackend) PeerBans(ctx context.Context) (*remote.PeerBansReply, error) {
+	result, err := back.remoteEthBackend.PeerBans(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.PeerBans() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) ClearPeerBans(ctx context.Context, request *remote.ClearPeerBansRequest) (*remote.ClearPeerBansReply, error) {
+	result, err := back.remoteEthBackend.ClearPeerBans(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.ClearPeerBans() error: %w", err)
+	}
+	return result, nil
+}
+
 func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	rpcPeers, err := back.remoteEthBackend.Peers(ctx, &emptypb.Empty{})
 	if err != nil {
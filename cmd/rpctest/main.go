@@ -442,15 +442,20 @@ func main() {
 	}
 	with(benchEthGetBalanceCmd, withErigonUrl, withGethUrl, withNeedCompare, withBlockNum)
 
+	var requestLogFile string
 	var replayCmd = &cobra.Command{
 		Use:   "replay",
-		Short: "",
+		Short: "Replays a record file, or a request log written by rpcdaemon with --rpc.requestlog",
 		Long:  ``,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if requestLogFile != "" {
+				return rpctest.ReplayRequestLog(erigonURL, gethURL, needCompare, requestLogFile, errorFile)
+			}
 			return rpctest.Replay(erigonURL, recordFile)
 		},
 	}
-	with(replayCmd, withErigonUrl, withRecord)
+	with(replayCmd, withErigonUrl, withGethUrl, withNeedCompare, withRecord, withErrorFile)
+	replayCmd.Flags().StringVar(&requestLogFile, "requestLog", "", "Request log (JSONL) to replay, reports the latency percentiles and the different responses")
 
 	var tmpDataDir, tmpDataDirOrig string
 	var notRegenerateGethData bool
@@ -18,12 +18,20 @@ package rpctest
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"os"
+	"slices"
+	"text/tabwriter"
 	"time"
 
 	"github.com/valyala/fastjson"
+
+	"github.com/erigontech/erigon/rpc"
 )
 
 func Replay(erigonURL string, recordFile string) error {
@@ -70,3 +78,194 @@ func Replay(erigonURL string, recordFile string) error {
 	}
 	return nil
 }
+
+// ReplayRequestLog replays the requests of a log written by rpcdaemon with --rpc.requestlog
+// against erigonURL and, with needCompare, against gethURL as well, comparing the responses of
+// both. It reports the latency percentiles of each method, as recorded in the log and as
+// replayed, and the responses which differ: between the two endpoints, or, with a single
+// endpoint, from the error recorded in the log.
+func ReplayRequestLog(erigonURL, gethURL string, needCompare bool, requestLogFile, errorFile string) error {
+	setRoutes(erigonURL, gethURL)
+	reqGen := &RequestGenerator{
+		client: &http.Client{Timeout: time.Second * 600},
+	}
+	f, err := os.Open(requestLogFile)
+	if err != nil {
+		return fmt.Errorf("cannot open request log %s: %w", requestLogFile, err)
+	}
+	defer f.Close()
+
+	var errs *bufio.Writer
+	if errorFile != "" {
+		ferr, err := os.Create(errorFile)
+		if err != nil {
+			return fmt.Errorf("cannot create file %s for errors: %w", errorFile, err)
+		}
+		defer ferr.Close()
+		errs = bufio.NewWriter(ferr)
+		defer errs.Flush()
+	}
+
+	report, err := replayRequestLog(reqGen, f, needCompare, errs)
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout, needCompare)
+	if report.differences > 0 {
+		return fmt.Errorf("%d of %d responses differ", report.differences, report.requests)
+	}
+	return nil
+}
+
+// requestLogReport collects the latencies of the replayed requests, per method.
+type requestLogReport struct {
+	requests    int
+	differences int
+	recorded    map[string][]time.Duration
+	erigon      map[string][]time.Duration
+	geth        map[string][]time.Duration
+}
+
+func replayRequestLog(reqGen *RequestGenerator, r io.Reader, needCompare bool, errs *bufio.Writer) (*requestLogReport, error) {
+	report := &requestLogReport{
+		recorded: make(map[string][]time.Duration),
+		erigon:   make(map[string][]time.Duration),
+		geth:     make(map[string][]time.Duration),
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 64*1024*1024) // 64 Mb line buffer
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+		var entry rpc.RequestLogEntry
+		if err := json.Unmarshal(s.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("could not parse request log entry %s: %w", s.Bytes(), err)
+		}
+		params := entry.Params
+		if len(params) == 0 {
+			params = json.RawMessage("[]")
+		}
+		reqGen.reqID++
+		request := fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":%s,"id":%d}`, entry.Method, params, reqGen.reqID)
+
+		report.requests++
+		report.recorded[entry.Method] = append(report.recorded[entry.Method], time.Duration(entry.DurationMs*float64(time.Millisecond)))
+		res := reqGen.Erigon2(entry.Method, request)
+		if res.Err != nil {
+			return nil, fmt.Errorf("could not replay %s: %w", request, res.Err)
+		}
+		report.erigon[entry.Method] = append(report.erigon[entry.Method], res.Took)
+
+		var diff error
+		if needCompare {
+			resg := reqGen.Geth2(entry.Method, request)
+			if resg.Err != nil {
+				return nil, fmt.Errorf("could not replay %s on the second endpoint: %w", request, resg.Err)
+			}
+			report.geth[entry.Method] = append(report.geth[entry.Method], resg.Took)
+			diff = compareReplayed(res.Result, resg.Result, entry.Method)
+			if diff != nil {
+				writeDifference(errs, request, diff, res.Response, resg.Response)
+			}
+		} else {
+			diff = compareWithRecorded(res.Result, &entry)
+			if diff != nil {
+				writeDifference(errs, request, diff, res.Response, nil)
+			}
+		}
+		if diff != nil {
+			report.differences++
+			fmt.Printf("Different results for %s: %v\n", request, diff)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("reading request log: %w", err)
+	}
+	return report, nil
+}
+
+func compareReplayed(v, vg *fastjson.Value, method string) error {
+	errVal, errValg := v.Get("error"), vg.Get("error")
+	if errVal != nil || errValg != nil {
+		if errVal == nil || errValg == nil {
+			return compareErrors(errVal, errValg, method, "", nil)
+		}
+		// both failed, the messages may legitimately differ between the implementations
+		return nil
+	}
+	return compareResults(v, vg)
+}
+
+// compareWithRecorded checks that the request fails again if, and only if, it failed when
+// it was recorded.
+func compareWithRecorded(v *fastjson.Value, entry *rpc.RequestLogEntry) error {
+	errVal := v.Get("error")
+	switch {
+	case errVal != nil && entry.Error == "":
+		return fmt.Errorf("returns error code=%d message=%s, while it was recorded OK", errVal.GetInt("code"), errVal.GetStringBytes("message"))
+	case errVal == nil && entry.Error != "":
+		return fmt.Errorf("returns OK, while it was recorded with error code=%d message=%s", entry.ErrorCode, entry.Error)
+	}
+	return nil
+}
+
+func writeDifference(errs *bufio.Writer, request string, diff error, response, responseg []byte) {
+	if errs == nil {
+		return
+	}
+	fmt.Fprintf(errs, "Different results for %s:\n%v\n", request, diff)
+	fmt.Fprintf(errs, "Erigon response: %s\n", response)
+	if responseg != nil {
+		fmt.Fprintf(errs, "G/OE response: %s\n", responseg)
+	}
+	fmt.Fprintln(errs)
+	errs.Flush() // nolint:errcheck
+}
+
+// latencies returns the p50, p90, p99 and max of the durations.
+func latencies(durations []time.Duration) [4]time.Duration {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	percentile := func(p float64) time.Duration {
+		if len(sorted) == 0 {
+			return 0
+		}
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	return [4]time.Duration{percentile(50), percentile(90), percentile(99), percentile(100)}
+}
+
+func (report *requestLogReport) print(w io.Writer, needCompare bool) {
+	methods := make([]string, 0, len(report.recorded))
+	var recorded, erigon, geth []time.Duration
+	for method := range report.recorded {
+		methods = append(methods, method)
+		recorded = append(recorded, report.recorded[method]...)
+		erigon = append(erigon, report.erigon[method]...)
+		geth = append(geth, report.geth[method]...)
+	}
+	slices.Sort(methods)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "method\tcount\tsource\tp50\tp90\tp99\tmax")
+	row := func(method string, count int, source string, durations []time.Duration) {
+		l := latencies(durations)
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", method, count, source,
+			l[0].Round(time.Microsecond), l[1].Round(time.Microsecond), l[2].Round(time.Microsecond), l[3].Round(time.Microsecond))
+	}
+	rows := func(method string, recorded, erigon, geth []time.Duration) {
+		row(method, len(recorded), "recorded", recorded)
+		row(method, len(erigon), "erigon", erigon)
+		if needCompare {
+			row(method, len(geth), "geth", geth)
+		}
+	}
+	for _, method := range methods {
+		rows(method, report.recorded[method], report.erigon[method], report.geth[method])
+	}
+	rows("all", recorded, erigon, geth)
+	tw.Flush()
+	fmt.Fprintf(w, "Replayed %d requests, %d with different results\n", report.requests, report.differences)
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpctest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// replayServer answers eth_blockNumber with block, and fails eth_call.
+func replayServer(t *testing.T, block string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Method {
+		case "eth_blockNumber":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":"%s"}`, req.ID, block)
+		default:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32000,"message":"execution reverted"}}`, req.ID)
+		}
+	}))
+}
+
+const replayTestLog = `{"time":"2024-01-01T00:00:00Z","method":"eth_blockNumber","durationMs":120,"responseSize":40,"block":100}
+{"time":"2024-01-01T00:00:01Z","method":"eth_call","params":[{"to":"0x01"},"latest"],"durationMs":3,"responseSize":90,"errorCode":-32000,"error":"execution reverted","block":100}
+
+{"time":"2024-01-01T00:00:02Z","method":"eth_blockNumber","params":[],"durationMs":80,"responseSize":40,"block":101}
+{"time":"2024-01-01T00:00:03Z","method":"eth_call","params":[{"to":"0x02"},"latest"],"durationMs":500,"responseSize":40,"block":101}
+`
+
+func TestReplayRequestLog(t *testing.T) {
+	erigon := replayServer(t, "0x64")
+	defer erigon.Close()
+
+	t.Run("single endpoint", func(t *testing.T) {
+		setRoutes(erigon.URL, "")
+		var errs bytes.Buffer
+		errsWriter := bufio.NewWriter(&errs)
+		report, err := replayRequestLog(&RequestGenerator{client: erigon.Client()}, strings.NewReader(replayTestLog), false, errsWriter)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.requests)
+		// the second eth_call was recorded OK
+		require.Equal(t, 1, report.differences)
+		require.Contains(t, errs.String(), `"to":"0x02"`)
+		require.Contains(t, errs.String(), "while it was recorded OK")
+
+		require.Len(t, report.erigon["eth_call"], 2)
+		require.Empty(t, report.geth)
+		require.Equal(t, [4]time.Duration{80 * time.Millisecond, 120 * time.Millisecond, 120 * time.Millisecond, 120 * time.Millisecond}, latencies(report.recorded["eth_blockNumber"]))
+
+		var out bytes.Buffer
+		report.print(&out, false)
+		require.Contains(t, out.String(), "eth_blockNumber")
+		require.NotContains(t, out.String(), "geth")
+		require.Contains(t, out.String(), "Replayed 4 requests, 1 with different results")
+	})
+
+	t.Run("two endpoints", func(t *testing.T) {
+		geth := replayServer(t, "0x65")
+		defer geth.Close()
+		setRoutes(erigon.URL, geth.URL)
+		report, err := replayRequestLog(&RequestGenerator{client: erigon.Client()}, strings.NewReader(replayTestLog), true, nil)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.requests)
+		// both eth_blockNumber differ, both endpoints fail all eth_call
+		require.Equal(t, 2, report.differences)
+		require.Len(t, report.geth["eth_blockNumber"], 2)
+
+		var out bytes.Buffer
+		report.print(&out, true)
+		require.Contains(t, out.String(), "geth")
+	})
+}
+
+func TestLatencies(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(t, [4]time.Duration{50 * time.Millisecond, 90 * time.Millisecond, 99 * time.Millisecond, 100 * time.Millisecond}, latencies(durations))
+	require.Equal(t, [4]time.Duration{}, latencies(nil))
+}
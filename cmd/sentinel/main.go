@@ -80,15 +80,16 @@ func runSentinelNode(cliCtx *cli.Context) error {
 		return err
 	}
 	_, err = service.StartSentinelService(&sentinel.SentinelConfig{
-		IpAddr:         cfg.Addr,
-		Port:           int(cfg.Port),
-		TCPPort:        cfg.ServerTcpPort,
-		NetworkConfig:  networkCfg,
-		BeaconConfig:   beaconCfg,
-		NoDiscovery:    cfg.NoDiscovery,
-		LocalDiscovery: cfg.LocalDiscovery,
-		EnableBlocks:   false,
-	}, nil, nil, nil, &service.ServerConfig{Network: cfg.ServerProtocol, Addr: cfg.ServerAddr}, eth_clock.NewEthereumClock(bs.GenesisTime(), bs.GenesisValidatorsRoot(), beaconCfg), nil, log.Root())
+		IpAddr:          cfg.Addr,
+		Port:            int(cfg.Port),
+		TCPPort:         cfg.ServerTcpPort,
+		NetworkConfig:   networkCfg,
+		BeaconConfig:    beaconCfg,
+		NoDiscovery:     cfg.NoDiscovery,
+		LocalDiscovery:  cfg.LocalDiscovery,
+		EnableBlocks:    false,
+		ScoreParamsPath: cfg.ScoreParams,
+	}, nil, nil, nil, &service.ServerConfig{Network: cfg.ServerProtocol, Addr: cfg.ServerAddr, DebugAddr: cfg.DebugAddr}, eth_clock.NewEthereumClock(bs.GenesisTime(), bs.GenesisValidatorsRoot(), beaconCfg), nil, log.Root())
 	if err != nil {
 		log.Error("[Sentinel] Could not start sentinel", "err", err)
 		return err
@@ -41,6 +41,8 @@ type SentinelCliCfg struct {
 	LocalDiscovery bool     `json:"local_discovery"`
 	Bootnodes      []string `json:"bootnodes"`
 	StaticPeers    []string `json:"static_peers"`
+	DebugAddr      string   `json:"debug_addr"`
+	ScoreParams    string   `json:"score_params"`
 }
 
 func SetupSentinelCli(ctx *cli.Context) (*SentinelCliCfg, error) {
@@ -58,6 +60,8 @@ func SetupSentinelCli(ctx *cli.Context) (*SentinelCliCfg, error) {
 	}
 	cfg.NoDiscovery = ctx.Bool(sentinelflags.NoDiscovery.Name)
 	cfg.LocalDiscovery = ctx.Bool(sentinelflags.LocalDiscovery.Name)
+	cfg.DebugAddr = ctx.String(sentinelflags.SentinelDebugAddrFlag.Name)
+	cfg.ScoreParams = ctx.String(sentinelflags.SentinelScoreParamsFlag.Name)
 
 	// Process bootnodes
 	if ctx.String(sentinelflags.BootnodesFlag.Name) != "" {
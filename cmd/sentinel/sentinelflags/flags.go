@@ -32,6 +32,8 @@ var CliFlags = []cli.Flag{
 	&NoDiscovery,
 	&BootnodesFlag,
 	&SentinelStaticPeersFlag,
+	&SentinelDebugAddrFlag,
+	&SentinelScoreParamsFlag,
 }
 
 var (
@@ -81,4 +83,14 @@ var (
 		Usage: "connect to comma-separated Consensus static peers",
 		Value: "",
 	}
+	SentinelDebugAddrFlag = cli.StringFlag{
+		Name:  "sentinel.debug.addr",
+		Usage: "sets the address to serve the sentinel debug endpoints (peer scores) on, disabled if empty",
+		Value: "",
+	}
+	SentinelScoreParamsFlag = cli.StringFlag{
+		Name:  "sentinel.score-params",
+		Usage: "path to a yaml file overriding the gossipsub topic score parameters",
+		Value: "",
+	}
 )
@@ -38,19 +38,20 @@ var (
 	sentryAddr string // Address of the sentry <host>:<port>
 	datadirCli string // Path to td working dir
 
-	natSetting   string   // NAT setting
-	port         int      // Listening port
-	staticPeers  []string // static peers
-	trustedPeers []string // trusted peers
-	discoveryDNS []string
-	nodiscover   bool // disable sentry's discovery mechanism
-	protocol     uint
-	allowedPorts []uint
-	netRestrict  string // CIDR to restrict peering to
-	maxPeers     int
-	maxPendPeers int
-	healthCheck  bool
-	metrics      bool
+	natSetting    string   // NAT setting
+	port          int      // Listening port
+	staticPeers   []string // static peers
+	trustedPeers  []string // trusted peers
+	discoveryDNS  []string
+	requireEthENR bool
+	nodiscover    bool // disable sentry's discovery mechanism
+	protocol      uint
+	allowedPorts  []uint
+	netRestrict   string // CIDR to restrict peering to
+	maxPeers      int
+	maxPendPeers  int
+	healthCheck   bool
+	metrics       bool
 )
 
 func init() {
@@ -63,6 +64,7 @@ func init() {
 	rootCmd.Flags().StringSliceVar(&staticPeers, utils.StaticPeersFlag.Name, []string{}, utils.StaticPeersFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&trustedPeers, utils.TrustedPeersFlag.Name, []string{}, utils.TrustedPeersFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&discoveryDNS, utils.DNSDiscoveryFlag.Name, []string{}, utils.DNSDiscoveryFlag.Usage)
+	rootCmd.Flags().BoolVar(&requireEthENR, utils.DiscoveryRequireEthENRFlag.Name, false, utils.DiscoveryRequireEthENRFlag.Usage)
 	rootCmd.Flags().BoolVar(&nodiscover, utils.NoDiscoverFlag.Name, false, utils.NoDiscoverFlag.Usage)
 	rootCmd.Flags().UintVar(&protocol, utils.P2pProtocolVersionFlag.Name, utils.P2pProtocolVersionFlag.Value.Value()[0], utils.P2pProtocolVersionFlag.Usage)
 	rootCmd.Flags().UintSliceVar(&allowedPorts, utils.P2pProtocolAllowedPorts.Name, utils.P2pProtocolAllowedPorts.Value.Value(), utils.P2pProtocolAllowedPorts.Usage)
@@ -108,6 +110,7 @@ var rootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		p2pConfig.RequireEthENR = requireEthENR
 
 		logger := debug.SetupCobra(cmd, "sentry")
 		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, healthCheck, logger)
@@ -274,6 +274,11 @@ var (
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
 	}
+	MinerTxOrderingFlag = cli.StringFlag{
+		Name:  "miner.txordering",
+		Usage: "Order of the txpool transactions in the PoS payloads: best, fifo (by arrival), priorityfee, fairness (round-robin over the senders). A policy other than best only reorders the window of up to 1000 best transactions that the txpool yields for a payload",
+		Value: "best",
+	}
 	VMEnableDebugFlag = cli.BoolFlag{
 		Name:  "vmdebug",
 		Usage: "Record information useful for VM and contract debugging",
@@ -417,6 +422,23 @@ var (
 		Name:  "rpc.accessList",
 		Usage: "Specify granular (method-by-method) API allowlist",
 	}
+	RpcRateLimitFlag = cli.StringFlag{
+		Name:  "rpc.ratelimit",
+		Usage: "Path to a JSON file with per-client rate limits, method costs and API keys of the HTTP/WS endpoints",
+	}
+	RpcResponseCacheSizeFlag = cli.StringFlag{
+		Name:  "rpc.responsecache.size",
+		Value: "0MB",
+		Usage: "Size of the cache of the responses to the calls pinned to finalized blocks (e.g. eth_getBlockByNumber, eth_getTransactionReceipt, trace_block). Set 0 to disable",
+	}
+	RpcAPIKeysFlag = cli.StringFlag{
+		Name:  "rpc.apikeys",
+		Usage: "Path to a JSON file with the API keys of the HTTP/WS endpoints, and the methods available with each key",
+	}
+	RpcRequestLogFlag = cli.StringFlag{
+		Name:  "rpc.requestlog",
+		Usage: "Path to a JSONL file where to append the failed requests and the ones slower than --rpc.slow, with their params, duration, response size and block height. Replay it with `rpctest replay --requestLog`",
+	}
 
 	RpcGasCapFlag = cli.UintFlag{
 		Name:  "rpc.gascap",
@@ -550,6 +572,10 @@ var (
 		Usage: "Allowed ports to pick for different eth p2p protocol versions as follows <porta>,<portb>,..,<porti>",
 		Value: cli.NewUintSlice(uint(ListenPortFlag.Value), 30304, 30305, 30306, 30307),
 	}
+	P2pSnapServeFlag = cli.BoolFlag{
+		Name:  "p2p.snap-serve",
+		Usage: "Serve snap/1 protocol (account and storage ranges of the state of the last 128 blocks) to peers",
+	}
 	SentryAddrFlag = cli.StringFlag{
 		Name:  "sentry.api.addr",
 		Usage: "Comma separated sentry addresses '<host>:<port>,<host>:<port>'",
@@ -615,6 +641,10 @@ var (
 		Name:  "discovery.dns",
 		Usage: "Sets DNS discovery entry points (use \"\" to disable DNS)",
 	}
+	DiscoveryRequireEthENRFlag = cli.BoolFlag{
+		Name:  "discovery.require-eth-enr",
+		Usage: "Only dial discovered nodes which advertise a compatible fork ID in their eth ENR entry",
+	}
 
 	// ATM the url is left to the user and deployment to
 	JSpathFlag = cli.StringFlag{
@@ -859,6 +889,11 @@ var (
 		Usage: "Enable caplin validator monitoring metrics",
 		Value: false,
 	}
+	CaplinGossipCaptureDirFlag = cli.StringFlag{
+		Name:  "caplin.gossip-capture-dir",
+		Usage: "Directory to archive gossip messages rejected by validation in, disabled if empty",
+		Value: "",
+	}
 
 	SentinelAddrFlag = cli.StringFlag{
 		Name:  "sentinel.addr",
@@ -870,6 +905,16 @@ var (
 		Usage: "Port for sentinel",
 		Value: 7777,
 	}
+	SentinelDebugAddrFlag = cli.StringFlag{
+		Name:  "sentinel.debug.addr",
+		Usage: "Address to serve the sentinel debug endpoints (peer scores) on, disabled if empty",
+		Value: "",
+	}
+	SentinelScoreParamsFlag = cli.StringFlag{
+		Name:  "sentinel.score-params",
+		Usage: "Path to a yaml file overriding the gossipsub topic score parameters",
+		Value: "",
+	}
 	SentinelBootnodes = cli.StringSliceFlag{
 		Name:  "sentinel.bootnodes",
 		Usage: "Comma separated enode URLs for P2P discovery bootstrap",
@@ -1014,6 +1059,11 @@ var (
 		Usage: "enables archival node in caplin",
 		Value: false,
 	}
+	CaplinStatesCheckpointIntervalFlag = cli.Uint64Flag{
+		Name:  "caplin.states.checkpoint-interval",
+		Usage: "Interval in slots between full beacon state checkpoints stored by the archival node, 0 disables checkpoints. States between two checkpoints are served by replaying the blocks on top of the earlier one",
+		Value: clparams.DefaultStateCheckpointInterval,
+	}
 	BeaconApiAllowCredentialsFlag = cli.BoolFlag{
 		Name:  "beacon.api.cors.allow-credentials",
 		Usage: "set the cors' allow credentials",
@@ -1204,6 +1254,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
 	case direct.ETH68:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
+	case direct.ETH69:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth69")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -1377,10 +1429,18 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 		cfg.DiscoveryV5 = ctx.Bool(DiscoveryV5Flag.Name)
 	}
 
+	if ctx.IsSet(DiscoveryRequireEthENRFlag.Name) {
+		cfg.RequireEthENR = ctx.Bool(DiscoveryRequireEthENRFlag.Name)
+	}
+
 	if ctx.IsSet(MetricsEnabledFlag.Name) {
 		cfg.MetricsEnabled = ctx.Bool(MetricsEnabledFlag.Name)
 	}
 
+	if ctx.IsSet(P2pSnapServeFlag.Name) {
+		cfg.SnapServe = ctx.Bool(P2pSnapServeFlag.Name)
+	}
+
 	ethPeers := cfg.MaxPeers
 	logger.Info("Maximum peer count", "ETH", ethPeers, "total", cfg.MaxPeers)
 
@@ -1666,6 +1726,9 @@ func setMiner(ctx *cli.Context, cfg *params.MiningConfig) {
 	if ctx.IsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.Bool(MinerNoVerfiyFlag.Name)
 	}
+	if ctx.IsSet(MinerTxOrderingFlag.Name) {
+		cfg.TxOrdering = ctx.String(MinerTxOrderingFlag.Name)
+	}
 }
 
 func setWhitelist(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1716,8 +1779,10 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.CaplinConfig.BlobPruningDisabled = ctx.Bool(CaplinDisableBlobPruningFlag.Name)
 	cfg.CaplinConfig.DisabledCheckpointSync = ctx.Bool(CaplinDisableCheckpointSyncFlag.Name)
 	cfg.CaplinConfig.Archive = ctx.Bool(CaplinArchiveFlag.Name)
+	cfg.CaplinConfig.StatesCheckpointInterval = ctx.Uint64(CaplinStatesCheckpointIntervalFlag.Name)
 	cfg.CaplinConfig.MevRelayUrl = ctx.String(CaplinMevRelayUrl.Name)
 	cfg.CaplinConfig.EnableValidatorMonitor = ctx.Bool(CaplinValidatorMonitorFlag.Name)
+	cfg.CaplinConfig.GossipCaptureDir = ctx.String(CaplinGossipCaptureDirFlag.Name)
 	if checkpointUrls := ctx.StringSlice(CaplinCheckpointSyncUrlFlag.Name); len(checkpointUrls) > 0 {
 		clparams.ConfigurableCheckpointsURLs = checkpointUrls
 	}
@@ -1788,6 +1853,8 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.CaplinConfig.CaplinDiscoveryTCPPort = ctx.Uint64(CaplinDiscoveryTCPPortFlag.Name)
 	cfg.CaplinConfig.SentinelAddr = ctx.String(SentinelAddrFlag.Name)
 	cfg.CaplinConfig.SentinelPort = ctx.Uint64(SentinelPortFlag.Name)
+	cfg.CaplinConfig.SentinelDebugAddr = ctx.String(SentinelDebugAddrFlag.Name)
+	cfg.CaplinConfig.SentinelScoreParamsPath = ctx.String(SentinelScoreParamsFlag.Name)
 	cfg.CaplinConfig.BootstrapNodes = ctx.StringSlice(SentinelBootnodes.Name)
 
 	chain := ctx.String(ChainFlag.Name) // mainnet by default
@@ -32,4 +32,5 @@ type BlockBuilderParameters struct {
 	SuggestedFeeRecipient libcommon.Address
 	Withdrawals           []*types.Withdrawal // added in Shapella (EIP-4895)
 	ParentBeaconBlockRoot *libcommon.Hash     // added in Dencun (EIP-4788)
+	InclusionList         types.Transactions  // forced to be included ahead of the txpool transactions, in order
 }
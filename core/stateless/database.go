@@ -0,0 +1,231 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/turbo/trie"
+)
+
+var (
+	_ state.StateReader          = (*Database)(nil)
+	_ state.WriterWithChangeSets = (*Database)(nil)
+)
+
+// Database is the state of a block backed by the partial trie of a witness.
+// Reads of keys the witness doesn't prove fail. Writes are buffered and applied
+// to the trie by Root, because the writer receives the storage of a new account
+// before the account itself.
+type Database struct {
+	trie  *trie.Trie
+	codes map[libcommon.Hash][]byte
+
+	accounts map[libcommon.Address]*accounts.Account
+	created  map[libcommon.Address]struct{}
+	deleted  map[libcommon.Address]struct{}
+	storage  map[libcommon.Address]map[libcommon.Hash]uint256.Int
+
+	missing error // the first read the witness couldn't serve
+}
+
+// NewDatabase opens the parent state of the witness.
+func NewDatabase(witness *Witness) (*Database, error) {
+	nodes := make([][]byte, 0, len(witness.State))
+	for node := range witness.State {
+		nodes = append(nodes, []byte(node))
+	}
+	t, err := trie.NewFromProofNodes(witness.Root(), nodes)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[libcommon.Hash][]byte, len(witness.Codes))
+	for code := range witness.Codes {
+		codes[crypto.Keccak256Hash([]byte(code))] = []byte(code)
+	}
+	return newDatabase(t, codes), nil
+}
+
+func newDatabase(t *trie.Trie, codes map[libcommon.Hash][]byte) *Database {
+	return &Database{
+		trie:     t,
+		codes:    codes,
+		accounts: make(map[libcommon.Address]*accounts.Account),
+		created:  make(map[libcommon.Address]struct{}),
+		deleted:  make(map[libcommon.Address]struct{}),
+		storage:  make(map[libcommon.Address]map[libcommon.Hash]uint256.Int),
+	}
+}
+
+// Missing returns the first read the witness couldn't serve. The execution may
+// swallow read errors, so it has to be checked once the block is executed.
+func (db *Database) Missing() error {
+	return db.missing
+}
+
+func (db *Database) fail(err error) error {
+	if db.missing == nil {
+		db.missing = err
+	}
+	return err
+}
+
+func (db *Database) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	acc, ok := db.trie.GetAccount(crypto.Keccak256(address[:]))
+	if !ok {
+		return nil, db.fail(fmt.Errorf("missing witness node for account %x", address))
+	}
+	if acc == nil {
+		return nil, nil
+	}
+	if !acc.IsEmptyCodeHash() {
+		// incarnations are not part of the trie
+		acc.Incarnation = state.FirstContractIncarnation
+	}
+	return acc, nil
+}
+
+func (db *Database) ReadAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash) ([]byte, error) {
+	v, ok := db.trie.Get(storageKey(address, *key))
+	if !ok {
+		return nil, db.fail(fmt.Errorf("missing witness node for storage %x %x", address, *key))
+	}
+	return v, nil
+}
+
+func (db *Database) ReadAccountCode(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash) ([]byte, error) {
+	if codeHash == (libcommon.Hash{}) || codeHash == trie.EmptyCodeHash {
+		return nil, nil
+	}
+	code, ok := db.codes[codeHash]
+	if !ok {
+		return nil, db.fail(fmt.Errorf("missing witness code %x of %x", codeHash, address))
+	}
+	return code, nil
+}
+
+func (db *Database) ReadAccountCodeSize(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash) (int, error) {
+	code, err := db.ReadAccountCode(address, incarnation, codeHash)
+	return len(code), err
+}
+
+func (db *Database) ReadAccountIncarnation(address libcommon.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (db *Database) UpdateAccountData(address libcommon.Address, original, account *accounts.Account) error {
+	acc := new(accounts.Account)
+	acc.Copy(account)
+	db.accounts[address] = acc
+	if _, ok := db.deleted[address]; ok {
+		// re-created after a self-destruct, with empty storage
+		delete(db.deleted, address)
+		db.created[address] = struct{}{}
+	}
+	return nil
+}
+
+func (db *Database) UpdateAccountCode(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash, code []byte) error {
+	if len(code) > 0 {
+		db.codes[codeHash] = code
+	}
+	return nil
+}
+
+func (db *Database) DeleteAccount(address libcommon.Address, original *accounts.Account) error {
+	delete(db.accounts, address)
+	delete(db.storage, address)
+	db.deleted[address] = struct{}{}
+	return nil
+}
+
+func (db *Database) WriteAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash, original, value *uint256.Int) error {
+	m, ok := db.storage[address]
+	if !ok {
+		m = make(map[libcommon.Hash]uint256.Int)
+		db.storage[address] = m
+	}
+	m[*key] = *value
+	return nil
+}
+
+func (db *Database) CreateContract(address libcommon.Address) error {
+	db.created[address] = struct{}{}
+	delete(db.storage, address)
+	return nil
+}
+
+func (db *Database) WriteChangeSets() error { return nil }
+func (db *Database) WriteHistory() error    { return nil }
+
+// Root applies the buffered writes to the trie and returns the new state root.
+// Insertions go first, so that a deletion only collapses the trie branches
+// that really lose their children, whose siblings the witness has to prove.
+func (db *Database) Root() (root libcommon.Hash, err error) {
+	defer func() {
+		// the trie panics when an update reaches a node missing in the witness
+		if r := recover(); r != nil {
+			err = fmt.Errorf("applying state changes: %v", r)
+		}
+	}()
+
+	for _, address := range sortedAddresses(db.accounts) {
+		acc := db.accounts[address]
+		acc.Root = trie.EmptyRoot
+		addrHash := crypto.Keccak256(address[:])
+		db.trie.UpdateAccount(addrHash, acc)
+		if _, ok := db.created[address]; ok {
+			db.trie.DeleteSubtree(addrHash)
+		}
+	}
+	for _, address := range sortedAddresses(db.storage) {
+		slots := db.storage[address]
+		for _, key := range sortedHashes(slots) {
+			if v := slots[key]; !v.IsZero() {
+				db.trie.Update(storageKey(address, key), v.Bytes())
+			}
+		}
+	}
+	for _, address := range sortedAddresses(db.storage) {
+		slots := db.storage[address]
+		for _, key := range sortedHashes(slots) {
+			if v := slots[key]; v.IsZero() {
+				db.trie.Delete(storageKey(address, key))
+			}
+		}
+	}
+	for _, address := range sortedAddresses(db.deleted) {
+		db.trie.Delete(crypto.Keccak256(address[:]))
+	}
+
+	db.accounts = make(map[libcommon.Address]*accounts.Account)
+	db.created = make(map[libcommon.Address]struct{})
+	db.deleted = make(map[libcommon.Address]struct{})
+	db.storage = make(map[libcommon.Address]map[libcommon.Hash]uint256.Int)
+	return db.trie.Hash(), nil
+}
+
+func storageKey(address libcommon.Address, key libcommon.Hash) []byte {
+	return append(crypto.Keccak256(address[:]), crypto.Keccak256(key[:])...)
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"bytes"
+	"math"
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types/accounts"
+)
+
+var (
+	_ state.StateReader          = (*Recorder)(nil)
+	_ state.WriterWithChangeSets = (*Recorder)(nil)
+)
+
+// Recorder wraps the reader of the parent state of a block and records all the
+// accounts, storage slots and codes accessed while the block is executed. It is
+// also the state writer of the execution: writes are discarded, but accounts and
+// slots deleted by the block are remembered, because a deletion may collapse a
+// trie branch and so needs more nodes in the witness than the deleted key alone.
+type Recorder struct {
+	reader state.StateReader
+
+	accounts map[libcommon.Address]struct{}
+	storage  map[libcommon.Address]map[libcommon.Hash]struct{}
+	codes    map[libcommon.Hash][]byte
+
+	deletedAccounts map[libcommon.Address]struct{}
+	deletedStorage  map[libcommon.Address]map[libcommon.Hash]struct{}
+
+	minBlockHash uint64
+}
+
+func NewRecorder(reader state.StateReader) *Recorder {
+	return &Recorder{
+		reader:          reader,
+		accounts:        make(map[libcommon.Address]struct{}),
+		storage:         make(map[libcommon.Address]map[libcommon.Hash]struct{}),
+		codes:           make(map[libcommon.Hash][]byte),
+		deletedAccounts: make(map[libcommon.Address]struct{}),
+		deletedStorage:  make(map[libcommon.Address]map[libcommon.Hash]struct{}),
+		minBlockHash:    math.MaxUint64,
+	}
+}
+
+// BlockHashFn wraps the BLOCKHASH lookup of the execution to record the oldest
+// ancestor the block needs.
+func (r *Recorder) BlockHashFn(blockHash func(n uint64) libcommon.Hash) func(n uint64) libcommon.Hash {
+	return func(n uint64) libcommon.Hash {
+		r.minBlockHash = min(r.minBlockHash, n)
+		return blockHash(n)
+	}
+}
+
+// MinBlockHash returns the lowest block number requested through BLOCKHASH.
+func (r *Recorder) MinBlockHash() (uint64, bool) {
+	return r.minBlockHash, r.minBlockHash != math.MaxUint64
+}
+
+// Accounts returns the accessed accounts, sorted.
+func (r *Recorder) Accounts() []libcommon.Address {
+	return sortedAddresses(r.accounts)
+}
+
+// Storage returns the accessed slots of every account, sorted.
+func (r *Recorder) Storage() map[libcommon.Address][]libcommon.Hash {
+	return sortedSlots(r.storage)
+}
+
+// Codes returns the accessed codes.
+func (r *Recorder) Codes() [][]byte {
+	codes := make([][]byte, 0, len(r.codes))
+	for _, code := range r.codes {
+		codes = append(codes, code)
+	}
+	slices.SortFunc(codes, bytes.Compare)
+	return codes
+}
+
+// DeletedAccounts returns the accounts deleted by the block, sorted.
+func (r *Recorder) DeletedAccounts() []libcommon.Address {
+	return sortedAddresses(r.deletedAccounts)
+}
+
+// DeletedStorage returns the slots zeroed by the block, sorted.
+func (r *Recorder) DeletedStorage() map[libcommon.Address][]libcommon.Hash {
+	return sortedSlots(r.deletedStorage)
+}
+
+func (r *Recorder) addSlot(slots map[libcommon.Address]map[libcommon.Hash]struct{}, address libcommon.Address, key libcommon.Hash) {
+	m, ok := slots[address]
+	if !ok {
+		m = make(map[libcommon.Hash]struct{})
+		slots[address] = m
+	}
+	m[key] = struct{}{}
+}
+
+func (r *Recorder) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	r.accounts[address] = struct{}{}
+	return r.reader.ReadAccountData(address)
+}
+
+func (r *Recorder) ReadAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash) ([]byte, error) {
+	r.accounts[address] = struct{}{}
+	r.addSlot(r.storage, address, *key)
+	return r.reader.ReadAccountStorage(address, incarnation, key)
+}
+
+func (r *Recorder) ReadAccountCode(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash) ([]byte, error) {
+	r.accounts[address] = struct{}{}
+	code, err := r.reader.ReadAccountCode(address, incarnation, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) > 0 {
+		r.codes[codeHash] = code
+	}
+	return code, nil
+}
+
+// ReadAccountCodeSize records the whole code: the size alone can't be proven
+// against the code hash.
+func (r *Recorder) ReadAccountCodeSize(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash) (int, error) {
+	code, err := r.ReadAccountCode(address, incarnation, codeHash)
+	if err != nil {
+		return 0, err
+	}
+	return len(code), nil
+}
+
+func (r *Recorder) ReadAccountIncarnation(address libcommon.Address) (uint64, error) {
+	return r.reader.ReadAccountIncarnation(address)
+}
+
+func (r *Recorder) UpdateAccountData(address libcommon.Address, original, account *accounts.Account) error {
+	r.accounts[address] = struct{}{}
+	delete(r.deletedAccounts, address)
+	return nil
+}
+
+func (r *Recorder) UpdateAccountCode(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash, code []byte) error {
+	return nil
+}
+
+func (r *Recorder) DeleteAccount(address libcommon.Address, original *accounts.Account) error {
+	r.accounts[address] = struct{}{}
+	r.deletedAccounts[address] = struct{}{}
+	return nil
+}
+
+func (r *Recorder) WriteAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash, original, value *uint256.Int) error {
+	r.addSlot(r.storage, address, *key)
+	if value.IsZero() {
+		r.addSlot(r.deletedStorage, address, *key)
+	} else if m, ok := r.deletedStorage[address]; ok {
+		delete(m, *key)
+	}
+	return nil
+}
+
+func (r *Recorder) CreateContract(address libcommon.Address) error {
+	r.accounts[address] = struct{}{}
+	return nil
+}
+
+func (r *Recorder) WriteChangeSets() error { return nil }
+func (r *Recorder) WriteHistory() error    { return nil }
+
+func sortedAddresses[V any](m map[libcommon.Address]V) []libcommon.Address {
+	res := make([]libcommon.Address, 0, len(m))
+	for addr := range m {
+		res = append(res, addr)
+	}
+	slices.SortFunc(res, func(a, b libcommon.Address) int { return bytes.Compare(a[:], b[:]) })
+	return res
+}
+
+func sortedHashes[V any](m map[libcommon.Hash]V) []libcommon.Hash {
+	res := make([]libcommon.Hash, 0, len(m))
+	for hash := range m {
+		res = append(res, hash)
+	}
+	slices.SortFunc(res, func(a, b libcommon.Hash) int { return bytes.Compare(a[:], b[:]) })
+	return res
+}
+
+func sortedSlots(slots map[libcommon.Address]map[libcommon.Hash]struct{}) map[libcommon.Address][]libcommon.Hash {
+	res := make(map[libcommon.Address][]libcommon.Hash, len(slots))
+	for addr, m := range slots {
+		if len(m) > 0 {
+			res[addr] = sortedHashes(m)
+		}
+	}
+	return res
+}
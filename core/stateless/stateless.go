@@ -0,0 +1,117 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/rlp"
+)
+
+// ExecuteStateless executes the block on top of the parent state proven by the
+// witness, without any access to the database, and returns the resulting state
+// and receipt roots. It's up to the caller to compare them with the ones in the
+// block header: a mismatch means either an invalid block or an invalid witness.
+func ExecuteStateless(config *chain.Config, vmConfig vm.Config, block *types.Block, witness *Witness, engine consensus.Engine, logger log.Logger) (stateRoot, receiptRoot libcommon.Hash, err error) {
+	if len(witness.Headers) == 0 {
+		return stateRoot, receiptRoot, errors.New("witness has no parent header")
+	}
+	if parent := witness.Headers[0]; block.ParentHash() != parent.Hash() {
+		return stateRoot, receiptRoot, fmt.Errorf("witness parent %d (%x) is not the parent of block %d", parent.Number.Uint64(), parent.Hash(), block.NumberU64())
+	}
+	db, err := NewDatabase(witness)
+	if err != nil {
+		return stateRoot, receiptRoot, fmt.Errorf("witness state: %w", err)
+	}
+	chainReader := newWitnessChain(config, witness)
+	blockHashFn := core.GetHashFn(block.Header(), chainReader.GetHeader)
+
+	vmConfig.Debug, vmConfig.Tracer = false, nil
+	res, err := core.ExecuteBlockEphemerally(config, &vmConfig, blockHashFn, engine, block, db, db, chainReader, nil, logger)
+	if db.Missing() != nil {
+		return stateRoot, receiptRoot, db.Missing()
+	}
+	if err != nil {
+		return stateRoot, receiptRoot, err
+	}
+	if stateRoot, err = db.Root(); err != nil {
+		return stateRoot, receiptRoot, err
+	}
+	return stateRoot, res.ReceiptRoot, nil
+}
+
+// witnessChain serves the headers of a witness to the consensus engine.
+type witnessChain struct {
+	config  *chain.Config
+	headers map[libcommon.Hash]*types.Header
+	parent  *types.Header
+}
+
+var _ consensus.ChainReader = (*witnessChain)(nil)
+
+func newWitnessChain(config *chain.Config, witness *Witness) *witnessChain {
+	headers := make(map[libcommon.Hash]*types.Header, len(witness.Headers))
+	for _, header := range witness.Headers {
+		headers[header.Hash()] = header
+	}
+	return &witnessChain{config: config, headers: headers, parent: witness.Headers[0]}
+}
+
+func (c *witnessChain) Config() *chain.Config                 { return c.config }
+func (c *witnessChain) CurrentHeader() *types.Header          { return c.parent }
+func (c *witnessChain) CurrentFinalizedHeader() *types.Header { return nil }
+func (c *witnessChain) CurrentSafeHeader() *types.Header      { return nil }
+
+func (c *witnessChain) GetHeader(hash libcommon.Hash, number uint64) *types.Header {
+	if header, ok := c.headers[hash]; ok && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *witnessChain) GetHeaderByNumber(number uint64) *types.Header {
+	for _, header := range c.headers {
+		if header.Number.Uint64() == number {
+			return header
+		}
+	}
+	return nil
+}
+
+func (c *witnessChain) GetHeaderByHash(hash libcommon.Hash) *types.Header { return c.headers[hash] }
+func (c *witnessChain) GetTd(hash libcommon.Hash, number uint64) *big.Int { return nil }
+func (c *witnessChain) FrozenBlocks() uint64                              { return 0 }
+func (c *witnessChain) FrozenBorBlocks() uint64                           { return 0 }
+func (c *witnessChain) BorSpan(spanId uint64) []byte                      { return nil }
+func (c *witnessChain) GetBlock(hash libcommon.Hash, number uint64) *types.Block {
+	return nil
+}
+func (c *witnessChain) HasBlock(hash libcommon.Hash, number uint64) bool { return false }
+func (c *witnessChain) BorEventsByBlock(hash libcommon.Hash, number uint64) []rlp.RawValue {
+	return nil
+}
+func (c *witnessChain) BorStartEventID(hash libcommon.Hash, number uint64) uint64 { return 0 }
@@ -0,0 +1,216 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+	"github.com/erigontech/erigon/turbo/trie"
+)
+
+// counter increments slot 0, clears slot 1 and stores the parent block hash in slot 3
+var counterCode = hexutility.MustDecodeHex("0x600054600101600055600060015560014303406003550000")
+
+type statelessFixture struct {
+	m      *mock.MockSentry
+	chain  *core.ChainPack
+	trie   *trie.Trie
+	codes  map[libcommon.Hash][]byte
+	alloc  types.GenesisAlloc
+	parent *types.Header
+}
+
+func newStatelessFixture(t *testing.T) *statelessFixture {
+	t.Helper()
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	contract := libcommon.HexToAddress("0xc0de")
+	empty := libcommon.HexToAddress("0xe111")
+	alloc := types.GenesisAlloc{
+		sender: {Balance: big.NewInt(params.Ether)},
+		contract: {Balance: big.NewInt(0), Code: counterCode, Storage: map[libcommon.Hash]libcommon.Hash{
+			libcommon.HexToHash("0x01"): libcommon.HexToHash("0x05"),
+			libcommon.HexToHash("0x02"): libcommon.HexToHash("0x07"),
+		}},
+		empty: {Balance: big.NewInt(0)},
+	}
+	for i := 0; i < 64; i++ {
+		alloc[libcommon.BytesToAddress([]byte{0xaa, byte(i)})] = types.GenesisAccount{Balance: big.NewInt(int64(i + 1))}
+	}
+	gspec := &types.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, func(i int, b *core.BlockGen) {
+		txs := []*types.LegacyTx{
+			// calls the counter
+			types.NewTransaction(b.TxNonce(sender), contract, uint256.NewInt(0), 100_000, uint256.NewInt(1), nil),
+			// creates an account
+			types.NewTransaction(b.TxNonce(sender)+1, libcommon.BytesToAddress([]byte{0xbb, byte(i)}), uint256.NewInt(1000), 21_000, uint256.NewInt(1), nil),
+		}
+		if i == 1 {
+			// touches and so deletes the empty account
+			txs = append(txs, types.NewTransaction(b.TxNonce(sender)+2, empty, uint256.NewInt(0), 21_000, uint256.NewInt(1), nil))
+		}
+		for _, txn := range txs {
+			signed, err := types.SignTx(txn, *signer, key)
+			require.NoError(t, err)
+			b.AddTx(signed)
+		}
+	})
+	require.NoError(t, err)
+
+	// the full parent state of the first block
+	tr := trie.New(libcommon.Hash{})
+	codes := map[libcommon.Hash][]byte{}
+	for addr, ga := range alloc {
+		acc := accounts.NewAccount()
+		acc.Initialised = true
+		acc.Balance.SetFromBig(ga.Balance)
+		acc.Nonce = ga.Nonce
+		acc.Root = trie.EmptyRoot
+		if len(ga.Code) > 0 {
+			acc.CodeHash = crypto.Keccak256Hash(ga.Code)
+			codes[acc.CodeHash] = ga.Code
+		}
+		tr.UpdateAccount(crypto.Keccak256(addr[:]), &acc)
+		for k, v := range ga.Storage {
+			tr.Update(storageKey(addr, k), libcommon.Copy(uint256.NewInt(0).SetBytes(v[:]).Bytes()))
+		}
+	}
+	require.Equal(t, m.Genesis.Root(), tr.Hash())
+
+	return &statelessFixture{m: m, chain: chain, trie: tr, codes: codes, alloc: alloc, parent: m.Genesis.Header()}
+}
+
+// witness executes the block against the full state, builds its witness and
+// moves the full state to the block.
+func (f *statelessFixture) witness(t *testing.T, block *types.Block) (*Witness, *Recorder) {
+	t.Helper()
+	logger := log.New()
+	headers := &Witness{Headers: []*types.Header{f.parent}}
+	chainReader := newWitnessChain(f.m.ChainConfig, headers)
+	blockHashFn := core.GetHashFn(block.Header(), chainReader.GetHeader)
+
+	rec := NewRecorder(newDatabase(f.trie, f.codes))
+	_, err := core.ExecuteBlockEphemerally(f.m.ChainConfig, &vm.Config{}, rec.BlockHashFn(blockHashFn), f.m.Engine, block, rec, rec, chainReader, nil, logger)
+	require.NoError(t, err)
+
+	w := NewWitness(f.parent)
+	for _, addr := range rec.Accounts() {
+		proof, err := f.trie.Prove(crypto.Keccak256(addr[:]), 0, false)
+		require.NoError(t, err)
+		w.AddState(proof)
+		w.AddKey(addr[:])
+	}
+	deleted := rec.DeletedStorage()
+	for addr, slots := range rec.Storage() {
+		if _, ok := deleted[addr]; ok {
+			// a deletion may need the siblings of the slot
+			for k := range f.alloc[addr].Storage {
+				slots = append(slots, k)
+			}
+		}
+		for _, slot := range slots {
+			proof, err := f.trie.Prove(storageKey(addr, slot), 64, true)
+			require.NoError(t, err)
+			w.AddState(proof)
+			w.AddKey(append(addr[:], slot[:]...))
+		}
+	}
+	if len(rec.DeletedAccounts()) > 0 {
+		for addr := range f.alloc {
+			proof, err := f.trie.Prove(crypto.Keccak256(addr[:]), 0, false)
+			require.NoError(t, err)
+			w.AddState(proof)
+		}
+	}
+	for _, code := range rec.Codes() {
+		w.AddCode(code)
+	}
+
+	db := newDatabase(f.trie, f.codes)
+	_, err = core.ExecuteBlockEphemerally(f.m.ChainConfig, &vm.Config{}, blockHashFn, f.m.Engine, block, db, db, chainReader, nil, logger)
+	require.NoError(t, err)
+	root, err := db.Root()
+	require.NoError(t, err)
+	require.Equal(t, block.Root(), root)
+	f.parent = block.Header()
+	return w, rec
+}
+
+func TestExecuteStateless(t *testing.T) {
+	f := newStatelessFixture(t)
+	for _, block := range f.chain.Blocks {
+		w, rec := f.witness(t, block)
+		minBlockHash, ok := rec.MinBlockHash()
+		require.True(t, ok)
+		require.Equal(t, block.NumberU64()-1, minBlockHash)
+		if block.NumberU64() == 2 {
+			require.Len(t, rec.DeletedAccounts(), 1)
+		}
+
+		// through the external representation
+		enc, err := json.Marshal(w.ToExtWitness())
+		require.NoError(t, err)
+		var ext ExtWitness
+		require.NoError(t, json.Unmarshal(enc, &ext))
+		decoded, err := ext.ToWitness()
+		require.NoError(t, err)
+		require.Equal(t, w.Root(), decoded.Root())
+
+		stateRoot, receiptRoot, err := ExecuteStateless(f.m.ChainConfig, vm.Config{}, block, decoded, f.m.Engine, log.New())
+		require.NoError(t, err)
+		require.Equal(t, block.Root(), stateRoot, "block %d", block.NumberU64())
+		require.Equal(t, block.ReceiptHash(), receiptRoot, "block %d", block.NumberU64())
+	}
+}
+
+func TestExecuteStatelessIncompleteWitness(t *testing.T) {
+	f := newStatelessFixture(t)
+	block := f.chain.Blocks[0]
+	w, _ := f.witness(t, block)
+
+	noCode := NewWitness(w.Headers[0])
+	noCode.State = w.State
+	_, _, err := ExecuteStateless(f.m.ChainConfig, vm.Config{}, block, noCode, f.m.Engine, log.New())
+	require.ErrorContains(t, err, "missing witness code")
+
+	noState := NewWitness(w.Headers[0])
+	noState.Codes = w.Codes
+	_, _, err = ExecuteStateless(f.m.ChainConfig, vm.Config{}, block, noState, f.m.Engine, log.New())
+	require.ErrorContains(t, err, "missing witness node")
+
+	_, _, err = ExecuteStateless(f.m.ChainConfig, vm.Config{}, f.chain.Blocks[1], w, f.m.Engine, log.New())
+	require.ErrorContains(t, err, "is not the parent")
+}
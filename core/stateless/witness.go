@@ -0,0 +1,137 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package stateless records execution witnesses of blocks and re-executes
+// blocks from a witness alone, without access to the state database.
+package stateless
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+
+	"github.com/erigontech/erigon/core/types"
+)
+
+// Witness is everything needed to execute a block on top of its parent state:
+// the trie nodes proving all the touched accounts and storage slots against the
+// parent state root, the bytecodes of executed contracts and the headers of the
+// ancestors accessible through BLOCKHASH.
+type Witness struct {
+	Headers []*types.Header     // Parent header first, followed by the older ancestors
+	Codes   map[string]struct{} // Bytecodes of the contracts touched by the block
+	State   map[string]struct{} // RLP-encoded trie nodes of the parent state
+	Keys    map[string]struct{} // Addresses and storage keys (address+slot) touched by the block
+}
+
+// NewWitness creates an empty witness for a block built on top of parent.
+func NewWitness(parent *types.Header) *Witness {
+	return &Witness{
+		Headers: []*types.Header{parent},
+		Codes:   make(map[string]struct{}),
+		State:   make(map[string]struct{}),
+		Keys:    make(map[string]struct{}),
+	}
+}
+
+// Root returns the parent state root the witness is proven against.
+func (w *Witness) Root() libcommon.Hash {
+	return w.Headers[0].Root
+}
+
+// AddHeader appends the next older ancestor, which must be the parent of the
+// oldest header already in the witness.
+func (w *Witness) AddHeader(header *types.Header) error {
+	last := w.Headers[len(w.Headers)-1]
+	if header.Hash() != last.ParentHash {
+		return fmt.Errorf("header %d (%x) is not the parent of %d", header.Number.Uint64(), header.Hash(), last.Number.Uint64())
+	}
+	w.Headers = append(w.Headers, header)
+	return nil
+}
+
+func (w *Witness) AddCode(code []byte) {
+	if len(code) > 0 {
+		w.Codes[string(code)] = struct{}{}
+	}
+}
+
+func (w *Witness) AddState(nodes [][]byte) {
+	for _, node := range nodes {
+		w.State[string(node)] = struct{}{}
+	}
+}
+
+func (w *Witness) AddKey(key []byte) {
+	w.Keys[string(key)] = struct{}{}
+}
+
+// ExtWitness is the external representation of a witness, compatible with the
+// one returned by debug_executionWitness in go-ethereum.
+type ExtWitness struct {
+	Headers []*types.Header    `json:"headers"`
+	Codes   []hexutility.Bytes `json:"codes"`
+	State   []hexutility.Bytes `json:"state"`
+	Keys    []hexutility.Bytes `json:"keys"`
+}
+
+// ToExtWitness converts the witness to its external representation, with the
+// codes, nodes and keys sorted to keep the output deterministic.
+func (w *Witness) ToExtWitness() *ExtWitness {
+	return &ExtWitness{
+		Headers: slices.Clone(w.Headers),
+		Codes:   sortedBytes(w.Codes),
+		State:   sortedBytes(w.State),
+		Keys:    sortedBytes(w.Keys),
+	}
+}
+
+// ToWitness converts the external representation back, checking that the
+// headers form a chain.
+func (ext *ExtWitness) ToWitness() (*Witness, error) {
+	if len(ext.Headers) == 0 {
+		return nil, errors.New("witness has no parent header")
+	}
+	w := NewWitness(ext.Headers[0])
+	for _, header := range ext.Headers[1:] {
+		if err := w.AddHeader(header); err != nil {
+			return nil, err
+		}
+	}
+	for _, code := range ext.Codes {
+		w.AddCode(code)
+	}
+	for _, node := range ext.State {
+		w.State[string(node)] = struct{}{}
+	}
+	for _, key := range ext.Keys {
+		w.AddKey(key)
+	}
+	return w, nil
+}
+
+func sortedBytes(set map[string]struct{}) []hexutility.Bytes {
+	res := make([]hexutility.Bytes, 0, len(set))
+	for k := range set {
+		res = append(res, hexutility.Bytes(k))
+	}
+	slices.SortFunc(res, func(a, b hexutility.Bytes) int { return bytes.Compare(a, b) })
+	return res
+}
@@ -0,0 +1,492 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+// Read-only access to the committed trie: merkle proofs, hash ordered iteration over leaves and
+// lookup of single trie nodes. Nodes are rebuilt from the branch data stored via PatriciaContext
+// together with the account and storage values, so the grid is neither unfolded nor modified and
+// these methods can be used on a trie restored from the latest commitment state.
+
+// ErrNodeNotFound is returned by TrieNode when the path does not end at a node boundary
+var ErrNodeNotFound = errors.New("trie node not found")
+
+// proofRow is a single branch node loaded from PatriciaContext
+type proofRow struct {
+	bitmap uint16
+	cells  [16]cell
+}
+
+// loadProofRow reads the branch node with the given nibble prefix and fills its cells
+// with the account and storage values they refer to
+func (hph *HexPatriciaHashed) loadProofRow(prefix []byte) (*proofRow, error) {
+	key := hexToCompact(prefix)
+	if len(key) == 0 {
+		key = temporalReplacementForEmpty
+	}
+	branchData, _, err := hph.ctx.Branch(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(branchData) < 4 {
+		return nil, fmt.Errorf("branch node %x not found", key)
+	}
+	branchData = branchData[2:] // skip touch map
+	row := &proofRow{bitmap: binary.BigEndian.Uint16(branchData[0:])}
+	pos := 2
+	for bitset := row.bitmap; bitset != 0; {
+		bit := bitset & -bitset
+		nibble := bits.TrailingZeros16(bit)
+		cell := &row.cells[nibble]
+		cell.reset()
+		if pos >= len(branchData) {
+			return nil, fmt.Errorf("branch node %x is truncated", key)
+		}
+		fieldBits := branchData[pos]
+		pos++
+		if pos, err = cell.fillFromFields(branchData, pos, cellFields(fieldBits)); err != nil {
+			return nil, fmt.Errorf("prefix [%x], branchData[%x]: %w", prefix, branchData, err)
+		}
+		if cell.accountAddrLen > 0 {
+			update, err := hph.ctx.Account(cell.accountAddr[:cell.accountAddrLen])
+			if err != nil {
+				return nil, fmt.Errorf("loadProofRow GetAccount: %w", err)
+			}
+			cell.setFromUpdate(update)
+		}
+		if cell.storageAddrLen > 0 {
+			update, err := hph.ctx.Storage(cell.storageAddr[:cell.storageAddrLen])
+			if err != nil {
+				return nil, fmt.Errorf("loadProofRow GetStorage: %w", err)
+			}
+			cell.setFromUpdate(update)
+		}
+		bitset ^= bit
+	}
+	return row, nil
+}
+
+// isLeaf reports whether the cell terminates the path: an account in the accounts trie
+// or a storage item in the storage trie
+func isLeaf(cell *cell, storage bool) bool {
+	if storage {
+		return cell.storageAddrLen > 0
+	}
+	return cell.accountAddrLen > 0
+}
+
+// leafKey returns remaining nibbles of the leaf key starting from depth, terminated by 16
+func (hph *HexPatriciaHashed) leafKey(cell *cell, depth int, storage bool, dest []byte) ([]byte, error) {
+	if storage {
+		offset := depth - 64
+		if err := hashKey(hph.keccak, cell.storageAddr[hph.accountKeyLen:cell.storageAddrLen], dest, offset); err != nil {
+			return nil, err
+		}
+		dest[64-offset] = 16
+		return dest[:65-offset], nil
+	}
+	if err := hashKey(hph.keccak, cell.accountAddr[:cell.accountAddrLen], dest, depth); err != nil {
+		return nil, err
+	}
+	dest[64-depth] = 16
+	return dest[:65-depth], nil
+}
+
+// storageRoot computes root of the storage trie of the account cell
+func (hph *HexPatriciaHashed) storageRoot(cell *cell) ([length.Hash]byte, error) {
+	switch {
+	case cell.storageAddrLen > 0:
+		// storage trie consists of a single leaf
+		var keyBuf [65]byte
+		key, err := hph.leafKey(cell, 64, true, keyBuf[:])
+		if err != nil {
+			return [length.Hash]byte{}, err
+		}
+		aux, err := hph.leafHashWithKeyVal(make([]byte, 0, 33), key, cell.Storage[:cell.StorageLen], true)
+		if err != nil {
+			return [length.Hash]byte{}, err
+		}
+		return *(*[length.Hash]byte)(aux[1:]), nil
+	case cell.extLen > 0 && cell.hashLen > 0:
+		return hph.extensionHash(cell.extension[:cell.extLen], cell.hash[:cell.hashLen])
+	case cell.hashLen > 0:
+		return cell.hash, nil
+	default:
+		return *(*[length.Hash]byte)(EmptyRootHash), nil
+	}
+}
+
+func appendRlpString(buf, s []byte) []byte {
+	pos := len(buf)
+	buf = append(buf, make([]byte, rlp.StringLen(s))...)
+	rlp.EncodeString(s, buf[pos:])
+	return buf
+}
+
+func rlpListNode(body []byte) []byte {
+	var prefix [10]byte
+	pt := rlp.EncodeListPrefix(len(body), prefix[:])
+	node := make([]byte, 0, pt+len(body))
+	node = append(node, prefix[:pt]...)
+	return append(node, body...)
+}
+
+func (hph *HexPatriciaHashed) leafNode(cell *cell, depth int, storage bool) ([]byte, error) {
+	var keyBuf [65]byte
+	key, err := hph.leafKey(cell, depth, storage, keyBuf[:])
+	if err != nil {
+		return nil, err
+	}
+	body := appendRlpString(nil, hexToCompact(key))
+	if storage {
+		return rlpListNode(appendRlpString(body, appendRlpString(nil, cell.Storage[:cell.StorageLen]))), nil
+	}
+	storageRoot, err := hph.storageRoot(cell)
+	if err != nil {
+		return nil, err
+	}
+	var valBuf [128]byte
+	valLen := cell.accountForHashing(valBuf[:], storageRoot)
+	return rlpListNode(appendRlpString(body, valBuf[:valLen])), nil
+}
+
+func extensionNode(ext, hash []byte) []byte {
+	body := appendRlpString(nil, hexToCompact(ext))
+	return rlpListNode(appendRlpString(body, hash))
+}
+
+func (hph *HexPatriciaHashed) branchNode(row *proofRow, depth int) ([]byte, error) {
+	body := make([]byte, 0, 17*(length.Hash+1))
+	for nibble := 0; nibble < 16; nibble++ {
+		if row.bitmap&(uint16(1)<<nibble) == 0 {
+			body = append(body, 0x80)
+			continue
+		}
+		cellHash, err := hph.computeCellHash(&row.cells[nibble], depth, nil)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, cellHash...)
+	}
+	body = append(body, 0x80)
+	return rlpListNode(body), nil
+}
+
+// walkPath follows key from the cell at given depth. When proof is not nil, encodings of all
+// hashed nodes met on the way are appended to it. Returns the leaf cell matching the key, if any.
+// onNode is called with the depth and encoding of each node, including embedded ones, and can stop the walk.
+func (hph *HexPatriciaHashed) walkPath(cell *cell, depth int, key []byte, storage bool, proof *[][]byte, onNode func(depth int, node []byte) bool) (*cell, error) {
+	collect := proof != nil || onNode != nil
+	for {
+		if isLeaf(cell, storage) {
+			var keyBuf [65]byte
+			leafKey, err := hph.leafKey(cell, depth, storage, keyBuf[:])
+			if err != nil {
+				return nil, err
+			}
+			if collect {
+				node, err := hph.leafNode(cell, depth, storage)
+				if err != nil {
+					return nil, err
+				}
+				// storage leaves shorter than a hash are embedded into the parent branch
+				if proof != nil && (!storage || depth == 64 || len(node) >= length.Hash) {
+					*proof = append(*proof, node)
+				}
+				if onNode != nil && !onNode(depth, node) {
+					return nil, nil
+				}
+			}
+			if !bytes.Equal(leafKey[:len(leafKey)-1], key[depth:]) {
+				return nil, nil
+			}
+			return cell, nil
+		}
+		if cell.hashLen == 0 {
+			return nil, nil
+		}
+		ext := cell.extension[:cell.extLen]
+		if len(ext) > 0 {
+			if collect {
+				node := extensionNode(ext, cell.hash[:cell.hashLen])
+				if proof != nil {
+					*proof = append(*proof, node)
+				}
+				if onNode != nil && !onNode(depth, node) {
+					return nil, nil
+				}
+			}
+			if !bytes.HasPrefix(key[depth:], ext) {
+				return nil, nil
+			}
+		}
+		prefix := key[:depth+len(ext)]
+		row, err := hph.loadProofRow(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if collect {
+			node, err := hph.branchNode(row, len(prefix)+1)
+			if err != nil {
+				return nil, err
+			}
+			if proof != nil {
+				*proof = append(*proof, node)
+			}
+			if onNode != nil && !onNode(len(prefix), node) {
+				return nil, nil
+			}
+		}
+		if len(prefix) == len(key) {
+			return nil, nil
+		}
+		nibble := key[len(prefix)]
+		if row.bitmap&(uint16(1)<<nibble) == 0 {
+			return nil, nil
+		}
+		cell, depth = &row.cells[nibble], len(prefix)+1
+	}
+}
+
+// walkLeaves visits leaves under the cell in key order, skipping those with keys lower than origin.
+// path holds the first depth nibbles of the key.
+func (hph *HexPatriciaHashed) walkLeaves(cell *cell, depth int, path, origin []byte, storage bool, fn func(cell *cell, key []byte) (bool, error)) (bool, error) {
+	if isLeaf(cell, storage) {
+		var keyBuf [65]byte
+		leafKey, err := hph.leafKey(cell, depth, storage, keyBuf[:])
+		if err != nil {
+			return false, err
+		}
+		key := append(append(make([]byte, 0, len(origin)), path[:depth]...), leafKey[:len(leafKey)-1]...)
+		if bytes.Compare(key, origin) < 0 {
+			return true, nil
+		}
+		return fn(cell, key)
+	}
+	if cell.hashLen == 0 {
+		return true, nil
+	}
+	prefix := append(append(make([]byte, 0, len(origin)), path[:depth]...), cell.extension[:cell.extLen]...)
+	cmp := bytes.Compare(prefix, origin[:len(prefix)])
+	if cmp < 0 {
+		return true, nil
+	}
+	row, err := hph.loadProofRow(prefix)
+	if err != nil {
+		return false, err
+	}
+	var from int
+	if cmp == 0 {
+		from = int(origin[len(prefix)])
+	}
+	for nibble := from; nibble < 16; nibble++ {
+		if row.bitmap&(uint16(1)<<nibble) == 0 {
+			continue
+		}
+		next, err := hph.walkLeaves(&row.cells[nibble], len(prefix)+1, append(prefix, byte(nibble)), origin, storage, fn)
+		if err != nil || !next {
+			return next, err
+		}
+	}
+	return true, nil
+}
+
+func nibblize(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2] = b >> 4
+		nibbles[i*2+1] = b & 0xf
+	}
+	return nibbles
+}
+
+func compactNibbles(nibbles []byte) []byte {
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[i*2]<<4 | nibbles[i*2+1]
+	}
+	return key
+}
+
+// findAccount returns the leaf cell of the account with given hashed key
+func (hph *HexPatriciaHashed) findAccount(hashedKey []byte) (*cell, error) {
+	if len(hashedKey) != length.Hash {
+		return nil, fmt.Errorf("invalid hashed account key length %d", len(hashedKey))
+	}
+	return hph.walkPath(&hph.root, 0, nibblize(hashedKey), false, nil, nil)
+}
+
+// ProveAccount returns merkle proof of presence or absence of the account with given hashed key,
+// starting from the root node
+func (hph *HexPatriciaHashed) ProveAccount(hashedKey []byte) ([][]byte, error) {
+	if len(hashedKey) != length.Hash {
+		return nil, fmt.Errorf("invalid hashed account key length %d", len(hashedKey))
+	}
+	proof := make([][]byte, 0, 8)
+	if _, err := hph.walkPath(&hph.root, 0, nibblize(hashedKey), false, &proof, nil); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// ProveStorage returns merkle proof of presence or absence of the storage item with given hashed key,
+// starting from the root node of the account storage trie. Proof is empty for accounts without storage.
+func (hph *HexPatriciaHashed) ProveStorage(hashedAccount, hashedKey []byte) ([][]byte, error) {
+	if len(hashedKey) != length.Hash {
+		return nil, fmt.Errorf("invalid hashed storage key length %d", len(hashedKey))
+	}
+	account, err := hph.findAccount(hashedAccount)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	proof := make([][]byte, 0, 8)
+	key := append(nibblize(hashedAccount), nibblize(hashedKey)...)
+	if _, err := hph.walkPath(account, 64, key, true, &proof, nil); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// TrieNode returns encoding of the node found at the nibble path, either in the accounts trie
+// or, when hashedAccount is given, in that account's storage trie
+func (hph *HexPatriciaHashed) TrieNode(hashedAccount, path []byte) ([]byte, error) {
+	start, depth, key := &hph.root, 0, make([]byte, 0, 128)
+	if hashedAccount != nil {
+		account, err := hph.findAccount(hashedAccount)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, ErrNodeNotFound
+		}
+		start, depth, key = account, 64, append(key, nibblize(hashedAccount)...)
+	}
+	key = append(key, path...)
+	if len(key) > depth+64 {
+		return nil, ErrNodeNotFound
+	}
+	// pad the path, the walk stops as soon as the requested depth is reached
+	target := len(key)
+	key = append(key, make([]byte, depth+64-len(key))...)
+	var found []byte
+	_, err := hph.walkPath(start, depth, key, hashedAccount != nil, nil, func(nodeDepth int, node []byte) bool {
+		if nodeDepth == target {
+			found = node
+		}
+		return nodeDepth < target
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrNodeNotFound
+	}
+	return found, nil
+}
+
+// LeafBranch returns the nibble path and children bitmap of the deepest branch node on the path
+// of the key, either in the accounts trie or, when hashedAccount is given, in that account's storage
+// trie. Deleting a leaf from a branch left with a single child merges the child into its parent,
+// so stateless clients need the encodings of the siblings of deleted leaves, see TrieNode.
+// ok is false if there is no branch on the path.
+func (hph *HexPatriciaHashed) LeafBranch(hashedAccount, hashedKey []byte) (path []byte, bitmap uint16, ok bool, err error) {
+	if len(hashedKey) != length.Hash {
+		return nil, 0, false, fmt.Errorf("invalid hashed key length %d", len(hashedKey))
+	}
+	cell, depth, key := &hph.root, 0, nibblize(hashedKey)
+	storage := hashedAccount != nil
+	if storage {
+		account, err := hph.findAccount(hashedAccount)
+		if err != nil || account == nil {
+			return nil, 0, false, err
+		}
+		cell, depth, key = account, 64, append(nibblize(hashedAccount), key...)
+	}
+	start := depth
+	for !isLeaf(cell, storage) && cell.hashLen > 0 {
+		ext := cell.extension[:cell.extLen]
+		if !bytes.HasPrefix(key[depth:], ext) {
+			break
+		}
+		prefix := key[:depth+len(ext)]
+		row, err := hph.loadProofRow(prefix)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		path, bitmap, ok = prefix[start:], row.bitmap, true
+		if len(prefix) == len(key) {
+			break
+		}
+		nibble := key[len(prefix)]
+		if row.bitmap&(uint16(1)<<nibble) == 0 {
+			break
+		}
+		cell, depth = &row.cells[nibble], len(prefix)+1
+	}
+	return bytes.Clone(path), bitmap, ok, nil
+}
+
+// IterateAccounts calls fn for accounts with hashed keys not lower than origin, in hashed key order,
+// until fn returns false. storageRoot is the root hash of the account storage trie.
+func (hph *HexPatriciaHashed) IterateAccounts(origin []byte, fn func(hashedKey, plainKey []byte, account *Update, storageRoot []byte) (bool, error)) error {
+	originNibbles := make([]byte, 64)
+	if origin != nil {
+		if len(origin) != length.Hash {
+			return fmt.Errorf("invalid origin length %d", len(origin))
+		}
+		originNibbles = nibblize(origin)
+	}
+	_, err := hph.walkLeaves(&hph.root, 0, nil, originNibbles, false, func(cell *cell, key []byte) (bool, error) {
+		storageRoot, err := hph.storageRoot(cell)
+		if err != nil {
+			return false, err
+		}
+		return fn(compactNibbles(key), cell.accountAddr[:cell.accountAddrLen], &cell.Update, storageRoot[:])
+	})
+	return err
+}
+
+// IterateStorage calls fn for storage items of the account with hashed keys not lower than origin,
+// in hashed key order, until fn returns false
+func (hph *HexPatriciaHashed) IterateStorage(hashedAccount, origin []byte, fn func(hashedKey, plainKey, value []byte) (bool, error)) error {
+	account, err := hph.findAccount(hashedAccount)
+	if err != nil || account == nil {
+		return err
+	}
+	path := nibblize(hashedAccount)
+	originNibbles := append(nibblize(hashedAccount), make([]byte, 64)...)
+	if origin != nil {
+		if len(origin) != length.Hash {
+			return fmt.Errorf("invalid origin length %d", len(origin))
+		}
+		copy(originNibbles[64:], nibblize(origin))
+	}
+	_, err = hph.walkLeaves(account, 64, path, originNibbles, true, func(cell *cell, key []byte) (bool, error) {
+		return fn(compactNibbles(key[64:]), cell.storageAddr[:cell.storageAddrLen], cell.Storage[:cell.StorageLen])
+	})
+	return err
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+func testKeccak(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// verifyTestProof walks the proof from root along the hashed key and returns the value of the leaf,
+// or nil if the proof shows that the key is absent
+func verifyTestProof(t *testing.T, root, hashedKey []byte, proof [][]byte) []byte {
+	t.Helper()
+	nodes := make(map[string][]byte, len(proof))
+	for _, node := range proof {
+		nodes[string(testKeccak(node))] = node
+	}
+	resolve := func(ref []byte) []byte {
+		if ref[0] >= 0xc0 {
+			return ref // embedded node
+		}
+		pos, l, err := rlp.String(ref, 0)
+		require.NoError(t, err)
+		if l == 0 {
+			return nil
+		}
+		require.Equal(t, length.Hash, l)
+		node, ok := nodes[string(ref[pos:pos+l])]
+		require.True(t, ok, "missing proof node %x", ref[pos:pos+l])
+		return node
+	}
+	path := nibblize(hashedKey)
+	node := resolve(append([]byte{0x80 + length.Hash}, root...))
+	for node != nil {
+		dataPos, dataLen, err := rlp.List(node, 0)
+		require.NoError(t, err)
+		var items [][]byte
+		for pos := dataPos; pos < dataPos+dataLen; {
+			p, l, _, err := rlp.Prefix(node, pos)
+			require.NoError(t, err)
+			items = append(items, node[pos:p+l])
+			pos = p + l
+		}
+		switch len(items) {
+		case 17:
+			require.NotEmpty(t, path)
+			node, path = resolve(items[path[0]]), path[1:]
+		case 2:
+			pos, l, err := rlp.String(items[0], 0)
+			require.NoError(t, err)
+			compact := items[0][pos : pos+l]
+			nibbles := CompactedKeyToHex(compact)
+			if compact[0]&0x20 != 0 {
+				if !bytes.Equal(nibbles[:len(nibbles)-1], path) { // strip terminator
+					return nil
+				}
+				pos, l, err := rlp.String(items[1], 0)
+				require.NoError(t, err)
+				return items[1][pos : pos+l]
+			}
+			if !bytes.HasPrefix(path, nibbles) {
+				return nil
+			}
+			node, path = resolve(items[1]), path[len(nibbles):]
+		default:
+			t.Fatalf("invalid node with %d items: %x", len(items), node)
+		}
+	}
+	return nil
+}
+
+func Test_HexPatriciaHashed_ProofsAndIteration(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMockState(t)
+	rnd := rand.New(rand.NewSource(42))
+
+	randHex := func(n int) string {
+		b := make([]byte, n)
+		rnd.Read(b)
+		return hex.EncodeToString(b)
+	}
+
+	builder := NewUpdateBuilder()
+	accounts := make(map[string]uint64) // hashed key -> balance
+	storages := make(map[string]map[string][]byte)
+	for i := 0; i < 300; i++ {
+		addr := randHex(length.Addr)
+		balance := rnd.Uint64()
+		builder.Balance(addr, balance).Nonce(addr, uint64(i))
+		addrBytes, _ := hex.DecodeString(addr)
+		accounts[string(testKeccak(addrBytes))] = balance
+
+		var slots int
+		switch i {
+		case 0:
+			slots = 1 // storage trie consisting of a single leaf
+		case 1:
+			slots = 2
+		case 2, 3:
+			slots = 60
+		}
+		if slots == 0 {
+			continue
+		}
+		storage := make(map[string][]byte)
+		for j := 0; j < slots; j++ {
+			loc := randHex(length.Hash)
+			value := randHex(1 + j%20) // small values end up embedded into branches
+			builder.Storage(addr, loc, value)
+			locBytes, _ := hex.DecodeString(loc)
+			storage[string(testKeccak(locBytes))], _ = hex.DecodeString(value)
+		}
+		storages[string(testKeccak(addrBytes))] = storage
+	}
+	plainKeys, updates := builder.Build()
+	require.NoError(t, ms.applyPlainUpdates(plainKeys, updates))
+
+	hph := NewHexPatriciaHashed(length.Addr, ms, ms.TempDir())
+	upds := WrapKeyUpdates(t, ModeDirect, hph.hashAndNibblizeKey, plainKeys, updates)
+	defer upds.Close()
+	root, err := hph.Process(ctx, upds, "")
+	require.NoError(t, err)
+
+	hashedAccounts := make([]string, 0, len(accounts))
+	for k := range accounts {
+		hashedAccounts = append(hashedAccounts, k)
+	}
+	sort.Strings(hashedAccounts)
+
+	rootNode, err := hph.TrieNode(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, root, testKeccak(rootNode))
+
+	storageRoots := make(map[string][]byte)
+	var iterated []string
+	err = hph.IterateAccounts(nil, func(hashedKey, plainKey []byte, account *Update, storageRoot []byte) (bool, error) {
+		require.Equal(t, hashedKey, testKeccak(plainKey))
+		require.Equal(t, accounts[string(hashedKey)], account.Balance.Uint64())
+		iterated = append(iterated, string(hashedKey))
+		storageRoots[string(hashedKey)] = bytes.Clone(storageRoot)
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedAccounts, iterated)
+
+	// iteration from the middle of the key space stops when asked to
+	origin := []byte(hashedAccounts[100])
+	iterated = iterated[:0]
+	err = hph.IterateAccounts(origin, func(hashedKey, _ []byte, _ *Update, _ []byte) (bool, error) {
+		iterated = append(iterated, string(hashedKey))
+		return len(iterated) < 10, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedAccounts[100:110], iterated)
+
+	for _, hashedKey := range hashedAccounts {
+		proof, err := hph.ProveAccount([]byte(hashedKey))
+		require.NoError(t, err)
+		value := verifyTestProof(t, root, []byte(hashedKey), proof)
+		require.NotNil(t, value, "account %x", hashedKey)
+
+		dataPos, _, err := rlp.List(value, 0)
+		require.NoError(t, err)
+		pos, _, err := rlp.U64(value, dataPos)
+		require.NoError(t, err)
+		var balance uint256.Int
+		pos, err = rlp.U256(value, pos, &balance)
+		require.NoError(t, err)
+		require.Equal(t, accounts[hashedKey], balance.Uint64())
+		var storageRoot [length.Hash]byte
+		_, err = rlp.ParseHash(value, pos, storageRoot[:])
+		require.NoError(t, err)
+		require.Equal(t, storageRoots[hashedKey], storageRoot[:])
+
+		storage := storages[hashedKey]
+		if storage == nil {
+			require.Equal(t, EmptyRootHash, storageRoot[:])
+			continue
+		}
+		hashedSlots := make([]string, 0, len(storage))
+		for k := range storage {
+			hashedSlots = append(hashedSlots, k)
+		}
+		sort.Strings(hashedSlots)
+		var slots []string
+		err = hph.IterateStorage([]byte(hashedKey), nil, func(hashedSlot, _, value []byte) (bool, error) {
+			require.Equal(t, storage[string(hashedSlot)], value)
+			slots = append(slots, string(hashedSlot))
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, hashedSlots, slots)
+
+		for _, hashedSlot := range hashedSlots {
+			proof, err := hph.ProveStorage([]byte(hashedKey), []byte(hashedSlot))
+			require.NoError(t, err)
+			value := verifyTestProof(t, storageRoot[:], []byte(hashedSlot), proof)
+			pos, l, err := rlp.String(value, 0)
+			require.NoError(t, err)
+			require.Equal(t, storage[hashedSlot], value[pos:pos+l])
+		}
+		storageRootNode, err := hph.TrieNode([]byte(hashedKey), nil)
+		require.NoError(t, err)
+		require.Equal(t, storageRoot[:], testKeccak(storageRootNode))
+	}
+
+	// the deepest branches on the paths of accounts and storage items
+	for _, hashedKey := range hashedAccounts[:20] {
+		path, bitmap, ok, err := hph.LeafBranch(nil, []byte(hashedKey))
+		require.NoError(t, err)
+		require.True(t, ok)
+		key := nibblize([]byte(hashedKey))
+		require.Equal(t, key[:len(path)], path)
+		require.NotZero(t, bitmap&(uint16(1)<<key[len(path)]))
+		_, err = hph.TrieNode(nil, path)
+		require.NoError(t, err)
+		child, err := hph.TrieNode(nil, key[:len(path)+1])
+		require.NoError(t, err)
+		proof, err := hph.ProveAccount([]byte(hashedKey))
+		require.NoError(t, err)
+		require.Equal(t, proof[len(proof)-1], child)
+	}
+	for hashedKey, storage := range storages {
+		if len(storage) < 60 {
+			continue
+		}
+		for hashedSlot := range storage {
+			path, bitmap, ok, err := hph.LeafBranch([]byte(hashedKey), []byte(hashedSlot))
+			require.NoError(t, err)
+			require.True(t, ok)
+			key := nibblize([]byte(hashedSlot))
+			require.Equal(t, key[:len(path)], path)
+			require.NotZero(t, bitmap&(uint16(1)<<key[len(path)]))
+		}
+	}
+
+	// proofs of absence
+	for i := 0; i < 20; i++ {
+		absent := testKeccak([]byte(fmt.Sprintf("absent-%d", i)))
+		proof, err := hph.ProveAccount(absent)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+		require.Nil(t, verifyTestProof(t, root, absent, proof))
+	}
+}
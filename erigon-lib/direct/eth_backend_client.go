@@ -223,6 +223,14 @@ func (s *EthBackendClientDirect) AddPeer(ctx context.Context, in *remote.AddPeer
 	return s.server.AddPeer(ctx, in)
 }
 
+func (s *EthBackendClientDirect) PeerBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PeerBansReply, error) {
+	return s.server.PeerBans(ctx, in)
+}
+
+func (s *EthBackendClientDirect) ClearPeerBans(ctx context.Context, in *remote.ClearPeerBansRequest, opts ...grpc.CallOption) (*remote.ClearPeerBansReply, error) {
+	return s.server.ClearPeerBans(ctx, in)
+}
+
 func (s *EthBackendClientDirect) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PendingBlockReply, error) {
 	return s.server.PendingBlock(ctx, in)
 }
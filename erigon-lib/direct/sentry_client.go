@@ -35,6 +35,7 @@ const (
 	ETH66 = 66
 	ETH67 = 67
 	ETH68 = 68
+	ETH69 = 69
 )
 
 var ProtoIds = map[uint]map[sentry.MessageId]struct{}{
@@ -97,6 +98,34 @@ var ProtoIds = map[uint]map[sentry.MessageId]struct{}{
 		sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68: struct{}{},
 		sentry.MessageId_GET_POOLED_TRANSACTIONS_66:       struct{}{},
 		sentry.MessageId_POOLED_TRANSACTIONS_66:           struct{}{},
+		// snap/1 requests, delivered when the sentry serves snap next to eth
+		sentry.MessageId_GET_ACCOUNT_RANGE_SNAP1:  struct{}{},
+		sentry.MessageId_GET_STORAGE_RANGES_SNAP1: struct{}{},
+		sentry.MessageId_GET_BYTE_CODES_SNAP1:     struct{}{},
+		sentry.MessageId_GET_TRIE_NODES_SNAP1:     struct{}{},
+	},
+	// an eth/69 sentry keeps serving eth/68 peers, so it also delivers their receipts ids and block announcements
+	ETH69: {
+		sentry.MessageId_GET_BLOCK_HEADERS_66:             struct{}{},
+		sentry.MessageId_BLOCK_HEADERS_66:                 struct{}{},
+		sentry.MessageId_GET_BLOCK_BODIES_66:              struct{}{},
+		sentry.MessageId_BLOCK_BODIES_66:                  struct{}{},
+		sentry.MessageId_GET_RECEIPTS_66:                  struct{}{},
+		sentry.MessageId_RECEIPTS_66:                      struct{}{},
+		sentry.MessageId_GET_RECEIPTS_69:                  struct{}{},
+		sentry.MessageId_RECEIPTS_69:                      struct{}{},
+		sentry.MessageId_NEW_BLOCK_HASHES_66:              struct{}{},
+		sentry.MessageId_NEW_BLOCK_66:                     struct{}{},
+		sentry.MessageId_TRANSACTIONS_66:                  struct{}{},
+		sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68: struct{}{},
+		sentry.MessageId_GET_POOLED_TRANSACTIONS_66:       struct{}{},
+		sentry.MessageId_POOLED_TRANSACTIONS_66:           struct{}{},
+		sentry.MessageId_BLOCK_RANGE_UPDATE_69:            struct{}{},
+		// snap/1 requests, delivered when the sentry serves snap next to eth
+		sentry.MessageId_GET_ACCOUNT_RANGE_SNAP1:  struct{}{},
+		sentry.MessageId_GET_STORAGE_RANGES_SNAP1: struct{}{},
+		sentry.MessageId_GET_BYTE_CODES_SNAP1:     struct{}{},
+		sentry.MessageId_GET_TRIE_NODES_SNAP1:     struct{}{},
 	},
 }
 
@@ -159,6 +188,8 @@ func (c *SentryClientRemote) HandShake(ctx context.Context, in *emptypb.Empty, o
 		c.protocol = ETH67
 	case sentry.Protocol_ETH68:
 		c.protocol = ETH68
+	case sentry.Protocol_ETH69:
+		c.protocol = ETH69
 	default:
 		return nil, fmt.Errorf("unexpected protocol: %d", reply.Protocol)
 	}
@@ -325,6 +356,14 @@ func (c *SentryClientDirect) AddPeer(ctx context.Context, in *sentry.AddPeerRequ
 	return c.server.AddPeer(ctx, in)
 }
 
+func (c *SentryClientDirect) PeerBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentry.PeerBansReply, error) {
+	return c.server.PeerBans(ctx, in)
+}
+
+func (c *SentryClientDirect) ClearPeerBans(ctx context.Context, in *sentry.ClearPeerBansRequest, opts ...grpc.CallOption) (*sentry.ClearPeerBansReply, error) {
+	return c.server.ClearPeerBans(ctx, in)
+}
+
 type peersReply struct {
 	r   *sentry.PeerEvent
 	err error
@@ -87,6 +87,50 @@ func (c *MockSentryClientAddPeerCall) DoAndReturn(f func(context.Context, *sentr
 	return c
 }
 
+// ClearPeerBans mocks base method.
+func (m *MockSentryClient) ClearPeerBans(arg0 context.Context, arg1 *sentryproto.ClearPeerBansRequest, arg2 ...grpc.CallOption) (*sentryproto.ClearPeerBansReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClearPeerBans", varargs...)
+	ret0, _ := ret[0].(*sentryproto.ClearPeerBansReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearPeerBans indicates an expected call of ClearPeerBans.
+func (mr *MockSentryClientMockRecorder) ClearPeerBans(arg0, arg1 any, arg2 ...any) *MockSentryClientClearPeerBansCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPeerBans", reflect.TypeOf((*MockSentryClient)(nil).ClearPeerBans), varargs...)
+	return &MockSentryClientClearPeerBansCall{Call: call}
+}
+
+// MockSentryClientClearPeerBansCall wrap *gomock.Call
+type MockSentryClientClearPeerBansCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientClearPeerBansCall) Return(arg0 *sentryproto.ClearPeerBansReply, arg1 error) *MockSentryClientClearPeerBansCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientClearPeerBansCall) Do(f func(context.Context, *sentryproto.ClearPeerBansRequest, ...grpc.CallOption) (*sentryproto.ClearPeerBansReply, error)) *MockSentryClientClearPeerBansCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientClearPeerBansCall) DoAndReturn(f func(context.Context, *sentryproto.ClearPeerBansRequest, ...grpc.CallOption) (*sentryproto.ClearPeerBansReply, error)) *MockSentryClientClearPeerBansCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // HandShake mocks base method.
 func (m *MockSentryClient) HandShake(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*sentryproto.HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +299,50 @@ func (c *MockSentryClientNodeInfoCall) DoAndReturn(f func(context.Context, *empt
 	return c
 }
 
+// PeerBans mocks base method.
+func (m *MockSentryClient) PeerBans(arg0 context.Context, arg1 *emptypb.Empty, arg2 ...grpc.CallOption) (*sentryproto.PeerBansReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PeerBans", varargs...)
+	ret0, _ := ret[0].(*sentryproto.PeerBansReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerBans indicates an expected call of PeerBans.
+func (mr *MockSentryClientMockRecorder) PeerBans(arg0, arg1 any, arg2 ...any) *MockSentryClientPeerBansCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerBans", reflect.TypeOf((*MockSentryClient)(nil).PeerBans), varargs...)
+	return &MockSentryClientPeerBansCall{Call: call}
+}
+
+// MockSentryClientPeerBansCall wrap *gomock.Call
+type MockSentryClientPeerBansCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientPeerBansCall) Return(arg0 *sentryproto.PeerBansReply, arg1 error) *MockSentryClientPeerBansCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientPeerBansCall) Do(f func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*sentryproto.PeerBansReply, error)) *MockSentryClientPeerBansCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientPeerBansCall) DoAndReturn(f func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*sentryproto.PeerBansReply, error)) *MockSentryClientPeerBansCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // PeerById mocks base method.
 func (m *MockSentryClient) PeerById(arg0 context.Context, arg1 *sentryproto.PeerByIdRequest, arg2 ...grpc.CallOption) (*sentryproto.PeerByIdReply, error) {
 	m.ctrl.T.Helper()
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.27.1
+// - protoc             (unknown)
 // source: remote/ethbackend.proto
 
 package remoteproto
@@ -37,6 +37,8 @@ const (
 	ETHBACKEND_NodeInfo_FullMethodName                = "/remote.ETHBACKEND/NodeInfo"
 	ETHBACKEND_Peers_FullMethodName                   = "/remote.ETHBACKEND/Peers"
 	ETHBACKEND_AddPeer_FullMethodName                 = "/remote.ETHBACKEND/AddPeer"
+	ETHBACKEND_PeerBans_FullMethodName                = "/remote.ETHBACKEND/PeerBans"
+	ETHBACKEND_ClearPeerBans_FullMethodName           = "/remote.ETHBACKEND/ClearPeerBans"
 	ETHBACKEND_PendingBlock_FullMethodName            = "/remote.ETHBACKEND/PendingBlock"
 	ETHBACKEND_BorEvent_FullMethodName                = "/remote.ETHBACKEND/BorEvent"
 )
@@ -75,6 +77,10 @@ type ETHBACKENDClient interface {
 	// Peers collects and returns peers information from all running sentry instances.
 	Peers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersReply, error)
 	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error)
+	// PeerBans returns the nodes banned for misbehaviour, ending soonest first.
+	PeerBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeerBansReply, error)
+	// ClearPeerBans lifts the bans of the given nodes, or of all nodes if none are given.
+	ClearPeerBans(ctx context.Context, in *ClearPeerBansRequest, opts ...grpc.CallOption) (*ClearPeerBansReply, error)
 	// PendingBlock returns latest built block.
 	PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingBlockReply, error)
 	BorEvent(ctx context.Context, in *BorEventRequest, opts ...grpc.CallOption) (*BorEventReply, error)
@@ -260,6 +266,26 @@ func (c *eTHBACKENDClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts
 	return out, nil
 }
 
+func (c *eTHBACKENDClient) PeerBans(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeerBansReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerBansReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_PeerBans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) ClearPeerBans(ctx context.Context, in *ClearPeerBansRequest, opts ...grpc.CallOption) (*ClearPeerBansReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearPeerBansReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_ClearPeerBans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *eTHBACKENDClient) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingBlockReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PendingBlockReply)
@@ -314,6 +340,10 @@ type ETHBACKENDServer interface {
 	// Peers collects and returns peers information from all running sentry instances.
 	Peers(context.Context, *emptypb.Empty) (*PeersReply, error)
 	AddPeer(context.Context, *AddPeerRequest) (*AddPeerReply, error)
+	// PeerBans returns the nodes banned for misbehaviour, ending soonest first.
+	PeerBans(context.Context, *emptypb.Empty) (*PeerBansReply, error)
+	// ClearPeerBans lifts the bans of the given nodes, or of all nodes if none are given.
+	ClearPeerBans(context.Context, *ClearPeerBansRequest) (*ClearPeerBansReply, error)
 	// PendingBlock returns latest built block.
 	PendingBlock(context.Context, *emptypb.Empty) (*PendingBlockReply, error)
 	BorEvent(context.Context, *BorEventRequest) (*BorEventReply, error)
@@ -375,6 +405,12 @@ func (UnimplementedETHBACKENDServer) Peers(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedETHBACKENDServer) AddPeer(context.Context, *AddPeerRequest) (*AddPeerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddPeer not implemented")
 }
+func (UnimplementedETHBACKENDServer) PeerBans(context.Context, *emptypb.Empty) (*PeerBansReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerBans not implemented")
+}
+func (UnimplementedETHBACKENDServer) ClearPeerBans(context.Context, *ClearPeerBansRequest) (*ClearPeerBansReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearPeerBans not implemented")
+}
 func (UnimplementedETHBACKENDServer) PendingBlock(context.Context, *emptypb.Empty) (*PendingBlockReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PendingBlock not implemented")
 }
@@ -672,6 +708,42 @@ func _ETHBACKEND_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETHBACKEND_PeerBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).PeerBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_PeerBans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).PeerBans(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_ClearPeerBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearPeerBansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).ClearPeerBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_ClearPeerBans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).ClearPeerBans(ctx, req.(*ClearPeerBansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ETHBACKEND_PendingBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -771,6 +843,14 @@ var ETHBACKEND_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddPeer",
 			Handler:    _ETHBACKEND_AddPeer_Handler,
 		},
+		{
+			MethodName: "PeerBans",
+			Handler:    _ETHBACKEND_PeerBans_Handler,
+		},
+		{
+			MethodName: "ClearPeerBans",
+			Handler:    _ETHBACKEND_ClearPeerBans_Handler,
+		},
 		{
 			MethodName: "PendingBlock",
 			Handler:    _ETHBACKEND_PendingBlock_Handler,
@@ -80,6 +80,45 @@ func (c *MockSentryServerAddPeerCall) DoAndReturn(f func(context.Context, *AddPe
 	return c
 }
 
+// ClearPeerBans mocks base method.
+func (m *MockSentryServer) ClearPeerBans(arg0 context.Context, arg1 *ClearPeerBansRequest) (*ClearPeerBansReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPeerBans", arg0, arg1)
+	ret0, _ := ret[0].(*ClearPeerBansReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearPeerBans indicates an expected call of ClearPeerBans.
+func (mr *MockSentryServerMockRecorder) ClearPeerBans(arg0, arg1 any) *MockSentryServerClearPeerBansCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPeerBans", reflect.TypeOf((*MockSentryServer)(nil).ClearPeerBans), arg0, arg1)
+	return &MockSentryServerClearPeerBansCall{Call: call}
+}
+
+// MockSentryServerClearPeerBansCall wrap *gomock.Call
+type MockSentryServerClearPeerBansCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerClearPeerBansCall) Return(arg0 *ClearPeerBansReply, arg1 error) *MockSentryServerClearPeerBansCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerClearPeerBansCall) Do(f func(context.Context, *ClearPeerBansRequest) (*ClearPeerBansReply, error)) *MockSentryServerClearPeerBansCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerClearPeerBansCall) DoAndReturn(f func(context.Context, *ClearPeerBansRequest) (*ClearPeerBansReply, error)) *MockSentryServerClearPeerBansCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // HandShake mocks base method.
 func (m *MockSentryServer) HandShake(arg0 context.Context, arg1 *emptypb.Empty) (*HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +235,45 @@ func (c *MockSentryServerNodeInfoCall) DoAndReturn(f func(context.Context, *empt
 	return c
 }
 
+// PeerBans mocks base method.
+func (m *MockSentryServer) PeerBans(arg0 context.Context, arg1 *emptypb.Empty) (*PeerBansReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerBans", arg0, arg1)
+	ret0, _ := ret[0].(*PeerBansReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerBans indicates an expected call of PeerBans.
+func (mr *MockSentryServerMockRecorder) PeerBans(arg0, arg1 any) *MockSentryServerPeerBansCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerBans", reflect.TypeOf((*MockSentryServer)(nil).PeerBans), arg0, arg1)
+	return &MockSentryServerPeerBansCall{Call: call}
+}
+
+// MockSentryServerPeerBansCall wrap *gomock.Call
+type MockSentryServerPeerBansCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerPeerBansCall) Return(arg0 *PeerBansReply, arg1 error) *MockSentryServerPeerBansCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerPeerBansCall) Do(f func(context.Context, *emptypb.Empty) (*PeerBansReply, error)) *MockSentryServerPeerBansCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerPeerBansCall) DoAndReturn(f func(context.Context, *emptypb.Empty) (*PeerBansReply, error)) *MockSentryServerPeerBansCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // PeerById mocks base method.
 func (m *MockSentryServer) PeerById(arg0 context.Context, arg1 *PeerByIdRequest) (*PeerByIdReply, error) {
 	m.ctrl.T.Helper()
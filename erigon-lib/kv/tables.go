@@ -407,6 +407,8 @@ const (
 
 	EffectiveBalancesDump = "EffectiveBalancesDump"
 	BalancesDump          = "BalancesDump"
+	// [slot] => [zstd compressed full beacon state]
+	StateCheckpoints = "StateCheckpoints"
 
 	// [slot] => [Canonical block root]
 	CanonicalBlockRoots = "CanonicalBlockRoots"
@@ -432,6 +434,9 @@ const (
 	// BlockRoot => Beacon Block Header
 	BeaconBlockHeaders = "BeaconBlockHeaders"
 
+	// [sync committee period] => [version byte + best LightClientUpdate of the period]
+	LightClientUpdates = "LightClientUpdates"
+
 	// Beacon historical data
 	// ValidatorIndex => [Field]
 	ValidatorPublicKeys         = "ValidatorPublickeys"
@@ -596,6 +601,7 @@ var ChaindataTables = []string{
 	StateRootToBlockRoot,
 	BlockRootToParentRoot,
 	BeaconBlockHeaders,
+	LightClientUpdates,
 	HighestFinalized,
 	BlockRootToBlockHash,
 	BlockRootToBlockNumber,
@@ -628,6 +634,7 @@ var ChaindataTables = []string{
 	ActiveValidatorIndicies,
 	EffectiveBalancesDump,
 	BalancesDump,
+	StateCheckpoints,
 }
 
 const (
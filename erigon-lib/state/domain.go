@@ -1618,6 +1618,39 @@ func (dt *DomainRoTx) getLatestFromDb(key []byte, roTx kv.Tx) ([]byte, uint64, b
 	return nil, 0, false, nil
 }
 
+// getLatestFromDbBeforeStep returns the latest value of the key written in db before the step,
+// ignoring the values of the step and later ones
+func (dt *DomainRoTx) getLatestFromDbBeforeStep(key []byte, step uint64, roTx kv.Tx) ([]byte, bool, error) {
+	if step == 0 {
+		return nil, false, nil
+	}
+	var invStep [8]byte
+	binary.BigEndian.PutUint64(invStep[:], ^(step - 1))
+	valsC, err := dt.valsCursor(roTx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if dt.d.largeVals {
+		fullkey, v, err := valsC.Seek(append(append(make([]byte, 0, len(key)+8), key...), invStep[:]...))
+		if err != nil {
+			return nil, false, fmt.Errorf("valsCursor.Seek: %w", err)
+		}
+		if len(fullkey) == 0 || !bytes.Equal(fullkey[:len(fullkey)-8], key) {
+			return nil, false, nil
+		}
+		return v, true, nil
+	}
+	stepWithVal, err := valsC.(kv.CursorDupSort).SeekBothRange(key, invStep[:])
+	if err != nil {
+		return nil, false, fmt.Errorf("valsCursor.SeekBothRange: %w", err)
+	}
+	if len(stepWithVal) == 0 {
+		return nil, false, nil
+	}
+	return stepWithVal[8:], true, nil
+}
+
 // GetLatest returns value, step in which the value last changed, and bool value which is true if the value
 // is present, and false if it is not present (not set or deleted)
 func (dt *DomainRoTx) GetLatest(key1, key2 []byte, roTx kv.Tx) ([]byte, uint64, bool, error) {
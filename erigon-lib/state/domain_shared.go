@@ -223,6 +223,59 @@ func (sd *SharedDomains) Unwind(ctx context.Context, rwTx kv.RwTx, blockUnwindTo
 	return sd.Flush(ctx, rwTx)
 }
 
+// UnwindInMem reverts the domains to the end of block blockUnwindTo by applying the changesets of
+// the blocks above it, the newest first. Unlike Unwind, nothing is written to db: the previous values
+// shadow the latest ones in memory, so it works on top of a read-only transaction. The commitment
+// trie is restored to blockUnwindTo, e.g. to prove historical state with PatriciaTrie.
+// Such domains must not be flushed.
+func (sd *SharedDomains) UnwindInMem(blockUnwindTo, txUnwindTo uint64, changesets ...*[kv.DomainLen][]DomainEntryDiff) error {
+	for _, changeset := range changesets {
+		for idx := range changeset {
+			domain := kv.Domain(idx)
+			for _, diff := range changeset[idx] {
+				key, stepBytes := diff.Key[:len(diff.Key)-8], diff.Key[len(diff.Key)-8:]
+				value := diff.Value
+				if !bytes.Equal(stepBytes, diff.PrevStepBytes) {
+					// the previous value was written in an older step
+					var err error
+					if value, err = sd.latestBeforeStep(domain, key, ^binary.BigEndian.Uint64(stepBytes)); err != nil {
+						return err
+					}
+				}
+				sd.put(domain, string(key), value)
+			}
+		}
+	}
+	sd.SetTxNum(txUnwindTo)
+	sd.SetBlockNum(blockUnwindTo)
+	sd.sdCtx.ResetBranchCache()
+	if _, _, _, err := sd.sdCtx.SeekCommitment(sd.roTx, sd.aggTx.d[kv.CommitmentDomain], 0, math.MaxUint64); err != nil {
+		return fmt.Errorf("UnwindInMem: restore commitment: %w", err)
+	}
+	return nil
+}
+
+// latestBeforeStep returns the latest value of the key written before the step, from db if that
+// step isn't pruned yet and from the files otherwise
+func (sd *SharedDomains) latestBeforeStep(domain kv.Domain, key []byte, step uint64) ([]byte, error) {
+	dt := sd.aggTx.d[domain]
+	v, found, err := dt.getLatestFromDbBeforeStep(key, step, sd.roTx)
+	if err != nil {
+		return nil, fmt.Errorf("%s %x read error: %w", domain, key, err)
+	}
+	if found {
+		return v, nil
+	}
+	v, found, startTx, endTx, err := dt.getFromFiles(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s %x read error: %w", domain, key, err)
+	}
+	if !found || domain != kv.CommitmentDomain || !sd.aggTx.a.commitmentValuesTransform || bytes.Equal(key, keyCommitmentState) {
+		return v, nil
+	}
+	return sd.replaceShortenedKeysInBranch(key, commitment.BranchData(v), startTx, endTx)
+}
+
 func (sd *SharedDomains) rebuildCommitment(ctx context.Context, roTx kv.Tx, blockNum uint64) ([]byte, error) {
 	it, err := sd.aggTx.HistoryRange(kv.AccountsHistory, int(sd.TxNum()), math.MaxInt64, order.Asc, -1, roTx)
 	if err != nil {
@@ -1281,6 +1334,17 @@ func (sd *SharedDomains) LatestCommitmentState(tx kv.Tx, sinceTx, untilTx uint64
 	return sd.sdCtx.LatestCommitmentState()
 }
 
+// PatriciaTrie returns commitment trie restored to the latest committed state. It reads branches,
+// accounts and storage through these domains, so proofs and leaf iteration see the same state
+// as the root hash. Must not be used for computing commitment.
+func (sd *SharedDomains) PatriciaTrie() (*commitment.HexPatriciaHashed, error) {
+	hph, ok := sd.sdCtx.patriciaTrie.(*commitment.HexPatriciaHashed)
+	if !ok {
+		return nil, fmt.Errorf("trie variant %s is not supported", sd.sdCtx.patriciaTrie.Variant())
+	}
+	return hph, nil
+}
+
 func _decodeTxBlockNums(v []byte) (txNum, blockNum uint64) {
 	return binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[8:16])
 }
@@ -202,6 +202,98 @@ Loop:
 	goto Loop
 }
 
+func TestSharedDomain_UnwindInMem(t *testing.T) {
+	stepSize := uint64(4) // the changesets cross the steps
+	db, agg := testDbAndAggregatorv3(t, stepSize)
+
+	ctx := context.Background()
+	rwTx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+
+	domains, err := NewSharedDomains(WrapTxWithCtx(rwTx, ac), log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	blocks := 20
+	roots := make([][]byte, blocks)
+	balances := make([]map[byte]uint64, blocks)
+	changesets := make([]*[kv.DomainLen][]DomainEntryDiff, blocks)
+	key := func(i byte) []byte { return append(make([]byte, length.Addr-1), i) }
+	state := make(map[byte]uint64)
+	for block := 0; block < blocks; block++ {
+		changeset := &StateChangeSet{}
+		domains.SetChangesetAccumulator(changeset)
+		domains.SetTxNum(uint64(block))
+		domains.SetBlockNum(uint64(block))
+		for i := byte(0); i < 8; i++ {
+			if (block+int(i))%3 != 0 {
+				continue
+			}
+			prev, step, err := domains.DomainGet(kv.AccountsDomain, key(i), nil)
+			require.NoError(t, err)
+			if block%7 == int(i) && prev != nil {
+				require.NoError(t, domains.DomainDel(kv.AccountsDomain, key(i), nil, prev, step))
+				delete(state, i)
+				continue
+			}
+			balance := uint64(block*1000) + uint64(i)
+			v := types.EncodeAccountBytesV3(uint64(block), uint256.NewInt(balance), nil, 0)
+			require.NoError(t, domains.DomainPut(kv.AccountsDomain, key(i), nil, v, prev, step))
+			state[i] = balance
+		}
+		roots[block], err = domains.ComputeCommitment(ctx, true, uint64(block), "")
+		require.NoError(t, err)
+
+		balances[block] = make(map[byte]uint64, len(state))
+		for i, balance := range state {
+			balances[block][i] = balance
+		}
+		diffs := [kv.DomainLen][]DomainEntryDiff{}
+		for idx := range changeset.Diffs {
+			diffs[idx] = changeset.Diffs[idx].GetDiffSet()
+		}
+		changesets[block] = &diffs
+		require.NoError(t, rawdbv3.TxNums.Append(rwTx, uint64(block), uint64(block)))
+	}
+	domains.SetChangesetAccumulator(nil)
+	require.NoError(t, domains.Flush(ctx, rwTx))
+	domains.Close()
+
+	for _, unwindTo := range []int{blocks - 1, 15, 9, 2} {
+		domains, err := NewSharedDomains(WrapTxWithCtx(rwTx, ac), log.New())
+		require.NoError(t, err)
+
+		var unwound []*[kv.DomainLen][]DomainEntryDiff
+		for block := blocks - 1; block > unwindTo; block-- {
+			unwound = append(unwound, changesets[block])
+		}
+		require.NoError(t, domains.UnwindInMem(uint64(unwindTo), uint64(unwindTo), unwound...))
+
+		trie, err := domains.PatriciaTrie()
+		require.NoError(t, err)
+		root, err := trie.RootHash()
+		require.NoError(t, err)
+		require.Equal(t, roots[unwindTo], root, "unwind to %d", unwindTo)
+
+		for i := byte(0); i < 8; i++ {
+			v, _, err := domains.DomainGet(kv.AccountsDomain, key(i), nil)
+			require.NoError(t, err)
+			balance, ok := balances[unwindTo][i]
+			if !ok {
+				require.Empty(t, v, "unwind to %d, account %d", unwindTo, i)
+				continue
+			}
+			_, b, _ := types.DecodeAccountBytesV3(v)
+			require.Equal(t, balance, b.Uint64(), "unwind to %d, account %d", unwindTo, i)
+		}
+		domains.Close()
+	}
+}
+
 func TestSharedDomain_IteratePrefix(t *testing.T) {
 	stepSize := uint64(8)
 	require := require.New(t)
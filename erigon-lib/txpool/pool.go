@@ -129,6 +129,7 @@ type metaTx struct {
 	bestIndex                 int
 	worstIndex                int
 	timestamp                 uint64 // when it was added to pool
+	arrival                   uint64 // the order in which it was added to pool
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
@@ -207,10 +208,12 @@ type TxPool struct {
 	queued                  *SubPool
 	minedBlobTxsByBlock     map[uint64][]*metaTx             // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxsByHash      map[string]*metaTx               // (hash => mt): map of recently mined blobs
+	blobHashToTxn           map[common.Hash]*metaTx          // (versioned hash => mt): blob txns in the pool, see GetBlobs
 	isLocalLRU              *simplelru.LRU[string, struct{}] // tx_hash => is_local : to restore isLocal flag of unwinded transactions
 	newPendingTxs           chan types.Announcements         // notifications about new txs in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of txn nonce => *metaTx)
 	deletedTxs              []*metaTx                        // list of discarded txs since last db commit
+	arrivals                uint64                           // counts the txs added to the pool, to record their arrival order
 	promoted                types.Announcements
 	cfg                     txpoolcfg.Config
 	chainID                 uint256.Int
@@ -286,6 +289,7 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		unprocessedRemoteByHash: map[string]int{},
 		minedBlobTxsByBlock:     map[uint64][]*metaTx{},
 		minedBlobTxsByHash:      map[string]*metaTx{},
+		blobHashToTxn:           map[common.Hash]*metaTx{},
 		maxBlobsPerBlock:        maxBlobsPerBlock,
 		feeCalculator:           feeCalculator,
 		logger:                  logger,
@@ -705,6 +709,30 @@ func (p *TxPool) getCachedBlobTxnLocked(tx kv.Tx, hash []byte) (*metaTx, error)
 	return newMetaTx(txSlot, false, 0), nil
 }
 
+// GetBlobs returns the blobs and KZG proofs of the given versioned hashes,
+// with nil entries for the blobs which aren't in the pool.
+func (p *TxPool) GetBlobs(blobHashes []common.Hash) (blobs [][]byte, proofs []gokzg4844.KZGProof) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	blobs = make([][]byte, len(blobHashes))
+	proofs = make([]gokzg4844.KZGProof, len(blobHashes))
+	for i, blobHash := range blobHashes {
+		mt, ok := p.blobHashToTxn[blobHash]
+		if !ok {
+			continue
+		}
+		for j, txnBlobHash := range mt.Tx.BlobHashes {
+			if txnBlobHash == blobHash && j < len(mt.Tx.Blobs) && j < len(mt.Tx.Proofs) {
+				blobs[i] = mt.Tx.Blobs[j]
+				proofs[i] = mt.Tx.Proofs[j]
+				break
+			}
+		}
+	}
+	return blobs, proofs
+}
+
 func (p *TxPool) IsLocal(idHash []byte) bool {
 	hashS := string(idHash)
 	p.lock.Lock()
@@ -782,6 +810,7 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 		txs.Txs[count] = rlpTx
 		copy(txs.Senders.At(count), sender.Bytes())
 		txs.IsLocal[count] = isLocal
+		txs.Arrivals[count] = mt.arrival
 		yielded.Add(mt.Tx.IDHash)
 		count++
 	}
@@ -1263,6 +1292,8 @@ func (p *TxPool) addTxs(blockNum uint64, cacheView kvcache.CacheView, senders *s
 			continue
 		}
 		mt := newMetaTx(txn, newTxs.IsLocal[i], blockNum)
+		p.arrivals++
+		mt.arrival = p.arrivals
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			discardReasons[i] = reason
 			continue
@@ -1314,6 +1345,8 @@ func (p *TxPool) addTxsOnNewBlock(blockNum uint64, cacheView kvcache.CacheView,
 			continue
 		}
 		mt := newMetaTx(txn, newTxs.IsLocal[i], blockNum)
+		p.arrivals++
+		mt.arrival = p.arrivals
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			p.discardLocked(mt, reason)
 			continue
@@ -1444,6 +1477,11 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 	if mt.Tx.Type == types.BlobTxType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t + (uint64(len(mt.Tx.BlobHashes))))
+		if len(mt.Tx.Blobs) == len(mt.Tx.BlobHashes) {
+			for _, blobHash := range mt.Tx.BlobHashes {
+				p.blobHashToTxn[blobHash] = mt
+			}
+		}
 	}
 
 	// Remove from mined cache as we are now "resurrecting" it to a sub-pool
@@ -1462,6 +1500,11 @@ func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 	if mt.Tx.Type == types.BlobTxType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.Tx.BlobHashes)))
+		for _, blobHash := range mt.Tx.BlobHashes {
+			if p.blobHashToTxn[blobHash] == mt {
+				delete(p.blobHashToTxn, blobHash)
+			}
+		}
 	}
 }
 
@@ -906,6 +906,39 @@ func TestBlobTxReplacement(t *testing.T) {
 	}
 }
 
+func TestGetBlobs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 5)
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	require.NoError(err)
+
+	blobTxn := makeBlobTx()
+	mt := newMetaTx(&blobTxn, true, 0)
+	pool.lock.Lock()
+	assert.Equal(txpoolcfg.NotSet, pool.addLocked(mt, &types.Announcements{}))
+	pool.lock.Unlock()
+
+	unknown := common.Hash{0x01}
+	blobs, proofs := pool.GetBlobs([]common.Hash{blobTxn.BlobHashes[1], unknown, blobTxn.BlobHashes[0]})
+	require.Len(blobs, 3)
+	require.Len(proofs, 3)
+	assert.Equal(blobTxn.Blobs[1], blobs[0])
+	assert.Equal(blobTxn.Proofs[1], proofs[0])
+	assert.Nil(blobs[1])
+	assert.Equal(blobTxn.Blobs[0], blobs[2])
+	assert.Equal(blobTxn.Proofs[0], proofs[2])
+
+	pool.lock.Lock()
+	pool.discardLocked(mt, txpoolcfg.Mined)
+	pool.lock.Unlock()
+
+	blobs, _ = pool.GetBlobs(blobTxn.BlobHashes)
+	assert.Equal([][]byte{nil, nil}, blobs)
+}
+
 // Todo, make the txn more realistic with good values
 func makeBlobTx() types.TxSlot {
 
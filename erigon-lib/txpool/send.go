@@ -167,7 +167,7 @@ func (f *Send) AnnouncePooledTxs(types []byte, sizes []uint32, hashes types2.Has
 						}
 					}
 				}
-			case direct.ETH68:
+			case direct.ETH68, direct.ETH69:
 
 				if j > prevJ {
 					req := &sentry.SendMessageToRandomPeersRequest{
@@ -246,7 +246,7 @@ func (f *Send) PropagatePooledTxsToPeersList(peers []types2.PeerID, types []byte
 							f.logger.Debug("[txpool.send] PropagatePooledTxsToPeersList", "err", err)
 						}
 					}
-				case direct.ETH68:
+				case direct.ETH68, direct.ETH69:
 
 					if j > prevJ {
 						req := &sentry.SendMessageByIdRequest{
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/holiman/uint256"
@@ -60,6 +61,7 @@ type txPool interface {
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
+	GetBlobs(blobHashes []common.Hash) (blobs [][]byte, proofs []gokzg4844.KZGProof)
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -98,6 +100,9 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) GetBlobs(ctx context.Context, request *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -304,6 +309,28 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpool_proto.NonceRequest) (
 	}, nil
 }
 
+// returns blobs and proofs for versioned hashes, empty entries for the blobs not in the pool
+func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	blobHashes := make([]common.Hash, len(in.BlobHashes))
+	for i, h := range in.BlobHashes {
+		blobHashes[i] = gointerfaces.ConvertH256ToHash(h)
+	}
+	blobs, proofs := s.txPool.GetBlobs(blobHashes)
+
+	reply := &txpool_proto.GetBlobsReply{
+		Blobs:  make([][]byte, len(blobs)),
+		Proofs: make([][]byte, len(proofs)),
+	}
+	for i := range blobs {
+		if blobs[i] == nil {
+			continue
+		}
+		reply.Blobs[i] = blobs[i]
+		reply.Proofs[i] = proofs[i][:]
+	}
+	return reply, nil
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpool_proto.Txpool_OnAddServer
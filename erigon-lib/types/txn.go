@@ -876,9 +876,10 @@ func (s *TxSlots) Append(slot *TxSlot, sender []byte, isLocal bool) {
 }
 
 type TxsRlp struct {
-	Txs     [][]byte
-	Senders Addresses
-	IsLocal []bool
+	Txs      [][]byte
+	Senders  Addresses
+	IsLocal  []bool
+	Arrivals []uint64 // the order in which the txpool has received the transactions
 }
 
 // Resize internal arrays to len=targetSize, shrinks if need. It rely on `append` algorithm to realloc
@@ -892,10 +893,14 @@ func (s *TxsRlp) Resize(targetSize uint) {
 	for uint(len(s.IsLocal)) < targetSize {
 		s.IsLocal = append(s.IsLocal, false)
 	}
+	for uint(len(s.Arrivals)) < targetSize {
+		s.Arrivals = append(s.Arrivals, 0)
+	}
 	//todo: set nil to overflow txs
 	s.Txs = s.Txs[:targetSize]
 	s.Senders = s.Senders[:length.Addr*targetSize]
 	s.IsLocal = s.IsLocal[:targetSize]
+	s.Arrivals = s.Arrivals[:targetSize]
 }
 
 var addressesGrowth = make([]byte, length.Addr)
@@ -187,8 +187,9 @@ type Ethereum struct {
 
 	downloaderClient protodownloader.DownloaderClient
 
-	notifications      *shards.Notifications
-	unsubscribeEthstat func()
+	notifications        *shards.Notifications
+	unsubscribeEthstat   func()
+	unsubscribeSnapState func()
 
 	waitForStageLoopStop chan struct{}
 	waitForMiningStop    chan struct{}
@@ -240,6 +241,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		logger.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
+	if _, err := stagedsync.TxOrderingPolicyByName(config.Miner.TxOrdering); err != nil {
+		return nil, err
+	}
 
 	dirs := stack.Config().Dirs
 	tmpdir := dirs.Tmp
@@ -253,6 +257,11 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		return nil, err
 	}
 	latestBlockBuiltStore := builder.NewLatestBlockBuiltStore()
+	inclusionList := builder.NewInclusionListStore()
+	var bundlePool *builder.BundlePool
+	if config.Miner.EnabledPOS {
+		bundlePool = builder.NewBundlePool(builder.DefaultMaxBundles, builder.DefaultMaxBundlesPerSender)
+	}
 
 	if err := chainKv.Update(context.Background(), func(tx kv.RwTx) error {
 		if err = stages.UpdateMetrics(tx); err != nil {
@@ -693,7 +702,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 				stages2.SilkwormForExecutionStage(backend.silkworm, config),
 			),
 			stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
-			stagedsync.StageMiningExecCfg(backend.chainDB, miner, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, nil, 0, backend.txPool, backend.txPoolDB, blockReader),
+			stagedsync.StageMiningExecCfg(backend.chainDB, miner, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, nil, 0, backend.txPool, backend.txPoolDB, nil, blockReader),
 			stagedsync.StageMiningFinishCfg(backend.chainDB, *backend.chainConfig, backend.engine, miner, backend.miningSealingQuit, backend.blockReader, latestBlockBuiltStore),
 		), stagedsync.MiningUnwindOrder, stagedsync.MiningPruneOrder,
 		logger)
@@ -735,13 +744,14 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 					stages2.SilkwormForExecutionStage(backend.silkworm, config),
 				),
 				stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
-				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, backend.txPool, backend.txPoolDB, blockReader),
+				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, backend.txPool, backend.txPoolDB, bundlePool, blockReader),
 				stagedsync.StageMiningFinishCfg(backend.chainDB, *backend.chainConfig, backend.engine, miningStatePos, backend.miningSealingQuit, backend.blockReader, latestBlockBuiltStore)), stagedsync.MiningUnwindOrder, stagedsync.MiningPruneOrder, logger)
 		// We start the mining step
 		if err := stages2.MiningStep(ctx, backend.chainDB, proposingSync, tmpdir, logger); err != nil {
 			return nil, err
 		}
 		block := <-miningStatePos.MiningResultCh
+		inclusionList.AddReport(param.PayloadId, miningStatePos.MiningBlock.InclusionReport)
 		return block, nil
 	}
 
@@ -916,7 +926,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	checkStateRoot := true
 	pipelineStages := stages2.NewPipelineStages(ctx, backend.chainDB, config, p2pConfig, backend.sentriesClient, backend.notifications, backend.downloaderClient, blockReader, blockRetire, backend.agg, backend.silkworm, backend.forkValidator, logger, checkStateRoot)
 	backend.pipelineStagedSync = stagedsync.New(config.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger)
-	backend.eth1ExecutionServer = eth1.NewEthereumExecutionModule(blockReader, backend.chainDB, backend.pipelineStagedSync, backend.forkValidator, chainConfig, assembleBlockPOS, hook, backend.notifications.Accumulator, backend.notifications.StateChangesConsumer, logger, backend.engine, config.Sync, ctx)
+	backend.eth1ExecutionServer = eth1.NewEthereumExecutionModule(blockReader, backend.chainDB, backend.pipelineStagedSync, backend.forkValidator, chainConfig, assembleBlockPOS, inclusionList, hook, backend.notifications.Accumulator, backend.notifications.StateChangesConsumer, logger, backend.engine, config.Sync, ctx)
 	executionRpc := direct.NewExecutionClientDirect(backend.eth1ExecutionServer)
 
 	var executionEngine executionclient.ExecutionEngine
@@ -937,7 +947,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			backend.chainDB, chainConfig, tmpdir, config.Sync),
 		config.InternalCL, // If the chain supports the engine API, then we should not make the server fail.
 		false,
-		config.Miner.EnabledPOS)
+		config.Miner.EnabledPOS,
+		inclusionList,
+		bundlePool)
 	backend.engineBackendRPC = engineBackendRPC
 	// If we choose not to run a consensus layer, run our embedded.
 	if config.InternalCL && (clparams.EmbeddedSupported(config.NetworkID) || config.CaplinConfig.IsDevnet()) {
@@ -1017,6 +1029,11 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 			return err
 		}
 	}
+	if stack.Config().P2P.SnapServe {
+		var headCh chan [][]byte
+		headCh, s.unsubscribeSnapState = s.notifications.Events.AddHeaderSubscription()
+		go s.sentriesClient.SnapStateLoop(s.sentryCtx, headCh)
+	}
 
 	s.apiList = jsonrpc.APIList(chainKv, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge)
 
@@ -1045,7 +1062,14 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 		s.silkwormRPCDaemonService = &silkwormRPCDaemonService
 	} else {
 		go func() {
-			if err := cli.StartRpcServer(ctx, &httpRpcCfg, s.apiList, s.logger); err != nil {
+			responseCache := cli.NewResponseCache(ctx, &httpRpcCfg, chainKv, blockReader, ff, s.logger)
+			requestLog, err := cli.NewRequestLog(&httpRpcCfg, chainKv, s.logger)
+			if err != nil {
+				s.logger.Error("cli.NewRequestLog error", "err", err)
+				return
+			}
+			defer requestLog.Close()
+			if err := cli.StartRpcServer(ctx, &httpRpcCfg, s.apiList, responseCache, requestLog, s.logger); err != nil {
 				s.logger.Error("cli.StartRpcServer error", "err", err)
 			}
 		}()
@@ -1491,6 +1515,37 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) PeerBans(ctx context.Context) (*remote.PeerBansReply, error) {
+	var reply remote.PeerBansReply
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		bans, err := sentryClient.PeerBans(ctx, &emptypb.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.PeerBans error: %w", err)
+		}
+		for _, ban := range bans.Bans {
+			reply.Bans = append(reply.Bans, &remote.PeerBan{
+				Id:          ban.Id,
+				Reason:      ban.Reason,
+				Score:       ban.Score,
+				BannedUntil: ban.BannedUntil,
+			})
+		}
+	}
+	return &reply, nil
+}
+
+func (s *Ethereum) ClearPeerBans(ctx context.Context, req *remote.ClearPeerBansRequest) (*remote.ClearPeerBansReply, error) {
+	var reply remote.ClearPeerBansReply
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		cleared, err := sentryClient.ClearPeerBans(ctx, &protosentry.ClearPeerBansRequest{Ids: req.Ids})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.ClearPeerBans error: %w", err)
+		}
+		reply.Cleared += cleared.Cleared
+	}
+	return &reply, nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -1568,6 +1623,9 @@ func (s *Ethereum) Stop() error {
 	if s.unsubscribeEthstat != nil {
 		s.unsubscribeEthstat()
 	}
+	if s.unsubscribeSnapState != nil {
+		s.unsubscribeSnapState()
+	}
 	if s.downloader != nil {
 		s.downloader.Close()
 	}
@@ -1743,7 +1801,8 @@ func polygonSyncSentry(sentries []direct.SentryClient) direct.SentryClient {
 	//      - for now we just use 1 sentry
 	var sentryClient direct.SentryClient
 	for _, client := range sentries {
-		if client.Protocol() == direct.ETH68 {
+		// eth/69 sentries also serve eth/68 peers
+		if client.Protocol() == direct.ETH68 || client.Protocol() == direct.ETH69 {
 			sentryClient = client
 			break
 		}
@@ -23,12 +23,21 @@ import (
 	"fmt"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/metrics"
 
 	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/enr"
 	"github.com/erigontech/erigon/rlp"
 )
 
+var (
+	nodeFilterAccepted     = metrics.GetOrCreateCounter(`p2p_discovery_candidates{result="accepted"}`)
+	nodeFilterNoENR        = metrics.GetOrCreateCounter(`p2p_discovery_candidates{result="no_eth_enr"}`)
+	nodeFilterInvalidENR   = metrics.GetOrCreateCounter(`p2p_discovery_candidates{result="invalid_eth_enr"}`)
+	nodeFilterIncompatible = metrics.GetOrCreateCounter(`p2p_discovery_candidates{result="incompatible_fork_id"}`)
+)
+
 // enrEntry is the ENR entry which advertises `eth` protocol on the discovery.
 type enrEntry struct {
 	ForkID forkid.ID // Fork identifier per EIP-2124
@@ -59,3 +68,30 @@ func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	}
 	return &entry.ForkID, nil
 }
+
+// NewNodeFilter returns a filter for discovered dial candidates which drops the nodes
+// advertising a fork ID incompatible with the one returned by forkFilter in their
+// `eth` ENR entry. Nodes without the entry are kept unless requireENR is set, since
+// not every client advertises it. A nil fork filter only checks for the entry.
+func NewNodeFilter(forkFilter func() forkid.Filter, requireENR bool) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		forkID, err := LoadENRForkID(n.Record())
+		switch {
+		case err != nil:
+			nodeFilterInvalidENR.Inc()
+			return false
+		case forkID == nil:
+			if requireENR {
+				nodeFilterNoENR.Inc()
+				return false
+			}
+		default:
+			if filter := forkFilter(); filter != nil && filter(*forkID) != nil {
+				nodeFilterIncompatible.Inc()
+				return false
+			}
+		}
+		nodeFilterAccepted.Inc()
+		return true
+	}
+}
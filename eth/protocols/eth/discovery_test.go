@@ -0,0 +1,118 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+func newTestNode(t *testing.T, entries ...enr.Entry) *enode.Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return newTestNodeWithKey(t, key, entries...)
+}
+
+func newTestNodeWithKey(t *testing.T, key *ecdsa.PrivateKey, entries ...enr.Entry) *enode.Node {
+	var r enr.Record
+	for _, e := range entries {
+		r.Set(e)
+	}
+	require.NoError(t, enode.SignV4(&r, key))
+	n, err := enode.New(enode.ValidSchemes, &r)
+	require.NoError(t, err)
+	return n
+}
+
+func TestNodeFilter(t *testing.T) {
+	heightForks := []uint64{100, 200}
+	genesis := libcommon.Hash{1}
+	forkFilter := forkid.NewFilterFromForks(heightForks, nil, genesis, 150, 0)
+
+	ours := newTestNode(t, CurrentENREntryFromForks(heightForks, nil, genesis, 150, 0))
+	stale := newTestNode(t, CurrentENREntryFromForks(heightForks, nil, genesis, 50, 0))
+	otherChain := newTestNode(t, CurrentENREntryFromForks(heightForks, nil, libcommon.Hash{2}, 150, 0))
+	noENR := newTestNode(t)
+	invalidENR := newTestNode(t, enr.WithEntry("eth", []byte{1, 2, 3}))
+
+	filter := NewNodeFilter(func() forkid.Filter { return forkFilter }, false)
+	require.True(t, filter(ours))
+	require.True(t, filter(stale), "a peer behind on the same chain is compatible")
+	require.False(t, filter(otherChain))
+	require.True(t, filter(noENR))
+	require.False(t, filter(invalidENR))
+
+	strict := NewNodeFilter(func() forkid.Filter { return forkFilter }, true)
+	require.True(t, strict(ours))
+	require.False(t, strict(noENR))
+
+	// before the first status message only the presence of the entry is checked
+	noStatus := NewNodeFilter(func() forkid.Filter { return nil }, true)
+	require.True(t, noStatus(otherChain))
+	require.False(t, noStatus(noENR))
+}
+
+// The discovery v4 nodes come from Neighbors packets without their ENR entries, so they are
+// judged on the record that is requested from them.
+func TestNodeFilterRequestedENR(t *testing.T) {
+	heightForks := []uint64{100, 200}
+	genesis := libcommon.Hash{1}
+	forkFilter := forkid.NewFilterFromForks(heightForks, nil, genesis, 150, 0)
+
+	records := make(map[enode.ID]*enode.Node)
+	neighbor := func(entries ...enr.Entry) *enode.Node {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		n := newTestNodeWithKey(t, key)
+		if len(entries) > 0 {
+			records[n.ID()] = newTestNodeWithKey(t, key, entries...)
+		}
+		return n
+	}
+	ours := neighbor(CurrentENREntryFromForks(heightForks, nil, genesis, 150, 0))
+	otherChain := neighbor(CurrentENREntryFromForks(heightForks, nil, libcommon.Hash{2}, 150, 0))
+	unreachable := neighbor()
+	requestENR := func(n *enode.Node) (*enode.Node, error) {
+		if r, ok := records[n.ID()]; ok {
+			return r, nil
+		}
+		return nil, errors.New("timeout")
+	}
+
+	discovered := func(requireENR bool) []enode.ID {
+		it := enode.Filter(enode.Resolve(enode.IterNodes([]*enode.Node{ours, otherChain, unreachable}), requestENR),
+			NewNodeFilter(func() forkid.Filter { return forkFilter }, requireENR))
+		defer it.Close()
+		var ids []enode.ID
+		for it.Next() {
+			ids = append(ids, it.Node().ID())
+		}
+		return ids
+	}
+	require.Equal(t, []enode.ID{ours.ID(), unreachable.ID()}, discovered(false), "the node of the other chain is skipped")
+	require.Equal(t, []enode.ID{ours.ID()}, discovered(true))
+}
@@ -165,6 +165,17 @@ type ReceiptsGetter interface {
 }
 
 func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue, error) { //nolint:unparam
+	return answerGetReceiptsQuery(ctx, cfg, receiptsGetter, br, db, query, func(receipts types.Receipts) ([]byte, error) {
+		return rlp.EncodeToBytes(receipts)
+	})
+}
+
+// AnswerGetReceiptsQuery69 is AnswerGetReceiptsQuery for eth/69 peers, which expect receipts without bloom filters.
+func AnswerGetReceiptsQuery69(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue, error) {
+	return answerGetReceiptsQuery(ctx, cfg, receiptsGetter, br, db, query, EncodeReceipts69)
+}
+
+func answerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket, encode func(types.Receipts) ([]byte, error)) ([]rlp.RawValue, error) {
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes    int
@@ -210,7 +221,7 @@ func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGett
 		//}
 
 		// If known, encode and queue for response packet
-		if encoded, err := rlp.EncodeToBytes(results); err != nil {
+		if encoded, err := encode(results); err != nil {
 			return nil, fmt.Errorf("failed to encode receipt: %w", err)
 		} else {
 			receipts = append(receipts, encoded)
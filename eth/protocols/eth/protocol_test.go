@@ -25,6 +25,8 @@ import (
 	"testing"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 
 	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/core/types"
@@ -232,3 +234,20 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+// Tests that eth/69 no longer maps the block announcements removed by the protocol.
+func TestETH69DropsBlockAnnouncements(t *testing.T) {
+	for _, code := range []uint64{NewBlockMsg, NewBlockHashesMsg} {
+		if _, ok := ToProto[direct.ETH68][code]; !ok {
+			t.Errorf("eth/68 message %d not mapped", code)
+		}
+		if id, ok := ToProto[direct.ETH69][code]; ok {
+			t.Errorf("eth/69 message %d mapped to %s", code, id)
+		}
+	}
+	for _, id := range []proto_sentry.MessageId{proto_sentry.MessageId_NEW_BLOCK_66, proto_sentry.MessageId_NEW_BLOCK_HASHES_66} {
+		if code, ok := FromProto[direct.ETH69][id]; ok {
+			t.Errorf("eth/69 message %s mapped to %d", id, code)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"fmt"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rlp"
+)
+
+var (
+	receipt69StatusFailed     = []byte{}
+	receipt69StatusSuccessful = []byte{0x01}
+)
+
+// receipt69RLP is the eth/69 wire encoding of a receipt: the bloom filter is dropped
+// and the transaction type is always present, so the receipt is a plain list.
+type receipt69RLP struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*types.Log
+}
+
+// EncodeReceipts69 encodes the receipts of a single block in the eth/69 format.
+func EncodeReceipts69(receipts types.Receipts) ([]byte, error) {
+	enc := make([]receipt69RLP, len(receipts))
+	for i, r := range receipts {
+		enc[i] = receipt69RLP{
+			Type:              r.Type,
+			PostStateOrStatus: receipt69StatusEncoding(r),
+			CumulativeGasUsed: r.CumulativeGasUsed,
+			Logs:              r.Logs,
+		}
+		if enc[i].Logs == nil {
+			enc[i].Logs = []*types.Log{}
+		}
+	}
+	return rlp.EncodeToBytes(enc)
+}
+
+// DecodeReceipts69 decodes the receipts of a single block from the eth/69 format and
+// re-derives the bloom filters which are not sent over the wire.
+func DecodeReceipts69(data []byte) (types.Receipts, error) {
+	var dec []receipt69RLP
+	if err := rlp.DecodeBytes(data, &dec); err != nil {
+		return nil, err
+	}
+	receipts := make(types.Receipts, len(dec))
+	for i, d := range dec {
+		r := &types.Receipt{
+			Type:              d.Type,
+			CumulativeGasUsed: d.CumulativeGasUsed,
+			Logs:              d.Logs,
+		}
+		switch {
+		case bytes.Equal(d.PostStateOrStatus, receipt69StatusSuccessful):
+			r.Status = types.ReceiptStatusSuccessful
+		case bytes.Equal(d.PostStateOrStatus, receipt69StatusFailed):
+			r.Status = types.ReceiptStatusFailed
+		case len(d.PostStateOrStatus) == len(libcommon.Hash{}):
+			r.PostState = d.PostStateOrStatus
+		default:
+			return nil, fmt.Errorf("invalid receipt status %x", d.PostStateOrStatus)
+		}
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+		receipts[i] = r
+	}
+	return receipts, nil
+}
+
+func receipt69StatusEncoding(r *types.Receipt) []byte {
+	if len(r.PostState) > 0 {
+		return r.PostState
+	}
+	if r.Status == types.ReceiptStatusFailed {
+		return receipt69StatusFailed
+	}
+	return receipt69StatusSuccessful
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rlp"
+)
+
+func TestReceipts69EncodeDecode(t *testing.T) {
+	receipts := types.Receipts{
+		{
+			Type:              types.LegacyTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs:              []*types.Log{},
+		},
+		{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusFailed,
+			CumulativeGasUsed: 42000,
+			Logs: []*types.Log{
+				{
+					Address: libcommon.HexToAddress("0x11"),
+					Topics:  []libcommon.Hash{libcommon.HexToHash("0x22")},
+					Data:    []byte{0x33},
+				},
+			},
+		},
+		{
+			Type:              types.LegacyTxType,
+			PostState:         libcommon.HexToHash("0x44").Bytes(),
+			CumulativeGasUsed: 63000,
+			Logs:              []*types.Log{},
+		},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+	}
+
+	enc, err := EncodeReceipts69(receipts)
+	require.NoError(t, err)
+
+	// eth/69 receipts are smaller than the eth/68 ones as the bloom is not sent
+	enc68, err := rlp.EncodeToBytes(receipts)
+	require.NoError(t, err)
+	require.Less(t, len(enc), len(enc68))
+
+	dec, err := DecodeReceipts69(enc)
+	require.NoError(t, err)
+	require.Len(t, dec, len(receipts))
+	for i := range receipts {
+		require.Equal(t, receipts[i].Type, dec[i].Type)
+		require.Equal(t, receipts[i].Status, dec[i].Status)
+		require.Equal(t, receipts[i].PostState, dec[i].PostState)
+		require.Equal(t, receipts[i].CumulativeGasUsed, dec[i].CumulativeGasUsed)
+		require.Equal(t, receipts[i].Bloom, dec[i].Bloom)
+		require.Equal(t, len(receipts[i].Logs), len(dec[i].Logs))
+		for j := range receipts[i].Logs {
+			require.Equal(t, receipts[i].Logs[j].Address, dec[i].Logs[j].Address)
+			require.Equal(t, receipts[i].Logs[j].Topics, dec[i].Logs[j].Topics)
+			require.Equal(t, receipts[i].Logs[j].Data, dec[i].Logs[j].Data)
+		}
+	}
+}
+
+func TestBlockRangeUpdatePacket(t *testing.T) {
+	packet := BlockRangeUpdatePacket{EarliestBlock: 10, LatestBlock: 20, LatestBlockHash: libcommon.HexToHash("0x01")}
+	require.NoError(t, packet.Validate())
+
+	enc, err := rlp.EncodeToBytes(&packet)
+	require.NoError(t, err)
+	var dec BlockRangeUpdatePacket
+	require.NoError(t, rlp.DecodeBytes(enc, &dec))
+	require.Equal(t, packet, dec)
+
+	dec.EarliestBlock = 21
+	require.Error(t, dec.Validate())
+	dec.EarliestBlock = 10
+	dec.LatestBlockHash = libcommon.Hash{}
+	require.Error(t, dec.Validate())
+}
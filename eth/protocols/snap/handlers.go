@@ -0,0 +1,492 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	"github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/rlp"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+
+	// stateLookupSlack defines the ratio by how much a state response can exceed
+	// the requested limit in order to try and avoid breaking up contracts into
+	// multiple packages and proving them.
+	stateLookupSlack = 0.1
+
+	// maxTrieNodeLookups is the maximum number of state trie nodes to serve. This
+	// number is there to limit the number of disk lookups.
+	maxTrieNodeLookups = 1024
+
+	// RecentStateRoots is the number of most recent blocks whose state roots are served. Snap
+	// sync pivots about 64 blocks behind the head of the chain.
+	RecentStateRoots = 128
+)
+
+var (
+	errBadRequest = errors.New("bad request")
+
+	maxHash = libcommon.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+)
+
+// CodeIndex maps code hashes to an account carrying the code. Code in E3 domains is keyed by
+// account address, so the index is built once from the code domain and then kept up to date
+// with the code written by new blocks. It holds one entry per distinct code.
+type CodeIndex struct {
+	mu     sync.RWMutex
+	owners map[libcommon.Hash]libcommon.Address
+}
+
+func NewCodeIndex() *CodeIndex {
+	return &CodeIndex{owners: make(map[libcommon.Hash]libcommon.Address)}
+}
+
+func (ci *CodeIndex) Add(codeHash libcommon.Hash, owner libcommon.Address) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.owners[codeHash] = owner
+}
+
+func (ci *CodeIndex) Owner(codeHash libcommon.Hash) (libcommon.Address, bool) {
+	ci.mu.RLock()
+	defer ci.mu.RUnlock()
+	owner, ok := ci.owners[codeHash]
+	return owner, ok
+}
+
+// Build indexes the code of every account of the stream, which ranges over the code domain.
+func (ci *CodeIndex) Build(ctx context.Context, codes stream.KV) error {
+	defer codes.Close()
+	for i := 0; codes.HasNext(); i++ {
+		if i%100_000 == 0 {
+			if err := libcommon.Stopped(ctx.Done()); err != nil {
+				return err
+			}
+		}
+		addr, code, err := codes.Next()
+		if err != nil {
+			return err
+		}
+		if len(code) == 0 {
+			continue
+		}
+		ci.Add(crypto.Keccak256Hash(code), libcommon.BytesToAddress(addr))
+	}
+	return nil
+}
+
+type recentBlock struct {
+	hash libcommon.Hash
+	root libcommon.Hash
+}
+
+// StateRoots maps the state roots of the last RecentStateRoots canonical blocks to their block
+// numbers. It is updated on new heads, so that requests for unknown roots are turned down
+// without reading any headers or changesets.
+type StateRoots struct {
+	mu     sync.RWMutex
+	blocks map[uint64]recentBlock
+	roots  map[libcommon.Hash]uint64
+}
+
+func NewStateRoots() *StateRoots {
+	return &StateRoots{blocks: make(map[uint64]recentBlock), roots: make(map[libcommon.Hash]uint64)}
+}
+
+// Update moves the head to the given block. Headers are read down from the head until a block
+// already known, and the headers of the blocks new to the map are returned, the newest first.
+func (sr *StateRoots) Update(head uint64, header func(blockNum uint64) (*types.Header, error)) ([]*types.Header, error) {
+	var added []*types.Header
+	for blockNum := head; blockNum > 0 && head-blockNum < RecentStateRoots; blockNum-- {
+		h, err := header(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if h == nil {
+			break
+		}
+		sr.mu.RLock()
+		known, ok := sr.blocks[blockNum]
+		sr.mu.RUnlock()
+		if ok && known.hash == h.Hash() {
+			break
+		}
+		added = append(added, h)
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	for _, h := range added {
+		blockNum := h.Number.Uint64()
+		sr.remove(blockNum)
+		sr.blocks[blockNum] = recentBlock{hash: h.Hash(), root: h.Root}
+		if prev, ok := sr.roots[h.Root]; !ok || prev < blockNum {
+			sr.roots[h.Root] = blockNum
+		}
+	}
+	for blockNum := range sr.blocks {
+		if blockNum > head || head-blockNum >= RecentStateRoots {
+			sr.remove(blockNum)
+		}
+	}
+	return added, nil
+}
+
+func (sr *StateRoots) remove(blockNum uint64) {
+	block, ok := sr.blocks[blockNum]
+	if !ok {
+		return
+	}
+	delete(sr.blocks, blockNum)
+	if sr.roots[block.root] == blockNum {
+		delete(sr.roots, block.root)
+	}
+}
+
+// Path returns the number of the recent block with the given state root, and the hashes of the
+// blocks after it up to the head, the newest first. ok is false for roots which aren't known.
+func (sr *StateRoots) Path(root libcommon.Hash, head uint64) (blockNum uint64, hashes []libcommon.Hash, ok bool) {
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	blockNum, ok = sr.roots[root]
+	if !ok || blockNum >= head {
+		return 0, nil, false
+	}
+	for n := head; n > blockNum; n-- {
+		block, ok := sr.blocks[n]
+		if !ok {
+			return 0, nil, false
+		}
+		hashes = append(hashes, block.hash)
+	}
+	return blockNum, hashes, true
+}
+
+// servesRoot tells whether the trie is at the state root requested by the peer. Requests for
+// other roots get empty responses.
+func servesRoot(trie *commitment.HexPatriciaHashed, root libcommon.Hash) (bool, error) {
+	rootHash, err := trie.RootHash()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(rootHash, root[:]), nil
+}
+
+// StateTrieAt returns the commitment trie at the state root of one of the recent blocks known to
+// roots. The domains are reverted in memory by the changesets of the blocks above that block, so
+// they must not be used for anything else afterwards. If the root isn't a recent one, the trie of
+// the latest state is returned and the request is answered with an empty response.
+func StateTrieAt(tx kv.Tx, sd *state.SharedDomains, root libcommon.Hash, roots *StateRoots, maxTxNum func(blockNum uint64) (uint64, error)) (*commitment.HexPatriciaHashed, error) {
+	trie, err := sd.PatriciaTrie()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := servesRoot(trie, root); err != nil || ok {
+		return trie, err
+	}
+	head := sd.BlockNum()
+	blockNum, hashes, ok := roots.Path(root, head)
+	if !ok {
+		return trie, nil
+	}
+
+	changesets := make([]*[kv.DomainLen][]state.DomainEntryDiff, 0, len(hashes))
+	for i, hash := range hashes {
+		changeset, ok, err := state.ReadDiffSet(tx, head-uint64(i), hash)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return trie, nil // pruned
+		}
+		changesets = append(changesets, &changeset)
+	}
+	txNum, err := maxTxNum(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if err := sd.UnwindInMem(blockNum, txNum, changesets...); err != nil {
+		return nil, err
+	}
+	return sd.PatriciaTrie()
+}
+
+// appendUniqueProof appends nodes of the proof skipping the ones already present
+func appendUniqueProof(proof [][]byte, seen map[string]struct{}, nodes [][]byte) [][]byte {
+	for _, node := range nodes {
+		if _, ok := seen[string(node)]; ok {
+			continue
+		}
+		seen[string(node)] = struct{}{}
+		proof = append(proof, node)
+	}
+	return proof
+}
+
+func AnswerGetAccountRangeQuery(trie *commitment.HexPatriciaHashed, req *GetAccountRangePacket) (*AccountRangePacket, error) {
+	resp := &AccountRangePacket{ID: req.ID}
+	if ok, err := servesRoot(trie, req.Root); err != nil || !ok {
+		return resp, err
+	}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+
+	var size uint64
+	err := trie.IterateAccounts(req.Origin[:], func(hashedKey, plainKey []byte, account *commitment.Update, storageRoot []byte) (bool, error) {
+		slim := SlimAccount{Nonce: account.Nonce, Balance: &account.Balance}
+		if !bytes.Equal(storageRoot, commitment.EmptyRootHash) {
+			slim.Root = libcommon.Copy(storageRoot)
+		}
+		if !bytes.Equal(account.CodeHash[:], commitment.EmptyCodeHash) {
+			slim.CodeHash = libcommon.Copy(account.CodeHash[:])
+		}
+		body, err := rlp.EncodeToBytes(&slim)
+		if err != nil {
+			return false, err
+		}
+		hash := libcommon.BytesToHash(hashedKey)
+		resp.Accounts = append(resp.Accounts, &AccountData{Hash: hash, Body: body})
+		size += uint64(length.Hash + len(body))
+
+		// If we've exceeded the request threshold, abort
+		return bytes.Compare(hash[:], req.Limit[:]) < 0 && size <= req.Bytes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the Merkle proofs for the first and last account
+	seen := make(map[string]struct{})
+	proof, err := trie.ProveAccount(req.Origin[:])
+	if err != nil {
+		return nil, err
+	}
+	resp.Proof = appendUniqueProof(resp.Proof, seen, proof)
+	if len(resp.Accounts) > 0 {
+		proof, err = trie.ProveAccount(resp.Accounts[len(resp.Accounts)-1].Hash[:])
+		if err != nil {
+			return nil, err
+		}
+		resp.Proof = appendUniqueProof(resp.Proof, seen, proof)
+	}
+	return resp, nil
+}
+
+func AnswerGetStorageRangesQuery(trie *commitment.HexPatriciaHashed, req *GetStorageRangesPacket) (*StorageRangesPacket, error) {
+	resp := &StorageRangesPacket{ID: req.ID}
+	if ok, err := servesRoot(trie, req.Root); err != nil || !ok {
+		return resp, err
+	}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	// Calculate the hard limit at which to abort, even if mid storage trie
+	hardLimit := uint64(float64(req.Bytes) * (1 + stateLookupSlack))
+
+	var size uint64
+	for _, account := range req.Accounts {
+		// If we've exceeded the requested data limit, abort without opening
+		// a new storage range (that we'd need to prove due to exceeded size)
+		if size >= req.Bytes {
+			break
+		}
+		// The first account might start from a different origin and end sooner
+		var origin libcommon.Hash
+		if len(req.Origin) > 0 {
+			origin, req.Origin = libcommon.BytesToHash(req.Origin), nil
+		}
+		limit := maxHash
+		if len(req.Limit) > 0 {
+			limit, req.Limit = libcommon.BytesToHash(req.Limit), nil
+		}
+
+		var (
+			storage []*StorageData
+			abort   bool
+		)
+		err := trie.IterateStorage(account[:], origin[:], func(hashedKey, _, value []byte) (bool, error) {
+			if size >= hardLimit {
+				abort = true
+				return false, nil
+			}
+			body, err := rlp.EncodeToBytes(value)
+			if err != nil {
+				return false, err
+			}
+			hash := libcommon.BytesToHash(hashedKey)
+			storage = append(storage, &StorageData{Hash: hash, Body: body})
+			size += uint64(length.Hash + len(body))
+			return bytes.Compare(hash[:], limit[:]) < 0, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(storage) > 0 {
+			resp.Slots = append(resp.Slots, storage)
+		}
+		// If the storage range was partial, generate the proofs for the first and last slot
+		// and stop serving, otherwise it's a complete trie which peer can verify by itself
+		if origin != (libcommon.Hash{}) || (abort && len(storage) > 0) {
+			seen := make(map[string]struct{})
+			proof, err := trie.ProveStorage(account[:], origin[:])
+			if err != nil {
+				return nil, err
+			}
+			resp.Proof = appendUniqueProof(resp.Proof, seen, proof)
+			if len(storage) > 0 {
+				proof, err = trie.ProveStorage(account[:], storage[len(storage)-1].Hash[:])
+				if err != nil {
+					return nil, err
+				}
+				resp.Proof = appendUniqueProof(resp.Proof, seen, proof)
+			}
+			break
+		}
+	}
+	return resp, nil
+}
+
+// AnswerGetByteCodesQuery serves bytecodes by hash through the code index. Unknown hashes and code
+// which changed since the owner was indexed are skipped, as snap/1 allows.
+func AnswerGetByteCodesQuery(codes *CodeIndex, readCode func(addr libcommon.Address) ([]byte, error), req *GetByteCodesPacket) (*ByteCodesPacket, error) {
+	resp := &ByteCodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+	var size uint64
+	for _, hash := range req.Hashes {
+		if bytes.Equal(hash[:], commitment.EmptyCodeHash) {
+			// Peers should not request the empty code, but if they do, at
+			// least sent them back a correct response without db lookups
+			resp.Codes = append(resp.Codes, []byte{})
+			continue
+		}
+		owner, ok := codes.Owner(hash)
+		if !ok {
+			continue
+		}
+		code, err := readCode(owner)
+		if err != nil {
+			return nil, err
+		}
+		if len(code) == 0 || crypto.Keccak256Hash(code) != hash {
+			continue
+		}
+		resp.Codes = append(resp.Codes, code)
+		if size += uint64(len(code)); size > req.Bytes {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// trieNodePath decodes compact encoded trie node path into nibbles
+func trieNodePath(compact []byte) ([]byte, error) {
+	if len(compact) == 0 || len(compact) > length.Hash+1 {
+		return nil, fmt.Errorf("%w: invalid path length %d", errBadRequest, len(compact))
+	}
+	path := commitment.CompactedKeyToHex(compact)
+	if len(path) > 0 && path[len(path)-1] == 16 {
+		path = path[:len(path)-1] // terminator
+	}
+	return path, nil
+}
+
+func AnswerGetTrieNodesQuery(trie *commitment.HexPatriciaHashed, req *GetTrieNodesPacket) (*TrieNodesPacket, error) {
+	resp := &TrieNodesPacket{ID: req.ID}
+	if ok, err := servesRoot(trie, req.Root); err != nil || !ok {
+		return resp, err
+	}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+
+	var size, loads uint64
+	// getNode returns the node or nil if there is no node at the path
+	getNode := func(hashedAccount, compactPath []byte) ([]byte, error) {
+		loads++
+		path, err := trieNodePath(compactPath)
+		if err != nil {
+			return nil, err
+		}
+		node, err := trie.TrieNode(hashedAccount, path)
+		if errors.Is(err, commitment.ErrNodeNotFound) {
+			return nil, nil
+		}
+		return node, err
+	}
+	for _, pathset := range req.Paths {
+		switch len(pathset) {
+		case 0:
+			// Ensure we penalize invalid requests
+			return nil, fmt.Errorf("%w: zero-item pathset requested", errBadRequest)
+		case 1:
+			// If we're only retrieving an account trie node, fetch it directly
+			node, err := getNode(nil, pathset[0])
+			if err != nil {
+				return nil, err
+			}
+			resp.Nodes = append(resp.Nodes, node)
+			size += uint64(len(node))
+		default:
+			// Storage slots requested, first item is the full account hash
+			if len(pathset[0]) != length.Hash {
+				return nil, fmt.Errorf("%w: invalid account hash length %d", errBadRequest, len(pathset[0]))
+			}
+			for _, path := range pathset[1:] {
+				node, err := getNode(pathset[0], path)
+				if err != nil {
+					return nil, err
+				}
+				resp.Nodes = append(resp.Nodes, node)
+				size += uint64(len(node))
+				if loads > maxTrieNodeLookups || size > req.Bytes {
+					break
+				}
+			}
+		}
+		// Abort request processing if we've exceeded our limits
+		if size > req.Bytes || loads > maxTrieNodeLookups {
+			break
+		}
+	}
+	return resp, nil
+}
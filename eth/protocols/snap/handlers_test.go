@@ -0,0 +1,477 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/rlp"
+	"github.com/erigontech/erigon/turbo/trie"
+)
+
+type testState struct {
+	root     libcommon.Hash
+	accounts map[libcommon.Hash]libcommon.Address
+	codes    map[libcommon.Hash][]byte
+	storages map[libcommon.Hash]int // hashed account -> number of slots
+	trie     *commitment.HexPatriciaHashed
+	tx       kv.Tx
+	sd       *state.SharedDomains
+}
+
+func newTestState(t *testing.T) *testState {
+	t.Helper()
+	ctx := context.Background()
+	logger := log.New()
+	db, agg := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	t.Cleanup(agg.Close)
+
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	sd, err := state.NewSharedDomains(tx, logger)
+	require.NoError(t, err)
+	defer sd.Close()
+	sd.SetTxNum(1)
+
+	ts := &testState{
+		accounts: make(map[libcommon.Hash]libcommon.Address),
+		codes:    make(map[libcommon.Hash][]byte),
+		storages: make(map[libcommon.Hash]int),
+	}
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < 200; i++ {
+		var addr libcommon.Address
+		rnd.Read(addr[:])
+		acc := accounts.NewAccount()
+		acc.Nonce = uint64(i)
+		acc.Balance.SetUint64(rnd.Uint64())
+		if i%10 == 0 {
+			code := make([]byte, 32+i)
+			rnd.Read(code)
+			acc.CodeHash = crypto.Keccak256Hash(code)
+			ts.codes[acc.CodeHash] = code
+			require.NoError(t, sd.DomainPut(kv.CodeDomain, addr[:], nil, code, nil, 0))
+		}
+		require.NoError(t, sd.DomainPut(kv.AccountsDomain, addr[:], nil, accounts.SerialiseV3(&acc), nil, 0))
+		hashedAddr := crypto.Keccak256Hash(addr[:])
+		ts.accounts[hashedAddr] = addr
+
+		if i%50 == 0 {
+			slots := 1 + i/2
+			for j := 0; j < slots; j++ {
+				var loc libcommon.Hash
+				rnd.Read(loc[:])
+				value := uint256.NewInt(rnd.Uint64()).Bytes()
+				require.NoError(t, sd.DomainPut(kv.StorageDomain, addr[:], loc[:], value, nil, 0))
+			}
+			ts.storages[hashedAddr] = slots
+		}
+	}
+	root, err := sd.ComputeCommitment(ctx, true, 1, "")
+	require.NoError(t, err)
+	ts.root = libcommon.BytesToHash(root)
+	require.NoError(t, sd.Flush(ctx, tx))
+	sd.Close()
+	for i := uint64(0); i <= 1; i++ {
+		require.NoError(t, rawdbv3.TxNums.Append(tx, i, i))
+	}
+	require.NoError(t, tx.Commit())
+
+	roTx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	t.Cleanup(roTx.Rollback)
+	ts.tx = roTx
+	ts.sd, err = state.NewSharedDomains(roTx, logger)
+	require.NoError(t, err)
+	t.Cleanup(ts.sd.Close)
+	ts.trie, err = ts.sd.PatriciaTrie()
+	require.NoError(t, err)
+	return ts
+}
+
+func decodeSlimAccount(t *testing.T, body []byte) *SlimAccount {
+	t.Helper()
+	var acc SlimAccount
+	require.NoError(t, rlp.DecodeBytes(body, &acc))
+	if len(acc.Root) == 0 {
+		acc.Root = commitment.EmptyRootHash
+	}
+	if len(acc.CodeHash) == 0 {
+		acc.CodeHash = commitment.EmptyCodeHash
+	}
+	return &acc
+}
+
+func verifyAccount(t *testing.T, root libcommon.Hash, hashedKey libcommon.Hash, acc *SlimAccount, proof [][]byte) {
+	t.Helper()
+	accProof := &accounts.AccProofResult{
+		Balance:     (*hexutil.Big)(acc.Balance.ToBig()),
+		Nonce:       hexutil.Uint64(acc.Nonce),
+		StorageHash: libcommon.BytesToHash(acc.Root),
+		CodeHash:    libcommon.BytesToHash(acc.CodeHash),
+	}
+	for _, node := range proof {
+		accProof.AccountProof = append(accProof.AccountProof, hexutility.Bytes(node))
+	}
+	require.NoError(t, trie.VerifyAccountProofByHash(root, hashedKey, accProof))
+}
+
+func TestAnswerGetAccountRangeQuery(t *testing.T) {
+	ts := newTestState(t)
+
+	var (
+		origin libcommon.Hash
+		served int
+	)
+	for {
+		resp, err := AnswerGetAccountRangeQuery(ts.trie, &GetAccountRangePacket{ID: 1, Root: ts.root, Origin: origin, Limit: maxHash, Bytes: 1000})
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), resp.ID)
+		if len(resp.Accounts) == 0 {
+			break
+		}
+		require.NotEmpty(t, resp.Proof)
+		for i, account := range resp.Accounts {
+			require.Contains(t, ts.accounts, account.Hash)
+			require.True(t, bytes.Compare(account.Hash[:], origin[:]) >= 0)
+			if i > 0 {
+				require.Equal(t, 1, bytes.Compare(account.Hash[:], resp.Accounts[i-1].Hash[:]))
+			}
+		}
+		// proof of the last account in the range is included
+		last := resp.Accounts[len(resp.Accounts)-1]
+		proof, err := ts.trie.ProveAccount(last.Hash[:])
+		require.NoError(t, err)
+		require.Subset(t, resp.Proof, proof)
+		verifyAccount(t, ts.root, last.Hash, decodeSlimAccount(t, last.Body), proof)
+
+		served += len(resp.Accounts)
+		next := new(uint256.Int).SetBytes(last.Hash[:])
+		if next.Eq(new(uint256.Int).SetBytes(maxHash[:])) {
+			break
+		}
+		origin = next.AddUint64(next, 1).Bytes32()
+	}
+	require.Equal(t, len(ts.accounts), served)
+
+	// unknown roots are not served
+	resp, err := AnswerGetAccountRangeQuery(ts.trie, &GetAccountRangePacket{ID: 2, Root: libcommon.Hash{1}, Limit: maxHash, Bytes: 1000})
+	require.NoError(t, err)
+	require.Empty(t, resp.Accounts)
+	require.Empty(t, resp.Proof)
+}
+
+func TestAnswerGetByteCodesQuery(t *testing.T) {
+	ts := newTestState(t)
+	codes := NewCodeIndex()
+	it, err := ts.tx.(state.HasAggTx).AggTx().(*state.AggregatorRoTx).DomainRangeLatest(ts.tx, kv.CodeDomain, nil, nil, -1)
+	require.NoError(t, err)
+	require.NoError(t, codes.Build(context.Background(), it))
+
+	var hashes []libcommon.Hash
+	for hash := range ts.codes {
+		hashes = append(hashes, hash)
+	}
+	hashes = append(hashes, libcommon.Hash{2})
+	readCode := func(addr libcommon.Address) ([]byte, error) {
+		code, _, err := ts.sd.DomainGet(kv.CodeDomain, addr[:], nil)
+		return code, err
+	}
+	codesResp, err := AnswerGetByteCodesQuery(codes, readCode, &GetByteCodesPacket{ID: 3, Hashes: hashes, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, codesResp.Codes, len(ts.codes))
+	for _, code := range codesResp.Codes {
+		require.Equal(t, ts.codes[crypto.Keccak256Hash(code)], code)
+	}
+}
+
+func TestAnswerGetStorageRangesQuery(t *testing.T) {
+	ts := newTestState(t)
+
+	var large libcommon.Hash
+	for hashedAddr, slots := range ts.storages {
+		if slots > ts.storages[large] {
+			large = hashedAddr
+		}
+	}
+	var small []libcommon.Hash
+	for hashedAddr, slots := range ts.storages {
+		if hashedAddr != large {
+			small = append(small, hashedAddr)
+			require.Less(t, slots, 100)
+		}
+	}
+
+	// complete storage tries are served without proofs
+	resp, err := AnswerGetStorageRangesQuery(ts.trie, &GetStorageRangesPacket{ID: 1, Root: ts.root, Accounts: small, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, resp.Slots, len(small))
+	for i, hashedAddr := range small {
+		require.Len(t, resp.Slots[i], ts.storages[hashedAddr])
+	}
+	require.Empty(t, resp.Proof)
+
+	// large storage trie is split into proven chunks
+	var (
+		origin []byte
+		served int
+	)
+	for {
+		resp, err = AnswerGetStorageRangesQuery(ts.trie, &GetStorageRangesPacket{ID: 2, Root: ts.root, Accounts: []libcommon.Hash{large}, Origin: origin, Bytes: 500})
+		require.NoError(t, err)
+		if len(resp.Slots) == 0 {
+			break
+		}
+		require.Len(t, resp.Slots, 1)
+		require.NotEmpty(t, resp.Proof)
+		slots := resp.Slots[0]
+		served += len(slots)
+		last := new(uint256.Int).SetBytes(slots[len(slots)-1].Hash[:])
+		if served == ts.storages[large] {
+			break
+		}
+		next := last.AddUint64(last, 1).Bytes32()
+		origin = next[:]
+	}
+	require.Equal(t, ts.storages[large], served)
+}
+
+func TestAnswerGetTrieNodesQuery(t *testing.T) {
+	ts := newTestState(t)
+
+	var withStorage libcommon.Hash
+	for hashedAddr := range ts.storages {
+		withStorage = hashedAddr
+		break
+	}
+	rootPath := []byte{0x00} // compact encoding of the empty path
+	resp, err := AnswerGetTrieNodesQuery(ts.trie, &GetTrieNodesPacket{
+		ID:   1,
+		Root: ts.root,
+		Paths: []TrieNodePathSet{
+			{rootPath},
+			{withStorage[:], rootPath},
+			{{0x11, 0x23, 0x45, 0x67}}, // odd path 1234567, not a node boundary
+		},
+		Bytes: softResponseLimit,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Nodes, 3)
+	require.Equal(t, ts.root, crypto.Keccak256Hash(resp.Nodes[0]))
+	require.NotEmpty(t, resp.Nodes[1])
+	require.Empty(t, resp.Nodes[2])
+
+	_, err = AnswerGetTrieNodesQuery(ts.trie, &GetTrieNodesPacket{ID: 2, Root: ts.root, Paths: []TrieNodePathSet{{}}, Bytes: softResponseLimit})
+	require.ErrorIs(t, err, errBadRequest)
+}
+
+func TestStateTrieAtRecentRoot(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	db, agg := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	t.Cleanup(agg.Close)
+
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	sd, err := state.NewSharedDomains(tx, logger)
+	require.NoError(t, err)
+	defer sd.Close()
+
+	const blocks = 5
+	addrs := make([]libcommon.Address, 20)
+	rnd := rand.New(rand.NewSource(42))
+	for i := range addrs {
+		rnd.Read(addrs[i][:])
+	}
+	headers := map[uint64]*types.Header{0: {Number: big.NewInt(0), Root: types.EmptyRootHash}}
+	balances := make([]map[libcommon.Hash]uint64, blocks+1)
+	balances[0] = make(map[libcommon.Hash]uint64)
+	for block := uint64(1); block <= blocks; block++ {
+		changeset := &state.StateChangeSet{}
+		sd.SetChangesetAccumulator(changeset)
+		sd.SetTxNum(block)
+		sd.SetBlockNum(block)
+		balances[block] = make(map[libcommon.Hash]uint64)
+		for hash, balance := range balances[block-1] {
+			balances[block][hash] = balance
+		}
+		// every block updates half of the accounts
+		for i, addr := range addrs {
+			if (int(block)+i)%2 != 0 {
+				continue
+			}
+			prev, step, err := sd.DomainGet(kv.AccountsDomain, addr[:], nil)
+			require.NoError(t, err)
+			acc := accounts.NewAccount()
+			acc.Nonce = block
+			acc.Balance.SetUint64(block*1000 + uint64(i))
+			require.NoError(t, sd.DomainPut(kv.AccountsDomain, addr[:], nil, accounts.SerialiseV3(&acc), prev, step))
+			balances[block][crypto.Keccak256Hash(addr[:])] = acc.Balance.Uint64()
+		}
+		root, err := sd.ComputeCommitment(ctx, true, block, "")
+		require.NoError(t, err)
+		headers[block] = &types.Header{Number: big.NewInt(int64(block)), ParentHash: headers[block-1].Hash(), Root: libcommon.BytesToHash(root)}
+		require.NoError(t, state.WriteDiffSet(tx, block, headers[block].Hash(), changeset))
+	}
+	sd.SetChangesetAccumulator(nil)
+	require.NoError(t, sd.Flush(ctx, tx))
+	sd.Close()
+	for i := uint64(0); i <= blocks; i++ {
+		require.NoError(t, rawdbv3.TxNums.Append(tx, i, i))
+	}
+	require.NoError(t, tx.Commit())
+
+	roTx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	defer roTx.Rollback()
+	roots := NewStateRoots()
+	added, err := roots.Update(blocks, func(blockNum uint64) (*types.Header, error) {
+		return headers[blockNum], nil
+	})
+	require.NoError(t, err)
+	require.Len(t, added, blocks)
+	maxTxNum := func(blockNum uint64) (uint64, error) {
+		return rawdbv3.TxNums.Max(roTx, blockNum)
+	}
+	stateTrieAt := func(root libcommon.Hash) *commitment.HexPatriciaHashed {
+		sd, err := state.NewSharedDomains(roTx, logger)
+		require.NoError(t, err)
+		t.Cleanup(sd.Close)
+		trie, err := StateTrieAt(roTx, sd, root, roots, maxTxNum)
+		require.NoError(t, err)
+		return trie
+	}
+
+	for _, block := range []uint64{blocks, 3, 1} {
+		root := headers[block].Root
+		trie := stateTrieAt(root)
+		resp, err := AnswerGetAccountRangeQuery(trie, &GetAccountRangePacket{ID: block, Root: root, Limit: maxHash, Bytes: softResponseLimit})
+		require.NoError(t, err)
+		require.Len(t, resp.Accounts, len(balances[block]), "block %d", block)
+		for _, account := range resp.Accounts {
+			acc := decodeSlimAccount(t, account.Body)
+			require.Equal(t, balances[block][account.Hash], acc.Balance.Uint64(), "block %d", block)
+		}
+		last := resp.Accounts[len(resp.Accounts)-1]
+		proof, err := trie.ProveAccount(last.Hash[:])
+		require.NoError(t, err)
+		verifyAccount(t, root, last.Hash, decodeSlimAccount(t, last.Body), proof)
+	}
+
+	// roots of other blocks are turned down
+	_, _, ok := roots.Path(libcommon.Hash{1}, blocks)
+	require.False(t, ok)
+	root := libcommon.Hash{1}
+	resp, err := AnswerGetAccountRangeQuery(stateTrieAt(root), &GetAccountRangePacket{ID: 1, Root: root, Limit: maxHash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, resp.Accounts)
+}
+
+func TestStateRootsReorg(t *testing.T) {
+	chain := func(fork byte, from, to uint64, parent *types.Header) map[uint64]*types.Header {
+		headers := make(map[uint64]*types.Header)
+		for n := from; n <= to; n++ {
+			h := &types.Header{Number: new(big.Int).SetUint64(n), Root: libcommon.Hash{fork, byte(n >> 8), byte(n)}}
+			if parent != nil {
+				h.ParentHash = parent.Hash()
+			}
+			headers[n], parent = h, h
+		}
+		return headers
+	}
+	canonical := chain(1, 1, 5, nil)
+	header := func(blockNum uint64) (*types.Header, error) {
+		return canonical[blockNum], nil
+	}
+
+	roots := NewStateRoots()
+	added, err := roots.Update(5, header)
+	require.NoError(t, err)
+	require.Len(t, added, 5)
+	added, err = roots.Update(5, header)
+	require.NoError(t, err)
+	require.Empty(t, added)
+
+	// blocks 4 and 5 are replaced by a fork
+	for n, h := range chain(2, 4, 6, canonical[3]) {
+		canonical[n] = h
+	}
+	added, err = roots.Update(6, header)
+	require.NoError(t, err)
+	require.Len(t, added, 3)
+	require.Equal(t, uint64(6), added[0].Number.Uint64())
+
+	_, _, ok := roots.Path(libcommon.Hash{1, 0, 5}, 6)
+	require.False(t, ok)
+	blockNum, hashes, ok := roots.Path(canonical[3].Root, 6)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), blockNum)
+	require.Equal(t, []libcommon.Hash{canonical[6].Hash(), canonical[5].Hash(), canonical[4].Hash()}, hashes)
+	_, _, ok = roots.Path(canonical[6].Root, 6)
+	require.False(t, ok, "the head is served without unwinding")
+
+	// blocks out of the window are dropped
+	for n, h := range chain(2, 7, 200, canonical[6]) {
+		canonical[n] = h
+	}
+	_, err = roots.Update(200, header)
+	require.NoError(t, err)
+	_, _, ok = roots.Path(canonical[200-RecentStateRoots].Root, 200)
+	require.False(t, ok)
+	_, _, ok = roots.Path(canonical[200-RecentStateRoots+1].Root, 200)
+	require.True(t, ok)
+}
+
+func TestEmptyResponse(t *testing.T) {
+	req, err := rlp.EncodeToBytes(&GetStorageRangesPacket{ID: 7, Root: libcommon.Hash{1}, Accounts: []libcommon.Hash{{2}}, Bytes: 100})
+	require.NoError(t, err)
+	code, b, err := EmptyResponse(GetStorageRangesMsg, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(StorageRangesMsg), code)
+	var resp StorageRangesPacket
+	require.NoError(t, rlp.DecodeBytes(b, &resp))
+	require.Equal(t, uint64(7), resp.ID)
+	require.Empty(t, resp.Slots)
+
+	_, _, err = EmptyResponse(AccountRangeMsg, req)
+	require.Error(t, err)
+	_, _, err = EmptyResponse(GetByteCodesMsg, []byte{0x01})
+	require.Error(t, err)
+}
@@ -0,0 +1,236 @@
+// Copyright 2020 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+
+	"github.com/erigontech/erigon/rlp"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	SNAP1 = 1
+)
+
+// ProtocolName is the official short name of the `snap` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "snap"
+
+// ProtocolLength is the number of implemented message corresponding to snap/1.
+const ProtocolLength = 8
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+const ProtocolMaxMsgSize = maxMessageSize
+
+const (
+	GetAccountRangeMsg  = 0x00
+	AccountRangeMsg     = 0x01
+	GetStorageRangesMsg = 0x02
+	StorageRangesMsg    = 0x03
+	GetByteCodesMsg     = 0x04
+	ByteCodesMsg        = 0x05
+	GetTrieNodesMsg     = 0x06
+	TrieNodesMsg        = 0x07
+)
+
+var ToProto = map[uint64]proto_sentry.MessageId{
+	GetAccountRangeMsg:  proto_sentry.MessageId_GET_ACCOUNT_RANGE_SNAP1,
+	AccountRangeMsg:     proto_sentry.MessageId_ACCOUNT_RANGE_SNAP1,
+	GetStorageRangesMsg: proto_sentry.MessageId_GET_STORAGE_RANGES_SNAP1,
+	StorageRangesMsg:    proto_sentry.MessageId_STORAGE_RANGES_SNAP1,
+	GetByteCodesMsg:     proto_sentry.MessageId_GET_BYTE_CODES_SNAP1,
+	ByteCodesMsg:        proto_sentry.MessageId_BYTE_CODES_SNAP1,
+	GetTrieNodesMsg:     proto_sentry.MessageId_GET_TRIE_NODES_SNAP1,
+	TrieNodesMsg:        proto_sentry.MessageId_TRIE_NODES_SNAP1,
+}
+
+var FromProto = map[proto_sentry.MessageId]uint64{
+	proto_sentry.MessageId_GET_ACCOUNT_RANGE_SNAP1:  GetAccountRangeMsg,
+	proto_sentry.MessageId_ACCOUNT_RANGE_SNAP1:      AccountRangeMsg,
+	proto_sentry.MessageId_GET_STORAGE_RANGES_SNAP1: GetStorageRangesMsg,
+	proto_sentry.MessageId_STORAGE_RANGES_SNAP1:     StorageRangesMsg,
+	proto_sentry.MessageId_GET_BYTE_CODES_SNAP1:     GetByteCodesMsg,
+	proto_sentry.MessageId_BYTE_CODES_SNAP1:         ByteCodesMsg,
+	proto_sentry.MessageId_GET_TRIE_NODES_SNAP1:     GetTrieNodesMsg,
+	proto_sentry.MessageId_TRIE_NODES_SNAP1:         TrieNodesMsg,
+}
+
+// Packet represents a p2p message in the `snap` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// GetAccountRangePacket represents an account query.
+type GetAccountRangePacket struct {
+	ID     uint64         // Request ID to match up responses with
+	Root   libcommon.Hash // Root hash of the account trie to serve
+	Origin libcommon.Hash // Hash of the first account to retrieve
+	Limit  libcommon.Hash // Hash of the last account to retrieve
+	Bytes  uint64         // Soft limit at which to stop returning data
+}
+
+// AccountRangePacket represents an account query response.
+type AccountRangePacket struct {
+	ID       uint64         // ID of the request this is a response for
+	Accounts []*AccountData // List of consecutive accounts from the trie
+	Proof    [][]byte       // List of trie nodes proving the account range
+}
+
+// AccountData represents a single account in a query response.
+type AccountData struct {
+	Hash libcommon.Hash // Hash of the account
+	Body rlp.RawValue   // Account body in slim format
+}
+
+// GetStorageRangesPacket represents an storage slot query.
+type GetStorageRangesPacket struct {
+	ID       uint64           // Request ID to match up responses with
+	Root     libcommon.Hash   // Root hash of the account trie to serve
+	Accounts []libcommon.Hash // Account hashes of the storage tries to serve
+	Origin   []byte           // Hash of the first storage slot to retrieve (large contract mode)
+	Limit    []byte           // Hash of the last storage slot to retrieve (large contract mode)
+	Bytes    uint64           // Soft limit at which to stop returning data
+}
+
+// StorageRangesPacket represents a storage slot query response.
+type StorageRangesPacket struct {
+	ID    uint64           // ID of the request this is a response for
+	Slots [][]*StorageData // Lists of consecutive storage slots for the requested accounts
+	Proof [][]byte         // Merkle proofs for the *last* slot range, if it's incomplete
+}
+
+// StorageData represents a single storage slot in a query response.
+type StorageData struct {
+	Hash libcommon.Hash // Hash of the storage slot
+	Body []byte         // Data content of the slot
+}
+
+// GetByteCodesPacket represents a contract bytecode query.
+type GetByteCodesPacket struct {
+	ID     uint64           // Request ID to match up responses with
+	Hashes []libcommon.Hash // Code hashes to retrieve the code for
+	Bytes  uint64           // Soft limit at which to stop returning data
+}
+
+// ByteCodesPacket represents a contract bytecode query response.
+type ByteCodesPacket struct {
+	ID    uint64   // ID of the request this is a response for
+	Codes [][]byte // Requested contract bytecodes
+}
+
+// GetTrieNodesPacket represents a state trie node query.
+type GetTrieNodesPacket struct {
+	ID    uint64            // Request ID to match up responses with
+	Root  libcommon.Hash    // Root hash of the account trie to serve
+	Paths []TrieNodePathSet // Trie node hashes to retrieve the nodes for
+	Bytes uint64            // Soft limit at which to stop returning data
+}
+
+// TrieNodePathSet is a list of trie node paths to retrieve. A naive way to
+// represent trie nodes would be a simple list of `account || storage` path
+// segments concatenated, but that would be very wasteful on the network.
+//
+// Instead, this array special cases the first element as the path in the
+// account trie and the remaining elements as paths in the storage trie. To
+// address an account node, the slice should have a length of 1 consisting
+// of only the account path. There's no need to be able to address both an
+// account node and a storage node in the same request as it cannot happen
+// that a slot is accessed before the account path is fully expanded.
+type TrieNodePathSet [][]byte
+
+// TrieNodesPacket represents a state trie node query response.
+type TrieNodesPacket struct {
+	ID    uint64   // ID of the request this is a response for
+	Nodes [][]byte // Requested state trie nodes
+}
+
+// SlimAccount is the account body in snap responses: storage root and code hash are
+// omitted when they are empty.
+type SlimAccount struct {
+	Nonce    uint64
+	Balance  *uint256.Int
+	Root     []byte // Nil if root equals to the empty root hash
+	CodeHash []byte // Nil if hash equals to the empty code hash
+}
+
+// requestID is the request ID leading every snap/1 request.
+type requestID struct {
+	ID   uint64
+	Rest []rlp.RawValue `rlp:"tail"`
+}
+
+// EmptyResponse returns the code and the encoding of an empty response to the request, it
+// tells the peer that the data isn't available.
+func EmptyResponse(code uint64, request []byte) (uint64, []byte, error) {
+	var req requestID
+	if err := rlp.DecodeBytes(request, &req); err != nil {
+		return 0, nil, err
+	}
+	var resp Packet
+	switch code {
+	case GetAccountRangeMsg:
+		resp = &AccountRangePacket{ID: req.ID}
+	case GetStorageRangesMsg:
+		resp = &StorageRangesPacket{ID: req.ID}
+	case GetByteCodesMsg:
+		resp = &ByteCodesPacket{ID: req.ID}
+	case GetTrieNodesMsg:
+		resp = &TrieNodesPacket{ID: req.ID}
+	default:
+		return 0, nil, fmt.Errorf("message %d is not a request", code)
+	}
+	b, err := rlp.EncodeToBytes(resp)
+	if err != nil {
+		return 0, nil, err
+	}
+	return uint64(resp.Kind()), b, nil
+}
+
+func (*GetAccountRangePacket) Name() string { return "GetAccountRange" }
+func (*GetAccountRangePacket) Kind() byte   { return GetAccountRangeMsg }
+
+func (*AccountRangePacket) Name() string { return "AccountRange" }
+func (*AccountRangePacket) Kind() byte   { return AccountRangeMsg }
+
+func (*GetStorageRangesPacket) Name() string { return "GetStorageRanges" }
+func (*GetStorageRangesPacket) Kind() byte   { return GetStorageRangesMsg }
+
+func (*StorageRangesPacket) Name() string { return "StorageRanges" }
+func (*StorageRangesPacket) Kind() byte   { return StorageRangesMsg }
+
+func (*GetByteCodesPacket) Name() string { return "GetByteCodes" }
+func (*GetByteCodesPacket) Kind() byte   { return GetByteCodesMsg }
+
+func (*ByteCodesPacket) Name() string { return "ByteCodes" }
+func (*ByteCodesPacket) Kind() byte   { return ByteCodesMsg }
+
+func (*GetTrieNodesPacket) Name() string { return "GetTrieNodes" }
+func (*GetTrieNodesPacket) Kind() byte   { return GetTrieNodesMsg }
+
+func (*TrieNodesPacket) Name() string { return "TrieNodes" }
+func (*TrieNodesPacket) Kind() byte   { return TrieNodesMsg }
@@ -0,0 +1,171 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+)
+
+// TxOrderingBest keeps the order in which the txpool yields its best transactions.
+const TxOrderingBest = "best"
+
+// PoolTx is a txpool transaction to be ordered by a TxOrderingPolicy.
+type PoolTx struct {
+	Txn     types.Transaction
+	Sender  libcommon.Address
+	Arrival uint64 // the order in which the txpool has received it
+}
+
+// TxOrderingPolicy orders the transactions yielded by the txpool before they are added to a PoS
+// payload. It may interleave the transactions of different senders in any way, but must keep
+// the transactions of a sender in the order they are given, which is their nonce order.
+// The txpool keeps selecting by its best order: a policy only sees the window of at most
+// maxTransactions best transactions yielded for a payload, never the ones beyond it.
+type TxOrderingPolicy interface {
+	Order(txs []*PoolTx, baseFee *uint256.Int) []*PoolTx
+}
+
+var txOrderingPolicies = map[string]TxOrderingPolicy{
+	"fifo":        fifoOrdering{},
+	"priorityfee": priorityFeeOrdering{},
+	"fairness":    senderFairnessOrdering{},
+}
+
+// RegisterTxOrderingPolicy makes a custom policy available to --miner.txordering. It is meant
+// to be called from init functions.
+func RegisterTxOrderingPolicy(name string, policy TxOrderingPolicy) {
+	if name == TxOrderingBest {
+		panic("the best transactions ordering can't be replaced")
+	}
+	txOrderingPolicies[name] = policy
+}
+
+// TxOrderingPolicyByName returns the registered policy, or nil for the best ordering of the
+// txpool.
+func TxOrderingPolicyByName(name string) (TxOrderingPolicy, error) {
+	if name == "" || name == TxOrderingBest {
+		return nil, nil
+	}
+	policy, ok := txOrderingPolicies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transactions ordering policy: %s", name)
+	}
+	return policy, nil
+}
+
+// fifoOrdering adds the transactions in the order the txpool has received them.
+type fifoOrdering struct{}
+
+func (fifoOrdering) Order(txs []*PoolTx, _ *uint256.Int) []*PoolTx {
+	return mergeSenders(txs, func(a, b *PoolTx) bool { return a.Arrival < b.Arrival })
+}
+
+// priorityFeeOrdering adds the transactions paying the highest effective tip first.
+type priorityFeeOrdering struct{}
+
+func (priorityFeeOrdering) Order(txs []*PoolTx, baseFee *uint256.Int) []*PoolTx {
+	tips := make(map[*PoolTx]*uint256.Int, len(txs))
+	for _, tx := range txs {
+		tips[tx] = tx.Txn.GetEffectiveGasTip(baseFee)
+	}
+	return mergeSenders(txs, func(a, b *PoolTx) bool {
+		if c := tips[a].Cmp(tips[b]); c != 0 {
+			return c > 0
+		}
+		return a.Arrival < b.Arrival
+	})
+}
+
+// senderFairnessOrdering takes a transaction from each sender in turn, so that a sender with
+// many transactions can't fill the block ahead of the others. Within a round the senders are
+// ordered by the arrival of their next transaction.
+type senderFairnessOrdering struct{}
+
+func (senderFairnessOrdering) Order(txs []*PoolTx, _ *uint256.Int) []*PoolTx {
+	queues := bySender(txs)
+	ordered := make([]*PoolTx, 0, len(txs))
+	for len(queues) > 0 {
+		sort.SliceStable(queues, func(i, j int) bool { return queues[i][0].Arrival < queues[j][0].Arrival })
+		remaining := queues[:0]
+		for _, queue := range queues {
+			ordered = append(ordered, queue[0])
+			if len(queue) > 1 {
+				remaining = append(remaining, queue[1:])
+			}
+		}
+		queues = remaining
+	}
+	return ordered
+}
+
+// bySender splits the transactions into a queue per sender, keeping their order.
+func bySender(txs []*PoolTx) [][]*PoolTx {
+	index := make(map[libcommon.Address]int)
+	var queues [][]*PoolTx
+	for _, tx := range txs {
+		i, ok := index[tx.Sender]
+		if !ok {
+			i = len(queues)
+			index[tx.Sender] = i
+			queues = append(queues, nil)
+		}
+		queues[i] = append(queues[i], tx)
+	}
+	return queues
+}
+
+// mergeSenders repeatedly takes the better of the next transactions of the senders.
+func mergeSenders(txs []*PoolTx, better func(a, b *PoolTx) bool) []*PoolTx {
+	queues := &senderQueues{queues: bySender(txs), better: better}
+	heap.Init(queues)
+	ordered := make([]*PoolTx, 0, len(txs))
+	for queues.Len() > 0 {
+		queue := queues.queues[0]
+		ordered = append(ordered, queue[0])
+		if len(queue) > 1 {
+			queues.queues[0] = queue[1:]
+			heap.Fix(queues, 0)
+		} else {
+			heap.Pop(queues)
+		}
+	}
+	return ordered
+}
+
+// senderQueues is a heap of the transaction queues of the senders, by their next transaction.
+type senderQueues struct {
+	queues [][]*PoolTx
+	better func(a, b *PoolTx) bool
+}
+
+func (q *senderQueues) Len() int           { return len(q.queues) }
+func (q *senderQueues) Less(i, j int) bool { return q.better(q.queues[i][0], q.queues[j][0]) }
+func (q *senderQueues) Swap(i, j int)      { q.queues[i], q.queues[j] = q.queues[j], q.queues[i] }
+func (q *senderQueues) Push(x any)         { q.queues = append(q.queues, x.([]*PoolTx)) }
+func (q *senderQueues) Pop() any {
+	last := q.queues[len(q.queues)-1]
+	q.queues = q.queues[:len(q.queues)-1]
+	return last
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+)
+
+// orderingTestTxs are, in the nonce order of each sender: a0 a1 a2 b0 b1 c0, with the tips
+// and the arrivals below.
+func orderingTestTxs() []*PoolTx {
+	tx := func(sender byte, nonce, tip, feeCap, arrival uint64) *PoolTx {
+		return &PoolTx{
+			Txn: &types.DynamicFeeTransaction{
+				CommonTx: types.CommonTx{Nonce: nonce},
+				Tip:      uint256.NewInt(tip),
+				FeeCap:   uint256.NewInt(feeCap),
+			},
+			Sender:  libcommon.Address{sender},
+			Arrival: arrival,
+		}
+	}
+	return []*PoolTx{
+		tx('a', 0, 1, 100, 5),
+		tx('a', 1, 9, 100, 6),
+		tx('a', 2, 9, 100, 1),
+		tx('b', 0, 5, 100, 2),
+		tx('b', 1, 3, 100, 3),
+		tx('c', 0, 50, 12, 4), // pays a tip of 2 over a base fee of 10
+	}
+}
+
+func orderingNames(txs []*PoolTx) []string {
+	names := make([]string, len(txs))
+	for i, tx := range txs {
+		names[i] = fmt.Sprintf("%c%d", tx.Sender[0], tx.Txn.GetNonce())
+	}
+	return names
+}
+
+func TestTxOrderingPolicies(t *testing.T) {
+	baseFee := uint256.NewInt(10)
+	tests := []struct {
+		policy string
+		want   []string
+	}{
+		{"fifo", []string{"b0", "b1", "c0", "a0", "a1", "a2"}},
+		{"priorityfee", []string{"b0", "b1", "c0", "a0", "a1", "a2"}},
+		{"fairness", []string{"b0", "c0", "a0", "b1", "a1", "a2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			policy, err := TxOrderingPolicyByName(tt.policy)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, orderingNames(policy.Order(orderingTestTxs(), baseFee)))
+		})
+	}
+
+	policy, err := TxOrderingPolicyByName(TxOrderingBest)
+	require.NoError(t, err)
+	require.Nil(t, policy)
+	_, err = TxOrderingPolicyByName("lifo")
+	require.Error(t, err)
+}
+
+func TestPriorityFeeOrdering(t *testing.T) {
+	txs := orderingTestTxs()
+	// without the low tip of a0 ahead of them, a1 and a2 come first
+	ordered := priorityFeeOrdering{}.Order(txs[1:], uint256.NewInt(10))
+	require.Equal(t, []string{"a1", "a2", "b0", "b1", "c0"}, orderingNames(ordered))
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/ethutils"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/builder"
 	"github.com/erigontech/erigon/turbo/services"
 )
 
@@ -52,6 +53,8 @@ type MiningBlock struct {
 	Withdrawals      []*types.Withdrawal
 	PreparedTxs      types.TransactionsStream
 	Requests         types.Requests
+	InclusionList    types.Transactions        // forced to be included ahead of the txpool transactions
+	InclusionReport  []builder.InclusionResult // the outcome of the InclusionList
 }
 
 type MiningState struct {
@@ -207,6 +210,7 @@ func SpawnMiningCreateBlockStage(s *StageState, txc wrap.TxContainer, cfg Mining
 		current.Header = header
 		current.Uncles = nil
 		current.Withdrawals = cfg.blockBuilderParameters.Withdrawals
+		current.InclusionList = cfg.blockBuilderParameters.InclusionList
 		return nil
 	}
 
@@ -47,6 +47,7 @@ import (
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/builder"
 	"github.com/erigontech/erigon/turbo/services"
 )
 
@@ -63,6 +64,7 @@ type MiningExecCfg struct {
 	payloadId   uint64
 	txPool      TxPoolForMining
 	txPoolDB    kv.RoDB
+	bundlePool  *builder.BundlePool // nil if the blocks take no bundles
 }
 
 type TxPoolForMining interface {
@@ -74,7 +76,7 @@ func StageMiningExecCfg(
 	notifier ChainEventNotifier, chainConfig chain.Config,
 	engine consensus.Engine, vmConfig *vm.Config,
 	tmpdir string, interrupt *int32, payloadId uint64,
-	txPool TxPoolForMining, txPoolDB kv.RoDB,
+	txPool TxPoolForMining, txPoolDB kv.RoDB, bundlePool *builder.BundlePool,
 	blockReader services.FullBlockReader,
 ) MiningExecCfg {
 	return MiningExecCfg{
@@ -90,6 +92,7 @@ func StageMiningExecCfg(
 		payloadId:   payloadId,
 		txPool:      txPool,
 		txPoolDB:    txPoolDB,
+		bundlePool:  bundlePool,
 	}
 }
 
@@ -154,8 +157,45 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 				return err
 			}
 
+			blockStart := types.CopyHeader(current.Header)
+			if len(current.InclusionList) > 0 {
+				logs, err := addInclusionListToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, cfg.miningState.MiningConfig.Etherbase, ibs, ctx, cfg.payloadId, logger)
+				if err != nil {
+					return err
+				}
+				NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+			}
+			if bundles := cfg.bundlePool.Bundles(current.Header.Number.Uint64(), current.Header.Time); len(bundles) > 0 {
+				var logs types.Logs
+				ibs, logs, err = addBundlesToMiningBlock(logPrefix, current, blockStart, bundles, cfg.bundlePool, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, cfg.miningState.MiningConfig.Etherbase, stateReader, ibs, ctx, cfg.payloadId, logger)
+				if err != nil {
+					return err
+				}
+				NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+			}
+			if len(current.Txs) > 0 {
+				for _, txn := range current.Txs {
+					yielded.Add(txn.Hash())
+				}
+				// let the simulation know about the included ones, so that the next transactions of their senders aren't filtered out
+				if _, err := filterBadTransactions(append([]types.Transaction(nil), current.Txs...), cfg.chainConfig, executionAt+1, current.Header.BaseFee, simStateReader, simStateWriter, logger); err != nil {
+					return err
+				}
+			}
+
+			policy, err := TxOrderingPolicyByName(cfg.miningState.MiningConfig.TxOrdering)
+			if err != nil {
+				return err
+			}
+			// a policy orders a whole window of the txpool rather than small batches of its best; the
+			// window is still selected by the txpool best order, so it can't reach transactions beyond it
+			batch := uint16(50)
+			if policy != nil {
+				batch = maxTransactions
+			}
+
 			for {
-				txs, y, err := getNextTransactions(cfg, chainID, current.Header, 50, executionAt, yielded, simStateReader, simStateWriter, logger)
+				txs, y, err := getNextTransactions(cfg, chainID, current.Header, batch, executionAt, yielded, simStateReader, simStateWriter, policy, logger)
 				if err != nil {
 					return err
 				}
@@ -174,7 +214,7 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 				}
 
 				// if we yielded less than the count we wanted, assume the txpool has run dry now and stop to save another loop
-				if y < 50 {
+				if y < int(batch) {
 					break
 				}
 			}
@@ -264,6 +304,7 @@ func getNextTransactions(
 	alreadyYielded mapset.Set[[32]byte],
 	simStateReader state.StateReader,
 	simStateWriter state.StateWriter,
+	policy TxOrderingPolicy,
 	logger log.Logger,
 ) (types.TransactionsStream, int, error) {
 	txSlots := types2.TxsRlp{}
@@ -287,6 +328,7 @@ func getNextTransactions(
 	}
 
 	var txs []types.Transaction //nolint:prealloc
+	arrivals := make(map[types.Transaction]uint64, len(txSlots.Txs))
 	for i := range txSlots.Txs {
 		transaction, err := types.DecodeWrappedTransaction(txSlots.Txs[i])
 		if err == io.EOF {
@@ -305,6 +347,7 @@ func getNextTransactions(
 		// Check if txn nonce is too low
 		txs = append(txs, transaction)
 		txs[len(txs)-1].SetSender(sender)
+		arrivals[transaction] = txSlots.Arrivals[i]
 	}
 
 	blockNum := executionAt + 1
@@ -313,9 +356,39 @@ func getNextTransactions(
 		return nil, 0, err
 	}
 
+	if policy != nil {
+		txs, err = orderTransactions(txs, arrivals, header.BaseFee, policy)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
 	return types.NewTransactionsFixedOrder(txs), count, nil
 }
 
+// orderTransactions applies the policy to the transactions, which filterBadTransactions has
+// left in the nonce order of each sender.
+func orderTransactions(txs []types.Transaction, arrivals map[types.Transaction]uint64, baseFee *big.Int, policy TxOrderingPolicy) ([]types.Transaction, error) {
+	var baseFee256 *uint256.Int
+	if baseFee != nil {
+		var overflow bool
+		if baseFee256, overflow = uint256.FromBig(baseFee); overflow {
+			return nil, fmt.Errorf("bad baseFee %s", baseFee)
+		}
+	}
+	poolTxs := make([]*PoolTx, len(txs))
+	for i, txn := range txs {
+		sender, _ := txn.GetSender()
+		poolTxs[i] = &PoolTx{Txn: txn, Sender: sender, Arrival: arrivals[txn]}
+	}
+	poolTxs = policy.Order(poolTxs, baseFee256)
+	ordered := make([]types.Transaction, len(poolTxs))
+	for i, tx := range poolTxs {
+		ordered[i] = tx.Txn
+	}
+	return ordered, nil
+}
+
 func filterBadTransactions(transactions []types.Transaction, config chain.Config, blockNumber uint64, baseFee *big.Int, simStateReader state.StateReader, simStateWriter state.StateWriter, logger log.Logger) ([]types.Transaction, error) {
 	initialCnt := len(transactions)
 	var filtered []types.Transaction
@@ -438,7 +511,7 @@ func addTransactionsToMiningBlock(logPrefix string, current *MiningBlock, chainC
 	engine consensus.Engine, txs types.TransactionsStream, coinbase libcommon.Address, ibs *state.IntraBlockState, ctx context.Context,
 	interrupt *int32, payloadId uint64, logger log.Logger) (types.Logs, bool, error) {
 	header := current.Header
-	tcount := 0
+	tcount := current.Txs.Len()
 	gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed)
 	if header.BlobGasUsed != nil {
 		gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock() - *header.BlobGasUsed)
@@ -564,6 +637,137 @@ LOOP:
 
 }
 
+// addInclusionListToMiningBlock executes the transactions of the inclusion list ahead of the
+// txpool ones, and records in the InclusionReport why those that failed were left out.
+func addInclusionListToMiningBlock(logPrefix string, current *MiningBlock, chainConfig chain.Config, vmConfig *vm.Config, getHeader func(hash libcommon.Hash, number uint64) *types.Header,
+	engine consensus.Engine, coinbase libcommon.Address, ibs *state.IntraBlockState, ctx context.Context, payloadId uint64, logger log.Logger) (types.Logs, error) {
+	header := current.Header
+	gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed)
+	if header.BlobGasUsed != nil {
+		gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock() - *header.BlobGasUsed)
+	}
+	signer := types.MakeSigner(&chainConfig, header.Number.Uint64(), header.Time)
+	noop := state.NewNoopWriter()
+
+	var coalescedLogs types.Logs
+	current.InclusionReport = make([]builder.InclusionResult, 0, len(current.InclusionList))
+	for _, txn := range current.InclusionList {
+		if err := libcommon.Stopped(ctx.Done()); err != nil {
+			return nil, err
+		}
+		result := builder.InclusionResult{Hash: txn.Hash()}
+		if _, err := txn.Sender(*signer); err != nil {
+			result.Reason = fmt.Sprintf("could not recover sender: %v", err)
+		} else if txn.Protected() && !chainConfig.IsSpuriousDragon(header.Number.Uint64()) {
+			result.Reason = "replay protected transaction before EIP-155"
+		} else {
+			ibs.SetTxContext(txn.Hash(), current.Txs.Len())
+			gasSnap, blobGasSnap := gasPool.Gas(), gasPool.BlobGas()
+			snap := ibs.Snapshot()
+			receipt, _, err := core.ApplyTransaction(&chainConfig, core.GetHashFn(header, getHeader), engine, &coinbase, gasPool, ibs, noop, header, txn, &header.GasUsed, header.BlobGasUsed, *vmConfig)
+			if err != nil {
+				ibs.RevertToSnapshot(snap)
+				gasPool = new(core.GasPool).AddGas(gasSnap).AddBlobGas(blobGasSnap) // restore gasPool as well as ibs
+				result.Reason = err.Error()
+			} else {
+				current.Txs = append(current.Txs, txn)
+				current.Receipts = append(current.Receipts, receipt)
+				coalescedLogs = append(coalescedLogs, receipt.Logs...)
+				result.Included = true
+			}
+		}
+		if !result.Included {
+			logger.Warn(fmt.Sprintf("[%s] Left out transaction of the inclusion list", logPrefix), "hash", result.Hash, "reason", result.Reason, "payload", payloadId)
+		}
+		current.InclusionReport = append(current.InclusionReport, result)
+	}
+	return coalescedLogs, nil
+}
+
+// addBundlesToMiningBlock tries the bundles ahead of the txpool transactions. The journal of
+// the block state doesn't span transactions, so a bundle is executed on a new state replaying
+// the block so far from blockStart: it is left out entirely if one of its transactions fails, or
+// reverts without being allowed to, and then dropped from the pool. It returns the state of the
+// block with the included bundles.
+func addBundlesToMiningBlock(logPrefix string, current *MiningBlock, blockStart *types.Header, bundles []*builder.Bundle, bundlePool *builder.BundlePool,
+	chainConfig chain.Config, vmConfig *vm.Config, getHeader func(hash libcommon.Hash, number uint64) *types.Header, engine consensus.Engine, coinbase libcommon.Address,
+	stateReader state.StateReader, ibs *state.IntraBlockState, ctx context.Context, payloadId uint64, logger log.Logger) (*state.IntraBlockState, types.Logs, error) {
+	var coalescedLogs types.Logs
+	for _, bundle := range bundles {
+		if err := libcommon.Stopped(ctx.Done()); err != nil {
+			return nil, nil, err
+		}
+		sim, err := simulateBundle(current, blockStart, bundle, chainConfig, vmConfig, getHeader, engine, coinbase, stateReader)
+		if err != nil {
+			return nil, nil, err
+		}
+		if sim.failure != nil {
+			if errors.Is(sim.failure, core.ErrGasLimitReached) {
+				// it may still fit another payload of the block
+				logger.Debug(fmt.Sprintf("[%s] Bundle does not fit the block", logPrefix), "hash", bundle.Hash(), "payload", payloadId)
+				continue
+			}
+			logger.Warn(fmt.Sprintf("[%s] Dropping bundle", logPrefix), "hash", bundle.Hash(), "err", sim.failure, "payload", payloadId)
+			bundlePool.Remove(bundle.Hash())
+			continue
+		}
+
+		ibs = sim.ibs
+		current.Header.GasUsed = sim.header.GasUsed
+		if current.Header.BlobGasUsed != nil {
+			*current.Header.BlobGasUsed = *sim.header.BlobGasUsed
+		}
+		current.Txs = append(current.Txs, bundle.Txs...)
+		current.Receipts = append(current.Receipts, sim.receipts...)
+		for _, receipt := range sim.receipts {
+			coalescedLogs = append(coalescedLogs, receipt.Logs...)
+		}
+		logger.Debug(fmt.Sprintf("[%s] Added bundle", logPrefix), "hash", bundle.Hash(), "txs", len(bundle.Txs), "payload", payloadId)
+	}
+	return ibs, coalescedLogs, nil
+}
+
+type bundleSimulation struct {
+	ibs      *state.IntraBlockState
+	header   *types.Header
+	receipts types.Receipts // of the bundle transactions
+	failure  error          // why the bundle can't be included, nil if it can
+}
+
+func simulateBundle(current *MiningBlock, blockStart *types.Header, bundle *builder.Bundle, chainConfig chain.Config, vmConfig *vm.Config,
+	getHeader func(hash libcommon.Hash, number uint64) *types.Header, engine consensus.Engine, coinbase libcommon.Address, stateReader state.StateReader) (*bundleSimulation, error) {
+	sim := &bundleSimulation{ibs: state.New(stateReader), header: types.CopyHeader(blockStart)}
+	gasPool := new(core.GasPool).AddGas(sim.header.GasLimit - sim.header.GasUsed)
+	if sim.header.BlobGasUsed != nil {
+		gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock() - *sim.header.BlobGasUsed)
+	}
+	noop := state.NewNoopWriter()
+	apply := func(txn types.Transaction, txIndex int) (*types.Receipt, error) {
+		sim.ibs.SetTxContext(txn.Hash(), txIndex)
+		receipt, _, err := core.ApplyTransaction(&chainConfig, core.GetHashFn(sim.header, getHeader), engine, &coinbase, gasPool, sim.ibs, noop, sim.header, txn, &sim.header.GasUsed, sim.header.BlobGasUsed, *vmConfig)
+		return receipt, err
+	}
+
+	for i, txn := range current.Txs {
+		if _, err := apply(txn, i); err != nil {
+			return nil, fmt.Errorf("replaying transaction %x of the block: %w", txn.Hash(), err)
+		}
+	}
+	for i, txn := range bundle.Txs {
+		receipt, err := apply(txn, len(current.Txs)+i)
+		if err != nil {
+			sim.failure = fmt.Errorf("transaction %x: %w", txn.Hash(), err)
+			return sim, nil
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.CanRevert(txn.Hash()) {
+			sim.failure = fmt.Errorf("transaction %x reverted", txn.Hash())
+			return sim, nil
+		}
+		sim.receipts = append(sim.receipts, receipt)
+	}
+	return sim, nil
+}
+
 func NotifyPendingLogs(logPrefix string, notifier ChainEventNotifier, logs types.Logs, logger log.Logger) {
 	if len(logs) == 0 {
 		return
@@ -0,0 +1,188 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/builder"
+)
+
+// revertingCode reverts every call: PUSH1 0 PUSH1 0 REVERT
+var revertingCode = []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+
+type miningExecTest struct {
+	config   *chain.Config
+	reader   state.StateReader
+	header   *types.Header
+	signer   *types.Signer
+	funded   *ecdsa.PrivateKey
+	unfunded *ecdsa.PrivateKey
+	reverter libcommon.Address // a contract that reverts every call
+}
+
+// newMiningExecTest writes a funded account and a reverting contract, and returns the header of
+// a block on top of them with room for the given gas.
+func newMiningExecTest(t *testing.T, gasLimit uint64) *miningExecTest {
+	db, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(tx.Rollback)
+	domains, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	t.Cleanup(domains.Close)
+	domains.SetTxNum(1)
+	domains.SetBlockNum(1)
+	require.NoError(t, rawdbv3.TxNums.Append(tx, 1, 1))
+
+	funded, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	unfunded, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	test := &miningExecTest{
+		config:   params.TestChainConfig,
+		reader:   state.NewReaderV3(domains),
+		funded:   funded,
+		unfunded: unfunded,
+		reverter: libcommon.HexToAddress("0xdead"),
+	}
+	ibs := state.New(test.reader)
+	ibs.AddBalance(crypto.PubkeyToAddress(funded.PublicKey), uint256.NewInt(params.Ether), 0)
+	ibs.SetCode(test.reverter, revertingCode)
+	require.NoError(t, ibs.FinalizeTx(&chain.Rules{}, state.NewWriterV4(domains)))
+
+	test.header = &types.Header{Number: big.NewInt(2), Difficulty: big.NewInt(1), GasLimit: gasLimit}
+	test.signer = types.MakeSigner(test.config, test.header.Number.Uint64(), test.header.Time)
+	return test
+}
+
+func (test *miningExecTest) tx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to libcommon.Address, gas uint64) types.Transaction {
+	txn, err := types.SignTx(types.NewTransaction(nonce, to, uint256.NewInt(1), gas, uint256.NewInt(1), nil), *test.signer, key)
+	require.NoError(t, err)
+	_, err = txn.Sender(*test.signer) // the bundle pool needs the senders
+	require.NoError(t, err)
+	return txn
+}
+
+func (test *miningExecTest) getHeader(libcommon.Hash, uint64) *types.Header { return nil }
+
+func TestAddInclusionListToMiningBlock(t *testing.T) {
+	test := newMiningExecTest(t, 70_000)
+	to := libcommon.HexToAddress("0xbeef")
+	included := test.tx(t, test.funded, 0, to, params.TxGas)
+	inclusionList := types.Transactions{
+		included,
+		test.tx(t, test.funded, 0, test.reverter, params.TxGas),   // nonce too low
+		test.tx(t, test.unfunded, 0, to, params.TxGas),            // insufficient funds
+		test.tx(t, test.funded, 1, to, 50_000),                    // gas limit reached
+		test.tx(t, test.funded, 1, test.reverter, params.TxGas*2), // reverts, but included
+	}
+	current := &MiningBlock{Header: types.CopyHeader(test.header), InclusionList: inclusionList}
+	ibs := state.New(test.reader)
+
+	_, err := addInclusionListToMiningBlock("test", current, *test.config, &vm.Config{}, test.getHeader, nil, libcommon.Address{}, ibs, context.Background(), 1, log.New())
+	require.NoError(t, err)
+
+	require.Len(t, current.Txs, 2)
+	require.Equal(t, included.Hash(), current.Txs[0].Hash())
+	require.Equal(t, inclusionList[4].Hash(), current.Txs[1].Hash())
+	require.Len(t, current.Receipts, 2)
+	require.Equal(t, types.ReceiptStatusFailed, current.Receipts[1].Status)
+	require.Equal(t, current.Receipts[0].GasUsed+current.Receipts[1].GasUsed, current.Header.GasUsed)
+
+	require.Len(t, current.InclusionReport, len(inclusionList))
+	for i, result := range current.InclusionReport {
+		require.Equal(t, inclusionList[i].Hash(), result.Hash)
+	}
+	require.True(t, current.InclusionReport[0].Included)
+	require.Empty(t, current.InclusionReport[0].Reason)
+	require.False(t, current.InclusionReport[1].Included)
+	require.Contains(t, current.InclusionReport[1].Reason, core.ErrNonceTooLow.Error())
+	require.False(t, current.InclusionReport[2].Included)
+	require.Contains(t, current.InclusionReport[2].Reason, core.ErrInsufficientFunds.Error())
+	require.False(t, current.InclusionReport[3].Included)
+	require.Contains(t, current.InclusionReport[3].Reason, core.ErrGasLimitReached.Error())
+	require.True(t, current.InclusionReport[4].Included)
+}
+
+func TestAddBundlesToMiningBlock(t *testing.T) {
+	test := newMiningExecTest(t, 100_000)
+	to := libcommon.HexToAddress("0xbeef")
+	sender := crypto.PubkeyToAddress(test.funded.PublicKey)
+	blockNumber := test.header.Number.Uint64()
+
+	included := &builder.Bundle{BlockNumber: blockNumber, Txs: types.Transactions{
+		test.tx(t, test.funded, 0, to, params.TxGas),
+	}}
+	reverted := &builder.Bundle{BlockNumber: blockNumber, Txs: types.Transactions{
+		test.tx(t, test.funded, 1, to, params.TxGas),
+		test.tx(t, test.funded, 2, test.reverter, 30_000),
+	}}
+	allowedRevert := &builder.Bundle{BlockNumber: blockNumber, Txs: types.Transactions{
+		test.tx(t, test.funded, 1, test.reverter, 30_000),
+	}}
+	allowedRevert.RevertingTxHashes = []libcommon.Hash{allowedRevert.Txs[0].Hash()}
+	tooLarge := &builder.Bundle{BlockNumber: blockNumber, Txs: types.Transactions{
+		test.tx(t, test.funded, 2, to, 60_000),
+	}}
+	pool := builder.NewBundlePool(builder.DefaultMaxBundles, builder.DefaultMaxBundlesPerSender)
+	for _, bundle := range []*builder.Bundle{included, reverted, allowedRevert, tooLarge} {
+		_, err := pool.Add(bundle)
+		require.NoError(t, err)
+	}
+	bundles := pool.Bundles(blockNumber, test.header.Time)
+	require.Equal(t, []*builder.Bundle{included, reverted, allowedRevert, tooLarge}, bundles)
+
+	current := &MiningBlock{Header: types.CopyHeader(test.header)}
+	blockStart := types.CopyHeader(current.Header)
+	ibs, _, err := addBundlesToMiningBlock("test", current, blockStart, bundles, pool, *test.config, &vm.Config{}, test.getHeader, nil, libcommon.Address{}, test.reader, state.New(test.reader), context.Background(), 1, log.New())
+	require.NoError(t, err)
+
+	// the reverted bundle is left out entirely, the second transaction of the block is the
+	// reverting one that is allowed to revert
+	require.Len(t, current.Txs, 2)
+	require.Equal(t, included.Txs[0].Hash(), current.Txs[0].Hash())
+	require.Equal(t, allowedRevert.Txs[0].Hash(), current.Txs[1].Hash())
+	require.Len(t, current.Receipts, 2)
+	require.Equal(t, types.ReceiptStatusSuccessful, current.Receipts[0].Status)
+	require.Equal(t, types.ReceiptStatusFailed, current.Receipts[1].Status)
+	require.Equal(t, current.Receipts[0].GasUsed+current.Receipts[1].GasUsed, current.Header.GasUsed)
+	require.Equal(t, uint64(2), ibs.GetNonce(sender))
+
+	// the reverted bundle is dropped, the one that doesn't fit the gas left is kept for the next payload
+	require.Equal(t, []*builder.Bundle{included, allowedRevert, tooLarge}, pool.Bundles(blockNumber, test.header.Time))
+}
@@ -1233,6 +1233,14 @@ func (s polygonSyncStageBridgeStore) EventTxnToBlockNum(context.Context, common.
 	panic("polygonSyncStageBridgeStore.EventTxnToBlockNum not supported")
 }
 
+func (s polygonSyncStageBridgeStore) EventBlockNum(context.Context, uint64) (uint64, bool, error) {
+	// used in RPCs
+	// astrid stage integration intends to use the bridge only for scrapping,
+	// not for reading which remains the same in RPCs (via BlockReader)
+	// astrid standalone mode introduces its own reader
+	panic("polygonSyncStageBridgeStore.EventBlockNum not supported")
+}
+
 func (s polygonSyncStageBridgeStore) PutEventTxnToBlockNum(context.Context, map[common.Hash]uint64) error {
 	// this is a no-op for the astrid stage integration mode because the BorTxLookup table is populated
 	// in stage_txlookup.go as part of borTxnLookupTransform
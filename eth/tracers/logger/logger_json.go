@@ -79,6 +79,9 @@ func (l *JSONLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, sco
 	if !l.cfg.DisableMemory {
 		log.Memory = memory.Data()
 	}
+	if !l.cfg.DisableReturnData {
+		log.ReturnData = rData
+	}
 	if !l.cfg.DisableStack {
 		//TODO(@holiman) improve this
 		logstack := make([]*big.Int, len(stack.Data))
@@ -90,8 +93,10 @@ func (l *JSONLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, sco
 	_ = l.encoder.Encode(log)
 }
 
-// CaptureFault outputs state information on the logger.
+// CaptureFault outputs state information on the logger. The step was already logged
+// by CaptureState, so EIP-3155 consumers see it once more, now carrying the error.
 func (l *JSONLogger) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	l.CaptureState(pc, op, gas, cost, scope, nil, depth, err)
 }
 
 // CaptureEnd is triggered at end of execution.
@@ -71,6 +71,8 @@ type EthBackend interface {
 	NodesInfo(limit int) (*remote.NodesInfoReply, error)
 	Peers(ctx context.Context) (*remote.PeersReply, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	PeerBans(ctx context.Context) (*remote.PeerBansReply, error)
+	ClearPeerBans(ctx context.Context, req *remote.ClearPeerBansRequest) (*remote.ClearPeerBansReply, error)
 }
 
 func NewEthBackendServer(ctx context.Context, eth EthBackend, db kv.RwDB, events *shards.Events, blockReader services.FullBlockReader,
@@ -321,6 +323,14 @@ func (s *EthBackendServer) AddPeer(ctx context.Context, req *remote.AddPeerReque
 	return s.eth.AddPeer(ctx, req)
 }
 
+func (s *EthBackendServer) PeerBans(ctx context.Context, _ *emptypb.Empty) (*remote.PeerBansReply, error) {
+	return s.eth.PeerBans(ctx)
+}
+
+func (s *EthBackendServer) ClearPeerBans(ctx context.Context, req *remote.ClearPeerBansRequest) (*remote.ClearPeerBansReply, error) {
+	return s.eth.ClearPeerBans(ctx, req)
+}
+
 func (s *EthBackendServer) SubscribeLogs(server remote.ETHBACKEND_SubscribeLogsServer) (err error) {
 	if s.logsFilter != nil {
 		return s.logsFilter.subscribeLogs(server)
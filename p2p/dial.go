@@ -83,6 +83,7 @@ var (
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
 	errNoPort           = errors.New("node does not provide TCP port")
+	errBanned           = errors.New("banned")
 )
 
 // dialer creates outbound connections and submits them into Server.
@@ -145,6 +146,7 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	banned         func(enode.ID) bool // reports banned nodes, which are not dialed dynamically
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -260,7 +262,11 @@ loop:
 			d.logStats()
 
 		case node := <-nodesCh:
-			if err := d.checkDial(node); err != nil {
+			err := d.checkDial(node)
+			if err == nil && d.banned != nil && d.banned(node.ID()) {
+				err = errBanned
+			}
+			if err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
@@ -128,6 +128,34 @@ func (f *filterIter) Next() bool {
 	return false
 }
 
+// Resolve wraps an iterator such that Next returns the nodes replaced by the result of
+// 'resolve', e.g. their latest record. A node that fails to resolve is returned as is.
+func Resolve(it Iterator, resolve func(*Node) (*Node, error)) Iterator {
+	return &resolveIter{Iterator: it, resolve: resolve}
+}
+
+type resolveIter struct {
+	Iterator
+	resolve func(*Node) (*Node, error)
+	cur     *Node
+}
+
+func (r *resolveIter) Next() bool {
+	if !r.Iterator.Next() {
+		r.cur = nil
+		return false
+	}
+	r.cur = r.Iterator.Node()
+	if n, err := r.resolve(r.cur); err == nil && n != nil {
+		r.cur = n
+	}
+	return true
+}
+
+func (r *resolveIter) Node() *Node {
+	return r.cur
+}
+
 // FairMix aggregates multiple node iterators. The mixer itself is an iterator which ends
 // only when Close is called. Source iterators added via AddSource are removed from the
 // mix when they end.
@@ -43,11 +43,12 @@ import (
 // Keys in the node database.
 
 const (
-	dbVersionKey   = "version" // Version of the database to flush if changes
-	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
-	dbLocalPrefix  = "local:"
-	dbDiscoverRoot = "v4"
-	dbDiscv5Root   = "v5"
+	dbVersionKey    = "version" // Version of the database to flush if changes
+	dbNodePrefix    = "n:"      // Identifier to prefix node entries with
+	dbLocalPrefix   = "local:"
+	dbPeerRepPrefix = "rep:" // Peer reputation is keyed by ID only, the full key is "rep:<ID>".
+	dbDiscoverRoot  = "v4"
+	dbDiscv5Root    = "v5"
 
 	// These fields are stored per ID and IP, the full key is "n:<ID>:v4:<IP>:findfail".
 	// Use nodeItemKey to create those keys.
@@ -552,6 +553,84 @@ func (db *DB) storeLocalSeq(id ID, n uint64) {
 	db.storeUint64(localItemKey(id, dbLocalSeq), n)
 }
 
+// PeerReputation is the penalty record of a node, kept across restarts so that
+// misbehaving peers stay banned.
+type PeerReputation struct {
+	Score       uint64 // penalty score in thousandths, decayed up to Updated
+	Updated     uint64 // unix time of the last score update
+	BannedUntil uint64 // unix time until which the node must not be connected
+	Reason      string // penalty kind which caused the ban
+}
+
+func peerReputationKey(id ID) []byte {
+	return append([]byte(dbPeerRepPrefix), id[:]...)
+}
+
+// PeerReputation retrieves the reputation of a node, or nil if it was never penalized.
+func (db *DB) PeerReputation(id ID) *PeerReputation {
+	var rep *PeerReputation
+	if err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		blob, errGet := tx.GetOne(kv.Inodes, peerReputationKey(id))
+		if errGet != nil || blob == nil {
+			return errGet
+		}
+		rep = new(PeerReputation)
+		return rlp.DecodeBytes(blob, rep)
+	}); err != nil {
+		return nil
+	}
+	return rep
+}
+
+// UpdatePeerReputation stores the reputation of a node.
+func (db *DB) UpdatePeerReputation(id ID, rep *PeerReputation) error {
+	blob, err := rlp.EncodeToBytes(rep)
+	if err != nil {
+		return err
+	}
+	return db.kv.Batch(func(tx kv.RwTx) error {
+		return tx.Put(kv.Inodes, peerReputationKey(id), blob)
+	})
+}
+
+// DeletePeerReputation forgets the reputation of a node.
+func (db *DB) DeletePeerReputation(id ID) error {
+	return db.kv.Batch(func(tx kv.RwTx) error {
+		return tx.Delete(kv.Inodes, peerReputationKey(id))
+	})
+}
+
+// PeerReputations retrieves the reputations of all penalized nodes.
+func (db *DB) PeerReputations() (map[ID]*PeerReputation, error) {
+	reps := make(map[ID]*PeerReputation)
+	err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		c, err := tx.Cursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		p := []byte(dbPeerRepPrefix)
+		for k, v, err := c.Seek(p); ; k, v, err = c.Next() {
+			// a failing cursor returns a nil key, check the error before the end of the prefix
+			if err != nil {
+				return err
+			}
+			if !bytes.HasPrefix(k, p) {
+				break
+			}
+			var id ID
+			copy(id[:], k[len(p):])
+			rep := new(PeerReputation)
+			if err := rlp.DecodeBytes(v, rep); err != nil {
+				return fmt.Errorf("p2p/enode: can't decode reputation of %x in DB: %w", id, err)
+			}
+			reps[id] = rep
+		}
+		return nil
+	})
+	return reps, err
+}
+
 // QuerySeeds retrieves random nodes to be used as potential seed nodes
 // for bootstrapping.
 func (db *DB) QuerySeeds(n int, maxAge time.Duration) []*Node {
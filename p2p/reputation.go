@@ -0,0 +1,197 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+// PenaltyKind is the kind of misbehaviour a peer is penalized for.
+type PenaltyKind uint8
+
+const (
+	// PenaltyKick is issued by the sync and pool components through the sentry,
+	// e.g. for invalid blocks or transactions.
+	PenaltyKick PenaltyKind = iota
+	// PenaltyProtocolBreach is issued for malformed messages and failed handshakes.
+	PenaltyProtocolBreach
+)
+
+func (k PenaltyKind) String() string {
+	switch k {
+	case PenaltyKick:
+		return "kick"
+	case PenaltyProtocolBreach:
+		return "protocol breach"
+	default:
+		return "unknown"
+	}
+}
+
+// penaltyBanDurations is the ban issued for a single penalty of each kind. Repeated
+// penalties add up: the ban lasts for the base duration times the decayed score.
+var penaltyBanDurations = map[PenaltyKind]time.Duration{
+	PenaltyKick:           10 * time.Minute,
+	PenaltyProtocolBreach: time.Hour,
+}
+
+const (
+	reputationHalfLife = 6 * time.Hour  // time after which the penalty score halves
+	maxBanDuration     = 24 * time.Hour // upper bound for bans of repeat offenders
+	scoreUnit          = 1000           // penalty score units per penalty
+)
+
+// PeerBan describes a banned node.
+type PeerBan struct {
+	ID          enode.ID
+	Reason      string
+	Score       float64 // penalties after decay
+	BannedUntil time.Time
+}
+
+// reputation keeps the penalty scores of peers in the node database and the
+// currently banned nodes in memory.
+type reputation struct {
+	db     *enode.DB
+	log    log.Logger
+	mu     sync.RWMutex
+	banned map[enode.ID]time.Time
+}
+
+// newReputation restores the bans which are still active at the given time.
+func newReputation(db *enode.DB, logger log.Logger, now time.Time) *reputation {
+	r := &reputation{
+		db:     db,
+		log:    logger,
+		banned: make(map[enode.ID]time.Time),
+	}
+	reps, err := db.PeerReputations()
+	if err != nil {
+		logger.Warn("[p2p] Failed to load peer reputations", "err", err)
+	}
+	for id, rep := range reps {
+		if until := time.Unix(int64(rep.BannedUntil), 0); until.After(now) {
+			r.banned[id] = until
+		}
+	}
+	return r
+}
+
+// decayedScore returns the penalty score of rep at the given time.
+func decayedScore(rep *enode.PeerReputation, now time.Time) float64 {
+	score := float64(rep.Score) / scoreUnit
+	if elapsed := now.Sub(time.Unix(int64(rep.Updated), 0)); elapsed > 0 {
+		score *= math.Pow(0.5, float64(elapsed)/float64(reputationHalfLife))
+	}
+	return score
+}
+
+// penalize adds a penalty of the given kind to the score of the node and bans it
+// for the resulting duration. It returns the end of the ban.
+func (r *reputation) penalize(id enode.ID, kind PenaltyKind, now time.Time) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := r.db.PeerReputation(id)
+	if rep == nil {
+		rep = &enode.PeerReputation{}
+	}
+	score := decayedScore(rep, now) + 1
+
+	ban := time.Duration(float64(penaltyBanDurations[kind]) * score)
+	until := now.Add(min(ban, maxBanDuration))
+	if prev := time.Unix(int64(rep.BannedUntil), 0); prev.After(until) {
+		until = prev
+	} else {
+		rep.Reason = kind.String()
+	}
+
+	rep.Score = uint64(score * scoreUnit)
+	rep.Updated = uint64(now.Unix())
+	rep.BannedUntil = uint64(until.Unix())
+	if err := r.db.UpdatePeerReputation(id, rep); err != nil {
+		r.log.Warn("[p2p] Failed to store peer reputation", "id", id, "err", err)
+	}
+	r.banned[id] = until
+	return until
+}
+
+// isBanned reports whether the node is banned at the given time.
+func (r *reputation) isBanned(id enode.ID, now time.Time) bool {
+	r.mu.RLock()
+	until, ok := r.banned[id]
+	r.mu.RUnlock()
+	return ok && until.After(now)
+}
+
+// bans returns the nodes banned at the given time, ending soonest first.
+func (r *reputation) bans(now time.Time) []PeerBan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bans := make([]PeerBan, 0, len(r.banned))
+	for id, until := range r.banned {
+		if !until.After(now) {
+			delete(r.banned, id)
+			continue
+		}
+		ban := PeerBan{ID: id, BannedUntil: until}
+		if rep := r.db.PeerReputation(id); rep != nil {
+			ban.Reason = rep.Reason
+			ban.Score = decayedScore(rep, now)
+		}
+		bans = append(bans, ban)
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].BannedUntil.Before(bans[j].BannedUntil) })
+	return bans
+}
+
+// clear lifts the bans of the given nodes, or of all nodes if none are given, and
+// resets their scores. It returns the number of lifted bans.
+func (r *reputation) clear(ids []enode.ID, now time.Time) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(ids) == 0 {
+		reps, err := r.db.PeerReputations()
+		if err != nil {
+			r.log.Warn("[p2p] Failed to load peer reputations", "err", err)
+		}
+		for id := range reps {
+			ids = append(ids, id)
+		}
+	}
+
+	var cleared int
+	for _, id := range ids {
+		if until, ok := r.banned[id]; ok && until.After(now) {
+			cleared++
+		}
+		delete(r.banned, id)
+		if err := r.db.DeletePeerReputation(id); err != nil {
+			r.log.Warn("[p2p] Failed to delete peer reputation", "id", id, "err", err)
+		}
+	}
+	return cleared
+}
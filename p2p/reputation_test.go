@@ -0,0 +1,77 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+func TestReputationBans(t *testing.T) {
+	logger := log.New()
+	db, err := enode.OpenDB(context.Background(), "", t.TempDir(), logger)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	now := time.Unix(1_700_000_000, 0)
+	a, b := enode.ID{1}, enode.ID{2}
+
+	r := newReputation(db, logger, now)
+	require.Equal(t, now.Add(10*time.Minute), r.penalize(a, PenaltyKick, now))
+	require.True(t, r.isBanned(a, now))
+	require.False(t, r.isBanned(a, now.Add(10*time.Minute)))
+	require.False(t, r.isBanned(b, now))
+
+	// repeated penalties lengthen the ban
+	require.Equal(t, now.Add(20*time.Minute), r.penalize(a, PenaltyKick, now))
+	require.Equal(t, now.Add(time.Hour), r.penalize(b, PenaltyProtocolBreach, now))
+
+	// a shorter ban does not override a longer one
+	require.Equal(t, now.Add(time.Hour), r.penalize(b, PenaltyKick, now))
+
+	// the score halves every half-life
+	rep := db.PeerReputation(a)
+	require.NotNil(t, rep)
+	require.InDelta(t, 2, decayedScore(rep, now), 1e-9)
+	require.InDelta(t, 1, decayedScore(rep, now.Add(reputationHalfLife)), 1e-9)
+
+	// bans are restored from the database
+	r = newReputation(db, logger, now.Add(30*time.Minute))
+	require.False(t, r.isBanned(a, now))
+	require.True(t, r.isBanned(b, now))
+	r = newReputation(db, logger, now)
+	bans := r.bans(now)
+	require.Len(t, bans, 2)
+	require.Equal(t, a, bans[0].ID)
+	require.Equal(t, "kick", bans[0].Reason)
+	require.Equal(t, b, bans[1].ID)
+	require.Equal(t, "protocol breach", bans[1].Reason)
+	require.Equal(t, now.Add(time.Hour), bans[1].BannedUntil)
+
+	require.Equal(t, 1, r.clear([]enode.ID{a}, now))
+	require.False(t, r.isBanned(a, now))
+	require.Nil(t, db.PeerReputation(a))
+	require.Equal(t, 1, r.clear(nil, now))
+	require.Empty(t, r.bans(now))
+}
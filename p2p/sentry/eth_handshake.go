@@ -19,6 +19,8 @@ package sentry
 import (
 	"fmt"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon/core/forkid"
@@ -26,18 +28,72 @@ import (
 	"github.com/erigontech/erigon/p2p"
 )
 
+// peerStatus is what the sentry keeps from the remote eth Status message.
+type peerStatus struct {
+	head libcommon.Hash
+	// the block range is only announced by eth/69 peers
+	hasBlockRange bool
+	earliestBlock uint64
+	latestBlock   uint64
+}
+
+func makeStatusPacket(status *proto_sentry.StatusData, version uint) eth.Packet {
+	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	forkID := forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
+	if version >= direct.ETH69 {
+		return &eth.StatusPacket69{
+			ProtocolVersion: uint32(version),
+			NetworkID:       status.NetworkId,
+			Genesis:         genesisHash,
+			ForkID:          forkID,
+			EarliestBlock:   status.EarliestBlock,
+			LatestBlock:     status.MaxBlockHeight,
+			LatestBlockHash: gointerfaces.ConvertH256ToHash(status.BestHash),
+		}
+	}
+	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
+	return &eth.StatusPacket{
+		ProtocolVersion: uint32(version),
+		NetworkID:       status.NetworkId,
+		TD:              ourTD.ToBig(),
+		Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
+		Genesis:         genesisHash,
+		ForkID:          forkID,
+	}
+}
+
 func readAndValidatePeerStatusMessage(
 	rw p2p.MsgReadWriter,
 	status *proto_sentry.StatusData,
 	version uint,
 	minVersion uint,
-) (*eth.StatusPacket, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	msg, err := rw.ReadMsg()
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusReceive, p2p.DiscNetworkError, err, "readAndValidatePeerStatusMessage rw.ReadMsg error")
 	}
 
-	reply, err := tryDecodeStatusMessage(&msg)
+	if version >= direct.ETH69 {
+		reply, err := tryDecodeStatusMessage[eth.StatusPacket69](&msg)
+		msg.Discard()
+		if err != nil {
+			return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage error")
+		}
+
+		err = checkPeerStatusCompatibility69(reply, status, version, minVersion)
+		if err != nil {
+			return nil, p2p.NewPeerError(p2p.PeerErrorStatusIncompatible, p2p.DiscUselessPeer, err, "readAndValidatePeerStatusMessage checkPeerStatusCompatibility69 error")
+		}
+
+		return &peerStatus{
+			head:          reply.LatestBlockHash,
+			hasBlockRange: true,
+			earliestBlock: reply.EarliestBlock,
+			latestBlock:   reply.LatestBlock,
+		}, nil
+	}
+
+	reply, err := tryDecodeStatusMessage[eth.StatusPacket](&msg)
 	msg.Discard()
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage error")
@@ -48,10 +104,10 @@ func readAndValidatePeerStatusMessage(
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusIncompatible, p2p.DiscUselessPeer, err, "readAndValidatePeerStatusMessage checkPeerStatusCompatibility error")
 	}
 
-	return reply, nil
+	return &peerStatus{head: reply.Head}, nil
 }
 
-func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
+func tryDecodeStatusMessage[T eth.StatusPacket | eth.StatusPacket69](msg *p2p.Msg) (*T, error) {
 	if msg.Code != eth.StatusMsg {
 		return nil, fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
 	}
@@ -60,7 +116,7 @@ func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
 		return nil, fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
 	}
 
-	var reply eth.StatusPacket
+	var reply T
 	if err := msg.Decode(&reply); err != nil {
 		return nil, fmt.Errorf("decode message %v: %w", msg, err)
 	}
@@ -74,23 +130,52 @@ func checkPeerStatusCompatibility(
 	version uint,
 	minVersion uint,
 ) error {
-	networkID := status.NetworkId
-	if reply.NetworkID != networkID {
-		return fmt.Errorf("network id does not match: theirs %d, ours %d", reply.NetworkID, networkID)
+	return checkPeerStatusFields(reply.NetworkID, reply.ProtocolVersion, reply.Genesis, reply.ForkID, status, version, minVersion)
+}
+
+func checkPeerStatusCompatibility69(
+	reply *eth.StatusPacket69,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) error {
+	if err := checkPeerStatusFields(reply.NetworkID, reply.ProtocolVersion, reply.Genesis, reply.ForkID, status, version, minVersion); err != nil {
+		return err
+	}
+
+	blockRange := eth.BlockRangeUpdatePacket{
+		EarliestBlock:   reply.EarliestBlock,
+		LatestBlock:     reply.LatestBlock,
+		LatestBlockHash: reply.LatestBlockHash,
+	}
+	return blockRange.Validate()
+}
+
+func checkPeerStatusFields(
+	networkID uint64,
+	protocolVersion uint32,
+	genesis libcommon.Hash,
+	forkID forkid.ID,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) error {
+	if networkID != status.NetworkId {
+		return fmt.Errorf("network id does not match: theirs %d, ours %d", networkID, status.NetworkId)
 	}
 
-	if uint(reply.ProtocolVersion) > version {
-		return fmt.Errorf("version is more than what this senty supports: theirs %d, max %d", reply.ProtocolVersion, version)
+	if uint(protocolVersion) > version {
+		return fmt.Errorf("version is more than what this senty supports: theirs %d, max %d", protocolVersion, version)
 	}
-	if uint(reply.ProtocolVersion) < minVersion {
-		return fmt.Errorf("version is less than allowed minimum: theirs %d, min %d", reply.ProtocolVersion, minVersion)
+	if uint(protocolVersion) < minVersion {
+		return fmt.Errorf("version is less than allowed minimum: theirs %d, min %d", protocolVersion, minVersion)
 	}
 
 	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
-	if reply.Genesis != genesisHash {
-		return fmt.Errorf("genesis hash does not match: theirs %x, ours %x", reply.Genesis, genesisHash)
+	if genesis != genesisHash {
+		return fmt.Errorf("genesis hash does not match: theirs %x, ours %x", genesis, genesisHash)
 	}
 
 	forkFilter := forkid.NewFilterFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
-	return forkFilter(reply.ForkID)
+	return forkFilter(forkID)
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/direct"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/eth/protocols/eth"
+	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/params"
 )
 
@@ -97,3 +99,128 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
 	})
 }
+
+func TestCheckPeerStatusCompatibility69(t *testing.T) {
+	var version uint = direct.ETH69
+	networkID := params.MainnetChainConfig.ChainID.Uint64()
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0 /* genesisTime */)
+	goodReply := eth.StatusPacket69{
+		ProtocolVersion: uint32(version),
+		NetworkID:       networkID,
+		Genesis:         params.MainnetGenesisHash,
+		ForkID:          forkid.NewIDFromForks(heightForks, timeForks, params.MainnetGenesisHash, 0, 0),
+		EarliestBlock:   0,
+		LatestBlock:     0,
+		LatestBlockHash: params.MainnetGenesisHash,
+	}
+	status := proto_sentry.StatusData{
+		NetworkId: networkID,
+		BestHash:  gointerfaces.ConvertHashToH256(params.MainnetGenesisHash),
+		ForkData: &proto_sentry.Forks{
+			Genesis:     gointerfaces.ConvertHashToH256(params.MainnetGenesisHash),
+			HeightForks: heightForks,
+			TimeForks:   timeForks,
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		err := checkPeerStatusCompatibility69(&goodReply, &status, version, version)
+		assert.Nil(t, err)
+	})
+	t.Run("network mismatch", func(t *testing.T) {
+		reply := goodReply
+		reply.NetworkID = 0
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "network")
+	})
+	t.Run("version mismatch min", func(t *testing.T) {
+		reply := goodReply
+		reply.ProtocolVersion = direct.ETH68
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "version is less")
+	})
+	t.Run("fork mismatch", func(t *testing.T) {
+		reply := goodReply
+		reply.ForkID = forkid.ID{}
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
+	})
+	t.Run("invalid block range", func(t *testing.T) {
+		reply := goodReply
+		reply.EarliestBlock = 10
+		reply.LatestBlock = 5
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "invalid block range")
+	})
+	t.Run("zero latest block hash", func(t *testing.T) {
+		reply := goodReply
+		reply.LatestBlockHash = libcommon.Hash{}
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "latest block hash")
+	})
+}
+
+func TestReadAndValidatePeerStatusMessage69(t *testing.T) {
+	networkID := params.MainnetChainConfig.ChainID.Uint64()
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0 /* genesisTime */)
+	latestHash := libcommon.HexToHash("0x01")
+	status := &proto_sentry.StatusData{
+		NetworkId:       networkID,
+		TotalDifficulty: gointerfaces.ConvertUint256IntToH256(new(uint256.Int)),
+		BestHash:        gointerfaces.ConvertHashToH256(latestHash),
+		MaxBlockHeight:  0,
+		EarliestBlock:   0,
+		ForkData: &proto_sentry.Forks{
+			Genesis:     gointerfaces.ConvertHashToH256(params.MainnetGenesisHash),
+			HeightForks: heightForks,
+			TimeForks:   timeForks,
+		},
+	}
+
+	t.Run("eth69 status", func(t *testing.T) {
+		ours, theirs := p2p.MsgPipe()
+		defer ours.Close()
+		defer theirs.Close()
+
+		packet := makeStatusPacket(status, direct.ETH69)
+		require.IsType(t, &eth.StatusPacket69{}, packet)
+		go func() { _ = p2p.Send(theirs, eth.StatusMsg, packet) }()
+
+		peerStatus, err := readAndValidatePeerStatusMessage(ours, status, direct.ETH69, direct.ETH69)
+		require.Nil(t, err)
+		assert.True(t, peerStatus.hasBlockRange)
+		assert.Equal(t, latestHash, peerStatus.head)
+		assert.Equal(t, uint64(0), peerStatus.earliestBlock)
+		assert.Equal(t, uint64(0), peerStatus.latestBlock)
+	})
+	t.Run("eth68 status", func(t *testing.T) {
+		ours, theirs := p2p.MsgPipe()
+		defer ours.Close()
+		defer theirs.Close()
+
+		packet := makeStatusPacket(status, direct.ETH68)
+		require.IsType(t, &eth.StatusPacket{}, packet)
+		go func() { _ = p2p.Send(theirs, eth.StatusMsg, packet) }()
+
+		peerStatus, err := readAndValidatePeerStatusMessage(ours, status, direct.ETH68, direct.ETH68)
+		require.Nil(t, err)
+		assert.False(t, peerStatus.hasBlockRange)
+		assert.Equal(t, latestHash, peerStatus.head)
+	})
+	t.Run("eth68 status on eth69", func(t *testing.T) {
+		ours, theirs := p2p.MsgPipe()
+		defer ours.Close()
+		defer theirs.Close()
+
+		go func() { _ = p2p.Send(theirs, eth.StatusMsg, makeStatusPacket(status, direct.ETH68)) }()
+
+		_, err := readAndValidatePeerStatusMessage(ours, status, direct.ETH69, direct.ETH69)
+		require.NotNil(t, err)
+		assert.Equal(t, p2p.PeerErrorStatusDecode, err.Code)
+	})
+}
@@ -55,6 +55,7 @@ import (
 	"github.com/erigontech/erigon/common/debug"
 	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/eth/protocols/eth"
+	"github.com/erigontech/erigon/eth/protocols/snap"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/dnsdisc"
 	"github.com/erigontech/erigon/p2p/enode"
@@ -67,6 +68,8 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+	// blockRangeUpdateInterval is how far our head has to advance before eth/69 peers are sent a BlockRangeUpdate
+	blockRangeUpdateInterval = 32
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -77,6 +80,7 @@ type PeerInfo struct {
 	deadlines     []time.Time // Request deadlines
 	latestDealine time.Time
 	height        uint64
+	earliestBlock uint64 // lowest block the peer can serve, only announced by eth/69 peers
 	rw            p2p.MsgReadWriter
 	protocol      uint
 
@@ -197,6 +201,16 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 	}
 }
 
+func (pi *PeerInfo) EarliestBlock() uint64 {
+	return atomic.LoadUint64(&pi.earliestBlock)
+}
+
+// SetBlockRange records the range of blocks announced by an eth/69 peer
+func (pi *PeerInfo) SetBlockRange(earliestBlock, latestBlock uint64) {
+	atomic.StoreUint64(&pi.earliestBlock, earliestBlock)
+	pi.SetIncreasedHeight(latestBlock)
+}
+
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
@@ -302,26 +316,17 @@ func handShake(
 	rw p2p.MsgReadWriter,
 	version uint,
 	minVersion uint,
-) (*libcommon.Hash, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	// Send out own handshake in a new thread
 	errChan := make(chan *p2p.PeerError, 2)
-	resultChan := make(chan *eth.StatusPacket, 1)
+	resultChan := make(chan *peerStatus, 1)
 
-	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
 	// Convert proto status data into the one required by devp2p
-	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	ourStatus := makeStatusPacket(status, version)
 
 	go func() {
 		defer debug.LogPanic()
-		status := &eth.StatusPacket{
-			ProtocolVersion: uint32(version),
-			NetworkID:       status.NetworkId,
-			TD:              ourTD.ToBig(),
-			Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
-			Genesis:         genesisHash,
-			ForkID:          forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime),
-		}
-		err := p2p.Send(rw, eth.StatusMsg, status)
+		err := p2p.Send(rw, eth.StatusMsg, ourStatus)
 
 		if err == nil {
 			errChan <- nil
@@ -357,8 +362,7 @@ func handShake(
 		}
 	}
 
-	peerStatus := <-resultChan
-	return &peerStatus.Head, nil
+	return <-resultChan, nil
 }
 
 func runPeer(
@@ -489,6 +493,10 @@ func runPeer(
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 			//log.Info(fmt.Sprintf("[%s] ReceiptsMsg", peerID))
 		case eth.NewBlockHashesMsg:
+			if protocol >= direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorMessageObsolete, p2p.DiscSubprotocolError, nil, fmt.Sprintf("unexpected NewBlockHashesMsg from %s in eth/%d", peerID, protocol))
+			}
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
 				continue
 			}
@@ -499,6 +507,10 @@ func runPeer(
 			//log.Debug("NewBlockHashesMsg from", "peerId", fmt.Sprintf("%x", peerID)[:20], "name", peerInfo.peer.Name())
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case eth.NewBlockMsg:
+			if protocol >= direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorMessageObsolete, p2p.DiscSubprotocolError, nil, fmt.Sprintf("unexpected NewBlockMsg from %s in eth/%d", peerID, protocol))
+			}
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
 				continue
 			}
@@ -548,6 +560,28 @@ func runPeer(
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
+		case eth.BlockRangeUpdateMsg:
+			if protocol < direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, fmt.Sprintf("unexpected BlockRangeUpdateMsg from %s in eth/%d", peerID, protocol))
+			}
+			b := make([]byte, msg.Size)
+			if _, err := io.ReadFull(msg.Payload, b); err != nil {
+				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
+			}
+			var blockRange eth.BlockRangeUpdatePacket
+			if err := rlp.DecodeBytes(b, &blockRange); err != nil {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: BlockRangeUpdateMsg decode error")
+			}
+			if err := blockRange.Validate(); err != nil {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: invalid BlockRangeUpdateMsg")
+			}
+			peerInfo.SetBlockRange(blockRange.EarliestBlock, blockRange.LatestBlock)
+			if hasSubscribers(eth.ToProto[protocol][msg.Code]) {
+				send(eth.ToProto[protocol][msg.Code], peerID, b)
+			}
 		case 11:
 			// Ignore
 			// TODO: Investigate why BSC peers for eth/67 send these messages
@@ -626,15 +660,19 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		disc = dialCandidates()
 	}
 	protocols := []uint{protocol}
-	if protocol == direct.ETH67 {
+	switch protocol {
+	case direct.ETH67:
 		protocols = append(protocols, direct.ETH66)
+	case direct.ETH69:
+		// eth/69 peers are still a minority, so keep serving eth/68 from the same sentry
+		protocols = append(protocols, direct.ETH68)
 	}
 	for _, p := range protocols {
 		protocol := p
 		ss.Protocols = append(ss.Protocols, p2p.Protocol{
 			Name:           eth.ProtocolName,
 			Version:        protocol,
-			Length:         17,
+			Length:         eth.ProtocolLengths[protocol],
 			DialCandidates: disc,
 			Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 				peerID := peer.Pubkey()
@@ -656,10 +694,16 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 					return p2p.NewPeerError(p2p.PeerErrorLocalStatusNeeded, p2p.DiscProtocolError, nil, "could not get status message from core")
 				}
 
-				peerBestHash, err := handShake(ctx, status, rw, protocol, protocol)
+				peerStatus, err := handShake(ctx, status, rw, protocol, protocol)
 				if err != nil {
+					if err.Code == p2p.PeerErrorStatusDecode {
+						ss.banPeer(peer, p2p.PenaltyProtocolBreach)
+					}
 					return err
 				}
+				if peerStatus.hasBlockRange {
+					peerInfo.SetBlockRange(peerStatus.earliestBlock, peerStatus.latestBlock)
+				}
 
 				// handshake is successful
 				logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
@@ -667,7 +711,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 				ss.GoodPeers.Store(peerID, peerInfo)
 				ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
 				defer ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
-				getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
+				getBlockHeadersErr := ss.getBlockHeaders(ctx, peerStatus.head, peerID)
 				if getBlockHeadersErr != nil {
 					return p2p.NewPeerError(p2p.PeerErrorFirstMessageSend, p2p.DiscNetworkError, getBlockHeadersErr, "p2p.Protocol.Run getBlockHeaders failure")
 				}
@@ -695,6 +739,9 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 			//Attributes: []enr.Entry{eth.CurrentENREntry(chainConfig, genesisHash, headHeight)},
 		})
 	}
+	if cfg.SnapServe {
+		ss.Protocols = append(ss.Protocols, ss.snapProtocol())
+	}
 
 	return ss
 }
@@ -735,10 +782,13 @@ type GrpcServer struct {
 	p2pServerLock        sync.RWMutex
 	statusData           *proto_sentry.StatusData
 	statusDataLock       sync.RWMutex
+	forkFilter           forkid.Filter // validates the fork IDs of discovered nodes, guarded by statusDataLock
+	blockRangeAnnounced  uint64        // head height of the last BlockRangeUpdate sent to eth/69 peers, guarded by statusDataLock
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
 	messageStreamsLock   sync.RWMutex
 	peersStreams         *PeersStreams
+	snapPeers            sync.Map // peer ID -> *PeerInfo of the snap/1 connection
 	p2p                  *p2p.Config
 	logger               log.Logger
 }
@@ -821,12 +871,67 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	peerInfo := ss.getPeer(peerID)
-	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
+	if peerInfo != nil {
+		if network := peerInfo.peer.Info().Network; network.Static || network.Trusted {
+			return &emptypb.Empty{}, nil
+		}
+	}
+	if srv := ss.getP2PServer(); srv != nil {
+		srv.PenalizePeer(enode.PubkeyEncoded(peerID).ID(), p2p.PenaltyKick)
+	}
+	if ss.statusData != nil && peerInfo != nil {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
 	}
 	return &emptypb.Empty{}, nil
 }
 
+// banPeer records a penalty for a peer which misbehaved during the protocol run,
+// unless it is a static or trusted peer.
+func (ss *GrpcServer) banPeer(peer *p2p.Peer, kind p2p.PenaltyKind) {
+	if network := peer.Info().Network; network.Static || network.Trusted {
+		return
+	}
+	if srv := ss.getP2PServer(); srv != nil {
+		srv.PenalizePeer(peer.ID(), kind)
+	}
+}
+
+func (ss *GrpcServer) PeerBans(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeerBansReply, error) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	bans := p2pServer.PeerBans()
+	reply := &proto_sentry.PeerBansReply{Bans: make([]*proto_sentry.PeerBan, 0, len(bans))}
+	for _, ban := range bans {
+		reply.Bans = append(reply.Bans, &proto_sentry.PeerBan{
+			Id:          ban.ID.String(),
+			Reason:      ban.Reason,
+			Score:       ban.Score,
+			BannedUntil: uint64(ban.BannedUntil.Unix()),
+		})
+	}
+	return reply, nil
+}
+
+func (ss *GrpcServer) ClearPeerBans(_ context.Context, req *proto_sentry.ClearPeerBansRequest) (*proto_sentry.ClearPeerBansReply, error) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	ids := make([]enode.ID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		parsed, err := enode.ParseID(id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, parsed)
+	}
+	return &proto_sentry.ClearPeerBansReply{Cleared: uint64(p2pServer.ClearPeerBans(ids...))}, nil
+}
+
 func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	if peerInfo := ss.getPeer(peerID); peerInfo != nil {
@@ -878,7 +983,7 @@ func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
 	var maxPermits int
 	now := time.Now()
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
-		if peerInfo.Height() >= minBlock {
+		if peerInfo.Height() >= minBlock && peerInfo.EarliestBlock() <= minBlock {
 			deadlines := peerInfo.ClearDeadlines(now, false /* givePermit */)
 			//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 			if deadlines < maxPermitsPerPeer {
@@ -920,8 +1025,18 @@ func (ss *GrpcServer) SendMessageByMinBlock(_ context.Context, inreq *proto_sent
 }
 
 func (ss *GrpcServer) SendMessageById(_ context.Context, inreq *proto_sentry.SendMessageByIdRequest) (*proto_sentry.SentPeers, error) {
+	if msgcode, ok := snap.FromProto[inreq.Data.Id]; ok {
+		return ss.sendSnapMessage(inreq, msgcode)
+	}
 	reply := &proto_sentry.SentPeers{}
-	msgcode := eth.FromProto[ss.Protocols[0].Version][inreq.Data.Id]
+	peerID := ConvertH512ToPeerID(inreq.PeerId)
+	peerInfo := ss.getPeer(peerID)
+	// sentries serve more than one protocol version, so the message code depends on the peer
+	protocol := ss.Protocols[0].Version
+	if peerInfo != nil {
+		protocol = peerInfo.protocol
+	}
+	msgcode := eth.FromProto[protocol][inreq.Data.Id]
 	if msgcode != eth.GetBlockHeadersMsg &&
 		msgcode != eth.BlockHeadersMsg &&
 		msgcode != eth.GetBlockBodiesMsg &&
@@ -934,8 +1049,6 @@ func (ss *GrpcServer) SendMessageById(_ context.Context, inreq *proto_sentry.Sen
 		return reply, fmt.Errorf("sendMessageById not implemented for message Id: %s", inreq.Data.Id)
 	}
 
-	peerID := ConvertH512ToPeerID(inreq.PeerId)
-	peerInfo := ss.getPeer(peerID)
 	if peerInfo == nil {
 		//TODO: enable after support peer to sentry mapping
 		//return reply, fmt.Errorf("peer not found: %s", peerID)
@@ -950,7 +1063,7 @@ func (ss *GrpcServer) SendMessageById(_ context.Context, inreq *proto_sentry.Sen
 func (ss *GrpcServer) SendMessageToRandomPeers(ctx context.Context, req *proto_sentry.SendMessageToRandomPeersRequest) (*proto_sentry.SentPeers, error) {
 	reply := &proto_sentry.SentPeers{}
 
-	msgcode := eth.FromProto[ss.Protocols[0].Version][req.Data.Id]
+	msgcode := ss.broadcastMsgcode(req.Data.Id)
 	if msgcode != eth.NewBlockMsg &&
 		msgcode != eth.NewBlockHashesMsg &&
 		msgcode != eth.NewPooledTransactionHashesMsg &&
@@ -960,7 +1073,9 @@ func (ss *GrpcServer) SendMessageToRandomPeers(ctx context.Context, req *proto_s
 
 	peerInfos := make([]*PeerInfo, 0, 100)
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
-		peerInfos = append(peerInfos, peerInfo)
+		if _, ok := eth.FromProto[peerInfo.protocol][req.Data.Id]; ok {
+			peerInfos = append(peerInfos, peerInfo)
+		}
 		return true
 	})
 	rand.Shuffle(len(peerInfos), func(i int, j int) {
@@ -983,10 +1098,21 @@ func (ss *GrpcServer) SendMessageToRandomPeers(ctx context.Context, req *proto_s
 	return reply, nil
 }
 
+// broadcastMsgcode returns the code of a broadcast message in the first served protocol version that has it:
+// eth/69 dropped the block announcements, which are still sent to the eth/68 peers of the same sentry.
+func (ss *GrpcServer) broadcastMsgcode(id proto_sentry.MessageId) uint64 {
+	for _, protocol := range ss.Protocols {
+		if msgcode, ok := eth.FromProto[protocol.Version][id]; ok {
+			return msgcode
+		}
+	}
+	return 0
+}
+
 func (ss *GrpcServer) SendMessageToAll(ctx context.Context, req *proto_sentry.OutboundMessageData) (*proto_sentry.SentPeers, error) {
 	reply := &proto_sentry.SentPeers{}
 
-	msgcode := eth.FromProto[ss.Protocols[0].Version][req.Id]
+	msgcode := ss.broadcastMsgcode(req.Id)
 	if msgcode != eth.NewBlockMsg &&
 		msgcode != eth.NewPooledTransactionHashesMsg && // to broadcast new local transactions
 		msgcode != eth.NewBlockHashesMsg {
@@ -995,6 +1121,9 @@ func (ss *GrpcServer) SendMessageToAll(ctx context.Context, req *proto_sentry.Ou
 
 	var lastErr error
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if _, ok := eth.FromProto[peerInfo.protocol][req.Id]; !ok {
+			return true
+		}
 		ss.writePeer("[sentry] SendMessageToAll", peerInfo, msgcode, req.Data, 0)
 		reply.Peers = append(reply.Peers, gointerfaces.ConvertHashToH512(peerInfo.ID()))
 		return true
@@ -1011,6 +1140,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH67
 	case direct.ETH68:
 		reply.Protocol = proto_sentry.Protocol_ETH68
+	case direct.ETH69:
+		reply.Protocol = proto_sentry.Protocol_ETH69
 	}
 	return reply, nil
 }
@@ -1031,7 +1162,10 @@ func (ss *GrpcServer) startP2PServer(genesisHash libcommon.Hash) (*p2p.Server, e
 		}
 	}
 
-	srv, err := makeP2PServer(*ss.p2p, genesisHash, ss.Protocols)
+	p2pConfig := *ss.p2p
+	p2pConfig.DiscoveryFilter = eth.NewNodeFilter(ss.getForkFilter, p2pConfig.RequireEthENR)
+
+	srv, err := makeP2PServer(p2pConfig, genesisHash, ss.Protocols)
 	if err != nil {
 		return nil, err
 	}
@@ -1072,10 +1206,37 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 	if ss.statusData == nil || statusData.MaxBlockHeight != 0 {
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
+		ss.forkFilter = forkid.NewFilterFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime)
+		ss.announceBlockRange(statusData)
 	}
 	return reply, nil
 }
 
+// announceBlockRange sends a BlockRangeUpdate to eth/69 peers once our head has moved far enough
+// since the last announcement. Must be called with statusDataLock held.
+func (ss *GrpcServer) announceBlockRange(statusData *proto_sentry.StatusData) {
+	if statusData.MaxBlockHeight < ss.blockRangeAnnounced+blockRangeUpdateInterval {
+		return
+	}
+	ss.blockRangeAnnounced = statusData.MaxBlockHeight
+
+	b, err := rlp.EncodeToBytes(&eth.BlockRangeUpdatePacket{
+		EarliestBlock:   statusData.EarliestBlock,
+		LatestBlock:     statusData.MaxBlockHeight,
+		LatestBlockHash: gointerfaces.ConvertH256ToHash(statusData.BestHash),
+	})
+	if err != nil {
+		ss.logger.Error("[sentry] announceBlockRange encode error", "err", err)
+		return
+	}
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if peerInfo.protocol >= direct.ETH69 {
+			ss.writePeer("[sentry] announceBlockRange", peerInfo, eth.BlockRangeUpdateMsg, b, 0)
+		}
+		return true
+	})
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1166,6 +1327,14 @@ func (ss *GrpcServer) GetStatus() *proto_sentry.StatusData {
 	return ss.statusData
 }
 
+// getForkFilter returns the fork ID filter for the current head, or nil before the
+// first status message.
+func (ss *GrpcServer) getForkFilter() forkid.Filter {
+	ss.statusDataLock.RLock()
+	defer ss.statusDataLock.RUnlock()
+	return ss.forkFilter
+}
+
 func (ss *GrpcServer) send(msgID proto_sentry.MessageId, peerID [64]byte, b []byte) {
 	ss.messageStreamsLock.RLock()
 	defer ss.messageStreamsLock.RUnlock()
@@ -48,6 +48,7 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/eth/protocols/eth"
+	"github.com/erigontech/erigon/eth/protocols/snap"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/rlp"
 	"github.com/erigontech/erigon/turbo/jsonrpc/receipts"
@@ -89,6 +90,11 @@ func (cs *MultiClient) RecvUploadMessageLoop(
 	ids := []proto_sentry.MessageId{
 		eth.ToProto[direct.ETH66][eth.GetBlockBodiesMsg],
 		eth.ToProto[direct.ETH66][eth.GetReceiptsMsg],
+		eth.ToProto[direct.ETH69][eth.GetReceiptsMsg],
+		snap.ToProto[snap.GetAccountRangeMsg],
+		snap.ToProto[snap.GetStorageRangesMsg],
+		snap.ToProto[snap.GetByteCodesMsg],
+		snap.ToProto[snap.GetTrieNodesMsg],
 	}
 	streamFactory := func(streamCtx context.Context, sentry direct.SentryClient) (SentryMessageStream, error) {
 		return sentry.Messages(streamCtx, &proto_sentry.MessagesRequest{Ids: ids}, grpc.WaitForReady(true))
@@ -296,6 +302,9 @@ type MultiClient struct {
 	logger                           log.Logger
 	getReceiptsActiveGoroutineNumber *semaphore.Weighted
 	ethApiWrapper                    eth.ReceiptsGetter
+	snapActiveGoroutineNumber        *semaphore.Weighted
+	snapCodes                        *snap.CodeIndex
+	snapRoots                        *snap.StateRoots
 }
 
 var _ eth.ReceiptsGetter = new(receipts.Generator) // compile-time interface-check
@@ -364,6 +373,9 @@ func NewMultiClient(
 		logger:                            logger,
 		getReceiptsActiveGoroutineNumber:  semaphore.NewWeighted(1),
 		ethApiWrapper:                     receipts.NewGenerator(32, blockReader, engine),
+		snapActiveGoroutineNumber:         semaphore.NewWeighted(1),
+		snapCodes:                         snap.NewCodeIndex(),
+		snapRoots:                         snap.NewStateRoots(),
 	}
 
 	return cs, nil
@@ -709,6 +721,17 @@ var (
 )
 
 func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	return cs.getReceipts(ctx, inreq, sentryClient, eth.AnswerGetReceiptsQuery, proto_sentry.MessageId_RECEIPTS_66)
+}
+
+// getReceipts69 answers eth/69 peers, which expect receipts without bloom filters
+func (cs *MultiClient) getReceipts69(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	return cs.getReceipts(ctx, inreq, sentryClient, eth.AnswerGetReceiptsQuery69, proto_sentry.MessageId_RECEIPTS_69)
+}
+
+type answerGetReceiptsQuery func(ctx context.Context, cfg *chain.Config, receiptsGetter eth.ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query eth.GetReceiptsPacket) ([]rlp.RawValue, error)
+
+func (cs *MultiClient) getReceipts(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient, answer answerGetReceiptsQuery, replyId proto_sentry.MessageId) error {
 	if !EnableP2PReceipts {
 		return nil
 	}
@@ -720,7 +743,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	defer cs.getReceiptsActiveGoroutineNumber.Release(1)
 	var query eth.GetReceiptsPacket66
 	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
-		return fmt.Errorf("decoding getReceipts66: %w, data: %x", err, inreq.Data)
+		return fmt.Errorf("decoding %s: %w, data: %x", inreq.Id, err, inreq.Data)
 	}
 
 	tx, err := cs.db.BeginRo(ctx)
@@ -729,7 +752,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	}
 	defer tx.Rollback()
 
-	receiptsList, err := eth.AnswerGetReceiptsQuery(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket)
+	receiptsList, err := answer(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket)
 	if err != nil {
 		return err
 	}
@@ -743,7 +766,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	outreq := proto_sentry.SendMessageByIdRequest{
 		PeerId: inreq.PeerId,
 		Data: &proto_sentry.OutboundMessageData{
-			Id:   proto_sentry.MessageId_RECEIPTS_66,
+			Id:   replyId,
 			Data: b,
 		},
 	}
@@ -804,6 +827,24 @@ func (cs *MultiClient) handleInboundMessage(ctx context.Context, inreq *proto_se
 		return cs.receipts66(ctx, inreq, sentry)
 	case proto_sentry.MessageId_GET_RECEIPTS_66:
 		return cs.getReceipts66(ctx, inreq, sentry)
+
+	// ========= eth 69 ==========
+
+	case proto_sentry.MessageId_RECEIPTS_69:
+		return cs.receipts66(ctx, inreq, sentry)
+	case proto_sentry.MessageId_GET_RECEIPTS_69:
+		return cs.getReceipts69(ctx, inreq, sentry)
+
+	// ========= snap 1 ==========
+
+	case proto_sentry.MessageId_GET_ACCOUNT_RANGE_SNAP1:
+		return cs.getAccountRangeSnap1(ctx, inreq, sentry)
+	case proto_sentry.MessageId_GET_STORAGE_RANGES_SNAP1:
+		return cs.getStorageRangesSnap1(ctx, inreq, sentry)
+	case proto_sentry.MessageId_GET_BYTE_CODES_SNAP1:
+		return cs.getByteCodesSnap1(ctx, inreq, sentry)
+	case proto_sentry.MessageId_GET_TRIE_NODES_SNAP1:
+		return cs.getTrieNodesSnap1(ctx, inreq, sentry)
 	default:
 		return fmt.Errorf("not implemented for message Id: %s", inreq.Id)
 	}
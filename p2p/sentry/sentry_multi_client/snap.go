@@ -0,0 +1,229 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentry_multi_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/eth/protocols/snap"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/rlp"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// snapQuery answers snap/1 request from the committed state. Ranges and proofs come from the
+// commitment trie, read through the domains of a fresh read-only transaction.
+func (cs *MultiClient) snapQuery(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient, replyId proto_sentry.MessageId, answer func(tx kv.Tx, sd *state.SharedDomains) (any, error)) error {
+	if err := cs.snapActiveGoroutineNumber.Acquire(ctx, 1); err != nil {
+		return err
+	}
+	defer cs.snapActiveGoroutineNumber.Release(1)
+
+	tx, err := cs.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	sd, err := state.NewSharedDomains(tx, cs.logger)
+	if err != nil {
+		return err
+	}
+	defer sd.Close()
+
+	response, err := answer(tx, sd)
+	if err != nil {
+		return err
+	}
+	b, err := rlp.EncodeToBytes(response)
+	if err != nil {
+		return fmt.Errorf("encode %s response: %w", replyId, err)
+	}
+	outreq := proto_sentry.SendMessageByIdRequest{
+		PeerId: inreq.PeerId,
+		Data: &proto_sentry.OutboundMessageData{
+			Id:   replyId,
+			Data: b,
+		},
+	}
+	if _, err = sentryClient.SendMessageById(ctx, &outreq, &grpc.OnFinishCallOption{}); err != nil {
+		if isPeerNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("send %s response: %w", replyId, err)
+	}
+	return nil
+}
+
+// SnapStateLoop keeps the recent state roots and the code index of snap/1 serving up to date. The
+// code index is built from the code domain first, then both follow the new heads.
+func (cs *MultiClient) SnapStateLoop(ctx context.Context, headCh <-chan [][]byte) {
+	if err := cs.buildSnapCodes(ctx); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			cs.logger.Warn("[snap] failed to index bytecodes", "err", err)
+		}
+		return
+	}
+	for {
+		if err := cs.updateSnapState(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			cs.logger.Warn("[snap] failed to update recent state roots", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-headCh:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (cs *MultiClient) buildSnapCodes(ctx context.Context) error {
+	tx, err := cs.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	codes, err := tx.(state.HasAggTx).AggTx().(*state.AggregatorRoTx).DomainRangeLatest(tx, kv.CodeDomain, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	return cs.snapCodes.Build(ctx, codes)
+}
+
+// updateSnapState moves the recent state roots to the executed head, and indexes the code written
+// by the blocks new to them.
+func (cs *MultiClient) updateSnapState(ctx context.Context) error {
+	tx, err := cs.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	added, err := cs.snapRoots.Update(head, func(blockNum uint64) (*types.Header, error) {
+		return cs.blockReader.HeaderByNumber(ctx, tx, blockNum)
+	})
+	if err != nil {
+		return err
+	}
+	for _, header := range added {
+		changeset, ok, err := state.ReadDiffSet(tx, header.Number.Uint64(), header.Hash())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		for _, diff := range changeset[kv.CodeDomain] {
+			addr := diff.Key[:len(diff.Key)-8]
+			code, _, err := tx.(kv.TemporalTx).DomainGet(kv.CodeDomain, addr, nil)
+			if err != nil {
+				return err
+			}
+			if len(code) > 0 {
+				cs.snapCodes.Add(crypto.Keccak256Hash(code), libcommon.BytesToAddress(addr))
+			}
+		}
+	}
+	return nil
+}
+
+// snapStateTrie returns the commitment trie at the root requested by the peer, if it's the state
+// root of one of the recent blocks.
+func (cs *MultiClient) snapStateTrie(ctx context.Context, tx kv.Tx, sd *state.SharedDomains, root libcommon.Hash) (*commitment.HexPatriciaHashed, error) {
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, cs.blockReader))
+	maxTxNum := func(blockNum uint64) (uint64, error) {
+		return txNumsReader.Max(tx, blockNum)
+	}
+	return snap.StateTrieAt(tx, sd, root, cs.snapRoots, maxTxNum)
+}
+
+func (cs *MultiClient) getAccountRangeSnap1(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	var query snap.GetAccountRangePacket
+	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
+		return fmt.Errorf("decoding %s: %w, data: %x", inreq.Id, err, inreq.Data)
+	}
+	return cs.snapQuery(ctx, inreq, sentryClient, proto_sentry.MessageId_ACCOUNT_RANGE_SNAP1, func(tx kv.Tx, sd *state.SharedDomains) (any, error) {
+		trie, err := cs.snapStateTrie(ctx, tx, sd, query.Root)
+		if err != nil {
+			return nil, err
+		}
+		return snap.AnswerGetAccountRangeQuery(trie, &query)
+	})
+}
+
+func (cs *MultiClient) getStorageRangesSnap1(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	var query snap.GetStorageRangesPacket
+	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
+		return fmt.Errorf("decoding %s: %w, data: %x", inreq.Id, err, inreq.Data)
+	}
+	return cs.snapQuery(ctx, inreq, sentryClient, proto_sentry.MessageId_STORAGE_RANGES_SNAP1, func(tx kv.Tx, sd *state.SharedDomains) (any, error) {
+		trie, err := cs.snapStateTrie(ctx, tx, sd, query.Root)
+		if err != nil {
+			return nil, err
+		}
+		return snap.AnswerGetStorageRangesQuery(trie, &query)
+	})
+}
+
+func (cs *MultiClient) getByteCodesSnap1(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	var query snap.GetByteCodesPacket
+	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
+		return fmt.Errorf("decoding %s: %w, data: %x", inreq.Id, err, inreq.Data)
+	}
+	return cs.snapQuery(ctx, inreq, sentryClient, proto_sentry.MessageId_BYTE_CODES_SNAP1, func(_ kv.Tx, sd *state.SharedDomains) (any, error) {
+		readCode := func(addr libcommon.Address) ([]byte, error) {
+			code, _, err := sd.DomainGet(kv.CodeDomain, addr[:], nil)
+			return code, err
+		}
+		return snap.AnswerGetByteCodesQuery(cs.snapCodes, readCode, &query)
+	})
+}
+
+func (cs *MultiClient) getTrieNodesSnap1(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient direct.SentryClient) error {
+	var query snap.GetTrieNodesPacket
+	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
+		return fmt.Errorf("decoding %s: %w, data: %x", inreq.Id, err, inreq.Data)
+	}
+	return cs.snapQuery(ctx, inreq, sentryClient, proto_sentry.MessageId_TRIE_NODES_SNAP1, func(tx kv.Tx, sd *state.SharedDomains) (any, error) {
+		trie, err := cs.snapStateTrie(ctx, tx, sd, query.Root)
+		if err != nil {
+			return nil, err
+		}
+		return snap.AnswerGetTrieNodesQuery(trie, &query)
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentry
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	proto_types "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+
+	"github.com/erigontech/erigon/eth/protocols/snap"
+	"github.com/erigontech/erigon/p2p"
+)
+
+// snapProtocol is the snap/1 satellite protocol. Sentry only serves it: requests of peers which
+// passed the eth handshake are forwarded to subscribers, which reply via SendMessageById. Other
+// requests are answered with empty responses.
+func (ss *GrpcServer) snapProtocol() p2p.Protocol {
+	return p2p.Protocol{
+		Name:    snap.ProtocolName,
+		Version: snap.SNAP1,
+		Length:  snap.ProtocolLength,
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+			return ss.runSnapPeer(peer, rw)
+		},
+		NodeInfo: func() interface{} {
+			return nil
+		},
+		PeerInfo: func(peerID [64]byte) interface{} {
+			return nil
+		},
+	}
+}
+
+func (ss *GrpcServer) getSnapPeer(peerID [64]byte) *PeerInfo {
+	if value, ok := ss.snapPeers.Load(peerID); ok {
+		return value.(*PeerInfo)
+	}
+	return nil
+}
+
+func (ss *GrpcServer) runSnapPeer(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+	peerID := peer.Pubkey()
+	peerInfo := NewPeerInfo(peer, rw)
+	peerInfo.protocol = snap.SNAP1
+	defer peerInfo.Close()
+	if _, loaded := ss.snapPeers.LoadOrStore(peerID, peerInfo); loaded {
+		return p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscAlreadyConnected, nil, "peer already has snap connection")
+	}
+	defer ss.snapPeers.Delete(peerID)
+
+	cap := p2p.Cap{Name: snap.ProtocolName, Version: snap.SNAP1}
+	for {
+		if err := libcommon.Stopped(ss.ctx.Done()); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscQuitting, ss.ctx.Err(), "sentry.runSnapPeer: context stopped")
+		}
+		if err := peerInfo.RemoveReason(); err != nil {
+			return err
+		}
+
+		msg, err := rw.ReadMsg()
+		if err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorMessageReceive, p2p.DiscNetworkError, err, "sentry.runSnapPeer: ReadMsg error")
+		}
+		if msg.Size > snap.ProtocolMaxMsgSize {
+			msg.Discard()
+			return p2p.NewPeerError(p2p.PeerErrorMessageSizeLimit, p2p.DiscSubprotocolError, nil, fmt.Sprintf("sentry.runSnapPeer: message is too large %d, limit %d", msg.Size, snap.ProtocolMaxMsgSize))
+		}
+
+		switch msg.Code {
+		case snap.GetAccountRangeMsg, snap.GetStorageRangesMsg, snap.GetByteCodesMsg, snap.GetTrieNodesMsg:
+			b := make([]byte, msg.Size)
+			if _, err := io.ReadFull(msg.Payload, b); err != nil {
+				ss.logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
+			}
+			// requests are served only to peers which passed the eth handshake, the others get empty responses
+			if ss.getPeer(peerID) == nil || !ss.hasSubscribers(snap.ToProto[msg.Code]) {
+				code, resp, err := snap.EmptyResponse(msg.Code, b)
+				if err != nil {
+					msg.Discard()
+					return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runSnapPeer: request decode error")
+				}
+				ss.writeSnapPeer(peerInfo, code, resp)
+				break
+			}
+			ss.send(snap.ToProto[msg.Code], peerID, b)
+		case snap.AccountRangeMsg, snap.StorageRangesMsg, snap.ByteCodesMsg, snap.TrieNodesMsg:
+			// this node never sends snap requests
+			msg.Discard()
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, fmt.Sprintf("sentry.runSnapPeer: unsolicited snap response %d", msg.Code))
+		default:
+			msg.Discard()
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, fmt.Sprintf("sentry.runSnapPeer: unknown message code %d", msg.Code))
+		}
+
+		trackPeerStatistics(peer.Info().ID, true, snap.ToProto[msg.Code].String(), cap.String(), int(msg.Size))
+		msg.Discard()
+	}
+}
+
+// sendSnapMessage writes snap/1 response to the snap connection of the peer
+func (ss *GrpcServer) sendSnapMessage(inreq *proto_sentry.SendMessageByIdRequest, msgcode uint64) (*proto_sentry.SentPeers, error) {
+	reply := &proto_sentry.SentPeers{}
+	if msgcode != snap.AccountRangeMsg &&
+		msgcode != snap.StorageRangesMsg &&
+		msgcode != snap.ByteCodesMsg &&
+		msgcode != snap.TrieNodesMsg {
+		return reply, fmt.Errorf("sendMessageById not implemented for message Id: %s", inreq.Data.Id)
+	}
+
+	peerInfo := ss.getSnapPeer(ConvertH512ToPeerID(inreq.PeerId))
+	if peerInfo == nil {
+		return reply, nil
+	}
+
+	ss.writeSnapPeer(peerInfo, msgcode, inreq.Data.Data)
+	reply.Peers = []*proto_types.H512{inreq.PeerId}
+	return reply, nil
+}
+
+func (ss *GrpcServer) writeSnapPeer(peerInfo *PeerInfo, msgcode uint64, data []byte) {
+	peerInfo.Async(func() {
+		trackPeerStatistics(peerInfo.peer.Info().ID, false, snap.ToProto[msgcode].String(), p2p.Cap{Name: snap.ProtocolName, Version: snap.SNAP1}.String(), len(data))
+		err := peerInfo.rw.WriteMsg(p2p.Msg{Code: msgcode, Size: uint32(len(data)), Payload: bytes.NewReader(data)})
+		if err != nil {
+			peerInfo.Remove(p2p.NewPeerError(p2p.PeerErrorMessageSend, p2p.DiscNetworkError, err, fmt.Sprintf("[sentry] writeSnapPeer msgcode=%d", msgcode)))
+		}
+	}, ss.logger)
+}
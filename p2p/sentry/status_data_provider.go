@@ -34,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/ethdb/prune"
 )
 
 var ErrNoHead = errors.New("ReadChainHead: ReadCurrentHeader error")
@@ -103,13 +104,14 @@ func makeGenesisChainHead(genesis *types.Block) ChainHead {
 	}
 }
 
-func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.StatusData {
+func (s *StatusDataProvider) makeStatusData(head ChainHead, earliestBlock uint64) *proto_sentry.StatusData {
 	return &proto_sentry.StatusData{
 		NetworkId:       s.networkId,
 		TotalDifficulty: gointerfaces.ConvertUint256IntToH256(head.HeadTd),
 		BestHash:        gointerfaces.ConvertHashToH256(head.HeadHash),
 		MaxBlockHeight:  head.HeadHeight,
 		MaxBlockTime:    head.HeadTime,
+		EarliestBlock:   earliestBlock,
 		ForkData: &proto_sentry.Forks{
 			Genesis:     gointerfaces.ConvertHashToH256(s.genesisHash),
 			HeightForks: s.heightForks,
@@ -119,15 +121,41 @@ func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.Status
 }
 
 func (s *StatusDataProvider) GetStatusData(ctx context.Context) (*proto_sentry.StatusData, error) {
-	chainHead, err := ReadChainHead(ctx, s.db)
+	var chainHead ChainHead
+	var earliestBlock uint64
+	err := s.db.View(ctx, func(tx kv.Tx) (err error) {
+		if chainHead, err = ReadChainHeadWithTx(tx); err != nil {
+			return err
+		}
+		earliestBlock, err = ReadEarliestBlockWithTx(tx, chainHead.HeadHeight)
+		return err
+	})
 	if err != nil {
 		if errors.Is(err, ErrNoHead) {
 			s.logger.Warn("sentry.StatusDataProvider: The canonical chain current header not found in the database. Check the database consistency. Using genesis as a fallback.")
-			return s.makeStatusData(s.genesisHead), nil
+			return s.makeStatusData(s.genesisHead, s.genesisHead.HeadHeight), nil
 		}
 		return nil, err
 	}
-	return s.makeStatusData(chainHead), err
+	return s.makeStatusData(chainHead, earliestBlock), nil
+}
+
+// ReadEarliestBlockWithTx returns the lowest block whose body and receipts are still served with the given head,
+// it is announced to eth/69 peers. Blocks below the prune distance of bodies or history are deleted or about to be.
+func ReadEarliestBlockWithTx(tx kv.Tx, headHeight uint64) (uint64, error) {
+	pruneMode, err := prune.Get(tx)
+	if err != nil {
+		return 0, fmt.Errorf("ReadEarliestBlock: prune mode: %w", err)
+	}
+	var earliest uint64
+	if pruneMode.Blocks.Enabled() {
+		earliest = pruneMode.Blocks.PruneTo(headHeight)
+	}
+	// receipts are re-executed from the state history
+	if pruneMode.History.Enabled() {
+		earliest = max(earliest, pruneMode.History.PruneTo(headHeight))
+	}
+	return earliest, nil
 }
 
 func ReadChainHeadWithTx(tx kv.Tx) (ChainHead, error) {
@@ -0,0 +1,56 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/kv/memdb"
+
+	"github.com/erigontech/erigon/ethdb/prune"
+)
+
+func TestReadEarliestBlock(t *testing.T) {
+	tests := []struct {
+		name          string
+		history       uint64
+		blocks        uint64
+		head          uint64
+		earliestBlock uint64
+	}{
+		{name: "archive", history: math.MaxUint64, blocks: math.MaxUint64, head: 1_000_000, earliestBlock: 0},
+		{name: "pruned history", history: 90_000, blocks: math.MaxUint64, head: 1_000_000, earliestBlock: 910_000},
+		{name: "pruned blocks", history: math.MaxUint64, blocks: 100_000, head: 1_000_000, earliestBlock: 900_000},
+		{name: "history pruned more than blocks", history: 10_000, blocks: 100_000, head: 1_000_000, earliestBlock: 990_000},
+		{name: "head below the prune distance", history: 90_000, blocks: 90_000, head: 50_000, earliestBlock: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tx := memdb.NewTestTx(t)
+			mode, err := prune.FromCli(1, tt.history, tt.blocks, nil)
+			require.NoError(t, err)
+			require.NoError(t, prune.Override(tx, mode))
+
+			earliestBlock, err := ReadEarliestBlockWithTx(tx, tt.head)
+			require.NoError(t, err)
+			require.Equal(t, tt.earliestBlock, earliestBlock)
+		})
+	}
+}
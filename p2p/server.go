@@ -103,6 +103,18 @@ type Config struct {
 	// protocol should be started or not.
 	DiscoveryV5 bool `toml:",omitempty"`
 
+	// DiscoveryFilter, if set, is applied to the nodes found by the discovery
+	// protocols before they are handed to the dialer.
+	DiscoveryFilter func(*enode.Node) bool `toml:"-"`
+
+	// RequireEthENR makes the discovery filter drop nodes which don't advertise
+	// an `eth` ENR entry.
+	RequireEthENR bool `toml:",omitempty"`
+
+	// SnapServe enables serving of the snap/1 protocol next to eth, so that
+	// peers can snap sync from the recent states of this node.
+	SnapServe bool `toml:",omitempty"`
+
 	// Name sets the node name of this server.
 	// Use common.MakeName to create a name that follows existing conventions.
 	Name string `toml:"-"`
@@ -215,11 +227,13 @@ type Server struct {
 	logger       log.Logger
 
 	nodedb             *enode.DB
+	reputation         *reputation
 	localnode          *enode.LocalNode
 	localnodeAddrCache atomic.Pointer[string]
 	ntab               *discover.UDPv4
 	DiscV5             *discover.UDPv5
 	discmix            *enode.FairMix
+	discoveryFiltered  atomic.Uint64 // discovered nodes dropped by DiscoveryFilter
 	dialsched          *dialScheduler
 
 	// Channels into the run loop.
@@ -557,6 +571,7 @@ func (srv *Server) setupLocalNode() error {
 		return err
 	}
 	srv.nodedb = db
+	srv.reputation = newReputation(db, srv.logger, time.Now())
 
 	srv.localnode = enode.NewLocalNode(db, srv.PrivateKey, srv.logger)
 	srv.localnode.SetFallbackIP(net.IP{127, 0, 0, 1})
@@ -604,7 +619,7 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	added := make(map[string]bool)
 	for _, proto := range srv.Protocols {
 		if proto.DialCandidates != nil && !added[proto.Name] {
-			srv.discmix.AddSource(proto.DialCandidates)
+			srv.discmix.AddSource(srv.filterDiscovered(proto.DialCandidates, nil))
 			added[proto.Name] = true
 		}
 	}
@@ -657,7 +672,8 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 			return err
 		}
 		srv.ntab = ntab
-		srv.discmix.AddSource(ntab.RandomNodes())
+		// the nodes of the Neighbors packets come without their ENR entries
+		srv.discmix.AddSource(srv.filterDiscovered(ntab.RandomNodes(), ntab.RequestENR))
 	}
 
 	// Discovery V5
@@ -678,10 +694,30 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		srv.discmix.AddSource(srv.filterDiscovered(srv.DiscV5.RandomNodes(), nil))
 	}
 	return nil
 }
 
+// filterDiscovered applies DiscoveryFilter to the nodes of a discovery source,
+// counting the dropped ones for the server stats. If the source yields nodes
+// without their full record, requestENR fetches it before they are judged.
+func (srv *Server) filterDiscovered(it enode.Iterator, requestENR func(*enode.Node) (*enode.Node, error)) enode.Iterator {
+	if srv.DiscoveryFilter == nil {
+		return it
+	}
+	if requestENR != nil {
+		it = enode.Resolve(it, requestENR)
+	}
+	return enode.Filter(it, func(n *enode.Node) bool {
+		if srv.DiscoveryFilter(n) {
+			return true
+		}
+		srv.discoveryFiltered.Add(1)
+		return false
+	})
+}
+
 func (srv *Server) setupDialScheduler() {
 	config := dialConfig{
 		self:           srv.localnode.ID(),
@@ -691,6 +727,7 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		banned:         srv.isBanned,
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
@@ -859,6 +896,9 @@ running:
 			}
 		case <-logTimer.C:
 			vals := []interface{}{"protocol", srv.Config.Protocols[0].Version, "peers", len(peers), "trusted", len(trusted), "inbound", inboundCount}
+			if srv.DiscoveryFilter != nil {
+				vals = append(vals, "filteredCandidates", srv.discoveryFiltered.Load())
+			}
 			vals = append(vals, srv.listErrors()...)
 
 			srv.logger.Debug("[p2p] Server", vals...)
@@ -900,11 +940,47 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscSelf
 	case (len(srv.Protocols) > 0) && (countMatchingProtocols(srv.Protocols, c.caps) == 0):
 		return DiscUselessPeer
+	case !c.is(trustedConn) && !c.is(staticDialedConn) && srv.isBanned(c.node.ID()):
+		return DiscUselessPeer
 	default:
 		return nil
 	}
 }
 
+// PenalizePeer records a penalty of the given kind for the node, which bans it for
+// a duration depending on the kind and on its previous penalties. The ban survives
+// restarts. It returns the end of the ban. The caller is responsible for
+// disconnecting the peer.
+func (srv *Server) PenalizePeer(id enode.ID, kind PenaltyKind) time.Time {
+	if srv.reputation == nil {
+		return time.Time{}
+	}
+	until := srv.reputation.penalize(id, kind, time.Now())
+	srv.logger.Debug("[p2p] Banned peer", "id", id, "reason", kind, "until", until)
+	return until
+}
+
+// PeerBans returns the currently banned nodes.
+func (srv *Server) PeerBans() []PeerBan {
+	if srv.reputation == nil {
+		return nil
+	}
+	return srv.reputation.bans(time.Now())
+}
+
+// ClearPeerBans lifts the bans of the given nodes, or of all nodes if none are
+// given, and returns the number of lifted bans.
+func (srv *Server) ClearPeerBans(ids ...enode.ID) int {
+	if srv.reputation == nil {
+		return 0
+	}
+	return srv.reputation.clear(ids, time.Now())
+}
+
+func (srv *Server) isBanned(id enode.ID) bool {
+	return srv.reputation != nil && srv.reputation.isBanned(id, time.Now())
+}
+
 // listenLoop runs in its own goroutine and accepts
 // inbound connections.
 func (srv *Server) listenLoop(ctx context.Context) {
@@ -656,3 +656,50 @@ func syncAddPeer(srv *Server, node *enode.Node) bool {
 		}
 	}
 }
+
+func TestServerFilterDiscovered(t *testing.T) {
+	node := func(key *ecdsa.PrivateKey, fork uint64) *enode.Node {
+		var r enr.Record
+		if fork != 0 {
+			r.Set(enr.WithEntry("fork", fork))
+		}
+		if err := enode.SignV4(&r, key); err != nil {
+			t.Fatal(err)
+		}
+		n, err := enode.New(enode.ValidSchemes, &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	ourKey, otherKey := newkey(), newkey()
+	ours, other := node(ourKey, 0), node(otherKey, 0)
+	records := map[enode.ID]*enode.Node{ours.ID(): node(ourKey, 1), other.ID(): node(otherKey, 2)}
+	requestENR := func(n *enode.Node) (*enode.Node, error) { return records[n.ID()], nil }
+
+	srv := &Server{Config: Config{DiscoveryFilter: func(n *enode.Node) bool {
+		var fork uint64
+		return n.Load(enr.WithEntry("fork", &fork)) == nil && fork == 1
+	}}}
+	discovered := func(it enode.Iterator) (nodes []*enode.Node) {
+		defer it.Close()
+		for it.Next() {
+			nodes = append(nodes, it.Node())
+		}
+		return nodes
+	}
+
+	// the bare nodes of the discovery v4 are judged on their requested record
+	nodes := discovered(srv.filterDiscovered(enode.IterNodes([]*enode.Node{ours, other}), requestENR))
+	if len(nodes) != 1 || nodes[0] != records[ours.ID()] {
+		t.Fatalf("wrong nodes %v", nodes)
+	}
+	if srv.discoveryFiltered.Load() != 1 {
+		t.Fatalf("wrong filtered count %d", srv.discoveryFiltered.Load())
+	}
+	// the other sources carry the full records
+	nodes = discovered(srv.filterDiscovered(enode.IterNodes([]*enode.Node{records[other.ID()], records[ours.ID()]}), nil))
+	if len(nodes) != 1 || nodes[0] != records[ours.ID()] {
+		t.Fatalf("wrong nodes %v", nodes)
+	}
+}
@@ -37,4 +37,5 @@ type MiningConfig struct {
 	GasLimit   uint64            // Target gas limit for mined blocks.
 	GasPrice   *big.Int          // Minimum gas price for mining a transaction
 	Recommit   time.Duration     // The time interval for miner to re-create mining work.
+	TxOrdering string            // The policy ordering the txpool transactions in the PoS payloads, "best" if empty
 }
@@ -24,8 +24,14 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 )
 
+// GetFinalizedBlockNumber returns the end block of the latest whitelisted milestone,
+// or of the latest whitelisted checkpoint if there is no milestone, as long as it is
+// on the canonical chain. It returns 0 otherwise.
 func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 	currentBlockNum := rawdb.ReadCurrentHeader(tx)
+	if currentBlockNum == nil {
+		return 0
+	}
 
 	service := whitelist.GetWhitelistingService()
 
@@ -60,6 +66,29 @@ func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 	return 0
 }
 
+// GetSafeBlockNumber returns the end block of the latest whitelisted checkpoint if it
+// is on the canonical chain, or 0 otherwise.
+func GetSafeBlockNumber(tx kv.Tx) uint64 {
+	currentBlockNum := rawdb.ReadCurrentHeader(tx)
+	if currentBlockNum == nil {
+		return 0
+	}
+
+	service := whitelist.GetWhitelistingService()
+
+	doExist, number, hash := service.GetWhitelistedCheckpoint()
+	if !doExist || number > currentBlockNum.Number.Uint64() {
+		return 0
+	}
+
+	blockHeader := rawdb.ReadHeaderByNumber(tx, number)
+	if blockHeader == nil || blockHeader.Hash() != hash {
+		return 0
+	}
+
+	return number
+}
+
 // CurrentFinalizedBlock retrieves the current finalized block of the canonical
 // chain. The block is retrieved from the blockchain's internal cache.
 func CurrentFinalizedBlock(tx kv.Tx, number uint64) *types.Block {
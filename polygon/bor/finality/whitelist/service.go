@@ -45,6 +45,12 @@ func GetWhitelistingService() *Service {
 	return ws
 }
 
+// UnregisterService drops the registered service, finality is no longer resolved
+// from the whitelist afterwards.
+func UnregisterService() {
+	ws = nil
+}
+
 func NewService(db kv.RwDB) *Service {
 	var checkpointDoExist = true
 	checkpointNumber, checkpointHash, err := rawdb.ReadFinality[*rawdb.Checkpoint](db)
@@ -338,6 +338,18 @@ func (b *Bridge) EventTxnLookup(ctx context.Context, borTxHash libcommon.Hash) (
 	return b.reader.EventTxnLookup(ctx, borTxHash)
 }
 
+func (b *Bridge) Event(ctx context.Context, eventID uint64) ([]byte, bool, error) {
+	return b.reader.Event(ctx, eventID)
+}
+
+func (b *Bridge) EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error) {
+	return b.reader.EventBlockNum(ctx, eventID)
+}
+
+func (b *Bridge) LastProcessedBlockInfo(ctx context.Context) (ProcessedBlockInfo, bool, error) {
+	return b.reader.LastProcessedBlockInfo(ctx)
+}
+
 func (b *Bridge) blockEventsTimeWindowEnd(last ProcessedBlockInfo, blockNum uint64, blockTime uint64) (uint64, error) {
 	if b.borConfig.IsIndore(blockNum) {
 		stateSyncDelay := b.borConfig.CalculateStateSyncDelay(blockNum)
@@ -185,6 +185,27 @@ func TestBridge(t *testing.T) {
 	require.Equal(t, len(res), 0)
 	require.NoError(t, err)
 
+	// look up events by id
+	for eventID, blockNum := range map[uint64]uint64{1: 4, 2: 4, 3: 6, 4: 10} {
+		eventBlockNum, ok, err := b.EventBlockNum(ctx, eventID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, blockNum, eventBlockNum)
+	}
+	_, ok, err := b.EventBlockNum(ctx, 5)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	event, ok, err := b.Event(ctx, 3)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, event3Data, event)
+
+	info, ok, err := b.LastProcessedBlockInfo(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(10), info.BlockNum)
+
 	cancel()
 	wg.Wait()
 }
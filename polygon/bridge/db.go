@@ -26,6 +26,7 @@ import (
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/polygon/polygoncommon"
 )
@@ -65,6 +66,7 @@ type Store interface {
 	Events(ctx context.Context, start, end uint64) ([][]byte, error)
 	PutBlockNumToEventID(ctx context.Context, blockNumToEventId map[uint64]uint64) error
 	BlockEventIDsRange(ctx context.Context, blockNum uint64) (start uint64, end uint64, err error) // [start,end)
+	EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error)
 	LastProcessedBlockInfo(ctx context.Context) (ProcessedBlockInfo, bool, error)
 	PutProcessedBlockInfo(ctx context.Context, info ProcessedBlockInfo) error
 	LastFrozenEventBlockNum() uint64
@@ -79,6 +81,10 @@ func NewStore(db *polygoncommon.Database) *MdbxStore {
 	return &MdbxStore{db: db}
 }
 
+func NewMdbxStore(logger log.Logger, dataDir string) *MdbxStore {
+	return NewStore(polygoncommon.NewDatabase(dataDir, kv.PolygonBridgeDB, databaseTablesCfg, logger))
+}
+
 func (s *MdbxStore) Prepare(ctx context.Context) error {
 	err := s.db.OpenOnce(ctx)
 	if err != nil {
@@ -442,6 +448,65 @@ func (s *MdbxStore) BlockEventIDsRange(ctx context.Context, blockNum uint64) (ui
 	return start, end, nil
 }
 
+// EventBlockNum returns the number of the block in which the event with the given ID was committed.
+func (s *MdbxStore) EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	return EventBlockNum(tx, eventID)
+}
+
+// EventBlockNum binary searches BorEventNums for the first block whose last event ID is
+// not lower than eventID. The table has the same layout in the bridge DB and in the
+// chain DB used by the bor heimdall stage.
+func EventBlockNum(tx kv.Tx, eventID uint64) (uint64, bool, error) {
+	cursor, err := tx.Cursor(kv.BorEventNums)
+	if err != nil {
+		return 0, false, err
+	}
+	defer cursor.Close()
+
+	k, _, err := cursor.First()
+	if err != nil || k == nil {
+		return 0, false, err
+	}
+	lo := binary.BigEndian.Uint64(k)
+
+	k, v, err := cursor.Last()
+	if err != nil {
+		return 0, false, err
+	}
+	if binary.BigEndian.Uint64(v) < eventID {
+		return 0, false, nil
+	}
+	hi := binary.BigEndian.Uint64(k)
+
+	// f(n) = last event ID of the first block >= n is non-decreasing in n
+	kByte := make([]byte, 8)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		binary.BigEndian.PutUint64(kByte, mid)
+		if _, v, err = cursor.Seek(kByte); err != nil {
+			return 0, false, err
+		}
+		if binary.BigEndian.Uint64(v) >= eventID {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	binary.BigEndian.PutUint64(kByte, lo)
+	if k, _, err = cursor.Seek(kByte); err != nil {
+		return 0, false, err
+	}
+
+	return binary.BigEndian.Uint64(k), true, nil
+}
+
 func (s *MdbxStore) PruneEventIDs(ctx context.Context, blockNum uint64) error {
 	//
 	// TODO rename func to Unwind, unwind BorEventProcessedBlocks, BorTxnLookup - in separate PR
@@ -88,6 +88,25 @@ func (r *Reader) EventTxnLookup(ctx context.Context, borTxHash libcommon.Hash) (
 	return r.store.EventTxnToBlockNum(ctx, borTxHash)
 }
 
+// Event returns the raw state sync event with the given ID
+func (r *Reader) Event(ctx context.Context, eventID uint64) ([]byte, bool, error) {
+	events, err := r.store.Events(ctx, eventID, eventID+1)
+	if err != nil || len(events) == 0 {
+		return nil, false, err
+	}
+
+	return events[0], true, nil
+}
+
+// EventBlockNum returns the number of the block in which the event with the given ID was committed
+func (r *Reader) EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error) {
+	return r.store.EventBlockNum(ctx, eventID)
+}
+
+func (r *Reader) LastProcessedBlockInfo(ctx context.Context) (ProcessedBlockInfo, bool, error) {
+	return r.store.LastProcessedBlockInfo(ctx)
+}
+
 func (r *Reader) Close() {
 	r.store.Close()
 }
@@ -35,6 +35,9 @@ type PolygonBridge interface {
 type PolygonBridgeReader interface {
 	Events(ctx context.Context, blockNum uint64) ([]*types.Message, error)
 	EventTxnLookup(ctx context.Context, borTxHash libcommon.Hash) (uint64, bool, error)
+	Event(ctx context.Context, eventID uint64) ([]byte, bool, error)
+	EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error)
+	LastProcessedBlockInfo(ctx context.Context) (ProcessedBlockInfo, bool, error)
 }
 
 type Service interface {
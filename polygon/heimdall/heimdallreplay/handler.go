@@ -0,0 +1,290 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallreplay
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// Heimdall error messages which heimdall.Client looks for to tell a missing entity
+// apart from a transient failure.
+const (
+	invalidMilestoneIndexMessage = "Invalid milestone index"
+	eventRecordNotFoundMessage   = "could not get state record; No record found"
+)
+
+type handler struct {
+	client heimdall.HeimdallClient
+	logger log.Logger
+}
+
+// NewHandler serves the entities of client over the Heimdall REST API, as far as it
+// is used by heimdall.Client.
+func NewHandler(client heimdall.HeimdallClient, logger log.Logger) http.Handler {
+	h := handler{client: client, logger: logger}
+	router := chi.NewRouter()
+
+	router.Get("/clerk/event-record/list", h.stateSyncEvents)
+	router.Get("/clerk/event-record/{id}", h.stateSyncEvent)
+	router.Get("/bor/latest-span", h.latestSpan)
+	router.Get("/bor/span/{id}", h.span)
+	router.Get("/checkpoints/latest", h.latestCheckpoint)
+	router.Get("/checkpoints/count", h.checkpointCount)
+	router.Get("/checkpoints/list", h.checkpointList)
+	router.Get("/checkpoints/{number}", h.checkpoint)
+	router.Get("/milestone/latest", h.latestMilestone)
+	router.Get("/milestone/count", h.milestoneCount)
+	router.Get("/milestone/lastNoAck", h.lastNoAckMilestone)
+	router.Get("/milestone/noAck/{id}", h.noAckMilestone)
+	router.Get("/milestone/ID/{id}", h.milestoneID)
+	router.Get("/milestone/{number}", h.milestone)
+
+	return router
+}
+
+func (h *handler) writeResult(w http.ResponseWriter, result any) {
+	response, err := json.Marshal(struct {
+		Height string `json:"height"`
+		Result any    `json:"result"`
+	}{
+		Height: "0",
+		Result: result,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+func (h *handler) writeError(w http.ResponseWriter, err error) {
+	status, message := http.StatusInternalServerError, err.Error()
+	switch {
+	case errors.Is(err, heimdall.ErrNotInMilestoneList):
+		status, message = http.StatusNotFound, invalidMilestoneIndexMessage
+	case errors.Is(err, heimdall.ErrEventRecordNotFound):
+		status, message = http.StatusNotFound, eventRecordNotFoundMessage
+	case errors.Is(err, heimdall.ErrNotInSpanList), errors.Is(err, heimdall.ErrNotInCheckpointList):
+		status = http.StatusNotFound
+	default:
+		h.logger.Warn("[heimdall-replay] request failed", "err", err)
+	}
+
+	response, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(response)
+}
+
+func badRequest(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+func (h *handler) stateSyncEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	fromId, err := strconv.ParseUint(query.Get("from-id"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	toTime, err := strconv.ParseInt(query.Get("to-time"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	limit := heimdall.StateEventsFetchLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			badRequest(w, err)
+			return
+		}
+	}
+
+	events, err := h.client.FetchStateSyncEvents(r.Context(), fromId, time.Unix(toTime, 0), limit)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	// the client only asks for a page at a time
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	h.writeResult(w, events)
+}
+
+func (h *handler) stateSyncEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	event, err := h.client.FetchStateSyncEvent(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, event)
+}
+
+func (h *handler) latestSpan(w http.ResponseWriter, r *http.Request) {
+	span, err := h.client.FetchLatestSpan(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, span)
+}
+
+func (h *handler) span(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	span, err := h.client.FetchSpan(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, span)
+}
+
+func (h *handler) latestCheckpoint(w http.ResponseWriter, r *http.Request) {
+	h.writeCheckpoint(w, r, -1)
+}
+
+func (h *handler) checkpoint(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.ParseInt(chi.URLParam(r, "number"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	h.writeCheckpoint(w, r, number)
+}
+
+func (h *handler) writeCheckpoint(w http.ResponseWriter, r *http.Request, number int64) {
+	checkpoint, err := h.client.FetchCheckpoint(r.Context(), number)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, checkpoint)
+}
+
+func (h *handler) checkpointCount(w http.ResponseWriter, r *http.Request) {
+	count, err := h.client.FetchCheckpointCount(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, heimdall.CheckpointCount{Result: count})
+}
+
+func (h *handler) checkpointList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, err := strconv.ParseUint(query.Get("page"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	limit, err := strconv.ParseUint(query.Get("limit"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	checkpoints, err := h.client.FetchCheckpoints(r.Context(), page, limit)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if checkpoints == nil {
+		checkpoints = make([]*heimdall.Checkpoint, 0)
+	}
+	h.writeResult(w, checkpoints)
+}
+
+func (h *handler) latestMilestone(w http.ResponseWriter, r *http.Request) {
+	h.writeMilestone(w, r, -1)
+}
+
+func (h *handler) milestone(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.ParseInt(chi.URLParam(r, "number"), 10, 64)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	h.writeMilestone(w, r, number)
+}
+
+func (h *handler) writeMilestone(w http.ResponseWriter, r *http.Request, number int64) {
+	milestone, err := h.client.FetchMilestone(r.Context(), number)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, milestone)
+}
+
+func (h *handler) milestoneCount(w http.ResponseWriter, r *http.Request) {
+	count, err := h.client.FetchMilestoneCount(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, heimdall.MilestoneCount{Count: count})
+}
+
+func (h *handler) lastNoAckMilestone(w http.ResponseWriter, r *http.Request) {
+	id, err := h.client.FetchLastNoAckMilestone(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, heimdall.MilestoneLastNoAck{Result: id})
+}
+
+func (h *handler) noAckMilestone(w http.ResponseWriter, r *http.Request) {
+	err := h.client.FetchNoAckMilestone(r.Context(), chi.URLParam(r, "id"))
+	if err != nil && !errors.Is(err, heimdall.ErrNotInRejectedList) {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, heimdall.MilestoneNoAck{Result: err == nil})
+}
+
+func (h *handler) milestoneID(w http.ResponseWriter, r *http.Request) {
+	err := h.client.FetchMilestoneID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil && !errors.Is(err, heimdall.ErrNotInMilestoneList) {
+		h.writeError(w, err)
+		return
+	}
+	h.writeResult(w, heimdall.MilestoneID{Result: err == nil})
+}
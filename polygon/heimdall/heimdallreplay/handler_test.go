@@ -0,0 +1,171 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallreplay
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+func newTestRecording() *Recording {
+	var r Recording
+	for i := uint64(0); i < 3; i++ {
+		r.Spans = append(r.Spans, &heimdall.Span{
+			Id:         heimdall.SpanId(i),
+			StartBlock: i * 6400,
+			EndBlock:   (i+1)*6400 - 1,
+			ChainID:    "80002",
+		})
+	}
+	for i := uint64(1); i <= 25; i++ {
+		r.Checkpoints = append(r.Checkpoints, &heimdall.Checkpoint{
+			Id: heimdall.CheckpointId(i),
+			Fields: heimdall.WaypointFields{
+				Proposer:   libcommon.Address{byte(i)},
+				StartBlock: big.NewInt(int64(i-1) * 256),
+				EndBlock:   big.NewInt(int64(i)*256 - 1),
+				RootHash:   libcommon.Hash{byte(i)},
+				ChainID:    "80002",
+				Timestamp:  1_700_000_000 + i,
+			},
+		})
+	}
+	for i := uint64(11); i <= 15; i++ {
+		r.Milestones = append(r.Milestones, &heimdall.Milestone{
+			Id:          heimdall.MilestoneId(i),
+			MilestoneId: fmt.Sprintf("milestone-%d", i),
+			Fields: heimdall.WaypointFields{
+				StartBlock: big.NewInt(int64(i-1) * 16),
+				EndBlock:   big.NewInt(int64(i)*16 - 1),
+				RootHash:   libcommon.Hash{byte(i)},
+				ChainID:    "80002",
+				Timestamp:  1_700_000_000 + i,
+			},
+		})
+	}
+	for i := uint64(1); i <= 120; i++ {
+		r.Events = append(r.Events, &heimdall.EventRecordWithTime{
+			EventRecord: heimdall.EventRecord{
+				ID:       i,
+				Contract: libcommon.Address{0x10},
+				Data:     []byte{byte(i)},
+				TxHash:   libcommon.Hash{byte(i)},
+				LogIndex: i % 3,
+				ChainID:  "80002",
+			},
+			Time: time.Unix(1_700_000_000+int64(i)*10, 0).UTC(),
+		})
+	}
+	return &r
+}
+
+func TestHandlerServesHeimdallClient(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	recording := newTestRecording()
+
+	server := httptest.NewServer(NewHandler(recording, logger))
+	t.Cleanup(server.Close)
+	client := heimdall.NewHeimdallClient(server.URL, logger)
+	t.Cleanup(client.Close)
+
+	span, err := client.FetchSpan(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, recording.Spans[1].EndBlock, span.EndBlock)
+
+	latestSpan, err := client.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, heimdall.SpanId(2), latestSpan.Id)
+
+	count, err := client.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(25), count)
+
+	checkpoints, err := client.FetchCheckpoints(ctx, 3, 10)
+	require.NoError(t, err)
+	require.Equal(t, recording.Checkpoints[20:], []*heimdall.Checkpoint(checkpoints))
+
+	checkpoints, err = client.FetchCheckpoints(ctx, 4, 10)
+	require.NoError(t, err)
+	require.Empty(t, checkpoints)
+
+	latestCheckpoint, err := client.FetchCheckpoint(ctx, -1)
+	require.NoError(t, err)
+	require.Equal(t, recording.Checkpoints[24], latestCheckpoint)
+
+	firstMilestone, err := client.FetchFirstMilestoneNum(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), firstMilestone)
+
+	milestone, err := client.FetchMilestone(ctx, 12)
+	require.NoError(t, err)
+	require.Equal(t, recording.Milestones[1], milestone)
+
+	_, err = client.FetchMilestone(ctx, 3)
+	require.ErrorIs(t, err, heimdall.ErrNotInMilestoneList)
+
+	require.NoError(t, client.FetchMilestoneID(ctx, "milestone-13"))
+	require.ErrorIs(t, client.FetchMilestoneID(ctx, "unknown"), heimdall.ErrNotInMilestoneList)
+	require.ErrorIs(t, client.FetchNoAckMilestone(ctx, "milestone-13"), heimdall.ErrNotInRejectedList)
+
+	// the client pages through events 50 at a time
+	events, err := client.FetchStateSyncEvents(ctx, 5, recording.Events[109].Time, 0)
+	require.NoError(t, err)
+	require.Equal(t, recording.Events[4:110], events)
+
+	event, err := client.FetchStateSyncEvent(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, recording.Events[6], event)
+
+	_, err = client.FetchStateSyncEvent(ctx, 1000)
+	require.ErrorIs(t, err, heimdall.ErrEventRecordNotFound)
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	recording := newTestRecording()
+
+	server := httptest.NewServer(NewHandler(recording, logger))
+	t.Cleanup(server.Close)
+	client := heimdall.NewHeimdallClient(server.URL, logger)
+	t.Cleanup(client.Close)
+
+	recorded, err := Record(ctx, client, RecordConfig{FromCheckpointId: 5, ToCheckpointId: 10, ToEventId: 60}, logger)
+	require.NoError(t, err)
+	require.Equal(t, recording.Spans, recorded.Spans)
+	require.Equal(t, recording.Checkpoints[4:10], recorded.Checkpoints)
+	require.Equal(t, recording.Milestones, recorded.Milestones)
+	require.Equal(t, recording.Events[:60], recorded.Events)
+
+	dir := t.TempDir()
+	require.NoError(t, recorded.Save(dir))
+	loaded, err := LoadRecording(dir)
+	require.NoError(t, err)
+	require.Equal(t, recorded, loaded)
+}
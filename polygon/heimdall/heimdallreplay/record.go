@@ -0,0 +1,122 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallreplay
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// RecordConfig selects the entities to record. A zero upper bound records up to the
+// latest entity known to Heimdall.
+type RecordConfig struct {
+	FromSpanId       uint64
+	ToSpanId         uint64
+	FromCheckpointId uint64
+	ToCheckpointId   uint64
+	FromEventId      uint64
+	ToEventId        uint64
+}
+
+// Record fetches the entities selected by cfg from client. All milestones still
+// retained by Heimdall are recorded.
+func Record(ctx context.Context, client heimdall.HeimdallClient, cfg RecordConfig, logger log.Logger) (*Recording, error) {
+	var r Recording
+
+	latestSpan, err := client.FetchLatestSpan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toSpanId := uint64(latestSpan.Id)
+	if cfg.ToSpanId != 0 && cfg.ToSpanId < toSpanId {
+		toSpanId = cfg.ToSpanId
+	}
+	for id := cfg.FromSpanId; id <= toSpanId; id++ {
+		span, err := client.FetchSpan(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.Spans = append(r.Spans, span)
+	}
+	logger.Info("[heimdall-replay] recorded spans", "count", len(r.Spans))
+
+	checkpointCount, err := client.FetchCheckpointCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toCheckpointId := uint64(checkpointCount)
+	if cfg.ToCheckpointId != 0 && cfg.ToCheckpointId < toCheckpointId {
+		toCheckpointId = cfg.ToCheckpointId
+	}
+	for id := max(cfg.FromCheckpointId, 1); id <= toCheckpointId; id++ {
+		checkpoint, err := client.FetchCheckpoint(ctx, int64(id))
+		if err != nil {
+			return nil, err
+		}
+		checkpoint.Id = heimdall.CheckpointId(id)
+		r.Checkpoints = append(r.Checkpoints, checkpoint)
+	}
+	logger.Info("[heimdall-replay] recorded checkpoints", "count", len(r.Checkpoints))
+
+	firstMilestone, err := client.FetchFirstMilestoneNum(ctx)
+	if err != nil {
+		return nil, err
+	}
+	milestoneCount, err := client.FetchMilestoneCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for number := firstMilestone; number <= milestoneCount; number++ {
+		milestone, err := client.FetchMilestone(ctx, number)
+		if err != nil {
+			// milestones can be pruned by Heimdall while recording
+			if errors.Is(err, heimdall.ErrNotInMilestoneList) {
+				continue
+			}
+			return nil, err
+		}
+		r.Milestones = append(r.Milestones, milestone)
+	}
+	logger.Info("[heimdall-replay] recorded milestones", "count", len(r.Milestones))
+
+	var limit int
+	if cfg.ToEventId != 0 {
+		if cfg.ToEventId < cfg.FromEventId {
+			return &r, nil
+		}
+		limit = int(cfg.ToEventId - max(cfg.FromEventId, 1) + 1)
+	}
+	events, err := client.FetchStateSyncEvents(ctx, max(cfg.FromEventId, 1), time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if cfg.ToEventId != 0 && event.ID > cfg.ToEventId {
+			break
+		}
+		r.Events = append(r.Events, event)
+	}
+	logger.Info("[heimdall-replay] recorded state sync events", "count", len(r.Events))
+
+	r.sort()
+	return &r, nil
+}
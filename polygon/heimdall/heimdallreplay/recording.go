@@ -0,0 +1,231 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallreplay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+const (
+	spansFile       = "spans.json"
+	checkpointsFile = "checkpoints.json"
+	milestonesFile  = "milestones.json"
+	eventsFile      = "events.json"
+)
+
+// Recording is an in-memory set of Heimdall entities which can be saved to and
+// loaded from a directory of json files, one per entity type. It implements
+// heimdall.HeimdallClient so that it can stand in for a live Heimdall.
+type Recording struct {
+	Spans       []*heimdall.Span
+	Checkpoints []*heimdall.Checkpoint
+	Milestones  []*heimdall.Milestone
+	Events      []*heimdall.EventRecordWithTime
+}
+
+var _ heimdall.HeimdallClient = (*Recording)(nil)
+
+// LoadRecording reads a recording from dir. Missing files are treated as empty.
+func LoadRecording(dir string) (*Recording, error) {
+	var r Recording
+	if err := readJSON(filepath.Join(dir, spansFile), &r.Spans); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, checkpointsFile), &r.Checkpoints); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, milestonesFile), &r.Milestones); err != nil {
+		return nil, err
+	}
+	if err := readJSON(filepath.Join(dir, eventsFile), &r.Events); err != nil {
+		return nil, err
+	}
+	r.sort()
+	return &r, nil
+}
+
+// Save writes the recording to dir, creating it if needed.
+func (r *Recording) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, spansFile), r.Spans); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, checkpointsFile), r.Checkpoints); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, milestonesFile), r.Milestones); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, eventsFile), r.Events)
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (r *Recording) sort() {
+	sort.Slice(r.Spans, func(i, j int) bool { return r.Spans[i].Id < r.Spans[j].Id })
+	sort.Slice(r.Checkpoints, func(i, j int) bool { return r.Checkpoints[i].Id < r.Checkpoints[j].Id })
+	sort.Slice(r.Milestones, func(i, j int) bool { return r.Milestones[i].Id < r.Milestones[j].Id })
+	sort.Slice(r.Events, func(i, j int) bool { return r.Events[i].ID < r.Events[j].ID })
+}
+
+func (r *Recording) FetchStateSyncEvents(_ context.Context, fromID uint64, to time.Time, limit int) ([]*heimdall.EventRecordWithTime, error) {
+	i := sort.Search(len(r.Events), func(i int) bool { return r.Events[i].ID >= fromID })
+
+	events := make([]*heimdall.EventRecordWithTime, 0)
+	for ; i < len(r.Events) && (limit <= 0 || len(events) < limit); i++ {
+		if r.Events[i].Time.After(to) {
+			break
+		}
+		events = append(events, r.Events[i])
+	}
+	return events, nil
+}
+
+func (r *Recording) FetchStateSyncEvent(_ context.Context, id uint64) (*heimdall.EventRecordWithTime, error) {
+	i := sort.Search(len(r.Events), func(i int) bool { return r.Events[i].ID >= id })
+	if i == len(r.Events) || r.Events[i].ID != id {
+		return nil, heimdall.ErrEventRecordNotFound
+	}
+	return r.Events[i], nil
+}
+
+func (r *Recording) FetchLatestSpan(_ context.Context) (*heimdall.Span, error) {
+	if len(r.Spans) == 0 {
+		return nil, heimdall.ErrNotInSpanList
+	}
+	return r.Spans[len(r.Spans)-1], nil
+}
+
+func (r *Recording) FetchSpan(_ context.Context, spanID uint64) (*heimdall.Span, error) {
+	i := sort.Search(len(r.Spans), func(i int) bool { return uint64(r.Spans[i].Id) >= spanID })
+	if i == len(r.Spans) || uint64(r.Spans[i].Id) != spanID {
+		return nil, fmt.Errorf("%w: span %d", heimdall.ErrNotInSpanList, spanID)
+	}
+	return r.Spans[i], nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given number, or the latest one for -1.
+func (r *Recording) FetchCheckpoint(_ context.Context, number int64) (*heimdall.Checkpoint, error) {
+	if number == -1 && len(r.Checkpoints) > 0 {
+		return r.Checkpoints[len(r.Checkpoints)-1], nil
+	}
+	i := sort.Search(len(r.Checkpoints), func(i int) bool { return int64(r.Checkpoints[i].Id) >= number })
+	if i == len(r.Checkpoints) || int64(r.Checkpoints[i].Id) != number {
+		return nil, fmt.Errorf("%w: number %d", heimdall.ErrNotInCheckpointList, number)
+	}
+	return r.Checkpoints[i], nil
+}
+
+func (r *Recording) FetchCheckpointCount(_ context.Context) (int64, error) {
+	if len(r.Checkpoints) == 0 {
+		return 0, nil
+	}
+	return int64(r.Checkpoints[len(r.Checkpoints)-1].Id), nil
+}
+
+// FetchCheckpoints returns the given page of checkpoints, pages are numbered from 1
+// and cover the checkpoint ids [(page-1)*limit+1, page*limit].
+func (r *Recording) FetchCheckpoints(_ context.Context, page uint64, limit uint64) ([]*heimdall.Checkpoint, error) {
+	checkpoints := make([]*heimdall.Checkpoint, 0)
+	if page == 0 || limit == 0 {
+		return checkpoints, nil
+	}
+	from := (page-1)*limit + 1
+	i := sort.Search(len(r.Checkpoints), func(i int) bool { return uint64(r.Checkpoints[i].Id) >= from })
+	for ; i < len(r.Checkpoints) && uint64(r.Checkpoints[i].Id) < from+limit; i++ {
+		checkpoints = append(checkpoints, r.Checkpoints[i])
+	}
+	return checkpoints, nil
+}
+
+// FetchMilestone returns the milestone with the given number, or the latest one for -1.
+func (r *Recording) FetchMilestone(_ context.Context, number int64) (*heimdall.Milestone, error) {
+	if number == -1 && len(r.Milestones) > 0 {
+		return r.Milestones[len(r.Milestones)-1], nil
+	}
+	i := sort.Search(len(r.Milestones), func(i int) bool { return int64(r.Milestones[i].Id) >= number })
+	if i == len(r.Milestones) || int64(r.Milestones[i].Id) != number {
+		return nil, fmt.Errorf("%w: number %d", heimdall.ErrNotInMilestoneList, number)
+	}
+	return r.Milestones[i], nil
+}
+
+func (r *Recording) FetchMilestoneCount(_ context.Context) (int64, error) {
+	if len(r.Milestones) == 0 {
+		return 0, nil
+	}
+	return int64(r.Milestones[len(r.Milestones)-1].Id), nil
+}
+
+func (r *Recording) FetchFirstMilestoneNum(_ context.Context) (int64, error) {
+	if len(r.Milestones) == 0 {
+		return 0, heimdall.ErrNotInMilestoneList
+	}
+	return int64(r.Milestones[0].Id), nil
+}
+
+// FetchNoAckMilestone reports every milestone as acknowledged, a recording has no
+// rejected milestones.
+func (r *Recording) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInRejectedList, milestoneID)
+}
+
+func (r *Recording) FetchLastNoAckMilestone(_ context.Context) (string, error) {
+	return "", nil
+}
+
+func (r *Recording) FetchMilestoneID(_ context.Context, milestoneID string) error {
+	for _, milestone := range r.Milestones {
+		if milestone.MilestoneId == milestoneID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInMilestoneList, milestoneID)
+}
+
+func (r *Recording) Close() {}
@@ -0,0 +1,225 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallreplay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// EventStore is the part of the bridge store needed to serve state sync events.
+type EventStore interface {
+	LatestEventID(ctx context.Context) (uint64, error)
+	// Events returns the raw events with ids in [start, end)
+	Events(ctx context.Context, start, end uint64) ([][]byte, error)
+}
+
+// StoreClient serves the entities a node has already downloaded from Heimdall:
+// spans, checkpoints and milestones from the heimdall service store and state
+// sync events from the bridge store.
+type StoreClient struct {
+	store  heimdall.ServiceStore
+	events EventStore
+}
+
+var _ heimdall.HeimdallClient = (*StoreClient)(nil)
+
+func NewStoreClient(store heimdall.ServiceStore, events EventStore) *StoreClient {
+	return &StoreClient{
+		store:  store,
+		events: events,
+	}
+}
+
+func (c *StoreClient) FetchStateSyncEvents(ctx context.Context, fromID uint64, to time.Time, limit int) ([]*heimdall.EventRecordWithTime, error) {
+	events := make([]*heimdall.EventRecordWithTime, 0)
+
+	latestID, err := c.events.LatestEventID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fromID > latestID {
+		return events, nil
+	}
+	end := latestID + 1
+	if limit > 0 && fromID+uint64(limit) < end {
+		end = fromID + uint64(limit)
+	}
+
+	rawEvents, err := c.events.Events(ctx, fromID, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range rawEvents {
+		var event heimdall.EventRecordWithTime
+		if err := event.UnmarshallBytes(raw); err != nil {
+			return nil, err
+		}
+		if event.Time.After(to) {
+			break
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+func (c *StoreClient) FetchStateSyncEvent(ctx context.Context, id uint64) (*heimdall.EventRecordWithTime, error) {
+	rawEvents, err := c.events.Events(ctx, id, id+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawEvents) == 0 {
+		return nil, heimdall.ErrEventRecordNotFound
+	}
+	var event heimdall.EventRecordWithTime
+	if err := event.UnmarshallBytes(rawEvents[0]); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (c *StoreClient) FetchLatestSpan(ctx context.Context) (*heimdall.Span, error) {
+	span, ok, err := c.store.Spans().LastEntity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, heimdall.ErrNotInSpanList
+	}
+	return span, nil
+}
+
+func (c *StoreClient) FetchSpan(ctx context.Context, spanID uint64) (*heimdall.Span, error) {
+	span, ok, err := c.store.Spans().Entity(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: span %d", heimdall.ErrNotInSpanList, spanID)
+	}
+	return span, nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given number, or the latest one for -1.
+func (c *StoreClient) FetchCheckpoint(ctx context.Context, number int64) (*heimdall.Checkpoint, error) {
+	var (
+		checkpoint *heimdall.Checkpoint
+		ok         bool
+		err        error
+	)
+	if number == -1 {
+		checkpoint, ok, err = c.store.Checkpoints().LastEntity(ctx)
+	} else {
+		checkpoint, ok, err = c.store.Checkpoints().Entity(ctx, uint64(number))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: number %d", heimdall.ErrNotInCheckpointList, number)
+	}
+	return checkpoint, nil
+}
+
+func (c *StoreClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	id, _, err := c.store.Checkpoints().LastEntityId(ctx)
+	return int64(id), err
+}
+
+// FetchCheckpoints returns the given page of checkpoints, pages are numbered from 1
+// and cover the checkpoint ids [(page-1)*limit+1, page*limit].
+func (c *StoreClient) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*heimdall.Checkpoint, error) {
+	checkpoints := make([]*heimdall.Checkpoint, 0)
+	if page == 0 || limit == 0 {
+		return checkpoints, nil
+	}
+	lastId, _, err := c.store.Checkpoints().LastEntityId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for id := (page-1)*limit + 1; id <= page*limit && id <= lastId; id++ {
+		checkpoint, ok, err := c.store.Checkpoints().Entity(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+	}
+	return checkpoints, nil
+}
+
+// FetchMilestone returns the milestone with the given number, or the latest one for -1.
+func (c *StoreClient) FetchMilestone(ctx context.Context, number int64) (*heimdall.Milestone, error) {
+	var (
+		milestone *heimdall.Milestone
+		ok        bool
+		err       error
+	)
+	if number == -1 {
+		milestone, ok, err = c.store.Milestones().LastEntity(ctx)
+	} else {
+		milestone, ok, err = c.store.Milestones().Entity(ctx, uint64(number))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: number %d", heimdall.ErrNotInMilestoneList, number)
+	}
+	return milestone, nil
+}
+
+func (c *StoreClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	id, _, err := c.store.Milestones().LastEntityId(ctx)
+	return int64(id), err
+}
+
+// FetchFirstMilestoneNum mirrors Heimdall, which only keeps the latest 100 milestones.
+func (c *StoreClient) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	count, err := c.FetchMilestoneCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return max(count-100+1, 1), nil
+}
+
+// FetchNoAckMilestone reports every milestone as acknowledged, the store does not
+// keep rejected milestones.
+func (c *StoreClient) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInRejectedList, milestoneID)
+}
+
+func (c *StoreClient) FetchLastNoAckMilestone(_ context.Context) (string, error) {
+	return "", nil
+}
+
+func (c *StoreClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	milestone, ok, err := c.store.Milestones().LastEntity(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok || milestone.MilestoneId != milestoneID {
+		return fmt.Errorf("%w: milestoneID %q", heimdall.ErrNotInMilestoneList, milestoneID)
+	}
+	return nil
+}
+
+func (c *StoreClient) Close() {}
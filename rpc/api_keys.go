@@ -0,0 +1,157 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/erigontech/erigon-lib/common/hexutility"
+)
+
+// APIKey gives its holders access to a subset of the methods of the server.
+// The holders authenticate with a JWT signed by Secret whose subject is Name.
+type APIKey struct {
+	Name   string           `json:"name"`
+	Secret hexutility.Bytes `json:"secret"`
+	// Namespaces and Allow list the methods available with the key: a method is available if
+	// its namespace is in Namespaces or if it is in Allow. Both empty means all methods.
+	Namespaces []string  `json:"namespaces"`
+	Allow      AllowList `json:"allow"`
+	// MaxResponseSize caps the size of every response in bytes, 0 means unlimited.
+	MaxResponseSize int `json:"maxResponseSize"`
+}
+
+// APIKeysConfig is the format of the --rpc.apikeys file, e.g.
+//
+//	{
+//	  "required": true,
+//	  "keys": [
+//	    {"name": "internal", "secret": "0x...", "namespaces": ["eth", "debug", "trace"]},
+//	    {"name": "public", "secret": "0x...", "allow": ["eth_blockNumber", "eth_call"], "maxResponseSize": 1048576}
+//	  ]
+//	}
+type APIKeysConfig struct {
+	// Required rejects the requests without a key, otherwise they are served as without the keys.
+	Required bool      `json:"required"`
+	Keys     []*APIKey `json:"keys"`
+}
+
+// APIKeys authenticates the clients of the HTTP and WebSocket endpoints.
+type APIKeys struct {
+	required bool
+	keys     map[string]*APIKey // name => key
+}
+
+func NewAPIKeys(cfg APIKeysConfig) (*APIKeys, error) {
+	k := &APIKeys{required: cfg.Required, keys: make(map[string]*APIKey, len(cfg.Keys))}
+	for _, key := range cfg.Keys {
+		if key.Name == "" {
+			return nil, errors.New("api key without a name")
+		}
+		if len(key.Secret) < 32 {
+			return nil, fmt.Errorf("secret of api key %q must have at least 32 bytes", key.Name)
+		}
+		if _, ok := k.keys[key.Name]; ok {
+			return nil, fmt.Errorf("duplicate api key %q", key.Name)
+		}
+		k.keys[key.Name] = key
+	}
+	return k, nil
+}
+
+// Get returns the key by its name, or nil.
+func (k *APIKeys) Get(name string) *APIKey {
+	if k == nil || name == "" {
+		return nil
+	}
+	return k.keys[name]
+}
+
+// authenticate returns the key the token is signed with. It returns nil and no error for
+// the requests without a token, if they are allowed.
+func (k *APIKeys) authenticate(token string) (*APIKey, error) {
+	if token == "" {
+		if k.required {
+			return nil, errors.New("missing api key")
+		}
+		return nil, nil
+	}
+
+	var key *APIKey
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		claims := token.Claims.(*jwt.RegisteredClaims)
+		if key = k.keys[claims.Subject]; key == nil {
+			return nil, fmt.Errorf("unknown api key %q", claims.Subject)
+		}
+		return []byte(key.Secret), nil
+	}
+	// unlike the Engine API tokens, API keys are long-lived: "iat" isn't required,
+	// while "exp" and "nbf" are checked if present
+	if _, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, keyFunc, jwt.WithValidMethods([]string{"HS256"})); err != nil {
+		return nil, fmt.Errorf("invalid api key: %w", err)
+	}
+	return key, nil
+}
+
+// NewAPIKeyToken creates a token for the key, expiring at expiry unless it's zero.
+func NewAPIKeyToken(key *APIKey, expiry time.Time) (string, error) {
+	claims := jwt.RegisteredClaims{Subject: key.Name, IssuedAt: jwt.NewNumericDate(time.Now())}
+	if !expiry.IsZero() {
+		claims.ExpiresAt = jwt.NewNumericDate(expiry)
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key.Secret))
+}
+
+// allows reports whether the method may be called with the key, a nil key allows everything.
+func (key *APIKey) allows(method string) bool {
+	if key == nil || (len(key.Namespaces) == 0 && len(key.Allow) == 0) {
+		return true
+	}
+	if _, ok := key.Allow[method]; ok {
+		return true
+	}
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	for _, ns := range key.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// methodNotAllowedError is returned for the methods not available with the client's API key.
+type methodNotAllowedError struct{ method, key string }
+
+func (e *methodNotAllowedError) ErrorCode() int { return -32601 }
+
+func (e *methodNotAllowedError) Error() string {
+	return fmt.Sprintf("the method %s is not available with the api key %s", e.method, e.key)
+}
+
+// responseTooLargeError replaces the responses exceeding APIKey.MaxResponseSize.
+type responseTooLargeError struct{ size, limit int }
+
+func (e *responseTooLargeError) ErrorCode() int { return -32005 }
+
+func (e *responseTooLargeError) Error() string {
+	return fmt.Sprintf("response size %d exceeds the limit %d of the api key", e.size, e.limit)
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func newTestAPIKeys(t *testing.T, required bool) *APIKeys {
+	apiKeys, err := NewAPIKeys(APIKeysConfig{
+		Required: required,
+		Keys: []*APIKey{
+			{Name: "admin", Secret: bytes.Repeat([]byte{1}, 32)},
+			{Name: "reader", Secret: bytes.Repeat([]byte{2}, 32), Namespaces: []string{"test"}, Allow: AllowList{"large_largeResp": {}}, MaxResponseSize: 1000},
+		},
+	})
+	require.NoError(t, err)
+	return apiKeys
+}
+
+func TestAPIKeysAuthenticate(t *testing.T) {
+	apiKeys := newTestAPIKeys(t, false)
+
+	token, err := NewAPIKeyToken(apiKeys.Get("reader"), time.Time{})
+	require.NoError(t, err)
+	key, err := apiKeys.authenticate(token)
+	require.NoError(t, err)
+	require.Equal(t, "reader", key.Name)
+
+	key, err = apiKeys.authenticate("")
+	require.NoError(t, err)
+	require.Nil(t, key)
+
+	// signed with the secret of another key
+	forged, err := NewAPIKeyToken(&APIKey{Name: "admin", Secret: apiKeys.Get("reader").Secret}, time.Time{})
+	require.NoError(t, err)
+	_, err = apiKeys.authenticate(forged)
+	require.Error(t, err)
+
+	expired, err := NewAPIKeyToken(apiKeys.Get("admin"), time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = apiKeys.authenticate(expired)
+	require.Error(t, err)
+
+	_, err = apiKeys.authenticate("not a token")
+	require.Error(t, err)
+
+	_, err = newTestAPIKeys(t, true).authenticate("")
+	require.Error(t, err)
+
+	_, err = NewAPIKeys(APIKeysConfig{Keys: []*APIKey{{Name: "short", Secret: []byte{1}}}})
+	require.Error(t, err)
+
+	reader := apiKeys.Get("reader")
+	require.True(t, reader.allows("test_echo"))
+	require.True(t, reader.allows("large_largeResp"))
+	require.False(t, reader.allows("large_other"))
+	require.True(t, apiKeys.Get("admin").allows("large_other"))
+}
+
+func TestHTTPAPIKeys(t *testing.T) {
+	logger := log.New()
+	s := NewServer(50, false /* traceRequests */, false /* debugSingleRequests */, false, logger, 100)
+	defer s.Stop()
+	require.NoError(t, s.RegisterName("test", new(testService)))
+	require.NoError(t, s.RegisterName("large", largeRespService{2000}))
+	apiKeys := newTestAPIKeys(t, true)
+	s.SetAPIKeys(apiKeys)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// no key
+	c, err := DialHTTP(ts.URL, logger)
+	require.NoError(t, err)
+	defer c.Close()
+	require.Error(t, c.Call(nil, "test_noArgsRets"))
+
+	// the admin key may call everything, with the key as a query parameter
+	adminToken, err := NewAPIKeyToken(apiKeys.Get("admin"), time.Time{})
+	require.NoError(t, err)
+	admin, err := DialHTTP(ts.URL+"?apikey="+adminToken, logger)
+	require.NoError(t, err)
+	defer admin.Close()
+	var info PeerInfo
+	require.NoError(t, admin.Call(&info, "test_peerInfo"))
+	require.Equal(t, "admin", info.APIKeyName)
+	var resp string
+	require.NoError(t, admin.Call(&resp, "large_largeResp"))
+	require.Len(t, resp, 2000)
+
+	// the reader key only gets its methods, with small responses
+	readerToken, err := NewAPIKeyToken(apiKeys.Get("reader"), time.Time{})
+	require.NoError(t, err)
+	reader, err := DialHTTP(ts.URL, logger)
+	require.NoError(t, err)
+	defer reader.Close()
+	reader.SetHeader("Authorization", "Bearer "+readerToken)
+	require.NoError(t, reader.Call(nil, "test_noArgsRets"))
+
+	var rpcErr Error
+	err = reader.Call(&resp, "large_largeResp")
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, -32005, rpcErr.ErrorCode())
+
+	err = reader.Call(nil, "large_other")
+	require.ErrorContains(t, err, "not available with the api key reader")
+
+	var echo echoResult
+	batch := []BatchElem{
+		{Method: "test_echo", Args: []interface{}{"x", 1}, Result: &echo},
+		{Method: "large_largeResp", Result: &resp},
+	}
+	require.NoError(t, reader.BatchCall(batch))
+	require.NoError(t, batch[0].Error)
+	require.Equal(t, "x", echo.String)
+	require.ErrorContains(t, batch[1].Error, "exceeds the limit 1000")
+}
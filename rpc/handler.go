@@ -71,6 +71,10 @@ type handler struct {
 
 	allowList     AllowList // a list of explicitly allowed methods, if empty -- everything is allowed
 	forbiddenList ForbiddenList
+	rateLimiter   *RateLimiter // per-client request budget, nil if unlimited
+	apiKey        *APIKey      // the key the client authenticated with, nil if none
+	responseCache *ResponseCache
+	requestLog    *RequestLog // the log of the slow and failed requests, nil if disabled
 
 	subLock             sync.Mutex
 	serverSubs          map[ID]*Subscription
@@ -212,6 +216,10 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 				if buf.Len() > 0 && answersWithNils[i] == nil {
 					answersWithNils[i] = json.RawMessage(buf.Bytes())
 				}
+				if h.apiKey != nil && answersWithNils[i] != nil {
+					resp, _ := json.Marshal(answersWithNils[i])
+					answersWithNils[i] = h.limitResponse(calls[i], resp)
+				}
 			}(i)
 		}
 		wg.Wait()
@@ -249,7 +257,7 @@ func (h *handler) handleMsg(msg *jsonrpcMessage, stream *jsoniter.Stream) {
 			stream.Write(buffer)
 		}
 		if needWriteStream {
-			h.conn.WriteJSON(cp.ctx, json.RawMessage(stream.Buffer()))
+			h.conn.WriteJSON(cp.ctx, h.limitResponse(msg, stream.Buffer()))
 		} else {
 			stream.Write([]byte("\n"))
 		}
@@ -259,6 +267,19 @@ func (h *handler) handleMsg(msg *jsonrpcMessage, stream *jsoniter.Stream) {
 	})
 }
 
+// limitResponse accounts the response to the API key of the client, and replaces it
+// with an error if it exceeds the response size limit of the key.
+func (h *handler) limitResponse(msg *jsonrpcMessage, resp json.RawMessage) interface{} {
+	if h.apiKey == nil || len(resp) == 0 {
+		return resp
+	}
+	rpcAPIKeyResponseBytesCounter(h.apiKey.Name).AddInt(len(resp))
+	if h.apiKey.MaxResponseSize > 0 && len(resp) > h.apiKey.MaxResponseSize {
+		return msg.errorResponse(&responseTooLargeError{size: len(resp), limit: h.apiKey.MaxResponseSize})
+	}
+	return resp
+}
+
 // close cancels all requests except for inflightReq and waits for
 // call goroutines to shut down.
 func (h *handler) close(err error, inflightReq *requestOp) {
@@ -418,14 +439,16 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage, stream *json
 		}
 
 		var start time.Time
-		if doSlowLog {
+		if doSlowLog || h.requestLog != nil {
 			start = time.Now()
 		}
 
-		resp := h.handleCall(ctx, msg, stream)
+		callStream, counter := h.requestLogStream(stream)
+		resp := h.handleCall(ctx, msg, callStream)
+		requestDuration := time.Since(start)
+		h.logRequest(msg, resp, callStream, counter, requestDuration)
 
 		if doSlowLog {
-			requestDuration := time.Since(start)
 			if requestDuration > h.slowLogThreshold {
 				h.logger.Info("[rpc.slow] finished", "method", msg.Method, "reqid", idForLog(msg.ID), "duration", requestDuration)
 			}
@@ -464,6 +487,20 @@ func (h *handler) isMethodAllowedByGranularControl(method string) bool {
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream *jsoniter.Stream) *jsonrpcMessage {
+	if h.apiKey != nil && !msg.isUnsubscribe() {
+		rpcAPIKeyRequestsCounter(h.apiKey.Name).Inc()
+		if !h.apiKey.allows(msg.Method) {
+			rpcAPIKeyDeniedCounter(h.apiKey.Name).Inc()
+			return msg.errorResponse(&methodNotAllowedError{method: msg.Method, key: h.apiKey.Name})
+		}
+	}
+	if h.rateLimiter != nil && !msg.isUnsubscribe() {
+		release, err := h.rateLimiter.acquire(cp.ctx, msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
+		defer release()
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg, stream)
 	}
@@ -481,7 +518,12 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream *jsoniter
 		return msg.errorResponse(&InvalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args, stream)
+	var answer *jsonrpcMessage
+	if h.responseCache != nil && callb != h.unsubscribeCb {
+		answer = h.runCachedMethod(cp.ctx, msg, callb, args, stream)
+	} else {
+		answer = h.runMethod(cp.ctx, msg, callb, args, stream)
+	}
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -550,6 +592,9 @@ func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *cal
 	stream.WriteObjectField("result")
 	_, err := callb.call(ctx, msg.Method, args, stream)
 	if err != nil {
+		if counter, ok := stream.Attachment.(*countingWriter); ok {
+			counter.err = err
+		}
 		writeNilIfNotPresent(stream)
 		stream.WriteMore()
 		HandleError(err, stream)
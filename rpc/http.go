@@ -253,6 +253,17 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	connInfo.APIKey = apiKeyFromRequest(r)
+	if s.opts.apiKeys != nil {
+		key, err := s.opts.apiKeys.authenticate(connInfo.APIKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if key != nil {
+			connInfo.APIKeyName = key.Name
+		}
+	}
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
@@ -279,12 +290,22 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	codec := newHTTPServerConn(r, w)
 	defer codec.Close()
 	var stream *jsoniter.Stream
-	if !s.disableStreaming {
+	// responses to API key holders are buffered to be accounted and capped, see handler.limitResponse
+	if !s.disableStreaming && connInfo.APIKeyName == "" {
 		stream = jsoniter.NewStream(jsoniter.ConfigDefault, w, 4096)
 	}
 	s.serveSingleRequest(ctx, codec, stream)
 }
 
+// apiKeyFromRequest returns the API key passed either as "Authorization: Bearer <key>"
+// or as the "apikey" query parameter.
+func apiKeyFromRequest(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return r.URL.Query().Get("apikey")
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func validateRequest(r *http.Request) (int, error) {
@@ -28,9 +28,10 @@ import (
 )
 
 var (
-	rpcMetricsLabels   = map[bool]map[string]string{}
-	rpcRequestGauge    = metrics.GetOrCreateCounter("rpc_total")
-	failedReqeustGauge = metrics.GetOrCreateCounter("rpc_failure")
+	rpcMetricsLabels     = map[bool]map[string]string{}
+	rpcRequestGauge      = metrics.GetOrCreateCounter("rpc_total")
+	failedReqeustGauge   = metrics.GetOrCreateCounter("rpc_failure")
+	rpcResponseCacheSize = metrics.GetOrCreateGauge("rpc_response_cache_size")
 )
 
 // PreAllocateRPCMetricLabels pre-allocates labels for all rpc methods inside API List
@@ -96,3 +97,39 @@ func newRPCServingTimerMS(method string, valid bool) metrics.Summary {
 
 	return metrics.GetOrCreateSummary(label)
 }
+
+// Per-client usage of the rate limited servers, see RateLimiter. Clients are labelled
+// "key:<api key name>" or "ip:<address>", with the IPs over the cap summed up as "ip:other".
+func rpcClientRequestsCounter(client string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_client_requests{client="%s"}`, client))
+}
+
+func rpcClientCostCounter(client string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_client_cost{client="%s"}`, client))
+}
+
+func rpcClientLimitedCounter(client, reason string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_client_limited{client="%s",reason="%s"}`, client, reason))
+}
+
+// Usage of the API keys, see APIKeys.
+func rpcAPIKeyRequestsCounter(key string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_requests{key="%s"}`, key))
+}
+
+func rpcAPIKeyDeniedCounter(key string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_denied{key="%s"}`, key))
+}
+
+func rpcAPIKeyResponseBytesCounter(key string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_response_bytes{key="%s"}`, key))
+}
+
+// Hits and misses of the ResponseCache, among the calls it may serve.
+func rpcResponseCacheCounter(method string, hit bool) metrics.Counter {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_response_cache{method="%s",result="%s"}`, method, result))
+}
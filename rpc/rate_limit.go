@@ -0,0 +1,242 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is the budget of a single client: a token bucket refilled with RequestsPerSecond
+// cost units per second and holding up to Burst units, plus a cap of concurrently served requests.
+// Zero values disable the corresponding limit.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+	MaxConcurrent     int     `json:"maxConcurrent"`
+}
+
+// RateLimitAPIKey gives the clients presenting Key their own budget instead of the per-IP one.
+// Name identifies the key in the metrics, so that the key itself is never exposed. Without Key,
+// the budget is given to the clients authenticated with the API key of that name, see APIKeys.
+type RateLimitAPIKey struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	RateLimit
+}
+
+// RateLimitConfig is the format of the --rpc.ratelimit file, e.g.
+//
+//	{
+//	  "requestsPerSecond": 50, "burst": 100, "maxConcurrent": 8,
+//	  "methodCosts": {"eth_getLogs": 20, "trace_filter": 100},
+//	  "apiKeys": [{"name": "explorer", "key": "...", "requestsPerSecond": 500, "burst": 1000, "maxConcurrent": 32}]
+//	}
+type RateLimitConfig struct {
+	RateLimit // per-IP budget of the clients without a known API key
+
+	// DefaultCost is the cost of the methods missing in MethodCosts, 1 if unset.
+	DefaultCost int `json:"defaultCost"`
+	// MethodCosts maps a method name, or a "namespace_*" wildcard, to the number of tokens it takes.
+	MethodCosts map[string]int    `json:"methodCosts"`
+	APIKeys     []RateLimitAPIKey `json:"apiKeys"`
+}
+
+// RateLimitExceededError is returned to clients which ran out of their request budget.
+type RateLimitExceededError struct{ Method string }
+
+func (e *RateLimitExceededError) ErrorCode() int { return -32005 }
+
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s, try again later", e.Method)
+}
+
+// TooManyConcurrentRequestsError is returned to clients which have too many requests in flight.
+type TooManyConcurrentRequestsError struct{ Limit int }
+
+func (e *TooManyConcurrentRequestsError) ErrorCode() int { return -32005 }
+
+func (e *TooManyConcurrentRequestsError) Error() string {
+	return fmt.Sprintf("too many concurrent requests, the limit is %d", e.Limit)
+}
+
+const (
+	rateLimitIdleTimeout     = 10 * time.Minute
+	rateLimitCleanupInterval = time.Minute
+	// rateLimitMaxIPLabels is the number of IPs with metrics of their own, see clientLabel.
+	rateLimitMaxIPLabels = 100
+)
+
+// RateLimiter accounts the requests of every client against its RateLimit.
+type RateLimiter struct {
+	defaultLimit RateLimit
+	defaultCost  int
+	methodCosts  map[string]int
+	apiKeys      map[string]RateLimitAPIKey // key => config
+	apiKeyNames  map[string]RateLimitAPIKey // name => config, of the authenticated API keys
+
+	mu          sync.Mutex
+	clients     map[string]*clientBudget // client name => budget
+	ipLabels    map[string]struct{}      // client names of the IPs with metrics of their own
+	maxIPLabels int
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+type clientBudget struct {
+	limit    RateLimit
+	bucket   *rate.Limiter // nil if the rate is unlimited
+	inflight int
+	lastSeen time.Time
+}
+
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{
+		defaultLimit: cfg.RateLimit,
+		defaultCost:  cfg.DefaultCost,
+		methodCosts:  cfg.MethodCosts,
+		apiKeys:      make(map[string]RateLimitAPIKey, len(cfg.APIKeys)),
+		apiKeyNames:  make(map[string]RateLimitAPIKey),
+		clients:      make(map[string]*clientBudget),
+		ipLabels:     make(map[string]struct{}),
+		maxIPLabels:  rateLimitMaxIPLabels,
+		now:          time.Now,
+	}
+	if l.defaultCost <= 0 {
+		l.defaultCost = 1
+	}
+	for i, k := range cfg.APIKeys {
+		if k.Name == "" {
+			k.Name = "key" + fmt.Sprint(i)
+		}
+		if k.Key == "" {
+			l.apiKeyNames[k.Name] = k
+		} else {
+			l.apiKeys[k.Key] = k
+		}
+	}
+	return l
+}
+
+// MethodCost returns the number of tokens a call to method takes.
+func (l *RateLimiter) MethodCost(method string) int {
+	if cost, ok := l.methodCosts[method]; ok {
+		return cost
+	}
+	if i := strings.IndexByte(method, '_'); i >= 0 {
+		if cost, ok := l.methodCosts[method[:i+1]+"*"]; ok {
+			return cost
+		}
+	}
+	return l.defaultCost
+}
+
+// client identifies the caller by its API key if it is a configured one, and by its IP otherwise.
+func (l *RateLimiter) client(info PeerInfo) (string, RateLimit) {
+	if info.APIKeyName != "" {
+		if k, ok := l.apiKeyNames[info.APIKeyName]; ok {
+			return "key:" + k.Name, k.RateLimit
+		}
+	}
+	if info.APIKey != "" {
+		if k, ok := l.apiKeys[info.APIKey]; ok {
+			return "key:" + k.Name, k.RateLimit
+		}
+	}
+	host, _, err := net.SplitHostPort(info.RemoteAddr)
+	if err != nil {
+		host = info.RemoteAddr
+	}
+	return "ip:" + host, l.defaultLimit
+}
+
+// clientLabel is the metrics label of a client. The first maxIPLabels IPs seen keep a label of
+// their own, the later ones are summed up as "ip:other": series are never removed, so their
+// number must stay bounded.
+func (l *RateLimiter) clientLabel(name string) string {
+	if strings.HasPrefix(name, "key:") {
+		return name
+	}
+	if _, ok := l.ipLabels[name]; ok {
+		return name
+	}
+	if len(l.ipLabels) < l.maxIPLabels {
+		l.ipLabels[name] = struct{}{}
+		return name
+	}
+	return "ip:other"
+}
+
+// acquire takes the cost of method from the budget of the client of ctx. The returned release
+// function must be called when the request is served.
+func (l *RateLimiter) acquire(ctx context.Context, method string) (release func(), err error) {
+	name, limit := l.client(PeerInfoFromContext(ctx))
+	cost := l.MethodCost(method)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastCleanup) > rateLimitCleanupInterval {
+		l.cleanup(now)
+	}
+	c, ok := l.clients[name]
+	if !ok {
+		c = &clientBudget{limit: limit}
+		if limit.RequestsPerSecond > 0 {
+			c.bucket = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), max(limit.Burst, 1))
+		}
+		l.clients[name] = c
+	}
+	c.lastSeen = now
+
+	label := l.clientLabel(name)
+	if limit.MaxConcurrent > 0 && c.inflight >= limit.MaxConcurrent {
+		rpcClientLimitedCounter(label, "concurrency").Inc()
+		return nil, &TooManyConcurrentRequestsError{Limit: limit.MaxConcurrent}
+	}
+	// a method costlier than the whole bucket is still allowed when the bucket is full
+	if c.bucket != nil && !c.bucket.AllowN(now, min(cost, c.bucket.Burst())) {
+		rpcClientLimitedCounter(label, "rate").Inc()
+		return nil, &RateLimitExceededError{Method: method}
+	}
+	c.inflight++
+	rpcClientRequestsCounter(label).Inc()
+	rpcClientCostCounter(label).AddInt(cost)
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		c.inflight--
+	}, nil
+}
+
+// cleanup forgets the idle clients, whose buckets are refilled by now anyway.
+func (l *RateLimiter) cleanup(now time.Time) {
+	for name, c := range l.clients {
+		if c.inflight == 0 && now.Sub(c.lastSeen) > rateLimitIdleTimeout {
+			delete(l.clients, name)
+		}
+	}
+	l.lastCleanup = now
+}
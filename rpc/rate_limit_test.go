@@ -0,0 +1,135 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{
+		RateLimit:   RateLimit{RequestsPerSecond: 10, Burst: 10, MaxConcurrent: 2},
+		MethodCosts: map[string]int{"eth_getLogs": 5, "trace_*": 100},
+		APIKeys:     []RateLimitAPIKey{{Name: "internal", Key: "secret", RateLimit: RateLimit{RequestsPerSecond: 1000, Burst: 1000}}},
+	})
+	now := time.Unix(1_700_000_000, 0)
+	l.now = func() time.Time { return now }
+
+	require.Equal(t, 1, l.MethodCost("eth_blockNumber"))
+	require.Equal(t, 5, l.MethodCost("eth_getLogs"))
+	require.Equal(t, 100, l.MethodCost("trace_filter"))
+
+	ctxA := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.1:1234"})
+	ctxA2 := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.1:5678", APIKey: "unknown"})
+	ctxB := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.2:1234"})
+	ctxKey := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.1:1234", APIKey: "secret"})
+
+	ipRequests, keyRequests := rpcClientRequestsCounter("ip:10.0.0.1").GetValueUint64(), rpcClientRequestsCounter("key:internal").GetValueUint64()
+	ipLimited, otherLimited := rpcClientLimitedCounter("ip:10.0.0.1", "rate").GetValueUint64(), rpcClientLimitedCounter("ip:other", "rate").GetValueUint64()
+	otherRequests := rpcClientRequestsCounter("ip:other").GetValueUint64()
+	l.maxIPLabels = 1
+
+	// the bucket is shared by all connections of an IP, unknown keys don't matter
+	release, err := l.acquire(ctxA, "eth_getLogs")
+	require.NoError(t, err)
+	release()
+	release, err = l.acquire(ctxA2, "eth_getLogs")
+	require.NoError(t, err)
+	release()
+	_, err = l.acquire(ctxA, "eth_blockNumber")
+	var rateErr *RateLimitExceededError
+	require.True(t, errors.As(err, &rateErr))
+	require.Equal(t, -32005, rateErr.ErrorCode())
+
+	// other clients have their own budgets
+	release, err = l.acquire(ctxKey, "trace_filter")
+	require.NoError(t, err)
+	release()
+
+	// a method costlier than the bucket is allowed only when the bucket is full
+	release, err = l.acquire(ctxB, "trace_filter")
+	require.NoError(t, err)
+	release()
+	_, err = l.acquire(ctxB, "trace_filter")
+	require.Error(t, err)
+
+	// the metrics have a series per API key and per IP, up to the cap of IPs
+	require.Equal(t, ipRequests+2, rpcClientRequestsCounter("ip:10.0.0.1").GetValueUint64())
+	require.Equal(t, ipLimited+1, rpcClientLimitedCounter("ip:10.0.0.1", "rate").GetValueUint64())
+	require.Equal(t, otherRequests+1, rpcClientRequestsCounter("ip:other").GetValueUint64())
+	require.Equal(t, otherLimited+1, rpcClientLimitedCounter("ip:other", "rate").GetValueUint64())
+	require.Equal(t, keyRequests+1, rpcClientRequestsCounter("key:internal").GetValueUint64())
+
+	// the bucket refills over time
+	now = now.Add(time.Second)
+	release1, err := l.acquire(ctxA, "eth_blockNumber")
+	require.NoError(t, err)
+	release2, err := l.acquire(ctxA, "eth_blockNumber")
+	require.NoError(t, err)
+
+	// too many requests in flight
+	_, err = l.acquire(ctxA, "eth_blockNumber")
+	var concurrencyErr *TooManyConcurrentRequestsError
+	require.True(t, errors.As(err, &concurrencyErr))
+	release1()
+	release3, err := l.acquire(ctxA, "eth_blockNumber")
+	require.NoError(t, err)
+	release2()
+	release3()
+
+	// idle clients are forgotten
+	require.Len(t, l.clients, 3)
+	now = now.Add(rateLimitIdleTimeout + time.Second)
+	release, err = l.acquire(ctxB, "eth_blockNumber")
+	require.NoError(t, err)
+	release()
+	require.Len(t, l.clients, 1)
+}
+
+func TestHTTPRateLimit(t *testing.T) {
+	logger := log.New()
+	s := newTestServer(logger)
+	s.SetRateLimiter(NewRateLimiter(RateLimitConfig{RateLimit: RateLimit{RequestsPerSecond: 0.001, Burst: 2}}))
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	c, err := DialHTTP(ts.URL, logger)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.Call(nil, "test_noArgsRets"))
+	require.NoError(t, c.Call(nil, "test_noArgsRets"))
+	err = c.Call(nil, "test_noArgsRets")
+	var rpcErr Error
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, -32005, rpcErr.ErrorCode())
+
+	// the key is passed as a query parameter, but isn't a configured one
+	c2, err := DialHTTP(ts.URL+"?apikey=foo", logger)
+	require.NoError(t, err)
+	defer c2.Close()
+	require.Error(t, c2.Call(nil, "test_noArgsRets"))
+}
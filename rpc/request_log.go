@@ -0,0 +1,173 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// RequestLogEntry is a line of the request log. It holds everything needed to replay the
+// request with `rpctest replay`.
+type RequestLogEntry struct {
+	Time         time.Time       `json:"time"`
+	Method       string          `json:"method"`
+	Params       json.RawMessage `json:"params,omitempty"`
+	DurationMs   float64         `json:"durationMs"`
+	ResponseSize int             `json:"responseSize"`
+	ErrorCode    int             `json:"errorCode,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	Block        uint64          `json:"block"` // latest block when the request was served
+	Client       string          `json:"client,omitempty"`
+	APIKey       string          `json:"apiKey,omitempty"`
+}
+
+// BlockHeightReader returns the latest block, which is recorded with the requests since the
+// results of most of them depend on it.
+type BlockHeightReader interface {
+	BlockHeight(ctx context.Context) (uint64, error)
+}
+
+// RequestLog writes the failed requests, and the requests slower than a threshold, to a JSONL
+// file: one RequestLogEntry per line.
+type RequestLog struct {
+	slowThreshold time.Duration // 0 logs the failed requests only
+	blockHeight   BlockHeightReader
+	logger        log.Logger
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewRequestLog(w io.Writer, slowThreshold time.Duration, blockHeight BlockHeightReader, logger log.Logger) *RequestLog {
+	return &RequestLog{slowThreshold: slowThreshold, blockHeight: blockHeight, logger: logger, w: w}
+}
+
+// OpenRequestLog appends the requests to the file at path, creating it if needed.
+func OpenRequestLog(path string, slowThreshold time.Duration, blockHeight BlockHeightReader, logger log.Logger) (*RequestLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewRequestLog(f, slowThreshold, blockHeight, logger), nil
+}
+
+// Close closes the underlying file, if any.
+func (l *RequestLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (l *RequestLog) write(entry *RequestLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		l.logger.Warn("[rpc] unable to encode request log entry", "method", entry.Method, "err", err)
+		return
+	}
+	line = append(line, '\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.w.Write(line); err != nil {
+		l.logger.Warn("[rpc] unable to write request log entry", "method", entry.Method, "err", err)
+	}
+}
+
+// countingWriter forwards the response of a streamable method to the stream of the request,
+// counting its size. It also keeps the error the method has written in the response.
+type countingWriter struct {
+	stream *jsoniter.Stream
+	size   int
+	err    error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.size += len(p)
+	return w.stream.Write(p)
+}
+
+// requestLogStream wraps the stream of the request to measure the response of the call, if the
+// request log is enabled.
+func (h *handler) requestLogStream(stream *jsoniter.Stream) (*jsoniter.Stream, *countingWriter) {
+	if h.requestLog == nil || stream == nil {
+		return stream, nil
+	}
+	counter := &countingWriter{stream: stream}
+	callStream := jsoniter.NewStream(jsoniter.ConfigDefault, counter, 4096)
+	callStream.Attachment = counter
+	return callStream, counter
+}
+
+// logRequest writes the call to the request log if it failed or was slow.
+func (h *handler) logRequest(msg *jsonrpcMessage, resp *jsonrpcMessage, callStream *jsoniter.Stream, counter *countingWriter, duration time.Duration) {
+	if counter == nil {
+		return
+	}
+	_ = callStream.Flush() // forward the rest of the streamed response
+
+	entry := &RequestLogEntry{
+		Time:         time.Now().Add(-duration),
+		Method:       msg.Method,
+		Params:       msg.Params,
+		DurationMs:   float64(duration) / float64(time.Millisecond),
+		ResponseSize: counter.size,
+		Client:       h.conn.remoteAddr(),
+	}
+	if h.apiKey != nil {
+		entry.APIKey = h.apiKey.Name
+	}
+	if resp != nil {
+		if resp.Error != nil {
+			entry.ErrorCode, entry.Error = resp.Error.Code, resp.Error.Message
+		}
+		if enc, err := json.Marshal(resp); err == nil {
+			entry.ResponseSize += len(enc)
+		}
+	} else if counter.err != nil {
+		entry.ErrorCode, entry.Error = defaultErrorCode, counter.err.Error()
+		if ec, ok := counter.err.(Error); ok {
+			entry.ErrorCode = ec.ErrorCode()
+		}
+	}
+
+	slow := h.requestLog.slowThreshold > 0 && duration > h.requestLog.slowThreshold && h.isRpcMethodNeedsCheck(msg.Method)
+	if entry.Error == "" && !slow {
+		return
+	}
+	if h.requestLog.blockHeight != nil {
+		block, err := h.requestLog.blockHeight.BlockHeight(h.rootCtx)
+		if err != nil {
+			h.logger.Debug("[rpc] unable to read the block height for the request log", "err", err)
+		}
+		entry.Block = block
+	}
+	h.requestLog.write(entry)
+}
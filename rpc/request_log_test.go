@@ -0,0 +1,131 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+type fixedBlockHeight uint64
+
+func (h fixedBlockHeight) BlockHeight(context.Context) (uint64, error) { return uint64(h), nil }
+
+type requestLogTestService struct{}
+
+func (s *requestLogTestService) Fast(n int) string { return strings.Repeat("x", n) }
+
+func (s *requestLogTestService) Slow(d time.Duration) string {
+	time.Sleep(d)
+	return "done"
+}
+
+func (s *requestLogTestService) Fail(n int) error {
+	return &InvalidParamsError{"bad n"}
+}
+
+func (s *requestLogTestService) Stream(n int, stream *jsoniter.Stream) error {
+	stream.WriteString(strings.Repeat("y", n))
+	if n == 0 {
+		return errors.New("streaming failed")
+	}
+	return nil
+}
+
+func TestRequestLog(t *testing.T) {
+	logger := log.New()
+	path := filepath.Join(t.TempDir(), "requests.jsonl")
+	requestLog, err := OpenRequestLog(path, 50*time.Millisecond, fixedBlockHeight(42), logger)
+	require.NoError(t, err)
+
+	s := NewServer(50, false /* traceRequests */, false /* debugSingleRequests */, false, logger, 0)
+	require.NoError(t, s.RegisterName("log", new(requestLogTestService)))
+	s.SetRequestLog(requestLog)
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	call := func(body string) string {
+		resp, err := ts.Client().Post(ts.URL, contentType, strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		out, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(out)
+	}
+
+	call(`{"jsonrpc":"2.0","id":1,"method":"log_fast","params":[3]}`)
+	call(`{"jsonrpc":"2.0","id":2,"method":"log_slow","params":[100000000]}`)
+	call(`{"jsonrpc":"2.0","id":3,"method":"log_fail","params":[7]}`)
+	require.Contains(t, call(`{"jsonrpc":"2.0","id":4,"method":"log_stream","params":[5]}`), `"yyyyy"`)
+	require.Contains(t, call(`{"jsonrpc":"2.0","id":5,"method":"log_stream","params":[0]}`), "streaming failed")
+	// the calls of a batch are logged one by one
+	call(`[{"jsonrpc":"2.0","id":6,"method":"log_fast","params":[1]},{"jsonrpc":"2.0","id":7,"method":"log_fail","params":[8]}]`)
+	require.NoError(t, requestLog.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []RequestLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry RequestLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, entries, 4)
+
+	slow := entries[0]
+	require.Equal(t, "log_slow", slow.Method)
+	require.JSONEq(t, `[100000000]`, string(slow.Params))
+	require.GreaterOrEqual(t, slow.DurationMs, 100.0)
+	require.Equal(t, uint64(42), slow.Block)
+	require.Empty(t, slow.Error)
+	require.Equal(t, len(`{"jsonrpc":"2.0","id":2,"result":"done"}`), slow.ResponseSize)
+	require.NotEmpty(t, slow.Client)
+
+	failed := entries[1]
+	require.Equal(t, "log_fail", failed.Method)
+	require.Equal(t, -32602, failed.ErrorCode)
+	require.Equal(t, "bad n", failed.Error)
+	require.Less(t, failed.DurationMs, 50.0)
+
+	streamed := entries[2]
+	require.Equal(t, "log_stream", streamed.Method)
+	require.JSONEq(t, `[0]`, string(streamed.Params))
+	require.Equal(t, "streaming failed", streamed.Error)
+	require.Equal(t, defaultErrorCode, streamed.ErrorCode)
+	require.Positive(t, streamed.ResponseSize)
+
+	require.Equal(t, "log_fail", entries[3].Method)
+	require.JSONEq(t, `[8]`, string(entries[3].Params))
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ResponseCachePolicy decides which calls have immutable results.
+type ResponseCachePolicy interface {
+	// PinnedBlock returns the block the result of the call depends on, if the call may be cached:
+	// the block must be canonical and finalized, so that the result never changes.
+	PinnedBlock(ctx context.Context, method string, params []json.RawMessage) (block uint64, ok bool)
+}
+
+// ResponseCache keeps the results of the calls pinned to finalized blocks, up to a total size,
+// evicting the least recently used ones.
+type ResponseCache struct {
+	policy  ResponseCachePolicy
+	maxSize int
+
+	mu         sync.Mutex
+	size       int
+	lru        *list.List               // of *responseCacheEntry, most recently used first
+	entries    map[string]*list.Element // key => element of lru
+	generation uint64                   // bumped by InvalidateFrom
+}
+
+type responseCacheEntry struct {
+	key    string
+	block  uint64
+	result json.RawMessage
+}
+
+// entrySizeOverhead approximates the memory taken by an entry beside its key and result.
+const entrySizeOverhead = 128
+
+func NewResponseCache(maxSize int, policy ResponseCachePolicy) *ResponseCache {
+	return &ResponseCache{
+		policy:  policy,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// responseCacheKey identifies a cacheable call.
+type responseCacheKey struct {
+	key        string
+	block      uint64 // the block the result is pinned to
+	generation uint64 // the generation of the cache when the call was pinned
+}
+
+// key returns the cache key of the call, if it may be cached. The params are canonicalized,
+// so that the calls differing only by the JSON formatting share the entry.
+func (c *ResponseCache) key(ctx context.Context, msg *jsonrpcMessage) (responseCacheKey, bool) {
+	var params []json.RawMessage
+	if len(msg.Params) > 0 {
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return responseCacheKey{}, false
+		}
+	}
+	// taken before the block is pinned, so that a concurrent unwind makes the result stale
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	block, ok := c.policy.PinnedBlock(ctx, msg.Method, params)
+	if !ok {
+		return responseCacheKey{}, false
+	}
+	var decoded interface{}
+	if len(msg.Params) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(msg.Params))
+		dec.UseNumber()
+		if err := dec.Decode(&decoded); err != nil {
+			return responseCacheKey{}, false
+		}
+	}
+	canonical, err := json.Marshal(decoded) // sorts the object keys and drops the whitespace
+	if err != nil {
+		return responseCacheKey{}, false
+	}
+	return responseCacheKey{key: msg.Method + string(canonical), block: block, generation: generation}, true
+}
+
+func (c *ResponseCache) get(method, key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		rpcResponseCacheCounter(method, false).Inc()
+		return nil, false
+	}
+	rpcResponseCacheCounter(method, true).Inc()
+	c.lru.MoveToFront(e)
+	return e.Value.(*responseCacheEntry).result, true
+}
+
+// add caches the result of the call, unless the cache was invalidated since the call was
+// pinned: the result may then come from an unwound block.
+func (c *ResponseCache) add(key responseCacheKey, result json.RawMessage) {
+	size := len(key.key) + len(result) + entrySizeOverhead
+	if size > c.maxSize {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key.generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[key.key]; ok {
+		return
+	}
+	c.entries[key.key] = c.lru.PushFront(&responseCacheEntry{key: key.key, block: key.block, result: result})
+	c.size += size
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+	rpcResponseCacheSize.SetInt(c.size)
+}
+
+func (c *ResponseCache) remove(e *list.Element) {
+	entry := c.lru.Remove(e).(*responseCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.key) + len(entry.result) + entrySizeOverhead
+}
+
+// InvalidateFrom drops the results pinned to the block and its descendants, which is needed
+// when the chain is unwound past the finalized block. The results of the calls in flight are
+// not cached.
+func (c *ResponseCache) InvalidateFrom(block uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for e := c.lru.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(*responseCacheEntry).block >= block {
+			c.remove(e)
+		}
+		e = next
+	}
+	rpcResponseCacheSize.SetInt(c.size)
+}
+
+// runCachedMethod serves the call from the cache, or runs it and caches its result if the call
+// is pinned to a finalized block.
+func (h *handler) runCachedMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value, stream *jsoniter.Stream) *jsonrpcMessage {
+	key, ok := h.responseCache.key(ctx, msg)
+	if !ok {
+		return h.runMethod(ctx, msg, callb, args, stream)
+	}
+	if result, ok := h.responseCache.get(msg.Method, key.key); ok {
+		return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: result}
+	}
+
+	// streamable methods write the whole response, which is buffered to extract the result
+	buf := jsoniter.NewStream(jsoniter.ConfigDefault, nil, 4096)
+	answer := h.runMethod(ctx, msg, callb, args, buf)
+	if answer == nil {
+		answer = new(jsonrpcMessage)
+		if err := json.Unmarshal(buf.Buffer(), answer); err != nil {
+			stream.Write(buf.Buffer())
+			return nil
+		}
+	}
+	if answer.Error == nil && len(answer.Result) > 0 && !bytes.Equal(answer.Result, null) {
+		h.responseCache.add(key, answer.Result)
+	}
+	return answer
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// blockParamPolicy pins the calls of cache_get to the block passed as their first param,
+// up to the finalized one.
+type blockParamPolicy struct{ finalized uint64 }
+
+func (p *blockParamPolicy) PinnedBlock(_ context.Context, method string, params []json.RawMessage) (uint64, bool) {
+	if method != "cache_get" || len(params) == 0 {
+		return 0, false
+	}
+	var block uint64
+	if err := json.Unmarshal(params[0], &block); err != nil || block > p.finalized {
+		return 0, false
+	}
+	return block, true
+}
+
+type cacheTestService struct{ calls atomic.Int32 }
+
+type cacheTestArgs struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func (s *cacheTestService) Get(block uint64, args *cacheTestArgs) string {
+	s.calls.Add(1)
+	return strings.Repeat("x", int(block))
+}
+
+func (s *cacheTestService) Fail(block uint64) error {
+	s.calls.Add(1)
+	return errors.New("failed")
+}
+
+func TestResponseCache(t *testing.T) {
+	logger := log.New()
+	service := new(cacheTestService)
+	s := NewServer(50, false /* traceRequests */, false /* debugSingleRequests */, true, logger, 100)
+	require.NoError(t, s.RegisterName("cache", service))
+	cache := NewResponseCache(1000, &blockParamPolicy{finalized: 300})
+	s.SetResponseCache(cache)
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	call := func(body string) string {
+		resp, err := ts.Client().Post(ts.URL, contentType, strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var msg jsonrpcMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&msg))
+		require.Nil(t, msg.Error)
+		require.Equal(t, `1`, string(msg.ID))
+		return string(msg.Result)
+	}
+
+	// the calls differing only by the formatting of the params share the entry
+	res := call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[10,{"a":1,"b":2}]}`)
+	require.Equal(t, `"xxxxxxxxxx"`, res)
+	require.Equal(t, res, call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[ 10, {"b":2, "a":1} ]}`))
+	require.Equal(t, int32(1), service.calls.Load())
+
+	// other params miss
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[10,{"a":2,"b":2}]}`)
+	require.Equal(t, int32(2), service.calls.Load())
+
+	// the calls not pinned to a finalized block aren't cached
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[301,{}]}`)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[301,{}]}`)
+	require.Equal(t, int32(4), service.calls.Load())
+
+	// the errors aren't cached
+	c, err := DialHTTP(ts.URL, logger)
+	require.NoError(t, err)
+	defer c.Close()
+	require.Error(t, c.Call(nil, "cache_fail", 1))
+	require.Error(t, c.Call(nil, "cache_fail", 1))
+	require.Equal(t, int32(6), service.calls.Load())
+
+	// the least recently used entries are evicted when the cache is full
+	require.Len(t, cache.entries, 2)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[10,{"a":1,"b":2}]}`)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[250,{}]}`)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[300,{}]}`)
+	require.LessOrEqual(t, cache.size, 1000)
+	require.Len(t, cache.entries, 2) // both block 10 entries are evicted
+	service.calls.Store(0)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[250,{}]}`)
+	require.Equal(t, int32(0), service.calls.Load())
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[10,{"a":2,"b":2}]}`)
+	require.Equal(t, int32(1), service.calls.Load())
+
+	// an unwind drops the entries of the unwound blocks
+	cache.InvalidateFrom(100)
+	require.Len(t, cache.entries, 1)
+	call(`{"jsonrpc":"2.0","id":1,"method":"cache_get","params":[250,{}]}`)
+	require.Equal(t, int32(2), service.calls.Load())
+}
+
+// unwindingPolicy invalidates the cache while a call is being pinned, as a concurrent unwind would.
+type unwindingPolicy struct {
+	blockParamPolicy
+	cache  *ResponseCache
+	unwind bool
+}
+
+func (p *unwindingPolicy) PinnedBlock(ctx context.Context, method string, params []json.RawMessage) (uint64, bool) {
+	block, ok := p.blockParamPolicy.PinnedBlock(ctx, method, params)
+	if ok && p.unwind {
+		p.cache.InvalidateFrom(block)
+	}
+	return block, ok
+}
+
+func TestResponseCacheInvalidatedDuringCall(t *testing.T) {
+	policy := &unwindingPolicy{blockParamPolicy: blockParamPolicy{finalized: 300}, unwind: true}
+	cache := NewResponseCache(1000, policy)
+	policy.cache = cache
+	msg := &jsonrpcMessage{Method: "cache_get", Params: json.RawMessage(`[10,{}]`)}
+
+	// the result of a call pinned before an unwind is dropped
+	key, ok := cache.key(context.Background(), msg)
+	require.True(t, ok)
+	cache.add(key, json.RawMessage(`"stale"`))
+	require.Empty(t, cache.entries)
+
+	policy.unwind = false
+	key, ok = cache.key(context.Background(), msg)
+	require.True(t, ok)
+	cache.add(key, json.RawMessage(`"fresh"`))
+	result, ok := cache.get(msg.Method, key.key)
+	require.True(t, ok)
+	require.Equal(t, `"fresh"`, string(result))
+}
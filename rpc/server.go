@@ -60,10 +60,26 @@ type Server struct {
 	traceRequests       bool // Whether to print requests at INFO level
 	debugSingleRequest  bool // Whether to print requests at INFO level
 	batchLimit          int  // Maximum number of requests in a batch
+	opts                serverOptions
 	logger              log.Logger
 	rpcSlowLogThreshold time.Duration
 }
 
+// serverOptions configure the handlers of the server connections.
+type serverOptions struct {
+	rateLimiter   *RateLimiter
+	apiKeys       *APIKeys
+	responseCache *ResponseCache
+	requestLog    *RequestLog
+}
+
+func (o serverOptions) apply(h *handler, info PeerInfo) {
+	h.rateLimiter = o.rateLimiter
+	h.apiKey = o.apiKeys.Get(info.APIKeyName)
+	h.responseCache = o.responseCache
+	h.requestLog = o.requestLog
+}
+
 // NewServer creates a new server instance with no registered handlers.
 func NewServer(batchConcurrency uint, traceRequests, debugSingleRequest, disableStreaming bool, logger log.Logger, rpcSlowLogThreshold time.Duration) *Server {
 	server := &Server{services: serviceRegistry{logger: logger}, idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1, batchConcurrency: batchConcurrency,
@@ -85,6 +101,27 @@ func (s *Server) SetBatchLimit(limit int) {
 	s.batchLimit = limit
 }
 
+// SetRateLimiter enables per-client rate limiting of the requests handled by this server
+func (s *Server) SetRateLimiter(rateLimiter *RateLimiter) {
+	s.opts.rateLimiter = rateLimiter
+}
+
+// SetAPIKeys requires the HTTP and WebSocket clients to authenticate with one of the keys,
+// which restricts the methods available to them
+func (s *Server) SetAPIKeys(apiKeys *APIKeys) {
+	s.opts.apiKeys = apiKeys
+}
+
+// SetResponseCache enables caching of the immutable responses
+func (s *Server) SetResponseCache(responseCache *ResponseCache) {
+	s.opts.responseCache = responseCache
+}
+
+// SetRequestLog enables the log of the slow and failed requests
+func (s *Server) SetRequestLog(requestLog *RequestLog) {
+	s.opts.requestLog = requestLog
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -110,7 +147,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services, s.logger)
+	c := initClient(codec, s.idgen, &s.services, s.opts, s.logger)
 	<-codec.closed()
 	c.Close()
 }
@@ -126,6 +163,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec, stre
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.methodAllowList, s.batchConcurrency, s.traceRequests, s.logger, s.rpcSlowLogThreshold)
 	h.allowSubscribe = false
+	s.opts.apply(h, PeerInfoFromContext(ctx))
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.ReadBatch()
@@ -190,6 +228,12 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// API key passed by the client as a bearer token or the "apikey" query parameter.
+	// It is not authenticated: only the keys known to the server have a meaning.
+	APIKey string
+	// Name of the API key the client authenticated with, see Server.SetAPIKeys.
+	APIKeyName string
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
@@ -62,12 +62,25 @@ func (s *Server) WebsocketHandler(allowedOrigins []string, jwtSecret []byte, com
 		if jwtSecret != nil && !CheckJwtSecret(w, r, jwtSecret) {
 			return
 		}
+		apiKey, apiKeyName := apiKeyFromRequest(r), ""
+		if s.opts.apiKeys != nil {
+			key, err := s.opts.apiKeys.authenticate(apiKey)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if key != nil {
+				apiKeyName = key.Name
+			}
+		}
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			logger.Warn("WebSocket upgrade failed", "err", err)
 			return
 		}
 		codec := NewWebsocketCodec(conn, r.Host, r.Header)
+		codec.(*websocketCodec).info.APIKey = apiKey
+		codec.(*websocketCodec).info.APIKeyName = apiKeyName
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -121,13 +121,20 @@ type btHeaderMarshaling struct {
 	ExcessBlobGas *math.HexOrDecimal64
 }
 
-func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
+// Network returns the fork the test is filled for.
+func (bt *BlockTest) Network() string {
+	return bt.json.Network
+}
+
+// Run imports the test blocks into a fresh chain and validates the outcome.
+// tb may be nil when the test is run outside of the go test framework.
+func (bt *BlockTest) Run(tb testing.TB, checkStateRoot bool) error {
 	config, ok := Forks[bt.json.Network]
 	if !ok {
 		return UnsupportedForkError{bt.json.Network}
 	}
 	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
-	m := mock.MockWithGenesisEngine(t, bt.genesis(config), engine, false, checkStateRoot)
+	m := mock.MockWithGenesisEngine(tb, bt.genesis(config), engine, false, checkStateRoot)
 	defer m.Close()
 
 	bt.br = m.BlockReader
@@ -0,0 +1,225 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto/cryptopool"
+)
+
+const (
+	// DefaultMaxBundles is the number of bundles a BundlePool holds by default
+	DefaultMaxBundles = 1024
+	// DefaultMaxBundlesPerSender is the number of bundles of a sender a BundlePool holds by default
+	DefaultMaxBundlesPerSender = 16
+	// MaxBundleTxs is the number of transactions a bundle can have
+	MaxBundleTxs = 64
+	// MaxBundleBlocksAhead is how far past the head of the chain a bundle can target a block
+	MaxBundleBlocksAhead = 64
+)
+
+var (
+	ErrEmptyBundle         = errors.New("bundle has no transactions")
+	ErrBundleTooLarge      = fmt.Errorf("bundle has more than %d transactions", MaxBundleTxs)
+	ErrUnknownBundleSender = errors.New("sender of the bundle is not recovered")
+	ErrBundlePoolFull      = errors.New("bundle pool is full")
+	ErrSenderBundlesFull   = errors.New("too many bundles of the sender")
+)
+
+// Bundle is a list of transactions that a payload includes atomically, in order, ahead of the
+// txpool transactions: all of them or none.
+type Bundle struct {
+	Txs               types.Transactions
+	BlockNumber       uint64           // the only block the bundle can be included in
+	MinTimestamp      uint64           // 0 if unbounded
+	MaxTimestamp      uint64           // 0 if unbounded
+	RevertingTxHashes []libcommon.Hash // the transactions allowed to revert, the bundle fails if any other does
+	ReplacementUuid   string           // identifies the bundle to replace or cancel it, optional
+
+	hash    libcommon.Hash
+	sender  libcommon.Address // of the first transaction, whose bundles are capped
+	arrival uint64
+}
+
+// Hash is the keccak of the hashes of the transactions, as returned by eth_callBundle.
+func (b *Bundle) Hash() libcommon.Hash {
+	return b.hash
+}
+
+// CanRevert tells whether the transaction is allowed to revert without failing the bundle.
+func (b *Bundle) CanRevert(txHash libcommon.Hash) bool {
+	for _, h := range b.RevertingTxHashes {
+		if h == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// fits tells whether the bundle can be included in the block.
+func (b *Bundle) fits(blockNumber, timestamp uint64) bool {
+	return b.BlockNumber == blockNumber &&
+		(b.MinTimestamp == 0 || timestamp >= b.MinTimestamp) &&
+		(b.MaxTimestamp == 0 || timestamp <= b.MaxTimestamp)
+}
+
+// BundlePool holds the bundles submitted by eth_sendBundle until the block they target.
+type BundlePool struct {
+	maxBundles   int
+	maxPerSender int
+	arrivals     uint64
+	bundles      map[libcommon.Hash]*Bundle
+	byUuid       map[string]libcommon.Hash
+	bySender     map[libcommon.Address]int // the number of bundles of each sender
+
+	lock sync.Mutex
+}
+
+func NewBundlePool(maxBundles, maxPerSender int) *BundlePool {
+	return &BundlePool{
+		maxBundles:   maxBundles,
+		maxPerSender: maxPerSender,
+		bundles:      make(map[libcommon.Hash]*Bundle),
+		byUuid:       make(map[string]libcommon.Hash),
+		bySender:     make(map[libcommon.Address]int),
+	}
+}
+
+// Add puts the bundle in the pool, replacing the bundle with the same ReplacementUuid if any,
+// and returns its hash. The sender of its first transaction must be recovered: it is the
+// sender of the bundle.
+func (p *BundlePool) Add(bundle *Bundle) (libcommon.Hash, error) {
+	if len(bundle.Txs) == 0 {
+		return libcommon.Hash{}, ErrEmptyBundle
+	}
+	if len(bundle.Txs) > MaxBundleTxs {
+		return libcommon.Hash{}, ErrBundleTooLarge
+	}
+	sender, ok := bundle.Txs[0].GetSender()
+	if !ok {
+		return libcommon.Hash{}, ErrUnknownBundleSender
+	}
+	bundle.sender = sender
+	hasher := cryptopool.NewLegacyKeccak256()
+	defer cryptopool.ReturnToPoolKeccak256(hasher)
+	for _, txn := range bundle.Txs {
+		hasher.Write(txn.Hash().Bytes())
+	}
+	copy(bundle.hash[:], hasher.Sum(nil))
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	replaced, replacing := p.byUuid[bundle.ReplacementUuid]
+	replacing = replacing && bundle.ReplacementUuid != ""
+	_, exists := p.bundles[bundle.hash]
+	if !exists && !replacing && len(p.bundles) >= p.maxBundles {
+		return libcommon.Hash{}, ErrBundlePoolFull
+	}
+	senderBundles := p.bySender[sender]
+	if exists {
+		senderBundles--
+	}
+	if replacing && replaced != bundle.hash && p.bundles[replaced].sender == sender {
+		senderBundles--
+	}
+	if senderBundles >= p.maxPerSender {
+		return libcommon.Hash{}, ErrSenderBundlesFull
+	}
+	if replacing {
+		p.removeLocked(replaced)
+	}
+	p.removeLocked(bundle.hash)
+	p.arrivals++
+	bundle.arrival = p.arrivals
+	p.bundles[bundle.hash] = bundle
+	p.bySender[sender]++
+	if bundle.ReplacementUuid != "" {
+		p.byUuid[bundle.ReplacementUuid] = bundle.hash
+	}
+	return bundle.hash, nil
+}
+
+// Cancel removes the bundle with the ReplacementUuid, and tells whether there was one.
+func (p *BundlePool) Cancel(replacementUuid string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	hash, ok := p.byUuid[replacementUuid]
+	if !ok {
+		return false
+	}
+	p.removeLocked(hash)
+	return true
+}
+
+// Remove drops the bundle, e.g. when its execution fails its constraints.
+func (p *BundlePool) Remove(hash libcommon.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.removeLocked(hash)
+}
+
+func (p *BundlePool) removeLocked(hash libcommon.Hash) {
+	bundle, ok := p.bundles[hash]
+	if !ok {
+		return
+	}
+	delete(p.bundles, hash)
+	if p.bySender[bundle.sender]--; p.bySender[bundle.sender] == 0 {
+		delete(p.bySender, bundle.sender)
+	}
+	if bundle.ReplacementUuid != "" && p.byUuid[bundle.ReplacementUuid] == hash {
+		delete(p.byUuid, bundle.ReplacementUuid)
+	}
+}
+
+// Bundles returns the bundles that can be included in the block, in the order they were
+// submitted. The bundles targeting earlier blocks are dropped. They stay in the pool once
+// included, since the payload they are included in may not make it into the chain.
+func (p *BundlePool) Bundles(blockNumber, timestamp uint64) []*Bundle {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var bundles []*Bundle
+	for hash, bundle := range p.bundles {
+		if bundle.BlockNumber < blockNumber {
+			p.removeLocked(hash)
+			continue
+		}
+		if bundle.fits(blockNumber, timestamp) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	slices.SortFunc(bundles, func(a, b *Bundle) int { return cmp.Compare(a.arrival, b.arrival) })
+	return bundles
+}
+
+// Len returns the number of the bundles in the pool.
+func (p *BundlePool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.bundles)
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+)
+
+var testSender = libcommon.HexToAddress("0x01")
+
+func testBundle(blockNumber uint64, nonces ...uint64) *Bundle {
+	return testSenderBundle(testSender, blockNumber, nonces...)
+}
+
+func testSenderBundle(sender libcommon.Address, blockNumber uint64, nonces ...uint64) *Bundle {
+	b := &Bundle{BlockNumber: blockNumber}
+	for _, nonce := range nonces {
+		txn := &types.LegacyTx{CommonTx: types.CommonTx{Nonce: nonce}}
+		txn.SetSender(sender)
+		b.Txs = append(b.Txs, txn)
+	}
+	return b
+}
+
+func TestBundlePool(t *testing.T) {
+	t.Parallel()
+	p := NewBundlePool(3, DefaultMaxBundlesPerSender)
+
+	_, err := p.Add(testBundle(10))
+	require.ErrorIs(t, err, ErrEmptyBundle)
+
+	first := testBundle(10, 1, 2)
+	hash, err := p.Add(first)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(first.Txs[0].Hash().Bytes(), first.Txs[1].Hash().Bytes()), hash)
+
+	timed := testBundle(10, 3)
+	timed.MinTimestamp, timed.MaxTimestamp = 100, 200
+	_, err = p.Add(timed)
+	require.NoError(t, err)
+	later := testBundle(11, 4)
+	_, err = p.Add(later)
+	require.NoError(t, err)
+
+	_, err = p.Add(testBundle(12, 5))
+	require.ErrorIs(t, err, ErrBundlePoolFull)
+
+	assert.Equal(t, []*Bundle{first, timed}, p.Bundles(10, 150))
+	assert.Equal(t, []*Bundle{first}, p.Bundles(10, 201))
+	assert.Equal(t, []*Bundle{first}, p.Bundles(10, 99))
+
+	// the bundles of the past blocks are dropped
+	assert.Equal(t, []*Bundle{later}, p.Bundles(11, 150))
+	assert.Equal(t, 1, p.Len())
+
+	p.Remove(later.Hash())
+	assert.Equal(t, 0, p.Len())
+}
+
+func TestBundlePoolReplacement(t *testing.T) {
+	t.Parallel()
+	p := NewBundlePool(1, DefaultMaxBundlesPerSender)
+
+	original := testBundle(10, 1)
+	original.ReplacementUuid = "uuid"
+	_, err := p.Add(original)
+	require.NoError(t, err)
+
+	// a full pool still takes the replacements
+	replacement := testBundle(10, 2)
+	replacement.ReplacementUuid = "uuid"
+	_, err = p.Add(replacement)
+	require.NoError(t, err)
+	assert.Equal(t, []*Bundle{replacement}, p.Bundles(10, 0))
+
+	assert.False(t, p.Cancel("other"))
+	assert.True(t, p.Cancel("uuid"))
+	assert.False(t, p.Cancel("uuid"))
+	assert.Empty(t, p.Bundles(10, 0))
+}
+
+func TestBundleCanRevert(t *testing.T) {
+	t.Parallel()
+	b := testBundle(10, 1, 2)
+	b.RevertingTxHashes = append(b.RevertingTxHashes, b.Txs[1].Hash())
+	assert.False(t, b.CanRevert(b.Txs[0].Hash()))
+	assert.True(t, b.CanRevert(b.Txs[1].Hash()))
+}
+
+func TestBundlePoolLimits(t *testing.T) {
+	t.Parallel()
+	p := NewBundlePool(DefaultMaxBundles, 2)
+
+	_, err := p.Add(testBundle(10, make([]uint64, MaxBundleTxs+1)...))
+	require.ErrorIs(t, err, ErrBundleTooLarge)
+	_, err = p.Add(&Bundle{BlockNumber: 10, Txs: types.Transactions{&types.LegacyTx{}}})
+	require.ErrorIs(t, err, ErrUnknownBundleSender)
+
+	first := testBundle(10, 1)
+	first.ReplacementUuid = "uuid"
+	_, err = p.Add(first)
+	require.NoError(t, err)
+	_, err = p.Add(testBundle(10, 2))
+	require.NoError(t, err)
+	_, err = p.Add(testBundle(10, 3))
+	require.ErrorIs(t, err, ErrSenderBundlesFull)
+
+	// the other senders, the replacements and the bundles already in the pool are still taken
+	_, err = p.Add(testSenderBundle(libcommon.HexToAddress("0x02"), 10, 3))
+	require.NoError(t, err)
+	replacement := testBundle(10, 4)
+	replacement.ReplacementUuid = "uuid"
+	_, err = p.Add(replacement)
+	require.NoError(t, err)
+	_, err = p.Add(testBundle(10, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Len())
+
+	// a sender can send again once its bundles are gone
+	assert.True(t, p.Cancel("uuid"))
+	_, err = p.Add(testBundle(10, 3))
+	require.NoError(t, err)
+	assert.Empty(t, p.Bundles(11, 0))
+	_, err = p.Add(testBundle(11, 1))
+	require.NoError(t, err)
+	_, err = p.Add(testBundle(11, 2))
+	require.NoError(t, err)
+}
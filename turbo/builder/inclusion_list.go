@@ -0,0 +1,107 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"sync"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/types"
+)
+
+// maxInclusionReports is the number of the most recent payloads whose inclusion reports are kept
+const maxInclusionReports = 128
+
+// InclusionResult tells whether a transaction of the inclusion list made it into the payload,
+// and why not if it didn't.
+type InclusionResult struct {
+	Hash     libcommon.Hash `json:"hash"`
+	Included bool           `json:"included"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// InclusionListStore keeps the transactions that the next payload must include ahead of the
+// txpool ones, and the inclusion reports of the recent payloads.
+type InclusionListStore struct {
+	txs     types.Transactions
+	reports map[uint64][]InclusionResult
+
+	lock sync.Mutex
+}
+
+func NewInclusionListStore() *InclusionListStore {
+	return &InclusionListStore{reports: make(map[uint64][]InclusionResult)}
+}
+
+// Set replaces the pending inclusion list, an empty list clears it.
+func (s *InclusionListStore) Set(txs types.Transactions) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(txs) == 0 {
+		s.txs = nil
+		return
+	}
+	s.txs = append(types.Transactions(nil), txs...)
+}
+
+// Pending returns the inclusion list of the next payload.
+func (s *InclusionListStore) Pending() types.Transactions {
+	if s == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.txs
+}
+
+// Consume clears the pending inclusion list once a payload is built with it, unless it has
+// been replaced in the meantime.
+func (s *InclusionListStore) Consume(txs types.Transactions) {
+	if s == nil || len(txs) == 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.txs) == len(txs) && &s.txs[0] == &txs[0] {
+		s.txs = nil
+	}
+}
+
+// AddReport records the outcome of the inclusion list of the payload.
+func (s *InclusionListStore) AddReport(payloadId uint64, report []InclusionResult) {
+	if s == nil || report == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reports[payloadId] = report
+	if len(s.reports) > maxInclusionReports {
+		ids := libcommon.SortedKeys(s.reports)
+		for _, id := range ids[:len(ids)-maxInclusionReports] {
+			delete(s.reports, id)
+		}
+	}
+}
+
+// Report returns the outcome of the inclusion list of the payload, if it was built with one.
+func (s *InclusionListStore) Report(payloadId uint64) ([]InclusionResult, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	report, ok := s.reports[payloadId]
+	return report, ok
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/erigontech/erigon/core/types"
+)
+
+func TestInclusionList(t *testing.T) {
+	t.Parallel()
+	s := NewInclusionListStore()
+	assert.Empty(t, s.Pending())
+
+	txs := types.Transactions{&types.LegacyTx{CommonTx: types.CommonTx{Nonce: 1}}, &types.LegacyTx{CommonTx: types.CommonTx{Nonce: 2}}}
+	s.Set(txs)
+	pending := s.Pending()
+	assert.Equal(t, txs, pending)
+
+	// replaced before the payload was built with it: the new list stays
+	replacement := types.Transactions{&types.LegacyTx{CommonTx: types.CommonTx{Nonce: 3}}}
+	s.Set(replacement)
+	s.Consume(pending)
+	assert.Equal(t, replacement, s.Pending())
+
+	s.Consume(s.Pending())
+	assert.Empty(t, s.Pending())
+
+	s.Set(txs)
+	s.Set(nil)
+	assert.Empty(t, s.Pending())
+
+	var nilStore *InclusionListStore
+	assert.Empty(t, nilStore.Pending())
+	nilStore.Consume(txs)
+	nilStore.AddReport(1, []InclusionResult{{Included: true}})
+}
+
+func TestInclusionReports(t *testing.T) {
+	t.Parallel()
+	s := NewInclusionListStore()
+	s.AddReport(1, nil) // the payload had no inclusion list
+	_, ok := s.Report(1)
+	assert.False(t, ok)
+
+	for id := uint64(1); id <= maxInclusionReports+2; id++ {
+		s.AddReport(id, []InclusionResult{{Included: id%2 == 0, Reason: "nonce too low"}})
+	}
+	_, ok = s.Report(2)
+	assert.False(t, ok, "the oldest reports are evicted")
+	report, ok := s.Report(maxInclusionReports + 2)
+	assert.True(t, ok)
+	assert.Equal(t, []InclusionResult{{Included: true, Reason: "nonce too low"}}, report)
+}
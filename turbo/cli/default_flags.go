@@ -83,6 +83,10 @@ var DefaultFlags = []cli.Flag{
 	&utils.RpcStreamingDisableFlag,
 	&utils.DBReadConcurrencyFlag,
 	&utils.RpcAccessListFlag,
+	&utils.RpcRateLimitFlag,
+	&utils.RpcAPIKeysFlag,
+	&utils.RpcRequestLogFlag,
+	&utils.RpcResponseCacheSizeFlag,
 	&utils.RpcTraceCompatFlag,
 	&utils.RpcGasCapFlag,
 	&utils.RpcBatchLimit,
@@ -125,6 +129,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.ListenPortFlag,
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
+	&utils.P2pSnapServeFlag,
 	&utils.NATFlag,
 	&utils.NoDiscoverFlag,
 	&utils.DiscoveryV5Flag,
@@ -132,6 +137,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.NodeKeyFileFlag,
 	&utils.NodeKeyHexFlag,
 	&utils.DNSDiscoveryFlag,
+	&utils.DiscoveryRequireEthENRFlag,
 	&utils.BootnodesFlag,
 	&utils.StaticPeersFlag,
 	&utils.TrustedPeersFlag,
@@ -158,6 +164,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.MinerNoVerfiyFlag,
 	&utils.MinerSigningKeyFileFlag,
 	&utils.MinerRecommitIntervalFlag,
+	&utils.MinerTxOrderingFlag,
 	&utils.SentryAddrFlag,
 	&utils.SentryLogPeerInfoFlag,
 	&utils.DownloaderAddrFlag,
@@ -184,6 +191,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinCheckpointSyncUrlFlag,
 	&utils.SentinelAddrFlag,
 	&utils.SentinelPortFlag,
+	&utils.SentinelDebugAddrFlag,
+	&utils.SentinelScoreParamsFlag,
 	&utils.SentinelBootnodes,
 
 	&utils.OtsSearchMaxCapFlag,
@@ -216,8 +225,10 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinDisableBlobPruningFlag,
 	&utils.CaplinDisableCheckpointSyncFlag,
 	&utils.CaplinArchiveFlag,
+	&utils.CaplinStatesCheckpointIntervalFlag,
 	&utils.CaplinMevRelayUrl,
 	&utils.CaplinValidatorMonitorFlag,
+	&utils.CaplinGossipCaptureDirFlag,
 	&utils.CaplinCustomConfigFlag,
 	&utils.CaplinCustomGenesisFlag,
 
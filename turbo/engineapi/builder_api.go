@@ -0,0 +1,87 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+)
+
+// BuilderAPI lets the operator of a private chain force transactions into the payloads built
+// for the consensus layer. It is served next to the engine API, behind its JWT authentication.
+type BuilderAPI struct {
+	config        *chain.Config
+	inclusionList *builder.InclusionListStore
+	logger        log.Logger
+}
+
+func NewBuilderAPI(config *chain.Config, inclusionList *builder.InclusionListStore, logger log.Logger) *BuilderAPI {
+	return &BuilderAPI{config: config, inclusionList: inclusionList, logger: logger}
+}
+
+// SetInclusionList replaces the raw transactions that the next payload includes, in order,
+// ahead of the txpool ones. It is taken by the next forkchoiceUpdated that starts a payload;
+// an empty list clears it. It returns the hashes of the transactions.
+func (api *BuilderAPI) SetInclusionList(ctx context.Context, rawTxs []hexutility.Bytes) ([]libcommon.Hash, error) {
+	chainID, _ := uint256.FromBig(api.config.ChainID)
+	txs := make(types.Transactions, 0, len(rawTxs))
+	hashes := make([]libcommon.Hash, 0, len(rawTxs))
+	for i, raw := range rawTxs {
+		txn, err := types.DecodeWrappedTransaction(raw)
+		if err != nil {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: %v", i, err)}
+		}
+		if !txn.GetChainID().IsZero() && txn.GetChainID().Cmp(chainID) != 0 {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: invalid chain id %d", i, txn.GetChainID())}
+		}
+		// the payload has to carry the blobs of its blob transactions
+		if _, ok := txn.(*types.BlobTxWrapper); txn.Type() == types.BlobTxType && !ok {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: blob transaction without blobs", i)}
+		}
+		txs = append(txs, txn)
+		hashes = append(hashes, txn.Hash())
+	}
+	api.inclusionList.Set(txs)
+	api.logger.Info("[builder] Inclusion list set", "txs", len(txs))
+	return hashes, nil
+}
+
+// GetInclusionReport tells which transactions of its inclusion list the payload includes, and
+// why the others were left out. The report is ready once the payload is built.
+func (api *BuilderAPI) GetInclusionReport(ctx context.Context, payloadID hexutility.Bytes) ([]builder.InclusionResult, error) {
+	if len(payloadID) != 8 {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("invalid payload id length %d", len(payloadID))}
+	}
+	report, ok := api.inclusionList.Report(binary.BigEndian.Uint64(payloadID))
+	if !ok {
+		return nil, &engine_helpers.UnknownPayloadErr
+	}
+	return report, nil
+}
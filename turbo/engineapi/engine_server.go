@@ -32,6 +32,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	types2 "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -46,6 +47,7 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/ethutils"
 	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/builder"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_block_downloader"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
@@ -68,6 +70,9 @@ type EngineServer struct {
 	test             bool
 	caplin           bool // we need to send errors for caplin.
 	executionService execution.ExecutionClient
+	txpool           txpool.TxpoolClient         // set in Start
+	inclusionList    *builder.InclusionListStore // forced transactions of the next payload
+	bundlePool       *builder.BundlePool         // the bundles of eth_sendBundle
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
 	lock    sync.Mutex
@@ -78,7 +83,8 @@ const fcuTimeout = 1000 // according to mathematics: 1000 millisecods = 1 second
 
 func NewEngineServer(logger log.Logger, config *chain.Config, executionService execution.ExecutionClient,
 	hd *headerdownload.HeaderDownload,
-	blockDownloader *engine_block_downloader.EngineBlockDownloader, caplin, test, proposing bool) *EngineServer {
+	blockDownloader *engine_block_downloader.EngineBlockDownloader, caplin, test, proposing bool,
+	inclusionList *builder.InclusionListStore, bundlePool *builder.BundlePool) *EngineServer {
 	chainRW := eth1_chain_reader.NewChainReaderEth1(config, executionService, fcuTimeout)
 	return &EngineServer{
 		logger:           logger,
@@ -89,6 +95,8 @@ func NewEngineServer(logger log.Logger, config *chain.Config, executionService e
 		proposing:        proposing,
 		hd:               hd,
 		caplin:           caplin,
+		inclusionList:    inclusionList,
+		bundlePool:       bundlePool,
 	}
 }
 
@@ -104,6 +112,8 @@ func (e *EngineServer) Start(
 	txPool txpool.TxpoolClient,
 	mining txpool.MiningClient,
 ) {
+	e.txpool = txPool
+
 	base := jsonrpc.NewBaseApi(filters, stateCache, blockReader, httpConfig.WithDatadir, httpConfig.EvmCallTimeout, engineReader, httpConfig.Dirs, nil)
 
 	ethImpl := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, httpConfig.Gascap, httpConfig.Feecap, httpConfig.ReturnDataLimit, httpConfig.AllowUnprotectedTxs, httpConfig.MaxGetProofRewindBlockCount, httpConfig.WebsocketSubscribeLogsChannelSize, e.logger)
@@ -122,6 +132,22 @@ func (e *EngineServer) Start(
 			Service:   EngineAPI(e),
 			Version:   "1.0",
 		}}
+	if e.proposing && e.inclusionList != nil {
+		apiList = append(apiList, rpc.API{
+			Namespace: "builder",
+			Public:    true,
+			Service:   NewBuilderAPI(e.config, e.inclusionList, e.logger),
+			Version:   "1.0",
+		})
+	}
+	if e.proposing && e.bundlePool != nil {
+		apiList = append(apiList, rpc.API{
+			Namespace: "eth",
+			Public:    true,
+			Service:   jsonrpc.BundleAPI(jsonrpc.NewBundleAPI(base, db, e.bundlePool)),
+			Version:   "1.0",
+		})
+	}
 
 	if err := cli.StartRpcServerWithJwtAuthentication(ctx, httpConfig, apiList, e.logger); err != nil {
 		e.logger.Error(err.Error())
@@ -784,6 +810,35 @@ func (e *EngineServer) GetPayloadBodiesByRangeV2(ctx context.Context, start, cou
 	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.ElectraVersion)
 }
 
+// Returns the blobs and proofs of pooled blob transactions referenced by their versioned hashes,
+// with null entries for the blobs that are not in the pool
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_getblobsv1
+func (e *EngineServer) GetBlobsV1(ctx context.Context, blobHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	if len(blobHashes) > 128 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+	if e.txpool == nil {
+		return nil, errors.New("txpool is not available")
+	}
+	req := &txpool.GetBlobsRequest{BlobHashes: make([]*types2.H256, len(blobHashes))}
+	for i, hash := range blobHashes {
+		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(hash)
+	}
+	reply, err := e.txpool.GetBlobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*engine_types.BlobAndProofV1, len(blobHashes))
+	for i := range resp {
+		if i >= len(reply.Blobs) || len(reply.Blobs[i]) == 0 {
+			continue
+		}
+		resp[i] = &engine_types.BlobAndProofV1{Blob: reply.Blobs[i], Proof: reply.Proofs[i]}
+	}
+	return resp, nil
+}
+
 var ourCapabilities = []string{
 	"engine_forkchoiceUpdatedV1",
 	"engine_forkchoiceUpdatedV2",
@@ -801,6 +856,7 @@ var ourCapabilities = []string{
 	"engine_getPayloadBodiesByHashV2",
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getPayloadBodiesByRangeV2",
+	"engine_getBlobsV1",
 }
 
 func (e *EngineServer) ExchangeCapabilities(fromCl []string) []string {
@@ -84,10 +84,14 @@ func (e *EthereumExecutionModule) AssembleBlock(ctx context.Context, req *execut
 
 	// TODO(racytech): add requests (Pectra)
 
+	inclusionList := e.inclusionList.Pending()
+
 	// First check if we're already building a block with the requested parameters
 	if e.lastParameters != nil {
 		param.PayloadId = e.lastParameters.PayloadId
-		if reflect.DeepEqual(e.lastParameters, &param) {
+		// a pending inclusion list needs a new payload, otherwise the duplicate keeps the list it was built with
+		param.InclusionList = e.lastParameters.InclusionList
+		if len(inclusionList) == 0 && reflect.DeepEqual(e.lastParameters, &param) {
 			e.logger.Info("[ForkChoiceUpdated] duplicate build request")
 			return &execution.AssembleBlockResponse{
 				Id:   e.lastParameters.PayloadId,
@@ -101,10 +105,12 @@ func (e *EthereumExecutionModule) AssembleBlock(ctx context.Context, req *execut
 
 	e.nextPayloadId++
 	param.PayloadId = e.nextPayloadId
+	param.InclusionList = inclusionList
+	e.inclusionList.Consume(inclusionList)
 	e.lastParameters = &param
 
 	e.builders[e.nextPayloadId] = builder.NewBlockBuilder(e.builderFunc, &param)
-	e.logger.Info("[ForkChoiceUpdated] BlockBuilder added", "payload", e.nextPayloadId)
+	e.logger.Info("[ForkChoiceUpdated] BlockBuilder added", "payload", e.nextPayloadId, "inclusionList", len(inclusionList))
 
 	return &execution.AssembleBlockResponse{
 		Id:   e.nextPayloadId,
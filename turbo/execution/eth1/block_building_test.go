@@ -0,0 +1,145 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/engineapi"
+)
+
+func TestAssembleBlockInclusionList(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	config := params.TestChainConfig
+	inclusionList := builder.NewInclusionListStore()
+	builderAPI := engineapi.NewBuilderAPI(config, inclusionList, logger)
+
+	// the builder includes the first transaction of the list and leaves out the others, like
+	// the mining stages would with transactions of a nonce that is too low
+	var built []types.Transactions
+	buildFunc := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		built = append(built, param.InclusionList)
+		var txs types.Transactions
+		var receipts types.Receipts
+		report := make([]builder.InclusionResult, 0, len(param.InclusionList))
+		for i, txn := range param.InclusionList {
+			if i == 0 {
+				txs = append(txs, txn)
+				receipts = append(receipts, &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: params.TxGas})
+				report = append(report, builder.InclusionResult{Hash: txn.Hash(), Included: true})
+				continue
+			}
+			report = append(report, builder.InclusionResult{Hash: txn.Hash(), Reason: core.ErrNonceTooLow.Error()})
+		}
+		inclusionList.AddReport(param.PayloadId, report)
+		header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0), GasLimit: params.GenesisGasLimit, BaseFee: big.NewInt(1)}
+		return &types.BlockWithReceipts{Block: types.NewBlock(header, txs, nil, receipts, nil, nil), Receipts: receipts}, nil
+	}
+	e := NewEthereumExecutionModule(nil, nil, nil, nil, config, buildFunc, inclusionList, nil, nil, nil, logger, nil, ethconfig.Sync{}, ctx)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSigner(config)
+	var txs types.Transactions
+	for _, nonce := range []uint64{0, 0} {
+		txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.HexToAddress("0xbeef"), uint256.NewInt(uint64(len(txs))), params.TxGas, uint256.NewInt(1), nil), *signer, key)
+		require.NoError(t, err)
+		txs = append(txs, txn)
+	}
+	rawTxs, err := types.MarshalTransactionsBinary(txs)
+	require.NoError(t, err)
+	hashes, err := builderAPI.SetInclusionList(ctx, []hexutility.Bytes{rawTxs[0], rawTxs[1]})
+	require.NoError(t, err)
+	require.Equal(t, []libcommon.Hash{txs[0].Hash(), txs[1].Hash()}, hashes)
+
+	req := &execution.AssembleBlockRequest{
+		ParentHash:            gointerfaces.ConvertHashToH256(libcommon.HexToHash("0x01")),
+		Timestamp:             1,
+		PrevRandao:            gointerfaces.ConvertHashToH256(libcommon.Hash{}),
+		SuggestedFeeRecipient: gointerfaces.ConvertAddressToH160(libcommon.Address{}),
+	}
+	assembled := func() uint64 {
+		resp, err := e.AssembleBlock(ctx, req)
+		require.NoError(t, err)
+		require.False(t, resp.Busy)
+		return resp.Id
+	}
+	payloadId := assembled()
+
+	// the payload has taken the list
+	require.Empty(t, inclusionList.Pending())
+	block, err := e.GetAssembledBlock(ctx, &execution.GetAssembledBlockRequest{Id: payloadId})
+	require.NoError(t, err)
+	require.Len(t, block.Data.ExecutionPayload.Transactions, 1)
+	require.Len(t, built, 1)
+	require.Len(t, built[0], 2)
+	require.Equal(t, txs[0].Hash(), built[0][0].Hash())
+	require.Equal(t, txs[1].Hash(), built[0][1].Hash())
+
+	id := make(hexutility.Bytes, 8)
+	binary.BigEndian.PutUint64(id, payloadId)
+	report, err := builderAPI.GetInclusionReport(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, []builder.InclusionResult{
+		{Hash: txs[0].Hash(), Included: true},
+		{Hash: txs[1].Hash(), Reason: core.ErrNonceTooLow.Error()},
+	}, report)
+
+	// the same parameters without a new list are a duplicate of the payload
+	require.Equal(t, payloadId, assembled())
+
+	// a new list needs a new payload even with the same parameters
+	_, err = builderAPI.SetInclusionList(ctx, []hexutility.Bytes{rawTxs[1]})
+	require.NoError(t, err)
+	nextPayloadId := assembled()
+	require.NotEqual(t, payloadId, nextPayloadId)
+	require.Empty(t, inclusionList.Pending())
+	_, err = e.GetAssembledBlock(ctx, &execution.GetAssembledBlockRequest{Id: nextPayloadId})
+	require.NoError(t, err)
+	require.Len(t, built, 2)
+	require.Len(t, built[1], 1)
+	require.Equal(t, txs[1].Hash(), built[1][0].Hash())
+
+	binary.BigEndian.PutUint64(id, nextPayloadId)
+	report, err = builderAPI.GetInclusionReport(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, []builder.InclusionResult{{Hash: txs[1].Hash(), Included: true}}, report)
+
+	// a payload without a list has no report
+	binary.BigEndian.PutUint64(id, nextPayloadId+1)
+	_, err = builderAPI.GetInclusionReport(ctx, id)
+	require.Error(t, err)
+}
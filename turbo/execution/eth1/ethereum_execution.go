@@ -71,6 +71,7 @@ type EthereumExecutionModule struct {
 	lastParameters *core.BlockBuilderParameters
 	builderFunc    builder.BlockBuilderFunc
 	builders       map[uint64]*builder.BlockBuilder
+	inclusionList  *builder.InclusionListStore // nil if the payloads have no inclusion list
 
 	// Changes accumulator
 	hook                *stages.Hook
@@ -95,6 +96,7 @@ type EthereumExecutionModule struct {
 func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.RwDB,
 	executionPipeline *stagedsync.Sync, forkValidator *engine_helpers.ForkValidator,
 	config *chain.Config, builderFunc builder.BlockBuilderFunc,
+	inclusionList *builder.InclusionListStore,
 	hook *stages.Hook, accumulator *shards.Accumulator,
 	stateChangeConsumer shards.StateChangeConsumer,
 	logger log.Logger, engine consensus.Engine,
@@ -109,6 +111,7 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.RwDB
 		forkValidator:       forkValidator,
 		builders:            make(map[uint64]*builder.BlockBuilder),
 		builderFunc:         builderFunc,
+		inclusionList:       inclusionList,
 		config:              config,
 		semaphore:           semaphore.NewWeighted(1),
 		hook:                hook,
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon/p2p"
 
@@ -38,6 +39,21 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// PeerBans returns the nodes banned for misbehaviour.
+	PeerBans(ctx context.Context) ([]*PeerBan, error)
+
+	// ClearPeerBans lifts the bans of the given node IDs, or of all nodes if none are
+	// given, and returns the number of lifted bans.
+	ClearPeerBans(ctx context.Context, ids []string) (hexutil.Uint64, error)
+}
+
+// PeerBan is a node banned by the sentry.
+type PeerBan struct {
+	ID          string         `json:"id"`
+	Reason      string         `json:"reason"`
+	Score       float64        `json:"score"`       // penalties after decay
+	BannedUntil hexutil.Uint64 `json:"bannedUntil"` // unix time
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -79,3 +95,28 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) PeerBans(ctx context.Context) ([]*PeerBan, error) {
+	result, err := api.ethBackend.PeerBans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bans := make([]*PeerBan, 0, len(result.Bans))
+	for _, ban := range result.Bans {
+		bans = append(bans, &PeerBan{
+			ID:          ban.Id,
+			Reason:      ban.Reason,
+			Score:       ban.Score,
+			BannedUntil: hexutil.Uint64(ban.BannedUntil),
+		})
+	}
+	return bans, nil
+}
+
+func (api *AdminAPIImpl) ClearPeerBans(ctx context.Context, ids []string) (hexutil.Uint64, error) {
+	result, err := api.ethBackend.ClearPeerBans(ctx, &remote.ClearPeerBansRequest{Ids: ids})
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(result.Cleared), nil
+}
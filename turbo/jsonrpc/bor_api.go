@@ -17,9 +17,11 @@
 package jsonrpc
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 
 	"github.com/erigontech/erigon/consensus"
@@ -41,6 +43,15 @@ type BorAPI interface {
 	GetSnapshotProposer(blockNrOrHash *rpc.BlockNumberOrHash) (common.Address, error)
 	GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOrHash) (BlockSigners, error)
 	GetRootHash(start uint64, end uint64) (string, error)
+
+	// Bor finality related (see ./bor_finality.go)
+	GetLatestMilestone() (*WhitelistedBlock, error)
+	GetLatestCheckpoint() (*WhitelistedBlock, error)
+
+	// Bor state sync events related (see ./bor_state_sync.go)
+	GetStateSyncEvents(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*StateSyncEvent, error)
+	GetStateSyncEventById(ctx context.Context, id hexutil.Uint64) (*StateSyncEvent, error)
+	StateSyncEvents(ctx context.Context) (*rpc.Subscription, error)
 }
 
 // BorImpl is implementation of the BorAPI interface
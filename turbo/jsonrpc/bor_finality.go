@@ -0,0 +1,66 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+)
+
+// WhitelistedBlock is the end block of the latest milestone or checkpoint in the
+// finality whitelist.
+type WhitelistedBlock struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// GetLatestMilestone returns the end block of the latest whitelisted milestone, or nil
+// if no milestone has been whitelisted yet. Its number is the "finalized" block.
+func (api *BorImpl) GetLatestMilestone() (*WhitelistedBlock, error) {
+	service := whitelist.GetWhitelistingService()
+	if service == nil {
+		return nil, errors.New("only available in Bor engine")
+	}
+
+	return newWhitelistedBlock(service.GetWhitelistedMilestone()), nil
+}
+
+// GetLatestCheckpoint returns the end block of the latest whitelisted checkpoint, or
+// nil if no checkpoint has been whitelisted yet. Its number is the "safe" block.
+func (api *BorImpl) GetLatestCheckpoint() (*WhitelistedBlock, error) {
+	service := whitelist.GetWhitelistingService()
+	if service == nil {
+		return nil, errors.New("only available in Bor engine")
+	}
+
+	return newWhitelistedBlock(service.GetWhitelistedCheckpoint()), nil
+}
+
+func newWhitelistedBlock(doExist bool, number uint64, hash common.Hash) *WhitelistedBlock {
+	if !doExist {
+		return nil
+	}
+
+	return &WhitelistedBlock{
+		Number: hexutil.Uint64(number),
+		Hash:   hash,
+	}
+}
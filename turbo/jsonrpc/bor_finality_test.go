@@ -0,0 +1,102 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+func TestBorFinalizedAndSafeBlocks(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ctx := context.Background()
+	borApi := NewBorAPI(newBaseApiForTest(m), m.DB)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+
+	_, err := borApi.GetLatestMilestone()
+	require.Error(t, err)
+	_, err = borApi.GetLatestCheckpoint()
+	require.Error(t, err)
+
+	whitelist.RegisterService(m.DB)
+	t.Cleanup(whitelist.UnregisterService)
+	service := whitelist.GetWhitelistingService()
+
+	t.Run("without milestone", func(t *testing.T) {
+		milestone, err := borApi.GetLatestMilestone()
+		require.NoError(t, err)
+		require.Nil(t, milestone)
+		checkpoint, err := borApi.GetLatestCheckpoint()
+		require.NoError(t, err)
+		require.Nil(t, checkpoint)
+
+		_, err = ethApi.GetBlockByNumber(ctx, rpc.FinalizedBlockNumber, false)
+		require.ErrorIs(t, err, rpchelper.UnknownBlockError)
+		_, err = ethApi.GetBlockByNumber(ctx, rpc.SafeBlockNumber, false)
+		require.ErrorIs(t, err, rpchelper.UnknownBlockError)
+	})
+
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	checkpointBlock, err := m.BlockReader.BlockByNumber(ctx, tx, 2)
+	require.NoError(t, err)
+	milestoneBlock, err := m.BlockReader.BlockByNumber(ctx, tx, 5)
+	require.NoError(t, err)
+	tx.Rollback()
+
+	t.Run("with checkpoint", func(t *testing.T) {
+		service.ProcessCheckpoint(checkpointBlock.NumberU64(), checkpointBlock.Hash())
+
+		checkpoint, err := borApi.GetLatestCheckpoint()
+		require.NoError(t, err)
+		require.Equal(t, &WhitelistedBlock{Number: hexutil.Uint64(checkpointBlock.NumberU64()), Hash: checkpointBlock.Hash()}, checkpoint)
+
+		// without a milestone the checkpoint is final
+		finalized, err := ethApi.GetBlockByNumber(ctx, rpc.FinalizedBlockNumber, false)
+		require.NoError(t, err)
+		require.Equal(t, checkpointBlock.Hash(), finalized["hash"])
+		safe, err := ethApi.GetBlockByNumber(ctx, rpc.SafeBlockNumber, false)
+		require.NoError(t, err)
+		require.Equal(t, checkpointBlock.Hash(), safe["hash"])
+	})
+
+	t.Run("with milestone", func(t *testing.T) {
+		service.ProcessMilestone(milestoneBlock.NumberU64(), milestoneBlock.Hash())
+
+		milestone, err := borApi.GetLatestMilestone()
+		require.NoError(t, err)
+		require.Equal(t, &WhitelistedBlock{Number: hexutil.Uint64(milestoneBlock.NumberU64()), Hash: milestoneBlock.Hash()}, milestone)
+
+		finalized, err := ethApi.GetBlockByNumber(ctx, rpc.FinalizedBlockNumber, false)
+		require.NoError(t, err)
+		require.Equal(t, milestoneBlock.Hash(), finalized["hash"])
+		safe, err := ethApi.GetBlockByNumber(ctx, rpc.SafeBlockNumber, false)
+		require.NoError(t, err)
+		require.Equal(t, checkpointBlock.Hash(), safe["hash"])
+	})
+}
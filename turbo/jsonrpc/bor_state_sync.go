@@ -0,0 +1,272 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/common/debug"
+	"github.com/erigontech/erigon/core/types"
+	bortypes "github.com/erigontech/erigon/polygon/bor/types"
+	"github.com/erigontech/erigon/polygon/bridge"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+// StateSyncEvent is a Heimdall state sync event together with the block and the bor
+// state sync transaction which committed it. The block fields are null for events
+// which have not been committed yet.
+type StateSyncEvent struct {
+	ID          hexutil.Uint64   `json:"id"`
+	Contract    common.Address   `json:"contract"`
+	Data        hexutility.Bytes `json:"data"`
+	TxHash      common.Hash      `json:"txHash"` // root chain transaction which emitted the event
+	LogIndex    hexutil.Uint64   `json:"logIndex"`
+	ChainID     string           `json:"borChainId"`
+	Time        hexutil.Uint64   `json:"time"`
+	BlockNumber *hexutil.Uint64  `json:"blockNumber"`
+	BlockHash   *common.Hash     `json:"blockHash"`
+	BorTxHash   *common.Hash     `json:"borTxHash"`
+}
+
+func newStateSyncEvent(raw []byte) (*StateSyncEvent, error) {
+	var event heimdall.EventRecordWithTime
+	if err := event.UnmarshallBytes(raw); err != nil {
+		return nil, err
+	}
+
+	return &StateSyncEvent{
+		ID:       hexutil.Uint64(event.ID),
+		Contract: event.Contract,
+		Data:     event.Data,
+		TxHash:   event.TxHash,
+		LogIndex: hexutil.Uint64(event.LogIndex),
+		ChainID:  event.ChainID,
+		Time:     hexutil.Uint64(event.Time.Unix()),
+	}, nil
+}
+
+func (e *StateSyncEvent) setBlock(blockNum uint64, blockHash common.Hash) {
+	borTxHash := bortypes.ComputeBorTxHash(blockNum, blockHash)
+	e.BlockNumber = (*hexutil.Uint64)(&blockNum)
+	e.BlockHash = &blockHash
+	e.BorTxHash = &borTxHash
+}
+
+// GetStateSyncEvents returns the state sync events committed in the given block.
+func (api *BorImpl) GetStateSyncEvents(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*StateSyncEvent, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.stateSyncEvents(ctx, tx, blockNum, hash)
+}
+
+// GetStateSyncEventById returns the state sync event with the given ID, or nil if it has
+// not been fetched from Heimdall yet.
+func (api *BorImpl) GetStateSyncEventById(ctx context.Context, id hexutil.Uint64) (*StateSyncEvent, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var (
+		raw       []byte
+		blockNum  uint64
+		committed bool
+	)
+	if api.bridgeReader != nil {
+		var ok bool
+		if raw, ok, err = api.bridgeReader.Event(ctx, uint64(id)); err != nil || !ok {
+			return nil, err
+		}
+		if blockNum, committed, err = api.bridgeReader.EventBlockNum(ctx, uint64(id)); err != nil {
+			return nil, err
+		}
+	} else {
+		if raw, err = tx.GetOne(kv.BorEvents, hexutility.EncodeTs(uint64(id))); err != nil {
+			return nil, err
+		}
+		if raw != nil {
+			if blockNum, committed, err = bridge.EventBlockNum(tx, uint64(id)); err != nil {
+				return nil, err
+			}
+		} else {
+			// events in the snapshot files are committed, the records carry their block
+			var ok bool
+			if raw, blockNum, ok, err = api._blockReader.EventByIdFromSnapshot(uint64(id)); err != nil || !ok {
+				return nil, err
+			}
+			committed = true
+		}
+	}
+
+	event, err := newStateSyncEvent(raw)
+	if err != nil {
+		return nil, err
+	}
+	if committed {
+		hash, err := api._blockReader.CanonicalHash(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if hash != (common.Hash{}) {
+			event.setBlock(blockNum, hash)
+		}
+	}
+
+	return event, nil
+}
+
+// StateSyncEvents sends a notification for every state sync event committed in a new
+// block, once the block has been processed by the bridge.
+func (api *BorImpl) StateSyncEvents(ctx context.Context) (*rpc.Subscription, error) {
+	if api.filters == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		headers, id := api.filters.SubscribeNewHeads(32)
+		defer api.filters.UnsubscribeHeads(id)
+
+		var lastNotified uint64 // 0 until the first header, genesis has no state sync events
+		for {
+			select {
+			case h, ok := <-headers:
+				if h != nil {
+					if err := api.notifyStateSyncEvents(notifier, rpcSub.ID, h, &lastNotified); err != nil {
+						log.Warn("[rpc] error while notifying state sync events subscription", "err", err)
+					}
+				}
+				if !ok {
+					log.Warn("[rpc] new heads channel was closed")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// notifyStateSyncEvents sends the events of the blocks after lastNotified up to the
+// given header, or the last block processed by the bridge if that is lower.
+func (api *BorImpl) notifyStateSyncEvents(notifier *rpc.Notifier, subID rpc.ID, header *types.Header, lastNotified *uint64) error {
+	ctx := context.Background()
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	to := header.Number.Uint64()
+	if api.bridgeReader != nil {
+		info, ok, err := api.bridgeReader.LastProcessedBlockInfo(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		to = min(to, info.BlockNum)
+	}
+
+	if *lastNotified == to {
+		return nil
+	}
+	// start from the current block on the first header and after reorgs
+	from := to
+	if *lastNotified != 0 && *lastNotified < to {
+		from = *lastNotified + 1
+	}
+
+	for blockNum := from; blockNum <= to; blockNum++ {
+		hash, err := api._blockReader.CanonicalHash(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if hash == (common.Hash{}) {
+			return nil
+		}
+		events, err := api.stateSyncEvents(ctx, tx, blockNum, hash)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := notifier.Notify(subID, event); err != nil {
+				return err
+			}
+		}
+		*lastNotified = blockNum
+	}
+
+	return nil
+}
+
+func (api *BorImpl) stateSyncEvents(ctx context.Context, tx kv.Tx, blockNum uint64, hash common.Hash) ([]*StateSyncEvent, error) {
+	var rawEvents [][]byte
+	if api.bridgeReader != nil {
+		msgs, err := api.bridgeReader.Events(ctx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			rawEvents = append(rawEvents, msg.Data())
+		}
+	} else {
+		values, err := api._blockReader.EventsByBlock(ctx, tx, hash, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			rawEvents = append(rawEvents, value)
+		}
+	}
+
+	events := make([]*StateSyncEvent, 0, len(rawEvents))
+	for _, raw := range rawEvents {
+		event, err := newStateSyncEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		event.setBlock(blockNum, hash)
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -35,7 +35,9 @@ import (
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/kv/stream"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core/stateless"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpccfg"
@@ -521,3 +523,22 @@ func TestAccountAt(t *testing.T) {
 		require.Equal(0, int(results.Nonce))
 	})
 }
+
+func TestExecutionWitness(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	_, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithNumber(0))
+	require.Error(t, err)
+
+	for _, block := range chain.Blocks {
+		ext, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), true))
+		require.NoError(t, err, "block %d", block.NumberU64())
+		witness, err := ext.ToWitness()
+		require.NoError(t, err)
+		stateRoot, receiptRoot, err := stateless.ExecuteStateless(m.ChainConfig, vm.Config{}, block, witness, m.Engine, log.New())
+		require.NoError(t, err, "block %d", block.NumberU64())
+		require.Equal(t, block.Root(), stateRoot, "block %d", block.NumberU64())
+		require.Equal(t, block.ReceiptHash(), receiptRoot, "block %d", block.NumberU64())
+	}
+}
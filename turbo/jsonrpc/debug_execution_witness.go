@@ -0,0 +1,322 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/stateless"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/rlp"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// ExecutionWitness implements debug_executionWitness. Returns everything needed to execute the block
+// without a state database: the state accessed by the block with its proofs against the parent state
+// root, the executed bytecodes and the ancestor headers reachable through BLOCKHASH. Proofs are built
+// from the commitment trie reverted to the parent block, so only the blocks with changesets, the last
+// config3.MaxReorgDepthV3 ones, are supported. See stateless.ExecuteStateless for the verifier.
+func (api *PrivateDebugAPIImpl) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*stateless.ExtWitness, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if blockNum == 0 {
+		return nil, errors.New("genesis block has no execution witness")
+	}
+	block, err := api.blockWithSenders(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNum)
+	}
+	parent, err := api._blockReader.Header(ctx, tx, block.ParentHash(), blockNum-1)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("parent of block %d not found", blockNum)
+	}
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := api.engine().(consensus.Engine)
+	if !ok {
+		return nil, errors.New("consensus engine can't execute blocks")
+	}
+	logger := log.New("debug_executionWitness")
+
+	// record the state accessed by the block
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+	reader, err := rpchelper.CreateHistoryStateReader(tx, txNumsReader, blockNum, -1, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	recorder := stateless.NewRecorder(reader)
+	getHeader := func(hash common.Hash, number uint64) *types.Header {
+		h, e := api._blockReader.Header(ctx, tx, hash, number)
+		if e != nil {
+			logger.Error("getHeader error", "number", number, "hash", hash, "err", e)
+		}
+		return h
+	}
+	blockHashFn := recorder.BlockHashFn(core.GetHashFn(block.HeaderNoCopy(), getHeader))
+	chainReader := consensuschain.NewReader(chainConfig, tx, api._blockReader, logger)
+	if _, err := core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, blockHashFn, engine, block, recorder, recorder, chainReader, nil, logger); err != nil {
+		return nil, fmt.Errorf("executing block %d: %w", blockNum, err)
+	}
+
+	// prove it against the parent state
+	sd, err := libstate.NewSharedDomains(tx, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer sd.Close()
+	trie, err := api.parentStateTrie(ctx, tx, sd, txNumsReader, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	witness := stateless.NewWitness(parent)
+	storage, deletedStorage := recorder.Storage(), recorder.DeletedStorage()
+	for _, address := range recorder.Accounts() {
+		hashedAddress := crypto.Keccak256(address[:])
+		proof, err := trie.ProveAccount(hashedAddress)
+		if err != nil {
+			return nil, fmt.Errorf("proving account %x: %w", address, err)
+		}
+		witness.AddState(proof)
+		witness.AddKey(address[:])
+		for _, slot := range storage[address] {
+			proof, err := trie.ProveStorage(hashedAddress, crypto.Keccak256(slot[:]))
+			if err != nil {
+				return nil, fmt.Errorf("proving storage %x %x: %w", address, slot, err)
+			}
+			witness.AddState(proof)
+			witness.AddKey(append(address[:], slot[:]...))
+		}
+		if slots := deletedStorage[address]; len(slots) > 0 {
+			hashedSlots := make([][]byte, len(slots))
+			for i, slot := range slots {
+				hashedSlots[i] = crypto.Keccak256(slot[:])
+			}
+			if err := witnessDeletionSiblings(trie, hashedAddress, hashedSlots, witness); err != nil {
+				return nil, fmt.Errorf("storage of %x: %w", address, err)
+			}
+		}
+	}
+	if deleted := recorder.DeletedAccounts(); len(deleted) > 0 {
+		hashedAddresses := make([][]byte, len(deleted))
+		for i, address := range deleted {
+			hashedAddresses[i] = crypto.Keccak256(address[:])
+		}
+		if err := witnessDeletionSiblings(trie, nil, hashedAddresses, witness); err != nil {
+			return nil, fmt.Errorf("accounts: %w", err)
+		}
+	}
+	for _, code := range recorder.Codes() {
+		witness.AddCode(code)
+	}
+	if minBlockHash, ok := recorder.MinBlockHash(); ok {
+		for header := parent; header.Number.Uint64() > minBlockHash; {
+			if header = getHeader(header.ParentHash, header.Number.Uint64()-1); header == nil {
+				return nil, fmt.Errorf("ancestor of block %d not found", blockNum)
+			}
+			if err := witness.AddHeader(header); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return witness.ToExtWitness(), nil
+}
+
+// parentStateTrie reverts the commitment trie to the parent block by applying the changesets of all
+// the blocks above it in memory.
+func (api *PrivateDebugAPIImpl) parentStateTrie(ctx context.Context, tx kv.Tx, sd *libstate.SharedDomains, txNumsReader rawdbv3.TxNumsReader, parent *types.Header) (*commitment.HexPatriciaHashed, error) {
+	parentNum := parent.Number.Uint64()
+	if sd.BlockNum() <= parentNum {
+		return nil, fmt.Errorf("block %d is not executed yet", parentNum+1)
+	}
+	changesets := make([]*[kv.DomainLen][]libstate.DomainEntryDiff, 0, sd.BlockNum()-parentNum)
+	for blockNum := sd.BlockNum(); blockNum > parentNum; blockNum-- {
+		hash, err := api._blockReader.CanonicalHash(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		changeset, ok, err := libstate.ReadDiffSet(tx, blockNum, hash)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("no changeset of block %d: execution witnesses are only available for the last %d blocks", blockNum, config3.MaxReorgDepthV3)
+		}
+		changesets = append(changesets, &changeset)
+	}
+	parentMaxTxNum, err := txNumsReader.Max(tx, parentNum)
+	if err != nil {
+		return nil, err
+	}
+	if err := sd.UnwindInMem(parentNum, parentMaxTxNum, changesets...); err != nil {
+		return nil, err
+	}
+	trie, err := sd.PatriciaTrie()
+	if err != nil {
+		return nil, err
+	}
+	root, err := trie.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if common.BytesToHash(root) != parent.Root {
+		return nil, fmt.Errorf("reverted state root %x doesn't match the root %x of block %d", root, parent.Root, parentNum)
+	}
+	return trie, nil
+}
+
+// witnessDeletionSiblings adds the nodes needed to apply deletions of the hashed keys, either from the
+// accounts trie or, when hashedAccount is given, from that account's storage trie. A branch left with
+// a single child is replaced by the child merged with the parent, so the sibling must be known, not
+// only its hash. A branch losing all its children is a deletion from its own parent branch.
+func witnessDeletionSiblings(trie *commitment.HexPatriciaHashed, hashedAccount []byte, hashedKeys [][]byte, witness *stateless.Witness) error {
+	type branch struct {
+		bitmap, deleted uint16
+	}
+	branches := make(map[string]*branch)
+	for _, hashedKey := range hashedKeys {
+		path, bitmap, ok, err := trie.LeafBranch(hashedAccount, hashedKey)
+		if err != nil {
+			return err
+		}
+		if !ok || len(path) >= 2*length.Hash {
+			continue
+		}
+		nibble := nibbleAt(hashedKey, len(path))
+		if bitmap&(1<<nibble) == 0 {
+			continue // the key is absent
+		}
+		b, ok := branches[string(path)]
+		if !ok {
+			b = &branch{bitmap: bitmap}
+			branches[string(path)] = b
+		}
+		b.deleted |= 1 << nibble
+	}
+
+	for len(branches) > 0 {
+		// the deepest first, so that vanishing branches are accounted in their parents
+		var path string
+		for p := range branches {
+			if len(p) > len(path) || (len(p) == len(path) && p > path) {
+				path = p
+			}
+		}
+		b := branches[path]
+		delete(branches, path)
+
+		remaining := b.bitmap &^ b.deleted
+		switch bits.OnesCount16(remaining) {
+		case 1:
+			child := append([]byte(path), byte(bits.TrailingZeros16(remaining)))
+			node, err := trie.TrieNode(hashedAccount, child)
+			if err != nil {
+				return fmt.Errorf("sibling %x: %w", child, err)
+			}
+			if len(node) >= length.Hash { // shorter nodes are embedded into the parent
+				witness.AddState([][]byte{node})
+			}
+		case 0:
+			for depth := len(path) - 1; depth >= 0; depth-- {
+				node, err := trie.TrieNode(hashedAccount, []byte(path[:depth]))
+				if errors.Is(err, commitment.ErrNodeNotFound) {
+					continue // inside an extension
+				}
+				if err != nil {
+					return err
+				}
+				bitmap, ok := branchBitmap(node)
+				if !ok {
+					continue // the extension vanishes along with the branch
+				}
+				parent, ok := branches[path[:depth]]
+				if !ok {
+					parent = &branch{bitmap: bitmap}
+					branches[path[:depth]] = parent
+				}
+				parent.deleted |= 1 << path[depth]
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func nibbleAt(key []byte, i int) byte {
+	if i%2 == 0 {
+		return key[i/2] >> 4
+	}
+	return key[i/2] & 0x0f
+}
+
+// branchBitmap returns the children bitmap of an RLP-encoded branch node
+func branchBitmap(node []byte) (uint16, bool) {
+	items, _, err := rlp.SplitList(node)
+	if err != nil {
+		return 0, false
+	}
+	if n, err := rlp.CountValues(items); err != nil || n != 17 {
+		return 0, false
+	}
+	var bitmap uint16
+	for i := 0; i < 16; i++ {
+		kind, val, rest, err := rlp.Split(items)
+		if err != nil {
+			return 0, false
+		}
+		if kind == rlp.List || len(val) > 0 {
+			bitmap |= 1 << i
+		}
+		items = rest
+	}
+	return bitmap, true
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/erigontech/erigon/core/types/accounts"
 	ethFilters "github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/ethdb/prune"
+	"github.com/erigontech/erigon/polygon/bridge"
 	"github.com/erigontech/erigon/rpc"
 	ethapi2 "github.com/erigontech/erigon/turbo/adapter/ethapi"
 	"github.com/erigontech/erigon/turbo/rpchelper"
@@ -344,6 +345,9 @@ func (api *BaseAPI) pruneMode(tx kv.Tx) (*prune.Mode, error) {
 type bridgeReader interface {
 	Events(ctx context.Context, blockNum uint64) ([]*types.Message, error)
 	EventTxnLookup(ctx context.Context, borTxHash common.Hash) (uint64, bool, error)
+	Event(ctx context.Context, eventID uint64) ([]byte, bool, error)
+	EventBlockNum(ctx context.Context, eventID uint64) (uint64, bool, error)
+	LastProcessedBlockInfo(ctx context.Context) (bridge.ProcessedBlockInfo, bool, error)
 }
 
 // APIImpl is implementation of the EthAPI interface based on remote Db access
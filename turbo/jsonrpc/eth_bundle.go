@@ -0,0 +1,159 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+// BundleAPI the interface for the eth_ RPC commands submitting bundles to the block building
+// of this node. It is only served on the engine API endpoint, behind its JWT authentication.
+type BundleAPI interface {
+	SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error)
+	CancelBundle(ctx context.Context, args CancelBundleArgs) (bool, error)
+}
+
+// SendBundleArgs are the arguments of eth_sendBundle.
+type SendBundleArgs struct {
+	Txs               []hexutility.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64     `json:"blockNumber"`
+	MinTimestamp      *uint64            `json:"minTimestamp"`
+	MaxTimestamp      *uint64            `json:"maxTimestamp"`
+	RevertingTxHashes []libcommon.Hash   `json:"revertingTxHashes"`
+	ReplacementUuid   string             `json:"replacementUuid"`
+}
+
+type SendBundleResult struct {
+	BundleHash libcommon.Hash `json:"bundleHash"`
+}
+
+// CancelBundleArgs are the arguments of eth_cancelBundle.
+type CancelBundleArgs struct {
+	ReplacementUuid string `json:"replacementUuid"`
+}
+
+// BundleAPIImpl data structure to store things needed for the bundle commands
+type BundleAPIImpl struct {
+	*BaseAPI
+	db   kv.RoDB
+	pool *builder.BundlePool
+}
+
+// NewBundleAPI returns BundleAPIImpl instance
+func NewBundleAPI(base *BaseAPI, db kv.RoDB, pool *builder.BundlePool) *BundleAPIImpl {
+	return &BundleAPIImpl{
+		BaseAPI: base,
+		db:      db,
+		pool:    pool,
+	}
+}
+
+// SendBundle submits the transactions to be included atomically, in order, ahead of the txpool
+// transactions of the block at args.BlockNumber, at most builder.MaxBundleBlocksAhead past the
+// head. The bundle is dropped if one of them fails, or reverts without being in
+// args.RevertingTxHashes.
+func (api *BundleAPIImpl) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, &rpc.InvalidParamsError{Message: builder.ErrEmptyBundle.Error()}
+	}
+	if len(args.Txs) > builder.MaxBundleTxs {
+		return nil, &rpc.InvalidParamsError{Message: builder.ErrBundleTooLarge.Error()}
+	}
+	bundle := &builder.Bundle{
+		BlockNumber:       uint64(args.BlockNumber),
+		RevertingTxHashes: args.RevertingTxHashes,
+		ReplacementUuid:   args.ReplacementUuid,
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = *args.MinTimestamp
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = *args.MaxTimestamp
+	}
+	if bundle.MaxTimestamp != 0 && bundle.MinTimestamp > bundle.MaxTimestamp {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("minTimestamp %d is after maxTimestamp %d", bundle.MinTimestamp, bundle.MaxTimestamp)}
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.BlockNumber <= latest {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("bundle targets block %d, the chain is at block %d", bundle.BlockNumber, latest)}
+	}
+	if bundle.BlockNumber > latest+builder.MaxBundleBlocksAhead {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("bundle targets block %d, more than %d blocks past block %d", bundle.BlockNumber, builder.MaxBundleBlocksAhead, latest)}
+	}
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	chainID, _ := uint256.FromBig(chainConfig.ChainID)
+	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+
+	for i, raw := range args.Txs {
+		txn, err := types.DecodeWrappedTransaction(raw)
+		if err != nil {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: %v", i, err)}
+		}
+		if !txn.GetChainID().IsZero() && txn.GetChainID().Cmp(chainID) != 0 {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: invalid chain id %d", i, txn.GetChainID())}
+		}
+		// the payload has to carry the blobs of its blob transactions
+		if _, ok := txn.(*types.BlobTxWrapper); txn.Type() == types.BlobTxType && !ok {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: blob transaction without blobs", i)}
+		}
+		// the pool caps the bundles of the sender of the first transaction
+		if _, err := txn.Sender(*signer); err != nil {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: %v", i, err)}
+		}
+		bundle.Txs = append(bundle.Txs, txn)
+	}
+
+	hash, err := api.pool.Add(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: hash}, nil
+}
+
+// CancelBundle removes the bundle submitted with the replacementUuid, and tells whether there
+// was one.
+func (api *BundleAPIImpl) CancelBundle(ctx context.Context, args CancelBundleArgs) (bool, error) {
+	if args.ReplacementUuid == "" {
+		return false, &rpc.InvalidParamsError{Message: "missing replacementUuid"}
+	}
+	return api.pool.Cancel(args.ReplacementUuid), nil
+}
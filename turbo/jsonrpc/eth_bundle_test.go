@@ -0,0 +1,80 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+func TestSendBundle(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	pool := builder.NewBundlePool(builder.DefaultMaxBundles, 1)
+	api := NewBundleAPI(newBaseApiForTest(m), m.DB, pool)
+	ctx := context.Background()
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	require.NoError(t, err)
+
+	signed := func(nonce uint64) (types.Transaction, hexutility.Bytes) {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.HexToAddress("0xbeef"), uint256.NewInt(1), 21_000, uint256.NewInt(1), nil), *types.LatestSigner(m.ChainConfig), m.Key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		return txn, buf.Bytes()
+	}
+	txn, raw := signed(0)
+
+	var invalidParams *rpc.InvalidParamsError
+	for _, args := range []SendBundleArgs{
+		{BlockNumber: hexutil.Uint64(latest + 1)},
+		{Txs: make([]hexutility.Bytes, builder.MaxBundleTxs+1), BlockNumber: hexutil.Uint64(latest + 1)},
+		{Txs: []hexutility.Bytes{raw}, BlockNumber: hexutil.Uint64(latest)},
+		{Txs: []hexutility.Bytes{raw}, BlockNumber: hexutil.Uint64(latest + builder.MaxBundleBlocksAhead + 1)},
+		{Txs: []hexutility.Bytes{raw}, BlockNumber: hexutil.Uint64(1) << 63},
+	} {
+		_, err = api.SendBundle(ctx, args)
+		require.ErrorAs(t, err, &invalidParams, "block %d, %d txs", args.BlockNumber, len(args.Txs))
+	}
+
+	result, err := api.SendBundle(ctx, SendBundleArgs{Txs: []hexutility.Bytes{raw}, BlockNumber: hexutil.Uint64(latest + builder.MaxBundleBlocksAhead)})
+	require.NoError(t, err)
+	require.Len(t, pool.Bundles(latest+builder.MaxBundleBlocksAhead, 0), 1)
+	require.Equal(t, txn.Hash(), pool.Bundles(latest+builder.MaxBundleBlocksAhead, 0)[0].Txs[0].Hash())
+	require.NotEqual(t, common.Hash{}, result.BundleHash)
+
+	// the sender has as many bundles as the pool keeps
+	_, next := signed(1)
+	_, err = api.SendBundle(ctx, SendBundleArgs{Txs: []hexutility.Bytes{next}, BlockNumber: hexutil.Uint64(latest + 1)})
+	require.ErrorIs(t, err, builder.ErrSenderBundlesFull)
+}
@@ -44,5 +44,7 @@ type ApiBackend interface {
 	NodeInfo(ctx context.Context, limit uint32) ([]p2p.NodeInfo, error)
 	Peers(ctx context.Context) ([]*p2p.PeerInfo, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	PeerBans(ctx context.Context) (*remote.PeerBansReply, error)
+	ClearPeerBans(ctx context.Context, req *remote.ClearPeerBansRequest) (*remote.ClearPeerBansReply, error)
 	PendingBlock(ctx context.Context) (*types.Block, error)
 }
@@ -0,0 +1,229 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+type pinningParamKind int
+
+const (
+	blockParam   pinningParamKind = iota // rpc.BlockNumberOrHash
+	txnHashParam                         // hash of a transaction
+)
+
+// pinningParam is the param which determines the block the result of a method depends on.
+type pinningParam struct {
+	index int
+	kind  pinningParamKind
+}
+
+// cacheableMethods are the methods whose results never change once their block is finalized.
+var cacheableMethods = map[string]pinningParam{
+	"eth_getBlockByNumber":                    {0, blockParam},
+	"eth_getBlockByHash":                      {0, blockParam},
+	"eth_getBlockTransactionCountByNumber":    {0, blockParam},
+	"eth_getBlockTransactionCountByHash":      {0, blockParam},
+	"eth_getTransactionByBlockNumberAndIndex": {0, blockParam},
+	"eth_getTransactionByBlockHashAndIndex":   {0, blockParam},
+	"eth_getUncleByBlockNumberAndIndex":       {0, blockParam},
+	"eth_getUncleByBlockHashAndIndex":         {0, blockParam},
+	"eth_getUncleCountByBlockNumber":          {0, blockParam},
+	"eth_getUncleCountByBlockHash":            {0, blockParam},
+	"eth_getBlockReceipts":                    {0, blockParam},
+	"eth_getBalance":                          {1, blockParam},
+	"eth_getCode":                             {1, blockParam},
+	"eth_getTransactionCount":                 {1, blockParam},
+	"eth_getStorageAt":                        {2, blockParam},
+	"eth_call":                                {1, blockParam},
+	"erigon_getHeaderByNumber":                {0, blockParam},
+	"erigon_getHeaderByHash":                  {0, blockParam},
+	"debug_traceBlockByNumber":                {0, blockParam},
+	"debug_traceBlockByHash":                  {0, blockParam},
+	"debug_traceCall":                         {1, blockParam},
+	"trace_block":                             {0, blockParam},
+	"eth_getTransactionByHash":                {0, txnHashParam},
+	"eth_getTransactionReceipt":               {0, txnHashParam},
+	"debug_traceTransaction":                  {0, txnHashParam},
+	"trace_transaction":                       {0, txnHashParam},
+	"trace_replayTransaction":                 {0, txnHashParam},
+}
+
+// ResponseCachePolicy lets rpc.ResponseCache keep the results of the calls pinned by their params
+// to a canonical block at or below the finalized one.
+type ResponseCachePolicy struct {
+	db          kv.RoDB
+	blockReader services.FullBlockReader
+	logger      log.Logger
+
+	// the highest finalized block seen, to detect the unwinds past it
+	mu            sync.Mutex
+	finalizedNum  uint64
+	finalizedHash libcommon.Hash
+}
+
+func NewResponseCachePolicy(db kv.RoDB, blockReader services.FullBlockReader, logger log.Logger) *ResponseCachePolicy {
+	return &ResponseCachePolicy{db: db, blockReader: blockReader, logger: logger}
+}
+
+func (p *ResponseCachePolicy) PinnedBlock(ctx context.Context, method string, params []json.RawMessage) (uint64, bool) {
+	param, ok := cacheableMethods[method]
+	if !ok || param.index >= len(params) {
+		return 0, false
+	}
+	tx, err := p.db.BeginRo(ctx)
+	if err != nil {
+		return 0, false
+	}
+	defer tx.Rollback()
+
+	var blockNum uint64
+	var blockHash libcommon.Hash
+	switch param.kind {
+	case txnHashParam:
+		var txnHash libcommon.Hash
+		if err := json.Unmarshal(params[param.index], &txnHash); err != nil {
+			return 0, false
+		}
+		if blockNum, ok, err = p.blockReader.TxnLookup(ctx, tx, txnHash); err != nil || !ok {
+			return 0, false
+		}
+	case blockParam:
+		var blockNrOrHash rpc.BlockNumberOrHash
+		if err := json.Unmarshal(params[param.index], &blockNrOrHash); err != nil {
+			return 0, false
+		}
+		if hash, ok := blockNrOrHash.Hash(); ok {
+			number, err := p.blockReader.HeaderNumber(ctx, tx, hash)
+			if err != nil || number == nil {
+				return 0, false
+			}
+			blockNum, blockHash = *number, hash
+		} else if number, ok := blockNrOrHash.Number(); ok && number >= 0 {
+			// the tags are negative, and move with the chain
+			blockNum = uint64(number)
+		} else {
+			return 0, false
+		}
+	}
+
+	finalizedNum, err := GetFinalizedBlockNumber(tx)
+	if err != nil || blockNum > finalizedNum {
+		return 0, false
+	}
+	if blockHash != (libcommon.Hash{}) {
+		canonicalHash, err := p.blockReader.CanonicalHash(ctx, tx, blockNum)
+		if err != nil || canonicalHash != blockHash {
+			return 0, false
+		}
+	}
+	if err := p.observeFinalized(ctx, tx, finalizedNum); err != nil {
+		return 0, false
+	}
+	return blockNum, true
+}
+
+// observeFinalized remembers the finalized block if it's higher than the ones seen so far.
+func (p *ResponseCachePolicy) observeFinalized(ctx context.Context, tx kv.Tx, finalizedNum uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if finalizedNum <= p.finalizedNum && p.finalizedHash != (libcommon.Hash{}) {
+		return nil
+	}
+	hash, err := p.blockReader.CanonicalHash(ctx, tx, finalizedNum)
+	if err != nil {
+		return err
+	}
+	p.finalizedNum, p.finalizedHash = finalizedNum, hash
+	return nil
+}
+
+// WatchFinality invalidates the cached responses when a new head unwinds the chain past
+// the finalized block.
+func (p *ResponseCachePolicy) WatchFinality(ctx context.Context, filters *Filters, cache *rpc.ResponseCache) {
+	heads, id := filters.SubscribeNewHeads(16)
+	defer filters.UnsubscribeHeads(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-heads:
+			if !ok {
+				return
+			}
+			if err := p.checkFinality(ctx, cache); err != nil {
+				p.logger.Debug("[rpc] response cache: failed to check finality", "err", err)
+			}
+		}
+	}
+}
+
+func (p *ResponseCachePolicy) checkFinality(ctx context.Context, cache *rpc.ResponseCache) error {
+	tx, err := p.db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finalizedHash == (libcommon.Hash{}) {
+		return nil
+	}
+	canonicalHash, err := p.blockReader.CanonicalHash(ctx, tx, p.finalizedNum)
+	if err != nil {
+		return err
+	}
+	if canonicalHash == p.finalizedHash {
+		return nil
+	}
+
+	// walk back the unwound chain to the first block still canonical
+	forkNum, hash := p.finalizedNum, p.finalizedHash
+	for forkNum > 0 {
+		if canonicalHash, err = p.blockReader.CanonicalHash(ctx, tx, forkNum); err != nil {
+			return err
+		}
+		if canonicalHash == hash {
+			break
+		}
+		header, err := p.blockReader.Header(ctx, tx, hash, forkNum)
+		if err != nil {
+			return err
+		}
+		if header == nil { // unknown ancestry, drop everything
+			forkNum = 0
+			break
+		}
+		forkNum, hash = forkNum-1, header.ParentHash
+	}
+	p.logger.Warn("[rpc] chain unwound past the finalized block, invalidating the response cache",
+		"finalized", p.finalizedNum, "forkPoint", forkNum)
+	cache.InvalidateFrom(forkNum + 1)
+	p.finalizedNum, p.finalizedHash = 0, libcommon.Hash{} // observed again by the next cached call
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+func newTestResponseCachePolicy(t *testing.T) (*ResponseCachePolicy, kv.RwDB, []libcommon.Hash) {
+	t.Helper()
+	logger := log.New()
+	dir := t.TempDir()
+	snapshots := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{}, dir, 0, logger)
+	t.Cleanup(snapshots.Close)
+	borSnapshots := freezeblocks.NewBorRoSnapshots(ethconfig.BlocksFreezing{}, dir, 0, logger)
+	t.Cleanup(borSnapshots.Close)
+
+	db := memdb.NewTestDB(t)
+	hashes := writeTestChain(t, db, 10)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		rawdb.WriteForkchoiceFinalized(tx, hashes[6])
+		return nil
+	}))
+	return NewResponseCachePolicy(db, freezeblocks.NewBlockReader(snapshots, borSnapshots), logger), db, hashes
+}
+
+func TestResponseCachePolicyPinnedBlock(t *testing.T) {
+	policy, db, hashes := newTestResponseCachePolicy(t)
+
+	// a header of block 4 off the canonical chain
+	sideHeader := &types.Header{Number: big.NewInt(4), ParentHash: hashes[3], Difficulty: big.NewInt(1), Extra: []byte("side")}
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		return rawdb.WriteHeader(tx, sideHeader)
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		params []string
+		block  uint64
+		ok     bool
+	}{
+		{"number", "eth_getBlockByNumber", []string{`"0x4"`, `false`}, 4, true},
+		{"finalized number", "eth_getBlockByNumber", []string{`"0x6"`, `false`}, 6, true},
+		{"number above finalized", "eth_getBlockByNumber", []string{`"0x7"`, `false`}, 0, false},
+		{"latest tag", "eth_getBlockByNumber", []string{`"latest"`, `false`}, 0, false},
+		{"finalized tag", "eth_getBlockByNumber", []string{`"finalized"`, `false`}, 0, false},
+		{"safe tag", "eth_getBlockByNumber", []string{`"safe"`, `false`}, 0, false},
+		{"pending tag", "eth_getBlockByNumber", []string{`"pending"`, `false`}, 0, false},
+		{"earliest tag", "eth_getBlockByNumber", []string{`"earliest"`, `false`}, 0, true}, // the genesis never changes
+		{"canonical hash", "eth_getBlockByHash", []string{fmt.Sprintf(`"%s"`, hashes[4]), `false`}, 4, true},
+		{"non-canonical hash", "eth_getBlockByHash", []string{fmt.Sprintf(`"%s"`, sideHeader.Hash()), `false`}, 0, false},
+		{"hash above finalized", "eth_getBlockByHash", []string{fmt.Sprintf(`"%s"`, hashes[8]), `false`}, 0, false},
+		{"unknown hash", "eth_getBlockByHash", []string{fmt.Sprintf(`"%s"`, libcommon.Hash{1}), `false`}, 0, false},
+		{"block param", "eth_getBalance", []string{`"0x0000000000000000000000000000000000000001"`, `"0x2"`}, 2, true},
+		{"missing block param", "eth_getBalance", []string{`"0x0000000000000000000000000000000000000001"`}, 0, false},
+		{"block hash object", "eth_call", []string{`{}`, fmt.Sprintf(`{"blockHash":"%s"}`, hashes[5])}, 5, true},
+		{"not cacheable", "eth_blockNumber", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := make([]json.RawMessage, 0, len(tt.params))
+			for _, param := range tt.params {
+				params = append(params, json.RawMessage(param))
+			}
+			block, ok := policy.PinnedBlock(context.Background(), tt.method, params)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.block, block)
+		})
+	}
+}
+
+type cacheTestEthService struct{ calls int }
+
+func (s *cacheTestEthService) GetBlockByNumber(number rpc.BlockNumberOrHash, fullTx bool) string {
+	s.calls++
+	return fmt.Sprintf("block %d #%d", number.BlockNumber.Int64(), s.calls)
+}
+
+func TestResponseCachePolicyUnwind(t *testing.T) {
+	policy, db, hashes := newTestResponseCachePolicy(t)
+	ctx := context.Background()
+	logger := log.New()
+
+	service := new(cacheTestEthService)
+	server := rpc.NewServer(50, false, false, true, logger, 100)
+	require.NoError(t, server.RegisterName("eth", service))
+	cache := rpc.NewResponseCache(1<<20, policy)
+	server.SetResponseCache(cache)
+	defer server.Stop()
+	client := rpc.DialInProc(server, logger)
+	defer client.Close()
+
+	call := func(number uint64) string {
+		var result string
+		require.NoError(t, client.Call(&result, "eth_getBlockByNumber", hexutil.Uint64(number), false))
+		return result
+	}
+
+	cached3, cached5 := call(3), call(5)
+	require.Equal(t, cached3, call(3))
+	require.Equal(t, cached5, call(5))
+	require.Equal(t, 2, service.calls)
+
+	// nothing to invalidate while the finalized block stays canonical
+	require.NoError(t, policy.checkFinality(ctx, cache))
+	require.Equal(t, cached5, call(5))
+
+	// a fork from block 4 unwinds the finalized block 6
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		parent := hashes[4]
+		for num := uint64(5); num <= 10; num++ {
+			header := &types.Header{Number: new(big.Int).SetUint64(num), ParentHash: parent, Difficulty: big.NewInt(2)}
+			if err := rawdb.WriteHeader(tx, header); err != nil {
+				return err
+			}
+			if err := rawdb.WriteCanonicalHash(tx, header.Hash(), num); err != nil {
+				return err
+			}
+			parent = header.Hash()
+		}
+		return rawdb.WriteHeadHeaderHash(tx, parent)
+	}))
+	require.NoError(t, policy.checkFinality(ctx, cache))
+
+	// the results of the blocks below the fork point stay cached
+	require.Equal(t, cached3, call(3))
+	require.NotEqual(t, cached5, call(5))
+	require.Equal(t, 3, service.calls)
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	borfinality "github.com/erigontech/erigon/polygon/bor/finality"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 	"github.com/erigontech/erigon/rpc"
 )
 
@@ -49,7 +51,16 @@ func GetLatestBlockNumber(tx kv.Tx) (uint64, error) {
 	return blockNum, nil
 }
 
+// GetFinalizedBlockNumber returns the latest milestone block on Bor chains running the
+// finality whitelist and the forkchoice finalized block otherwise.
 func GetFinalizedBlockNumber(tx kv.Tx) (uint64, error) {
+	if whitelist.GetWhitelistingService() != nil {
+		if num := borfinality.GetFinalizedBlockNumber(tx); num > 0 {
+			return num, nil
+		}
+		return 0, UnknownBlockError
+	}
+
 	forkchoiceFinalizedHash := rawdb.ReadForkchoiceFinalized(tx)
 	if forkchoiceFinalizedHash != (libcommon.Hash{}) {
 		forkchoiceFinalizedNum := rawdb.ReadHeaderNumber(tx, forkchoiceFinalizedHash)
@@ -61,7 +72,16 @@ func GetFinalizedBlockNumber(tx kv.Tx) (uint64, error) {
 	return 0, UnknownBlockError
 }
 
+// GetSafeBlockNumber returns the latest checkpoint block on Bor chains running the
+// finality whitelist and the forkchoice safe block otherwise.
 func GetSafeBlockNumber(tx kv.Tx) (uint64, error) {
+	if whitelist.GetWhitelistingService() != nil {
+		if num := borfinality.GetSafeBlockNumber(tx); num > 0 {
+			return num, nil
+		}
+		return 0, UnknownBlockError
+	}
+
 	forkchoiceSafeHash := rawdb.ReadForkchoiceSafe(tx)
 	if forkchoiceSafeHash != (libcommon.Hash{}) {
 		forkchoiceSafeNum := rawdb.ReadHeaderNumber(tx, forkchoiceSafeHash)
@@ -0,0 +1,116 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+)
+
+func TestGetFinalizedAndSafeBlockNumber(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	hashes := writeTestChain(t, db, 10)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		rawdb.WriteForkchoiceFinalized(tx, hashes[4])
+		rawdb.WriteForkchoiceSafe(tx, hashes[6])
+		return nil
+	}))
+
+	check := func(t *testing.T, finalized, safe uint64) {
+		t.Helper()
+		tx, err := db.BeginRo(context.Background())
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		for _, tc := range []struct {
+			get      func(kv.Tx) (uint64, error)
+			expected uint64
+		}{{GetFinalizedBlockNumber, finalized}, {GetSafeBlockNumber, safe}} {
+			num, err := tc.get(tx)
+			if tc.expected == 0 {
+				require.ErrorIs(t, err, UnknownBlockError)
+				continue
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, num)
+		}
+	}
+
+	t.Run("forkchoice", func(t *testing.T) {
+		check(t, 4, 6)
+	})
+
+	whitelist.RegisterService(db)
+	t.Cleanup(whitelist.UnregisterService)
+	service := whitelist.GetWhitelistingService()
+
+	t.Run("bor without checkpoint and milestone", func(t *testing.T) {
+		check(t, 0, 0)
+	})
+	t.Run("bor with checkpoint", func(t *testing.T) {
+		service.ProcessCheckpoint(5, hashes[5])
+		check(t, 5, 5)
+	})
+	t.Run("bor with milestone", func(t *testing.T) {
+		service.ProcessMilestone(8, hashes[8])
+		check(t, 8, 5)
+	})
+	t.Run("bor with milestone off the canonical chain", func(t *testing.T) {
+		service.ProcessMilestone(9, libcommon.Hash{9})
+		check(t, 5, 5)
+	})
+	t.Run("bor with milestone above the head", func(t *testing.T) {
+		service.ProcessMilestone(12, libcommon.Hash{12})
+		check(t, 5, 5)
+	})
+	t.Run("bor with checkpoint off the canonical chain", func(t *testing.T) {
+		service.ProcessCheckpoint(7, libcommon.Hash{7})
+		check(t, 0, 0)
+	})
+}
+
+// writeTestChain writes a canonical chain of headers up to head and returns their hashes.
+func writeTestChain(t *testing.T, db kv.RwDB, head uint64) []libcommon.Hash {
+	t.Helper()
+	hashes := make([]libcommon.Hash, head+1)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		var parent libcommon.Hash
+		for num := uint64(0); num <= head; num++ {
+			header := &types.Header{Number: new(big.Int).SetUint64(num), ParentHash: parent, Difficulty: big.NewInt(1)}
+			if err := rawdb.WriteHeader(tx, header); err != nil {
+				return err
+			}
+			if err := rawdb.WriteCanonicalHash(tx, header.Hash(), num); err != nil {
+				return err
+			}
+			hashes[num], parent = header.Hash(), header.Hash()
+		}
+		return rawdb.WriteHeadHeaderHash(tx, hashes[head])
+	}))
+	return hashes
+}
@@ -61,6 +61,7 @@ type BorEventReader interface {
 	BorStartEventID(ctx context.Context, tx kv.Tx, hash common.Hash, blockNum uint64) (uint64, error)
 	LastFrozenEventId() uint64
 	LastFrozenEventBlockNum() uint64
+	EventByIdFromSnapshot(eventId uint64) ([]byte, uint64, bool, error)
 }
 
 type BorSpanReader interface {
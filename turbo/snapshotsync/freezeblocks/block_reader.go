@@ -319,6 +319,10 @@ func (r *RemoteBlockReader) LastFrozenEventId() uint64 {
 	panic("not implemented")
 }
 
+func (r *RemoteBlockReader) EventByIdFromSnapshot(eventId uint64) ([]byte, uint64, bool, error) {
+	return nil, 0, false, errors.New("not implemented")
+}
+
 func (r *RemoteBlockReader) LastFrozenEventBlockNum() uint64 {
 	panic("not implemented")
 }
@@ -1536,6 +1540,52 @@ func (r *BlockReader) EventsByIdFromSnapshot(from uint64, to time.Time, limit in
 	return result, maxTime, nil
 }
 
+// EventByIdFromSnapshot returns the event with the given id and the number of the block which
+// committed it, if the event is in the snapshot files. The index has an entry for the first event
+// of every block, the block of the event is binary searched among them.
+func (r *BlockReader) EventByIdFromSnapshot(eventId uint64) ([]byte, uint64, bool, error) {
+	if r.borSn == nil {
+		return nil, 0, false, nil
+	}
+	segments, release := r.borSn.ViewType(borsnaptype.BorEvents)
+	defer release()
+
+	var buf []byte
+	recordEventId := func() uint64 {
+		return binary.BigEndian.Uint64(buf[length.Hash+length.BlockNum : length.Hash+length.BlockNum+8])
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		sn := segments[i]
+		idxBorTxnHash := sn.Index()
+		if idxBorTxnHash == nil || idxBorTxnHash.KeyCount() == 0 {
+			continue
+		}
+		if idxBorTxnHash.BaseDataID() > eventId {
+			continue
+		}
+
+		gg := sn.MakeGetter()
+		// the first block whose first event is after the event, the event is in the block before it
+		blockOrdinal := sort.Search(int(idxBorTxnHash.KeyCount()), func(i int) bool {
+			gg.Reset(idxBorTxnHash.OrdinalLookup(uint64(i)))
+			buf, _ = gg.Next(buf[:0])
+			return recordEventId() > eventId
+		}) - 1
+		gg.Reset(idxBorTxnHash.OrdinalLookup(uint64(blockOrdinal)))
+		for gg.HasNext() {
+			buf, _ = gg.Next(buf[:0])
+			if id := recordEventId(); id > eventId {
+				break
+			} else if id == eventId {
+				blockNum := binary.BigEndian.Uint64(buf[length.Hash : length.Hash+length.BlockNum])
+				return common.Copy(buf[length.Hash+length.BlockNum+8:]), blockNum, true, nil
+			}
+		}
+		return nil, 0, false, nil
+	}
+	return nil, 0, false, nil
+}
+
 func (r *BlockReader) LastEventId(_ context.Context, tx kv.Tx) (uint64, bool, error) {
 	cursor, err := tx.Cursor(kv.BorEvents)
 	if err != nil {
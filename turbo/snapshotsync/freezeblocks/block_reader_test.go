@@ -204,6 +204,54 @@ func TestBlockReaderLastFrozenEventIdReturnsZeroWhenAllSegmentsDoNotHaveIdx(t *t
 	require.Equal(t, uint64(0), blockReader.LastFrozenEventId())
 }
 
+func TestBlockReaderEventByIdFromSnapshot(t *testing.T) {
+	t.Parallel()
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	// events 5-7 in block 10, 8 in block 11 and 9-12 in block 13
+	blockEvents := map[uint64][]uint64{10: {5, 6, 7}, 11: {8}, 13: {9, 10, 11, 12}}
+	compressCfg := seg.DefaultCfg
+	compressCfg.MinPatternScore = 100
+	fileName := snaptype.SegmentFileName(1, 0, 500_000, borsnaptype.Enums.BorEvents)
+	compressor, err := seg.NewCompressor(context.Background(), "test", filepath.Join(dir, fileName), dir, compressCfg, log.LvlDebug, logger)
+	require.NoError(t, err)
+	defer compressor.Close()
+	compressor.DisableFsync()
+	for _, blockNum := range []uint64{10, 11, 13} {
+		for _, eventId := range blockEvents[blockNum] {
+			data := make([]byte, length.Hash+length.BlockNum+8, length.Hash+length.BlockNum+9)
+			data[0] = byte(blockNum)
+			binary.BigEndian.PutUint64(data[length.Hash:length.Hash+length.BlockNum], blockNum)
+			binary.BigEndian.PutUint64(data[length.Hash+length.BlockNum:length.Hash+length.BlockNum+8], eventId)
+			require.NoError(t, compressor.AddWord(append(data, byte(eventId))))
+		}
+	}
+	require.NoError(t, compressor.Compress())
+	info, _, ok := snaptype.ParseFileName(dir, fileName)
+	require.True(t, ok)
+	require.NoError(t, borsnaptype.BorEvents.BuildIndexes(context.Background(), info, nil, dir, nil, log.LvlDebug, logger))
+	borRoSnapshots := NewBorRoSnapshots(ethconfig.BlocksFreezing{}, dir, 0, logger)
+	defer borRoSnapshots.Close()
+	require.NoError(t, borRoSnapshots.ReopenFolder())
+
+	blockReader := &BlockReader{borSn: borRoSnapshots}
+	for blockNum, eventIds := range blockEvents {
+		for _, eventId := range eventIds {
+			event, eventBlockNum, ok, err := blockReader.EventByIdFromSnapshot(eventId)
+			require.NoError(t, err)
+			require.True(t, ok, "event %d", eventId)
+			require.Equal(t, blockNum, eventBlockNum)
+			require.Equal(t, []byte{byte(eventId)}, event)
+		}
+	}
+	for _, eventId := range []uint64{4, 13} {
+		_, _, ok, err := blockReader.EventByIdFromSnapshot(eventId)
+		require.NoError(t, err)
+		require.False(t, ok, "event %d", eventId)
+	}
+}
+
 func createTestBorEventSegmentFile(t *testing.T, from, to, eventId uint64, dir string, logger log.Logger) {
 	compressCfg := seg.DefaultCfg
 	compressCfg.MinPatternScore = 100
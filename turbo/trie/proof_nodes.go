@@ -0,0 +1,108 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"fmt"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/rlp"
+)
+
+// NewFromProofNodes builds a partial trie with the given state root out of a set
+// of RLP-encoded trie nodes, e.g. the union of account and storage proofs.
+// Storage tries are attached to the accounts whose storage root is in the set.
+// Nodes that are not in the set are kept as hash nodes: the trie still hashes
+// correctly, but reading or modifying keys below them is not possible.
+func NewFromProofNodes(root libcommon.Hash, nodes [][]byte) (*Trie, error) {
+	byHash := make(map[libcommon.Hash][]byte, len(nodes))
+	for _, n := range nodes {
+		byHash[crypto.Keccak256Hash(n)] = n
+	}
+	t := New(root)
+	if t.root == nil {
+		return t, nil
+	}
+	resolved, err := resolveProofNode(t.root, byHash, false)
+	if err != nil {
+		return nil, err
+	}
+	t.root = resolved
+	return t, nil
+}
+
+func resolveProofNode(n node, nodes map[libcommon.Hash][]byte, storage bool) (node, error) {
+	var err error
+	switch n := n.(type) {
+	case hashNode:
+		enc, ok := nodes[libcommon.BytesToHash(n.hash)]
+		if !ok {
+			return n, nil
+		}
+		decoded, err := decodeNode(enc)
+		if err != nil {
+			return nil, fmt.Errorf("node %x: %w", n.hash, err)
+		}
+		return resolveProofNode(decoded, nodes, storage)
+	case *fullNode:
+		for i := 0; i < 16; i++ {
+			if n.Children[i] == nil {
+				continue
+			}
+			if n.Children[i], err = resolveProofNode(n.Children[i], nodes, storage); err != nil {
+				return nil, err
+			}
+		}
+		return n, nil
+	case *shortNode:
+		if n.Key[len(n.Key)-1] != 16 {
+			if n.Val, err = resolveProofNode(n.Val, nodes, storage); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+		v, ok := n.Val.(valueNode)
+		if !ok {
+			return nil, fmt.Errorf("unexpected leaf value %T", n.Val)
+		}
+		if storage {
+			// the trie keeps storage values unencoded
+			val, _, err := rlp.SplitString(v)
+			if err != nil {
+				return nil, fmt.Errorf("storage leaf: %w", err)
+			}
+			n.Val = valueNode(val)
+			return n, nil
+		}
+		var acc accounts.Account
+		if err := acc.DecodeForHashing(v); err != nil {
+			return nil, err
+		}
+		an := &accountNode{Account: acc, rootCorrect: true, codeSize: codeSizeUncached}
+		if acc.Root != EmptyRoot {
+			if an.storage, err = resolveProofNode(hashNode{hash: libcommon.Copy(acc.Root[:])}, nodes, true); err != nil {
+				return nil, err
+			}
+		}
+		n.Val = an
+		return n, nil
+	default:
+		return n, nil
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/crypto"
+)
+
+type proofNodesFixture struct {
+	accounts [][]byte
+	slots    map[int][][]byte
+}
+
+func newProofNodesFixture(t *testing.T, rnd *rand.Rand) (*Trie, proofNodesFixture) {
+	t.Helper()
+	tr := newEmpty()
+	f := proofNodesFixture{slots: map[int][][]byte{}}
+	for i := 0; i < 200; i++ {
+		addrHash := crypto.Keccak256([]byte{byte(i), byte(i >> 8)})
+		acc := &accounts.Account{Nonce: uint64(i), Balance: *uint256.NewInt(uint64(i) * 100), Root: EmptyRoot, CodeHash: emptyState}
+		tr.UpdateAccount(addrHash, acc)
+		f.accounts = append(f.accounts, addrHash)
+		if i%5 != 0 {
+			continue
+		}
+		for j := 0; j < 1+rnd.Intn(30); j++ {
+			key := append(libcommon.Copy(addrHash), crypto.Keccak256([]byte{byte(j)})...)
+			tr.Update(key, []byte{byte(rnd.Intn(255) + 1), byte(j)})
+			f.slots[i] = append(f.slots[i], key)
+		}
+	}
+	return tr, f
+}
+
+func collectProofNodes(t *testing.T, tr *Trie, f proofNodesFixture, accs []int) [][]byte {
+	t.Helper()
+	var nodes [][]byte
+	for _, i := range accs {
+		proof, err := tr.Prove(f.accounts[i], 0, false)
+		require.NoError(t, err)
+		nodes = append(nodes, proof...)
+		for _, key := range f.slots[i] {
+			proof, err := tr.Prove(key, 64, true)
+			require.NoError(t, err)
+			nodes = append(nodes, proof...)
+		}
+	}
+	return nodes
+}
+
+func TestNewFromProofNodes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	full, f := newProofNodesFixture(t, rnd)
+	root := full.Hash()
+
+	var touched []int
+	for i := 0; i < len(f.accounts); i += 3 {
+		touched = append(touched, i)
+	}
+	partial, err := NewFromProofNodes(root, collectProofNodes(t, full, f, touched))
+	require.NoError(t, err)
+	require.Equal(t, root, partial.Hash())
+
+	for _, i := range touched {
+		acc, ok := partial.GetAccount(f.accounts[i])
+		require.True(t, ok)
+		require.Equal(t, uint64(i), acc.Nonce)
+		for _, key := range f.slots[i] {
+			expected, _ := full.Get(key)
+			v, ok := partial.Get(key)
+			require.True(t, ok)
+			require.Equal(t, expected, v)
+		}
+	}
+	_, ok := partial.GetAccount(f.accounts[1])
+	require.False(t, ok)
+
+	// identical updates of the proven keys produce identical roots
+	for _, tr := range []*Trie{full, partial} {
+		for _, i := range touched {
+			acc, _ := tr.GetAccount(f.accounts[i])
+			acc.Balance.AddUint64(&acc.Balance, 1)
+			tr.UpdateAccount(f.accounts[i], acc)
+			for j, key := range f.slots[i] {
+				tr.Update(key, []byte{byte(j), 0xff})
+			}
+		}
+	}
+	require.Equal(t, full.Hash(), partial.Hash())
+}
+
+func TestNewFromProofNodesDeletes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	full, f := newProofNodesFixture(t, rnd)
+	root := full.Hash()
+
+	all := make([]int, len(f.accounts))
+	for i := range all {
+		all[i] = i
+	}
+	partial, err := NewFromProofNodes(root, collectProofNodes(t, full, f, all))
+	require.NoError(t, err)
+	require.Equal(t, root, partial.Hash())
+
+	for _, tr := range []*Trie{full, partial} {
+		for i := 0; i < len(f.accounts); i += 4 {
+			tr.Delete(f.accounts[i])
+		}
+		for i, keys := range f.slots {
+			if i%4 == 0 {
+				continue
+			}
+			for j := 0; j < len(keys); j += 2 {
+				tr.Delete(keys[j])
+			}
+		}
+	}
+	require.Equal(t, full.Hash(), partial.Hash())
+
+	empty, err := NewFromProofNodes(EmptyRoot, nil)
+	require.NoError(t, err)
+	require.Equal(t, EmptyRoot, empty.Hash())
+}